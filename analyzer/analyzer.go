@@ -1,9 +1,12 @@
 package analyzer
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"reflect"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -13,15 +16,26 @@ import (
 	"github.com/aslakhellesoy/mermerd/util"
 )
 
+const (
+	columnOrderAlphabetical = "alphabetical"
+	columnOrderOrdinal      = "ordinal"
+)
+
 type analyzer struct {
 	loadingSpinner   presentation.LoadingSpinner
+	progressBar      presentation.ProgressBar
 	config           config.MermerdConfig
 	connectorFactory database.ConnectorFactory
 	questioner       Questioner
+	retryDelay       time.Duration
+	// ctx is the context of the analysis (see AnalyzeContext), the queries use context.Background() if it is nil
+	ctx context.Context
 }
 
 type Analyzer interface {
 	Analyze() (*database.Result, error)
+	AnalyzeContext(ctx context.Context) (*database.Result, error)
+	Watch(ctx context.Context, interval time.Duration, onChange func(result *database.Result) error) (*database.Result, error)
 	GetConnectionString() (string, error)
 	GetSchemas(db database.Connector) ([]string, error)
 	GetTables(db database.Connector, selectedSchemas []string) ([]database.TableDetail, error)
@@ -30,41 +44,233 @@ type Analyzer interface {
 
 func NewAnalyzer(config config.MermerdConfig, connectorFactory database.ConnectorFactory, questioner Questioner) Analyzer {
 	loadingSpinner := presentation.NewLoadingSpinner()
-	return analyzer{loadingSpinner, config, connectorFactory, questioner}
+	progressBar := presentation.NewProgressBar()
+	return analyzer{loadingSpinner, progressBar, config, connectorFactory, questioner, time.Second, nil}
 }
 
 func (a analyzer) Analyze() (*database.Result, error) {
-	connectionString, err := a.GetConnectionString()
+	return a.AnalyzeContext(context.Background())
+}
+
+// AnalyzeContext analyzes the database like Analyze, but cancels the queries (and their retries) once the context
+// is done. The error of a cancelled analysis is ErrCancelled, the result then contains the tables that were analyzed
+// until the cancellation (if any).
+func (a analyzer) AnalyzeContext(ctx context.Context) (*database.Result, error) {
+	a.ctx = ctx
+	result, err := a.analyze()
+	if err != nil && ctx.Err() != nil {
+		return result, cancelledError{err}
+	}
+
+	return result, err
+}
+
+func (a analyzer) analyze() (*database.Result, error) {
+	db, err := a.connect()
 	if err != nil {
 		return nil, err
 	}
+	defer db.Close()
 
-	db, err := a.connectorFactory.NewConnector(connectionString)
+	selectedSchemas, err := a.GetSchemas(db)
 	if err != nil {
 		return nil, err
 	}
 
-	a.loadingSpinner.Start("Connecting to database")
-	if err = db.Connect(); err != nil {
+	if err = a.checkPermissions(db, selectedSchemas); err != nil {
+		return nil, err
+	}
+
+	selectedTables, err := a.GetTables(db, selectedSchemas)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.config.Incremental() {
+		return a.getIncrementalResult(db, selectedSchemas, selectedTables)
+	}
+
+	return a.getResult(db, selectedTables)
+}
+
+// Watch keeps the connection open and polls the database in the given interval. onChange is called with the
+// initial result and every time the result has changed. Watch only returns if an error occurs or the context is done.
+// The error of a cancelled watch is ErrCancelled like in AnalyzeContext, the result then contains the tables that
+// were analyzed until the cancellation or the last result if the watch was waiting for the next poll.
+func (a analyzer) Watch(ctx context.Context, interval time.Duration, onChange func(result *database.Result) error) (*database.Result, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("the watch interval must be positive, got %s", interval)
+	}
+
+	a.ctx = ctx
+	result, err := a.watch(interval, onChange)
+	if err != nil && ctx.Err() != nil {
+		return result, cancelledError{err}
+	}
+
+	return result, err
+}
+
+func (a analyzer) watch(interval time.Duration, onChange func(result *database.Result) error) (*database.Result, error) {
+	db, err := a.connect()
+	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
-	a.loadingSpinner.Stop()
 
 	selectedSchemas, err := a.GetSchemas(db)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = a.checkPermissions(db, selectedSchemas); err != nil {
+		return nil, err
+	}
+
 	selectedTables, err := a.GetTables(db, selectedSchemas)
 	if err != nil {
 		return nil, err
 	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previousResult *database.Result
+	for {
+		result, err := a.getResult(db, selectedTables)
+		if err != nil {
+			if result == nil {
+				result = previousResult
+			}
+
+			return result, err
+		}
+
+		if !reflect.DeepEqual(previousResult, result) {
+			logrus.Info("Database changed")
+			if err = onChange(result); err != nil {
+				return result, err
+			}
+			previousResult = result
+		}
+
+		select {
+		case <-a.ctx.Done():
+			return previousResult, a.ctx.Err()
+		case <-ticker.C:
+		}
+
+		// new tables are only picked up if all tables should be used or the tables are selected via patterns or
+		// relations, otherwise the selection stays the same
+		if a.shouldRefreshTables() {
+			if selectedTables, err = a.GetTables(db, selectedSchemas); err != nil {
+				return previousResult, err
+			}
+		}
+	}
+}
+
+func (a analyzer) shouldRefreshTables() bool {
+	return a.config.UseAllTables() ||
+		database.ContainsTableNamePattern(a.config.SelectedTables()) ||
+		len(util.Filter(a.config.Focus(), isNotEmpty)) > 0
+}
+
+func (a analyzer) connect() (database.Connector, error) {
+	connectionString, err := a.GetConnectionString()
+	if err != nil {
+		return nil, err
+	}
+
+	connectionString, err = a.resolveCredentials(connectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := a.connectorFactory.NewConnector(connectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	a.loadingSpinner.Start("Connecting to database")
+	defer a.loadingSpinner.Stop()
+	err = a.query(func(ctx context.Context) error {
+		return db.Connect(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrConnection, err)
+	}
+
+	presentation.RecordPhase(a.getContext(), "connect", start)
+
+	if a.config.AssertReadOnly() {
+		if err = a.assertReadOnly(db); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	return db, nil
+}
+
+// query runs the request with the configured query timeout. Requests that fail because of the connection (see
+// database.IsTransientError) are repeated until the configured connection attempts are used up, waiting twice as long
+// before every further attempt.
+func (a analyzer) query(request func(ctx context.Context) error) error {
+	attempts := a.config.ConnectionAttempts()
+	delay := a.retryDelay
+	for attempt := 1; ; attempt++ {
+		err := a.queryOnce(request)
+		if err == nil || attempt >= attempts || !database.IsTransientError(err) || a.getContext().Err() != nil {
+			return err
+		}
+
+		logrus.WithFields(logrus.Fields{"attempt": attempt, "delay": delay}).Warn("Database request failed, retrying", " | ", err)
+		select {
+		case <-time.After(delay):
+		case <-a.getContext().Done():
+			return err
+		}
+		delay *= 2
+	}
+}
+
+func (a analyzer) queryOnce(request func(ctx context.Context) error) error {
+	ctx, cancel := a.getQueryContext()
+	defer cancel()
+	return request(ctx)
+}
+
+// getQueryContext returns the context of a single query, which is cancelled after the configured query timeout (if
+// any), so a database that does not respond fails instead of blocking forever
+func (a analyzer) getQueryContext() (context.Context, context.CancelFunc) {
+	if timeout := a.config.QueryTimeout(); timeout > 0 {
+		return context.WithTimeout(a.getContext(), timeout)
+	}
+
+	return context.WithCancel(a.getContext())
+}
+
+func (a analyzer) getContext() context.Context {
+	if a.ctx == nil {
+		return context.Background()
+	}
+
+	return a.ctx
+}
+
+func (a analyzer) getResult(db database.Connector, selectedTables []database.TableDetail) (*database.Result, error) {
 	// sort the tables so the output is more deterministic
 	sortTables(selectedTables)
 
 	tableResults, err := a.GetColumnsAndConstraints(db, selectedTables)
 	if err != nil {
+		// the tables that were analyzed until the analysis was cancelled can still be inspected (see AnalyzeContext)
+		if a.getContext().Err() != nil && len(tableResults) > 0 {
+			return &database.Result{Tables: tableResults}, err
+		}
+
 		return nil, err
 	}
 
@@ -82,43 +288,83 @@ func sortTables(tables []database.TableDetail) {
 }
 
 func (a analyzer) GetConnectionString() (string, error) {
-	if connectionString := a.config.ConnectionString(); connectionString != "" {
-		return connectionString, nil
+	connectionString, err := getConfiguredConnectionString(a.config)
+	if err != nil || connectionString != "" {
+		return connectionString, err
+	}
+
+	if a.config.NonInteractive() {
+		return "", fmt.Errorf("%w: no connection string given (use --connectionString, --profile or the configuration file)", ErrMissingInput)
 	}
 
 	return a.questioner.AskConnectionQuestion(a.config.ConnectionStringSuggestions())
 }
 
+// getConfiguredConnectionString returns the connection string or builds it from the parts of the connection, it is
+// empty if neither is configured
+func getConfiguredConnectionString(config config.MermerdConfig) (string, error) {
+	if connectionString := config.ConnectionString(); connectionString != "" {
+		return connectionString, nil
+	}
+
+	// the parts of the connection are only used without a connection string
+	if connection := config.Connection(); connection.Type != "" {
+		connectionString, err := database.BuildConnectionString(database.ConnectionDetails(connection))
+		if err != nil {
+			return "", fmt.Errorf("invalid connection: %w", err)
+		}
+
+		return connectionString, nil
+	}
+
+	return "", nil
+}
+
 func (a analyzer) GetSchemas(db database.Connector) ([]string, error) {
 	if selectedSchema := a.config.Schemas(); len(selectedSchema) > 0 {
 		return selectedSchema, nil
 	}
 
+	start := time.Now()
 	a.loadingSpinner.Start("Getting schemas")
-	schemas, err := db.GetSchemas()
+	var schemas []string
+	err := a.query(func(ctx context.Context) (err error) {
+		schemas, err = db.GetSchemas(ctx)
+		return err
+	})
 	a.loadingSpinner.Stop()
 	if err != nil {
 		logrus.Error("Getting schemas failed", " | ", err)
 		return []string{}, err
 	}
 
-	logrus.WithField("count", len(schemas)).Info("Got schemas")
+	logrus.WithFields(logrus.Fields{"count": len(schemas), "durationMs": getDurationMs(start)}).Info("Got schemas")
+	presentation.RecordPhase(a.getContext(), "schemas", start)
 	if a.config.UseAllSchemas() {
 		return schemas, nil
 	}
 
 	switch len(schemas) {
 	case 0:
-		return []string{}, errors.New("no schemas available")
+		return []string{}, ErrNoSchemas
 	case 1:
 		return schemas, nil
 	default:
+		if a.config.NonInteractive() {
+			return []string{}, fmt.Errorf("%w: multiple schemas available (%s), use --schema or --useAllSchemas", ErrMissingInput, strings.Join(schemas, ", "))
+		}
+
 		return a.questioner.AskSchemaQuestion(schemas)
 	}
 }
 
 func (a analyzer) GetTables(db database.Connector, selectedSchemas []string) ([]database.TableDetail, error) {
-	if selectedTables := a.config.SelectedTables(); len(selectedTables) > 0 {
+	if focus := util.Filter(a.config.Focus(), isNotEmpty); len(focus) > 0 {
+		return a.getFocusedTables(db, selectedSchemas, focus)
+	}
+
+	selectedTables := a.config.SelectedTables()
+	if len(selectedTables) > 0 && !database.ContainsTableNamePattern(selectedTables) {
 		return util.Map2(selectedTables, func(value string) database.TableDetail {
 			res, err := database.ParseTableName(value, selectedSchemas)
 			if err != nil {
@@ -129,24 +375,37 @@ func (a analyzer) GetTables(db database.Connector, selectedSchemas []string) ([]
 		}), nil
 	}
 
+	start := time.Now()
 	a.loadingSpinner.Start("Getting tables")
-	tables, err := db.GetTables(selectedSchemas)
+	tables, err := a.getAvailableTables(db, selectedSchemas)
 	a.loadingSpinner.Stop()
 	if err != nil {
-		logrus.Error("Getting tables failed", " | ", err)
 		return nil, err
 	}
 
+	if len(selectedTables) > 0 {
+		if tables, err = filterTables(tables, selectedTables, true); err != nil {
+			logrus.Error("Selecting tables failed", " | ", err)
+			return nil, err
+		}
+	}
+
 	if len(tables) == 0 {
-		logrus.Error("No tables found")
+		return nil, ErrNoTables
 	}
 
-	logrus.WithField("count", len(tables)).Info("Got tables")
+	logrus.WithFields(logrus.Fields{"schemas": selectedSchemas, "count": len(tables), "durationMs": getDurationMs(start)}).Info("Got tables")
+	presentation.RecordPhase(a.getContext(), "tables", start)
 
-	if a.config.UseAllTables() {
+	// tables that were selected via patterns do not need to be confirmed
+	if len(selectedTables) > 0 || a.config.UseAllTables() {
 		return tables, nil
 	}
 
+	if a.config.NonInteractive() {
+		return []database.TableDetail{}, fmt.Errorf("%w: no tables selected (use --selectedTables, --useAllTables or --focus)", ErrMissingInput)
+	}
+
 	tableNames := util.Map2(tables, func(table database.TableDetail) string {
 		return fmt.Sprintf("%s.%s", table.Schema, table.Name)
 	})
@@ -160,35 +419,363 @@ func (a analyzer) GetTables(db database.Connector, selectedSchemas []string) ([]
 			logrus.Error("Could not parse table name", value)
 		}
 
+		// use the table of the available tables, as it contains all details (e.g. if it is a view)
+		if table, ok := findTable(tables, res); ok {
+			return table
+		}
+
 		return res
 	}), nil
 }
 
+// getAvailableTables gets the tables of the schemas without the excluded tables and, if not configured otherwise,
+// without the views and partitions
+func (a analyzer) getAvailableTables(db database.Connector, selectedSchemas []string) ([]database.TableDetail, error) {
+	var tables []database.TableDetail
+	err := a.query(func(ctx context.Context) (err error) {
+		tables, err = db.GetTables(ctx, selectedSchemas)
+		return err
+	})
+	if err != nil {
+		logrus.Error("Getting tables failed", " | ", err)
+		return nil, err
+	}
+
+	if !a.config.IncludeViews() {
+		tables = util.Filter(tables, func(table database.TableDetail) bool {
+			return !table.IsView
+		})
+	}
+
+	// the partitions have the same columns as the partitioned table, which already represents them
+	if !a.config.ShowPartitions() {
+		tables = util.Filter(tables, func(table database.TableDetail) bool {
+			return table.PartitionOf == ""
+		})
+	}
+
+	if tables, err = filterTables(tables, a.config.ExcludeTables(), false); err != nil {
+		logrus.Error("Excluding tables failed", " | ", err)
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// getFocusedTables selects the focused tables and walks their foreign keys (in both directions) to select the
+// related tables, until the configured depth is reached
+func (a analyzer) getFocusedTables(db database.Connector, selectedSchemas []string, focus []string) ([]database.TableDetail, error) {
+	start := time.Now()
+	a.loadingSpinner.Start("Getting related tables")
+	defer a.loadingSpinner.Stop()
+
+	availableTables, err := a.getAvailableTables(db, selectedSchemas)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedTables, err := filterTables(availableTables, focus, true)
+	if err != nil {
+		logrus.Error("Selecting focused tables failed", " | ", err)
+		return nil, err
+	}
+
+	if len(selectedTables) == 0 {
+		return nil, fmt.Errorf("none of the focused tables was found: %w", ErrNoTables)
+	}
+
+	currentLevel := selectedTables
+	maxDepth := a.config.Depth()
+	for depth := 0; depth < maxDepth; depth++ {
+		var nextLevel []database.TableDetail
+		for _, table := range currentLevel {
+			var constraints []database.ConstraintResult
+			err := a.query(func(ctx context.Context) (err error) {
+				constraints, err = db.GetConstraints(ctx, table)
+				return err
+			})
+			if err != nil {
+				logrus.Error("Getting constraints failed", " | ", err)
+				return nil, err
+			}
+
+			for _, constraint := range constraints {
+				for _, relatedTable := range []database.TableDetail{
+					{Schema: constraint.FkSchema, Name: constraint.FkTable},
+					{Schema: constraint.PkSchema, Name: constraint.PkTable},
+				} {
+					// use the table of the available tables, as it contains all details (e.g. the locality)
+					relatedTable, ok := findTable(availableTables, relatedTable)
+					if ok && !containsTable(selectedTables, relatedTable) {
+						selectedTables = append(selectedTables, relatedTable)
+						nextLevel = append(nextLevel, relatedTable)
+					}
+				}
+			}
+		}
+
+		currentLevel = nextLevel
+	}
+
+	logrus.WithFields(logrus.Fields{"schemas": selectedSchemas, "count": len(selectedTables), "durationMs": getDurationMs(start)}).Info("Got related tables")
+	presentation.RecordPhase(a.getContext(), "related tables", start)
+	return selectedTables, nil
+}
+
+func findTable(tables []database.TableDetail, table database.TableDetail) (database.TableDetail, bool) {
+	for _, item := range tables {
+		if item.Schema == table.Schema && item.Name == table.Name {
+			return item, true
+		}
+	}
+
+	return database.TableDetail{}, false
+}
+
+func containsTable(tables []database.TableDetail, table database.TableDetail) bool {
+	_, ok := findTable(tables, table)
+	return ok
+}
+
+// filterTables keeps the tables that match one of the patterns (include) or the ones that match none (exclude)
+func filterTables(tables []database.TableDetail, patterns []string, include bool) ([]database.TableDetail, error) {
+	if len(patterns) == 0 {
+		return tables, nil
+	}
+
+	var result []database.TableDetail
+	for _, table := range tables {
+		matches, err := database.MatchAnyTableName(patterns, table)
+		if err != nil {
+			return nil, err
+		}
+
+		if matches == include {
+			result = append(result, table)
+		}
+	}
+
+	return result, nil
+}
+
 func (a analyzer) GetColumnsAndConstraints(db database.Connector, selectedTables []database.TableDetail) ([]database.TableResult, error) {
 	var tableResults []database.TableResult
-	a.loadingSpinner.Start("Getting columns and constraints")
+	includeColumns := util.Filter(a.config.IncludeColumns(), isNotEmpty)
+	excludeColumns := util.Filter(a.config.ExcludeColumns(), isNotEmpty)
+	showIndexes := a.config.ShowIndexes()
+	skipFailingTables := a.config.SkipFailingTables()
+	var skippedTables []string
+	sampleRows := a.config.SampleRows()
+	var maskColumns []string
+	sampleConnector, isSampleConnector := db.(database.SampleConnector)
+	if sampleRows > 0 {
+		maskColumns = util.Filter(a.config.MaskColumns(), isNotEmpty)
+		if !isSampleConnector {
+			logrus.Warn("The database does not support sample rows, they are omitted")
+		}
+	}
+	classifications, err := a.config.Classifications()
+	if err != nil {
+		logrus.Error("Getting columns and constraints failed", " | ", err)
+		return nil, err
+	}
+
+	columnOrder := a.config.ColumnOrder()
+	if columnOrder != "" && columnOrder != columnOrderAlphabetical && columnOrder != columnOrderOrdinal {
+		err := fmt.Errorf("unsupported column order %q (alphabetical or ordinal)", columnOrder)
+		logrus.Error("Getting columns and constraints failed", " | ", err)
+		return nil, err
+	}
+
+	start := time.Now()
+	a.progressBar.Start("Getting columns and constraints", "table", len(selectedTables))
+	// stops the progress bar if the analysis fails, it is stopped explicitly before the results are shown otherwise
+	defer a.progressBar.Stop()
+	bulkResult, err := a.getBulkResult(db, selectedTables)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, table := range selectedTables {
-		columns, err := db.GetColumns(table)
+		if err := a.getContext().Err(); err != nil {
+			return tableResults, err
+		}
+
+		tableStart := time.Now()
+		var columns []database.ColumnResult
+		err := a.query(func(ctx context.Context) (err error) {
+			columns, err = bulkResult.getColumns(ctx, db, table)
+			return err
+		})
 		if err != nil {
+			if a.skipFailingTable(table, skipFailingTables, err) {
+				skippedTables = append(skippedTables, table.Schema+"."+table.Name)
+				a.progressBar.Increment()
+				continue
+			}
+
 			logrus.Error("Getting columns failed", " | ", err)
+			return a.getCancelledTableResults(tableResults, err)
+		}
+
+		columns, err = filterColumns(table, columns, includeColumns, excludeColumns)
+		if err != nil {
+			logrus.Error("Filtering columns failed", " | ", err)
 			return nil, err
 		}
 
-		constraints, err := db.GetConstraints(table)
+		if err = classifyColumns(table, columns, classifications); err != nil {
+			logrus.Error("Classifying columns failed", " | ", err)
+			return nil, err
+		}
+
+		var constraints []database.ConstraintResult
+		err = a.query(func(ctx context.Context) (err error) {
+			constraints, err = bulkResult.getConstraints(ctx, db, table)
+			return err
+		})
 		if err != nil {
+			if a.skipFailingTable(table, skipFailingTables, err) {
+				skippedTables = append(skippedTables, table.Schema+"."+table.Name)
+				a.progressBar.Increment()
+				continue
+			}
+
 			logrus.Error("Getting constraints failed", " | ", err)
+			return a.getCancelledTableResults(tableResults, err)
+		}
+
+		constraints = database.MergeCompositeConstraints(constraints)
+
+		var indexes []database.IndexResult
+		if showIndexes {
+			err = a.query(func(ctx context.Context) (err error) {
+				indexes, err = db.GetIndexes(ctx, table)
+				return err
+			})
+			if err != nil {
+				if a.skipFailingTable(table, skipFailingTables, err) {
+					skippedTables = append(skippedTables, table.Schema+"."+table.Name)
+					a.progressBar.Increment()
+					continue
+				}
+
+				logrus.Error("Getting indexes failed", " | ", err)
+				return a.getCancelledTableResults(tableResults, err)
+			}
+		}
+
+		sortColumns(columns, columnOrder)
+		var rows [][]string
+		if sampleRows > 0 && isSampleConnector {
+			// the sample rows only document the tables, so the analysis continues without them (e.g. without permission)
+			rows, err = a.getSampleRows(sampleConnector, table, columns, sampleRows, maskColumns)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{"schema": table.Schema, "table": table.Name}).Warn("Getting sample rows failed", " | ", err)
+			}
+		}
+
+		tableResults = append(tableResults, database.TableResult{Table: table, Columns: columns, Constraints: constraints, Indexes: indexes, SampleRows: rows})
+		logrus.WithFields(logrus.Fields{
+			"schema":      table.Schema,
+			"table":       table.Name,
+			"columns":     len(columns),
+			"constraints": len(constraints),
+			"durationMs":  getDurationMs(tableStart),
+		}).Info("Got table")
+		a.progressBar.Increment()
+	}
+	a.progressBar.Stop()
+
+	if len(skippedTables) > 0 {
+		if len(tableResults) == 0 {
+			return nil, fmt.Errorf("none of the tables could be read: %w", ErrNoTables)
+		}
+
+		logrus.WithField("tables", skippedTables).Warn("Skipped the tables that could not be read")
+		presentation.ShowSkippedTables(skippedTables)
+	}
+
+	if a.config.InferRelationships() {
+		var err error
+		tableResults, err = database.InferConstraints(tableResults, a.config.InferRelationshipPatterns())
+		if err != nil {
+			logrus.Error("Inferring relationships failed", " | ", err)
 			return nil, err
 		}
+	}
 
-		sortColumns(columns)
-		tableResults = append(tableResults, database.TableResult{Table: table, Columns: columns, Constraints: constraints})
+	// the databases return the constraints and indexes in the order of their catalogs, which changes e.g. after a
+	// migration, so they are sorted to keep the output stable
+	for _, tableResult := range tableResults {
+		sortConstraints(tableResult.Constraints)
+		sortIndexes(tableResult.Indexes)
 	}
-	a.loadingSpinner.Stop()
+
 	columnCount, constraintCount := getTableResultStats(tableResults)
-	logrus.WithFields(logrus.Fields{"columns": columnCount, "constraints": constraintCount}).Info("Got columns and constraints")
+	logrus.WithFields(logrus.Fields{"columns": columnCount, "constraints": constraintCount, "durationMs": getDurationMs(start)}).Info("Got columns and constraints")
+	presentation.RecordPhase(a.getContext(), "columns and constraints", start)
 	return tableResults, nil
 }
 
+// skipFailingTable is true if the table is left out of the result because it could not be read (--skip-failing-tables),
+// e.g. because of missing permissions or a broken view. A cancelled analysis is never continued.
+func (a analyzer) skipFailingTable(table database.TableDetail, skipFailingTables bool, err error) bool {
+	if !skipFailingTables || a.getContext().Err() != nil {
+		return false
+	}
+
+	logrus.WithFields(logrus.Fields{"schema": table.Schema, "table": table.Name}).Warn("Skipping the table, as it could not be read", " | ", err)
+	return true
+}
+
+// getCancelledTableResults returns the tables that were analyzed so far if the analysis was cancelled, otherwise the
+// query failed and there is no result
+func (a analyzer) getCancelledTableResults(tableResults []database.TableResult, err error) ([]database.TableResult, error) {
+	if a.getContext().Err() != nil {
+		return tableResults, err
+	}
+
+	return nil, err
+}
+
+// filterColumns keeps the columns that match one of the include patterns (if any are given) and none of the exclude
+// patterns
+func filterColumns(table database.TableDetail, columns []database.ColumnResult, includePatterns []string, excludePatterns []string) ([]database.ColumnResult, error) {
+	if len(includePatterns) == 0 && len(excludePatterns) == 0 {
+		return columns, nil
+	}
+
+	var result []database.ColumnResult
+	for _, column := range columns {
+		if len(includePatterns) > 0 {
+			included, err := database.MatchAnyColumnName(includePatterns, table, column.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			if !included {
+				continue
+			}
+		}
+
+		excluded, err := database.MatchAnyColumnName(excludePatterns, table, column.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !excluded {
+			result = append(result, column)
+		}
+	}
+
+	return result, nil
+}
+
+func isNotEmpty(value string) bool {
+	return value != ""
+}
+
 func getTableResultStats(tableResults []database.TableResult) (columnCount int, constraintCount int) {
 	for _, tableResult := range tableResults {
 		columnCount += len(tableResult.Columns)
@@ -198,8 +785,46 @@ func getTableResultStats(tableResults []database.TableResult) (columnCount int,
 	return columnCount, constraintCount
 }
 
-func sortColumns(columns []database.ColumnResult) {
+// sortColumns sorts the columns by name or by their position in the table definition
+func sortColumns(columns []database.ColumnResult, columnOrder string) {
 	sort.SliceStable(columns, func(i, j int) bool {
+		if columnOrder == columnOrderOrdinal {
+			return columns[i].OrdinalPosition < columns[j].OrdinalPosition
+		}
+
 		return columns[i].Name < columns[j].Name
 	})
 }
+
+// sortConstraints sorts the constraints by their tables and columns, the constraint name distinguishes the constraints
+// of the same columns
+func sortConstraints(constraints []database.ConstraintResult) {
+	sort.SliceStable(constraints, func(i, j int) bool {
+		a, b := constraints[i], constraints[j]
+		switch {
+		case a.FkSchema != b.FkSchema:
+			return a.FkSchema < b.FkSchema
+		case a.FkTable != b.FkTable:
+			return a.FkTable < b.FkTable
+		case a.PkSchema != b.PkSchema:
+			return a.PkSchema < b.PkSchema
+		case a.PkTable != b.PkTable:
+			return a.PkTable < b.PkTable
+		case a.ColumnName != b.ColumnName:
+			return a.ColumnName < b.ColumnName
+		default:
+			return a.ConstraintName < b.ConstraintName
+		}
+	})
+}
+
+func sortIndexes(indexes []database.IndexResult) {
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return indexes[i].Name < indexes[j].Name
+	})
+}
+
+// getDurationMs returns the milliseconds since start, which are logged for every step of the analysis
+func getDurationMs(start time.Time) int64 {
+	return time.Since(start).Milliseconds()
+}
@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/util"
+)
+
+// bulkResult contains the columns and constraints of all schemas of the selected tables, which are read at once if
+// the connector supports it
+type bulkResult struct {
+	columns     map[database.TableDetail][]database.ColumnResult
+	constraints []database.ConstraintResult
+}
+
+// getBulkResult returns nil if the connector does not support bulk queries, in which case the columns and
+// constraints are read per table
+func (a analyzer) getBulkResult(db database.Connector, selectedTables []database.TableDetail) (*bulkResult, error) {
+	bulkConnector, ok := db.(database.BulkConnector)
+	if !ok || len(selectedTables) == 0 {
+		return nil, nil
+	}
+
+	schemaNames := getSchemaNames(selectedTables)
+	var columns map[database.TableDetail][]database.ColumnResult
+	err := a.query(func(ctx context.Context) (err error) {
+		columns, err = bulkConnector.GetAllColumns(ctx, schemaNames)
+		return err
+	})
+	if err != nil {
+		logrus.Error("Getting columns failed", " | ", err)
+		return nil, err
+	}
+
+	var constraints []database.ConstraintResult
+	err = a.query(func(ctx context.Context) (err error) {
+		constraints, err = bulkConnector.GetAllConstraints(ctx, schemaNames)
+		return err
+	})
+	if err != nil {
+		logrus.Error("Getting constraints failed", " | ", err)
+		return nil, err
+	}
+
+	logrus.WithField("schemas", len(schemaNames)).Debug("Got columns and constraints with bulk queries")
+	return &bulkResult{columns: columns, constraints: constraints}, nil
+}
+
+// getColumns returns the columns of the table. Tables that are not part of the bulk result (e.g. materialized views
+// of postgres) are read separately.
+func (r *bulkResult) getColumns(ctx context.Context, db database.Connector, table database.TableDetail) ([]database.ColumnResult, error) {
+	if r == nil {
+		return db.GetColumns(ctx, table)
+	}
+
+	columns, ok := r.columns[database.TableDetail{Schema: table.Schema, Name: table.Name}]
+	if !ok {
+		return db.GetColumns(ctx, table)
+	}
+
+	return columns, nil
+}
+
+// getConstraints returns the constraints of the table and the constraints of other tables referencing it, in the
+// same way as the connectors do per table
+func (r *bulkResult) getConstraints(ctx context.Context, db database.Connector, table database.TableDetail) ([]database.ConstraintResult, error) {
+	if r == nil {
+		return db.GetConstraints(ctx, table)
+	}
+
+	var constraints []database.ConstraintResult
+	for _, constraint := range r.constraints {
+		isFkTable := constraint.FkSchema == table.Schema && database.SanitizeValue(constraint.FkTable) == table.Name
+		isPkTable := constraint.PkSchema == table.Schema && database.SanitizeValue(constraint.PkTable) == table.Name
+		if isFkTable || isPkTable {
+			constraints = append(constraints, constraint)
+		}
+	}
+
+	return constraints, nil
+}
+
+func getSchemaNames(tables []database.TableDetail) []string {
+	var schemaNames []string
+	for _, table := range tables {
+		if !util.Contains(schemaNames, table.Schema) {
+			schemaNames = append(schemaNames, table.Schema)
+		}
+	}
+
+	return schemaNames
+}
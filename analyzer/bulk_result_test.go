@@ -0,0 +1,146 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+// bulkConnectorMock is a connector that supports the bulk queries
+type bulkConnectorMock struct {
+	mocks.Connector
+	mocks.BulkConnector
+}
+
+func getAnalyzerWithConfigMock() analyzer {
+	configMock := mocks.MermerdConfig{}
+	configMock.On("QueryTimeout").Return(time.Duration(0)).Maybe()
+	configMock.On("ConnectionAttempts").Return(1).Maybe()
+	return analyzer{config: &configMock}
+}
+
+func TestAnalyzer_GetBulkResult(t *testing.T) {
+	tables := []database.TableDetail{
+		{Schema: "schemaA", Name: "tableA"},
+		{Schema: "schemaB", Name: "tableA"},
+		{Schema: "schemaA", Name: "tableB"},
+	}
+
+	t.Run("Connector without bulk support", func(t *testing.T) {
+		// Arrange
+		connectorMock := mocks.Connector{}
+
+		// Act
+		result, err := getAnalyzerWithConfigMock().getBulkResult(&connectorMock, tables)
+
+		// Assert
+		connectorMock.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Connector with bulk support", func(t *testing.T) {
+		// Arrange
+		connectorMock := bulkConnectorMock{}
+		columns := map[database.TableDetail][]database.ColumnResult{{Schema: "schemaA", Name: "tableA"}: {{Name: "id"}}}
+		constraints := []database.ConstraintResult{{FkSchema: "schemaA", FkTable: "tableB", PkSchema: "schemaA", PkTable: "tableA"}}
+		connectorMock.BulkConnector.On("GetAllColumns", mock.Anything, []string{"schemaA", "schemaB"}).Return(columns, nil).Once()
+		connectorMock.BulkConnector.On("GetAllConstraints", mock.Anything, []string{"schemaA", "schemaB"}).Return(constraints, nil).Once()
+
+		// Act
+		result, err := getAnalyzerWithConfigMock().getBulkResult(&connectorMock, tables)
+
+		// Assert
+		connectorMock.BulkConnector.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Equal(t, &bulkResult{columns: columns, constraints: constraints}, result)
+	})
+
+	t.Run("Bulk query fails", func(t *testing.T) {
+		// Arrange
+		connectorMock := bulkConnectorMock{}
+		connectorMock.BulkConnector.On("GetAllColumns", mock.Anything, []string{"schemaA", "schemaB"}).Return(nil, errors.New("error")).Once()
+
+		// Act
+		result, err := getAnalyzerWithConfigMock().getBulkResult(&connectorMock, tables)
+
+		// Assert
+		connectorMock.BulkConnector.AssertExpectations(t)
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestBulkResult_GetColumns(t *testing.T) {
+	t.Run("Columns of the bulk result", func(t *testing.T) {
+		// Arrange
+		connectorMock := mocks.Connector{}
+		result := &bulkResult{columns: map[database.TableDetail][]database.ColumnResult{
+			{Schema: "schemaA", Name: "tableA"}: {{Name: "id"}},
+		}}
+
+		// Act
+		columns, err := result.getColumns(context.Background(), &connectorMock, database.TableDetail{Schema: "schemaA", Name: "tableA", IsView: true})
+
+		// Assert
+		connectorMock.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Equal(t, []database.ColumnResult{{Name: "id"}}, columns)
+	})
+
+	t.Run("Tables that are not part of the bulk result are read separately", func(t *testing.T) {
+		// Arrange
+		connectorMock := mocks.Connector{}
+		table := database.TableDetail{Schema: "schemaA", Name: "materializedView", IsView: true}
+		connectorMock.On("GetColumns", mock.Anything, table).Return([]database.ColumnResult{{Name: "total"}}, nil).Once()
+		result := &bulkResult{columns: map[database.TableDetail][]database.ColumnResult{}}
+
+		// Act
+		columns, err := result.getColumns(context.Background(), &connectorMock, table)
+
+		// Assert
+		connectorMock.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Equal(t, []database.ColumnResult{{Name: "total"}}, columns)
+	})
+
+	t.Run("Without bulk result", func(t *testing.T) {
+		// Arrange
+		connectorMock := mocks.Connector{}
+		table := database.TableDetail{Schema: "schemaA", Name: "tableA"}
+		connectorMock.On("GetColumns", mock.Anything, table).Return([]database.ColumnResult{{Name: "id"}}, nil).Once()
+		var result *bulkResult
+
+		// Act
+		columns, err := result.getColumns(context.Background(), &connectorMock, table)
+
+		// Assert
+		connectorMock.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Equal(t, []database.ColumnResult{{Name: "id"}}, columns)
+	})
+}
+
+func TestBulkResult_GetConstraints(t *testing.T) {
+	// Arrange
+	connectorMock := mocks.Connector{}
+	ownConstraint := database.ConstraintResult{FkSchema: "schemaA", FkTable: "tableA", PkSchema: "schemaA", PkTable: "tableB"}
+	referencingConstraint := database.ConstraintResult{FkSchema: "schemaA", FkTable: "tableC", PkSchema: "schemaA", PkTable: "tableA"}
+	otherSchemaConstraint := database.ConstraintResult{FkSchema: "schemaB", FkTable: "tableA", PkSchema: "schemaB", PkTable: "tableB"}
+	result := &bulkResult{constraints: []database.ConstraintResult{ownConstraint, referencingConstraint, otherSchemaConstraint}}
+
+	// Act
+	constraints, err := result.getConstraints(context.Background(), &connectorMock, database.TableDetail{Schema: "schemaA", Name: "tableA"})
+
+	// Assert
+	connectorMock.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, []database.ConstraintResult{ownConstraint, referencingConstraint}, constraints)
+}
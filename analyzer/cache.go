@@ -0,0 +1,201 @@
+package analyzer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/util"
+)
+
+// cachingAnalyzer reads the model from the cache instead of the database, if the same connection and tables were
+// analyzed within the ttl. The other methods (e.g. Watch) always query the database.
+type cachingAnalyzer struct {
+	Analyzer
+	config config.MermerdConfig
+}
+
+// cacheKeyValues are the options that change the analyzed model, the options of the diagram (e.g. the output format)
+// are not part of the cache key
+type cacheKeyValues struct {
+	// ConnectionTarget is the resolved connection string without the password (e.g. of env:DB_URL), so the key
+	// distinguishes the databases and users behind the same configured value
+	ConnectionTarget string
+	Schemas          []string
+	UseAllSchemas    bool
+	SelectedTables   []string
+	UseAllTables     bool
+	ExcludeTables    []string
+	IncludeViews     bool
+	// SkipFailingTables is part of the key, so a strict run does not use the model without the skipped tables
+	SkipFailingTables         bool
+	ShowPartitions            bool
+	Focus                     []string
+	Depth                     int
+	IncludeColumns            []string
+	ExcludeColumns            []string
+	InferRelationships        bool
+	InferRelationshipPatterns []string
+	ShowIndexes               bool
+	ColumnOrder               string
+	SampleRows                int
+	MaskColumns               []string
+	Classifications           []config.Classification
+	DataTypes                 map[string]string
+}
+
+func NewCachingAnalyzer(config config.MermerdConfig, analyzer Analyzer) Analyzer {
+	return cachingAnalyzer{analyzer, config}
+}
+
+func (c cachingAnalyzer) Analyze() (*database.Result, error) {
+	return c.AnalyzeContext(context.Background())
+}
+
+func (c cachingAnalyzer) AnalyzeContext(ctx context.Context) (*database.Result, error) {
+	if !isCacheable(c.config) {
+		logrus.Warn("The model is not cached, as the connection string, the schemas or the tables would be asked for")
+		return c.Analyzer.AnalyzeContext(ctx)
+	}
+
+	fileName, err := getCacheFileName(c.config)
+	if err != nil {
+		return nil, err
+	}
+
+	if result, ok := readCachedResult(fileName, c.config.CacheTtl()); ok {
+		return result, nil
+	}
+
+	result, err := c.Analyzer.AnalyzeContext(ctx)
+	if err != nil {
+		// the partial result of a cancelled analysis is returned, but not cached
+		return result, err
+	}
+
+	// the diagram can be created without the cache, so the analysis does not fail
+	if err = writeCachedResult(fileName, result); err != nil {
+		logrus.WithField("file", fileName).Warn("Could not cache the model", " | ", err)
+	}
+
+	return result, nil
+}
+
+// isCacheable is true if the options determine the analyzed tables, the answers of the questions are not part of
+// the cache key
+func isCacheable(config config.MermerdConfig) bool {
+	if config.ConnectionString() == "" && config.Connection().Type == "" {
+		return false
+	}
+
+	if config.NonInteractive() {
+		return true
+	}
+
+	hasSchemas := config.UseAllSchemas() || len(util.Filter(config.Schemas(), isNotEmpty)) > 0
+	hasTables := config.UseAllTables() || len(util.Filter(config.SelectedTables(), isNotEmpty)) > 0 ||
+		len(util.Filter(config.Focus(), isNotEmpty)) > 0
+	return hasSchemas && hasTables
+}
+
+// getCacheFileName returns the cache file of the options, whose hash is the name so that the connection string is
+// not stored
+func getCacheFileName(config config.MermerdConfig) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	key, err := getCacheKey(config)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, "mermerd", key+".json"), nil
+}
+
+func getCacheKey(config config.MermerdConfig) (string, error) {
+	classifications, err := config.Classifications()
+	if err != nil {
+		return "", err
+	}
+
+	connectionString, err := getConfiguredConnectionString(config)
+	if err != nil {
+		return "", err
+	}
+
+	connectionString, err = resolveConnectionString(connectionString)
+	if err != nil {
+		return "", err
+	}
+
+	values, err := json.Marshal(cacheKeyValues{
+		ConnectionTarget:          database.GetConnectionTarget(connectionString),
+		Schemas:                   util.Filter(config.Schemas(), isNotEmpty),
+		UseAllSchemas:             config.UseAllSchemas(),
+		SelectedTables:            util.Filter(config.SelectedTables(), isNotEmpty),
+		UseAllTables:              config.UseAllTables(),
+		ExcludeTables:             util.Filter(config.ExcludeTables(), isNotEmpty),
+		IncludeViews:              config.IncludeViews(),
+		SkipFailingTables:         config.SkipFailingTables(),
+		ShowPartitions:            config.ShowPartitions(),
+		Focus:                     util.Filter(config.Focus(), isNotEmpty),
+		Depth:                     config.Depth(),
+		IncludeColumns:            util.Filter(config.IncludeColumns(), isNotEmpty),
+		ExcludeColumns:            util.Filter(config.ExcludeColumns(), isNotEmpty),
+		InferRelationships:        config.InferRelationships(),
+		InferRelationshipPatterns: config.InferRelationshipPatterns(),
+		ShowIndexes:               config.ShowIndexes(),
+		ColumnOrder:               config.ColumnOrder(),
+		SampleRows:                config.SampleRows(),
+		MaskColumns:               util.Filter(config.MaskColumns(), isNotEmpty),
+		Classifications:           classifications,
+		DataTypes:                 config.DataTypes(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(values)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// readCachedResult returns the cached model if it is younger than the ttl (a ttl of 0 never expires)
+func readCachedResult(fileName string, ttl time.Duration) (*database.Result, bool) {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return nil, false
+	}
+
+	age := time.Since(info.ModTime())
+	if ttl > 0 && age > ttl {
+		logrus.WithField("file", fileName).Info("The cached model expired")
+		return nil, false
+	}
+
+	result, err := database.ReadResult(fileName)
+	if err != nil {
+		logrus.WithField("file", fileName).Warn("Could not read the cached model", " | ", err)
+		return nil, false
+	}
+
+	logrus.WithFields(logrus.Fields{"file": fileName, "ageMs": age.Milliseconds()}).Info("Using the cached model")
+	return result, true
+}
+
+func writeCachedResult(fileName string, result *database.Result) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0o700); err != nil {
+		return err
+	}
+
+	return database.WriteResult(fileName, result)
+}
@@ -0,0 +1,25 @@
+package analyzer
+
+import (
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+// classifyColumns tags the columns with the names of the classifications that match them, in the order of the
+// configuration file
+func classifyColumns(table database.TableDetail, columns []database.ColumnResult, classifications []config.Classification) error {
+	for columnIndex := range columns {
+		for _, classification := range classifications {
+			matches, err := database.MatchAnyColumnName(classification.Columns, table, columns[columnIndex].Name)
+			if err != nil {
+				return err
+			}
+
+			if matches {
+				columns[columnIndex].Classifications = append(columns[columnIndex].Classifications, classification.Name)
+			}
+		}
+	}
+
+	return nil
+}
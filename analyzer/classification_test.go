@@ -0,0 +1,71 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+func TestClassifyColumns(t *testing.T) {
+	table := database.TableDetail{Schema: "public", Name: "user"}
+
+	t.Run("Columns are tagged with all matching classifications", func(t *testing.T) {
+		// Arrange
+		columns := []database.ColumnResult{{Name: "id"}, {Name: "email"}, {Name: "ssn"}, {Name: "password_hash"}}
+		classifications := []config.Classification{
+			{Name: "pii", Columns: []string{"*.email", "/ssn/"}},
+			{Name: "secret", Columns: []string{"*_hash", "ssn"}},
+		}
+
+		// Act
+		err := classifyColumns(table, columns, classifications)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Nil(t, columns[0].Classifications)
+		assert.Equal(t, []string{"pii"}, columns[1].Classifications)
+		assert.Equal(t, []string{"pii", "secret"}, columns[2].Classifications)
+		assert.Equal(t, []string{"secret"}, columns[3].Classifications)
+	})
+
+	t.Run("Invalid pattern", func(t *testing.T) {
+		// Arrange
+		columns := []database.ColumnResult{{Name: "id"}}
+
+		// Act
+		err := classifyColumns(table, columns, []config.Classification{{Name: "pii", Columns: []string{"/[/"}}})
+
+		// Assert
+		assert.NotNil(t, err)
+	})
+}
+
+func TestAnalyzer_GetColumnsAndConstraintsWithClassifications(t *testing.T) {
+	// Arrange
+	analyzer, configMock, _, _ := getAnalyzerWithMocks()
+	connectorMock := mocks.Connector{}
+	table := database.TableDetail{Schema: "public", Name: "user"}
+	configMock.On("IncludeColumns").Return([]string{}).Once()
+	configMock.On("ExcludeColumns").Return([]string{}).Once()
+	configMock.On("InferRelationships").Return(false).Once()
+	configMock.On("ShowIndexes").Return(false).Once()
+	configMock.On("ColumnOrder").Return("").Once()
+	configMock.On("SampleRows").Return(0).Once()
+	configMock.On("Classifications").Return([]config.Classification{{Name: "pii", Columns: []string{"email"}}}, nil).Once()
+	connectorMock.On("GetColumns", mock.Anything, table).Return([]database.ColumnResult{{Name: "id"}, {Name: "email"}}, nil).Once()
+	connectorMock.On("GetConstraints", mock.Anything, table).Return([]database.ConstraintResult{}, nil).Once()
+
+	// Act
+	result, err := analyzer.GetColumnsAndConstraints(&connectorMock, []database.TableDetail{table})
+
+	// Assert
+	configMock.AssertExpectations(t)
+	connectorMock.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, []database.ColumnResult{{Name: "email", Classifications: []string{"pii"}}, {Name: "id"}}, result[0].Columns)
+}
@@ -0,0 +1,27 @@
+package analyzer
+
+import (
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+// NewConnectorFactory returns the factory of the connectors, which applies the configured ssh tunnel, tls,
+// authentication, credential command, read-only session, data type and connector plugin options
+func NewConnectorFactory(config config.MermerdConfig) database.ConnectorFactory {
+	return database.NewConnectorFactoryWithOptions(database.ConnectorOptions{
+		SshTunnel: database.SshTunnelConfig{
+			Destination:  config.Ssh(),
+			IdentityFile: config.SshIdentityFile(),
+		},
+		Tls: database.TlsConfig{
+			CaCertFile:     config.TlsCaCert(),
+			ClientCertFile: config.TlsClientCert(),
+			ClientKeyFile:  config.TlsClientKey(),
+			SkipVerify:     config.TlsSkipVerify(),
+		},
+		AuthMode:        config.AuthMode(),
+		ReadOnlySession: config.AssertReadOnly(),
+		DataTypes:       config.DataTypes(),
+		Plugins:         config.ConnectorPlugins(),
+	})
+}
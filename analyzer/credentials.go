@@ -0,0 +1,89 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+// envConnectionStringPrefix marks a connection string that is read from an environment variable (e.g. env:DB_URL)
+const envConnectionStringPrefix = "env:"
+
+// resolveCredentials reads the connection string from the environment variable (if it has the env: prefix) and adds
+// the password of the configured password file, the credential command or the asked password, so no credentials have to be stored in the run
+// configuration
+func (a analyzer) resolveCredentials(connectionString string) (string, error) {
+	connectionString, err := resolveConnectionString(connectionString)
+	if err != nil {
+		return "", err
+	}
+
+	password, found, err := a.getPassword()
+	if err != nil {
+		return "", err
+	}
+
+	if !found {
+		return connectionString, nil
+	}
+
+	return database.SetConnectionPassword(connectionString, password)
+}
+
+// resolveConnectionString reads the connection string from the environment variable if it has the env: prefix
+func resolveConnectionString(connectionString string) (string, error) {
+	if !strings.HasPrefix(connectionString, envConnectionStringPrefix) {
+		return connectionString, nil
+	}
+
+	variable := strings.TrimPrefix(connectionString, envConnectionStringPrefix)
+	connectionString = os.Getenv(variable)
+	if connectionString == "" {
+		return "", fmt.Errorf("environment variable %s of the connection string is not set", variable)
+	}
+
+	return connectionString, nil
+}
+
+// getPassword reads the password of the password file, runs the credential command or asks for the password
+// (--ask-password), found is false if the password is part of the connection string. Only one of them can be
+// configured, so it is always clear which password is used.
+func (a analyzer) getPassword() (password string, found bool, err error) {
+	passwordFile, credentialCommand, askPassword := a.config.PasswordFile(), a.config.CredentialCommand(), a.config.AskPassword()
+	sources := 0
+	for _, isSet := range []bool{passwordFile != "", credentialCommand != "", askPassword} {
+		if isSet {
+			sources++
+		}
+	}
+
+	if sources > 1 {
+		return "", false, errors.New("only one of --passwordFile, --credentialCommand and --ask-password can be used")
+	}
+
+	switch {
+	case passwordFile != "":
+		content, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return "", false, fmt.Errorf("could not read password file: %w", err)
+		}
+
+		// the trailing line break of the file is not part of the password
+		return strings.TrimRight(string(content), "\r\n"), true, nil
+	case credentialCommand != "":
+		password, err = database.RunCredentialCommand(credentialCommand)
+		return password, err == nil, err
+	case askPassword:
+		if a.config.NonInteractive() {
+			return "", false, fmt.Errorf("%w: the password cannot be asked for (use --passwordFile)", ErrMissingInput)
+		}
+
+		password, err = a.questioner.AskPasswordQuestion()
+		return password, err == nil, err
+	}
+
+	return "", false, nil
+}
@@ -0,0 +1,38 @@
+package analyzer
+
+import "errors"
+
+// The errors of the analyzer can be distinguished via errors.Is (e.g. to choose the exit code of the cli)
+var (
+	// ErrConnection is returned if the connection to the database could not be established
+	ErrConnection = errors.New("could not connect to database")
+	// ErrNoSchemas is returned if the database does not contain any schema
+	ErrNoSchemas = errors.New("no schemas available")
+	// ErrNoTables is returned if no tables are found (e.g. none of the focused tables)
+	ErrNoTables = errors.New("no tables found")
+	// ErrMissingInput is returned in non-interactive mode instead of asking for missing values
+	ErrMissingInput = errors.New("missing input in non-interactive mode")
+	// ErrNotReadOnly is returned by --assert-readonly if the user of the connection can change the database
+	ErrNotReadOnly = errors.New("the user can change the database")
+	// ErrMissingPermissions is returned if the user cannot read the catalogs of the selected schemas
+	ErrMissingPermissions = errors.New("the user is missing permissions to read the schemas")
+	// ErrCancelled is returned if the context of the analysis is done (e.g. on SIGINT)
+	ErrCancelled = errors.New("the analysis was cancelled")
+)
+
+// cancelledError is ErrCancelled with the error of the cancelled step, which stays in the chain (e.g. ErrConnection)
+type cancelledError struct {
+	err error
+}
+
+func (e cancelledError) Error() string {
+	return ErrCancelled.Error() + ": " + e.err.Error()
+}
+
+func (e cancelledError) Is(target error) bool {
+	return target == ErrCancelled
+}
+
+func (e cancelledError) Unwrap() error {
+	return e.err
+}
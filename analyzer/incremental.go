@@ -0,0 +1,242 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+// incrementalState is the model of the previous run with the modification times of its tables (see --incremental)
+type incrementalState struct {
+	Result *database.Result `json:"result"`
+	// ModificationTimes are keyed by schema.table, as json only supports string keys
+	ModificationTimes map[string]time.Time `json:"modificationTimes"`
+}
+
+// getIncrementalResult only analyzes the tables whose modification time changed since the previous run and the
+// tables related to them, the other tables are taken from the previous model
+func (a analyzer) getIncrementalResult(db database.Connector, selectedSchemas []string, selectedTables []database.TableDetail) (*database.Result, error) {
+	modificationTimeConnector, ok := db.(database.ModificationTimeConnector)
+	if !ok {
+		logrus.Warn("The database does not provide the modification times of the tables, all tables are analyzed")
+		return a.getResult(db, selectedTables)
+	}
+
+	if a.config.InferRelationships() {
+		logrus.Warn("The inferred relationships depend on all tables, all tables are analyzed")
+		return a.getResult(db, selectedTables)
+	}
+
+	fileName, err := getIncrementalStateFileName(a.config)
+	if err != nil {
+		return nil, err
+	}
+
+	var modificationTimes map[database.TableDetail]time.Time
+	err = a.query(func(ctx context.Context) (err error) {
+		modificationTimes, err = modificationTimeConnector.GetModificationTimes(ctx, selectedSchemas)
+		return err
+	})
+	if err != nil {
+		logrus.Error("Getting modification times failed", " | ", err)
+		return nil, err
+	}
+
+	sortTables(selectedTables)
+	previousState := readIncrementalState(fileName)
+	changedTables := getChangedTables(previousState, selectedTables, modificationTimes)
+	logrus.WithFields(logrus.Fields{"tables": len(selectedTables), "changedTables": len(changedTables)}).Info("Analyzing the changed tables")
+
+	changedResults, err := a.getChangedResults(db, changedTables, selectedTables)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &database.Result{Tables: mergeTableResults(previousState, selectedTables, changedResults)}
+	if err = writeIncrementalState(fileName, result, modificationTimes); err != nil {
+		logrus.WithField("file", fileName).Warn("Could not store the model for the next run", " | ", err)
+	}
+
+	return result, nil
+}
+
+// getChangedResults analyzes the changed tables and afterwards the unchanged tables that are referenced by new
+// constraints of the changed tables, whose constraints also contain the new constraint
+func (a analyzer) getChangedResults(db database.Connector, changedTables []database.TableDetail, selectedTables []database.TableDetail) (map[string]database.TableResult, error) {
+	changedResults := make(map[string]database.TableResult)
+	for len(changedTables) > 0 {
+		tableResults, err := a.GetColumnsAndConstraints(db, changedTables)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tableResult := range tableResults {
+			changedResults[getTableKey(tableResult.Table)] = tableResult
+		}
+
+		changedTables = getReferencedTables(tableResults, selectedTables, changedResults)
+	}
+
+	return changedResults, nil
+}
+
+// getChangedTables returns the selected tables that are new, whose modification time changed or that are related
+// to a changed or removed table via a constraint of the previous model
+func getChangedTables(previousState *incrementalState, selectedTables []database.TableDetail, modificationTimes map[database.TableDetail]time.Time) []database.TableDetail {
+	if previousState == nil || previousState.Result == nil {
+		return selectedTables
+	}
+
+	previousResults := make(map[string]database.TableResult)
+	for _, tableResult := range previousState.Result.Tables {
+		previousResults[getTableKey(tableResult.Table)] = tableResult
+	}
+
+	selected := make(map[string]bool)
+	changed := make(map[string]bool)
+	for _, table := range selectedTables {
+		key := getTableKey(table)
+		selected[key] = true
+		modificationTime, hasModificationTime := modificationTimes[database.TableDetail{Schema: table.Schema, Name: table.Name}]
+		previousTime, hasPreviousTime := previousState.ModificationTimes[key]
+		_, hasPreviousResult := previousResults[key]
+		if !hasModificationTime || !hasPreviousTime || !hasPreviousResult || !modificationTime.Equal(previousTime) {
+			changed[key] = true
+		}
+	}
+
+	affected := make(map[string]bool)
+	for key := range changed {
+		affected[key] = true
+	}
+
+	for key := range previousResults {
+		if !selected[key] {
+			affected[key] = true
+		}
+	}
+
+	for _, tableResult := range previousState.Result.Tables {
+		for _, constraint := range tableResult.Constraints {
+			fkKey := constraint.FkSchema + "." + constraint.FkTable
+			pkKey := constraint.PkSchema + "." + constraint.PkTable
+			if affected[fkKey] && selected[pkKey] {
+				changed[pkKey] = true
+			}
+
+			if affected[pkKey] && selected[fkKey] {
+				changed[fkKey] = true
+			}
+		}
+	}
+
+	var changedTables []database.TableDetail
+	for _, table := range selectedTables {
+		if changed[getTableKey(table)] {
+			changedTables = append(changedTables, table)
+		}
+	}
+
+	return changedTables
+}
+
+// getReferencedTables returns the selected tables that are referenced by the constraints of the table results, but
+// were not analyzed yet
+func getReferencedTables(tableResults []database.TableResult, selectedTables []database.TableDetail, analyzedResults map[string]database.TableResult) []database.TableDetail {
+	referenced := make(map[string]bool)
+	for _, tableResult := range tableResults {
+		for _, constraint := range tableResult.Constraints {
+			referenced[constraint.FkSchema+"."+constraint.FkTable] = true
+			referenced[constraint.PkSchema+"."+constraint.PkTable] = true
+		}
+	}
+
+	var referencedTables []database.TableDetail
+	for _, table := range selectedTables {
+		key := getTableKey(table)
+		if _, analyzed := analyzedResults[key]; referenced[key] && !analyzed {
+			referencedTables = append(referencedTables, table)
+		}
+	}
+
+	return referencedTables
+}
+
+// mergeTableResults returns the results of the selected tables, the results of the changed tables replace the
+// previous ones
+func mergeTableResults(previousState *incrementalState, selectedTables []database.TableDetail, changedResults map[string]database.TableResult) []database.TableResult {
+	previousResults := make(map[string]database.TableResult)
+	if previousState != nil && previousState.Result != nil {
+		for _, tableResult := range previousState.Result.Tables {
+			previousResults[getTableKey(tableResult.Table)] = tableResult
+		}
+	}
+
+	var tableResults []database.TableResult
+	for _, table := range selectedTables {
+		key := getTableKey(table)
+		if tableResult, ok := changedResults[key]; ok {
+			tableResults = append(tableResults, tableResult)
+		} else if tableResult, ok := previousResults[key]; ok {
+			tableResults = append(tableResults, tableResult)
+		}
+	}
+
+	return tableResults
+}
+
+func getTableKey(table database.TableDetail) string {
+	return table.Schema + "." + table.Name
+}
+
+// getIncrementalStateFileName returns the file of the previous model next to the cached models (see --cache), the
+// options that change the model are part of the name
+func getIncrementalStateFileName(config config.MermerdConfig) (string, error) {
+	fileName, err := getCacheFileName(config)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(fileName, ".json") + ".incremental.json", nil
+}
+
+func readIncrementalState(fileName string) *incrementalState {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil
+	}
+
+	var state incrementalState
+	if err = json.Unmarshal(content, &state); err != nil {
+		logrus.WithField("file", fileName).Warn("Could not read the previous model, all tables are analyzed", " | ", err)
+		return nil
+	}
+
+	return &state
+}
+
+func writeIncrementalState(fileName string, result *database.Result, modificationTimes map[database.TableDetail]time.Time) error {
+	state := incrementalState{Result: result, ModificationTimes: make(map[string]time.Time)}
+	for table, modificationTime := range modificationTimes {
+		state.ModificationTimes[getTableKey(table)] = modificationTime
+	}
+
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(fileName), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, content, 0o600)
+}
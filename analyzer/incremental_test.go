@@ -0,0 +1,119 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+func TestGetChangedTables(t *testing.T) {
+	now := time.Now().UTC()
+	article := database.TableDetail{Schema: "public", Name: "article"}
+	author := database.TableDetail{Schema: "public", Name: "author"}
+	label := database.TableDetail{Schema: "public", Name: "label"}
+	previousState := &incrementalState{
+		Result: &database.Result{Tables: []database.TableResult{
+			{Table: article, Constraints: database.ConstraintResultList{{FkSchema: "public", FkTable: "article", PkSchema: "public", PkTable: "author"}}},
+			{Table: author},
+			{Table: label},
+		}},
+		ModificationTimes: map[string]time.Time{"public.article": now, "public.author": now, "public.label": now},
+	}
+	selectedTables := []database.TableDetail{article, author, label}
+
+	t.Run("Without previous model all tables are changed", func(t *testing.T) {
+		// Act
+		changedTables := getChangedTables(nil, selectedTables, map[database.TableDetail]time.Time{})
+
+		// Assert
+		assert.Equal(t, selectedTables, changedTables)
+	})
+
+	t.Run("Unchanged tables are not analyzed", func(t *testing.T) {
+		// Arrange
+		modificationTimes := map[database.TableDetail]time.Time{article: now, author: now, label: now}
+
+		// Act
+		changedTables := getChangedTables(previousState, selectedTables, modificationTimes)
+
+		// Assert
+		assert.Empty(t, changedTables)
+	})
+
+	t.Run("Related tables of a changed table are analyzed", func(t *testing.T) {
+		// Arrange
+		modificationTimes := map[database.TableDetail]time.Time{article: now, author: now.Add(time.Minute), label: now}
+
+		// Act
+		changedTables := getChangedTables(previousState, selectedTables, modificationTimes)
+
+		// Assert
+		assert.Equal(t, []database.TableDetail{article, author}, changedTables)
+	})
+
+	t.Run("New tables and tables without modification time are analyzed", func(t *testing.T) {
+		// Arrange
+		comment := database.TableDetail{Schema: "public", Name: "comment"}
+		modificationTimes := map[database.TableDetail]time.Time{article: now, author: now, comment: now}
+
+		// Act
+		changedTables := getChangedTables(previousState, append(selectedTables, comment), modificationTimes)
+
+		// Assert
+		assert.Equal(t, []database.TableDetail{label, comment}, changedTables)
+	})
+
+	t.Run("Related tables of a removed table are analyzed", func(t *testing.T) {
+		// Arrange
+		modificationTimes := map[database.TableDetail]time.Time{article: now, label: now}
+
+		// Act
+		changedTables := getChangedTables(previousState, []database.TableDetail{article, label}, modificationTimes)
+
+		// Assert
+		assert.Equal(t, []database.TableDetail{article}, changedTables)
+	})
+}
+
+func TestMergeTableResults(t *testing.T) {
+	// Arrange
+	article := database.TableDetail{Schema: "public", Name: "article"}
+	author := database.TableDetail{Schema: "public", Name: "author"}
+	previousState := &incrementalState{Result: &database.Result{Tables: []database.TableResult{
+		{Table: article, Columns: []database.ColumnResult{{Name: "id"}}},
+		{Table: author, Columns: []database.ColumnResult{{Name: "id"}}},
+	}}}
+	changedResults := map[string]database.TableResult{
+		"public.author": {Table: author, Columns: []database.ColumnResult{{Name: "id"}, {Name: "name"}}},
+	}
+
+	// Act
+	tableResults := mergeTableResults(previousState, []database.TableDetail{article, author}, changedResults)
+
+	// Assert
+	assert.Len(t, tableResults, 2)
+	assert.Equal(t, previousState.Result.Tables[0], tableResults[0])
+	assert.Equal(t, changedResults["public.author"], tableResults[1])
+}
+
+func TestIncrementalState(t *testing.T) {
+	// Arrange
+	fileName := filepath.Join(t.TempDir(), "mermerd", "state.incremental.json")
+	table := database.TableDetail{Schema: "public", Name: "article"}
+	result := &database.Result{Tables: []database.TableResult{{Table: table}}}
+	modificationTime := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	err := writeIncrementalState(fileName, result, map[database.TableDetail]time.Time{table: modificationTime})
+	state := readIncrementalState(fileName)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.NotNil(t, state)
+	assert.Equal(t, result, state.Result)
+	assert.True(t, modificationTime.Equal(state.ModificationTimes["public.article"]))
+}
@@ -0,0 +1,40 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/presentation"
+)
+
+// checkPermissions fails before the tables are analyzed if the user cannot read the catalogs of the schemas, so the
+// missing grants are reported instead of a driver error in the middle of the analysis
+func (a analyzer) checkPermissions(db database.Connector, schemas []string) error {
+	permissionConnector, ok := db.(database.PermissionConnector)
+	if !ok || len(schemas) == 0 {
+		return nil
+	}
+
+	var missingPermissions []string
+	err := a.query(func(ctx context.Context) (err error) {
+		missingPermissions, err = permissionConnector.GetMissingPermissions(ctx, schemas)
+		return err
+	})
+	if err != nil {
+		// the check is only a hint, the analysis itself shows whether the schemas can be read
+		logrus.Warn("Could not check the permissions of the user", " | ", err)
+		presentation.ShowUncheckedPermissions(err)
+		return nil
+	}
+
+	if len(missingPermissions) == 0 {
+		return nil
+	}
+
+	// all missing grants are listed, so they can be granted at once
+	return fmt.Errorf("%w (%s)", ErrMissingPermissions, strings.Join(missingPermissions, ", "))
+}
@@ -0,0 +1,92 @@
+package analyzer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+// permissionConnectorMock is a connector that can check the permissions of the user
+type permissionConnectorMock struct {
+	mocks.Connector
+	mocks.PermissionConnector
+}
+
+func TestAnalyzer_CheckPermissions(t *testing.T) {
+	t.Run("User with all permissions", func(t *testing.T) {
+		// Arrange
+		connectorMock := permissionConnectorMock{}
+		connectorMock.PermissionConnector.On("GetMissingPermissions", mock.Anything, []string{"public"}).Return(nil, nil).Once()
+
+		// Act
+		err := getAnalyzerWithConfigMock().checkPermissions(&connectorMock, []string{"public"})
+
+		// Assert
+		connectorMock.PermissionConnector.AssertExpectations(t)
+		assert.Nil(t, err)
+	})
+
+	t.Run("User with missing permissions", func(t *testing.T) {
+		// Arrange
+		connectorMock := permissionConnectorMock{}
+		connectorMock.PermissionConnector.On("GetMissingPermissions", mock.Anything, []string{"sales", "billing"}).
+			Return([]string{"USAGE on schema sales", "USAGE on schema billing"}, nil).Once()
+
+		// Act
+		err := getAnalyzerWithConfigMock().checkPermissions(&connectorMock, []string{"sales", "billing"})
+
+		// Assert
+		connectorMock.PermissionConnector.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrMissingPermissions)
+		assert.Contains(t, err.Error(), "(USAGE on schema sales, USAGE on schema billing)")
+	})
+
+	t.Run("All missing permissions are listed", func(t *testing.T) {
+		// Arrange
+		schemas := []string{"s1", "s2", "s3", "s4", "s5", "s6", "s7"}
+		var missingPermissions []string
+		for _, schema := range schemas {
+			missingPermissions = append(missingPermissions, "USAGE on schema "+schema)
+		}
+		connectorMock := permissionConnectorMock{}
+		connectorMock.PermissionConnector.On("GetMissingPermissions", mock.Anything, schemas).Return(missingPermissions, nil).Once()
+
+		// Act
+		err := getAnalyzerWithConfigMock().checkPermissions(&connectorMock, schemas)
+
+		// Assert
+		connectorMock.PermissionConnector.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrMissingPermissions)
+		assert.Contains(t, err.Error(), "("+strings.Join(missingPermissions, ", ")+")")
+	})
+
+	t.Run("Permissions cannot be checked", func(t *testing.T) {
+		// Arrange
+		connectorMock := permissionConnectorMock{}
+		connectorMock.PermissionConnector.On("GetMissingPermissions", mock.Anything, []string{"public"}).Return(nil, errors.New("function does not exist")).Once()
+
+		// Act
+		err := getAnalyzerWithConfigMock().checkPermissions(&connectorMock, []string{"public"})
+
+		// Assert
+		connectorMock.PermissionConnector.AssertExpectations(t)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Connector without permission check", func(t *testing.T) {
+		// Arrange
+		connectorMock := mocks.Connector{}
+
+		// Act
+		err := getAnalyzerWithConfigMock().checkPermissions(&connectorMock, []string{"public"})
+
+		// Assert
+		connectorMock.AssertExpectations(t)
+		assert.Nil(t, err)
+	})
+}
@@ -1,21 +1,42 @@
 package analyzer
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/presentation"
+	"github.com/aslakhellesoy/mermerd/util"
 )
 
-type questioner struct{}
+// questioner keeps the answers of the connection string, the schemas and the tables, the connection string before its
+// environment variables are expanded
+type questioner struct {
+	answers *config.RunConfig
+}
 
 type Questioner interface {
 	AskConnectionQuestion(suggestions []string) (string, error)
+	AskPasswordQuestion() (string, error)
+	AskProfileQuestion(profiles []string) (string, error)
 	AskSchemaQuestion(schemas []string) ([]string, error)
 	AskTableQuestion(tables []string) ([]string, error)
+	AskSelectQuestion(message string, options []string, defaultOption string) (string, error)
+	AskInputQuestion(message string, defaultValue string) (string, error)
+	AskConfirmQuestion(message string, defaultValue bool) (bool, error)
+	Answers() config.RunConfig
 }
 
 func NewQuestioner() Questioner {
-	return questioner{}
+	return questioner{answers: &config.RunConfig{}}
+}
+
+// Answers returns the run configuration with the connection string, the schemas and the tables that were asked for
+func (q questioner) Answers() config.RunConfig {
+	return *q.answers
 }
 
 func (q questioner) AskConnectionQuestion(suggestions []string) (string, error) {
@@ -27,14 +48,44 @@ func (q questioner) AskConnectionQuestion(suggestions []string) (string, error)
 		},
 	}
 
-	err := survey.AskOne(&question, &result, survey.WithValidator(survey.Required))
+	err := askOne(&question, &result, survey.WithValidator(survey.Required))
 	if err != nil {
 		return "", err
 	}
 
+	q.answers.ConnectionString = result
 	return os.ExpandEnv(result), nil
 }
 
+// AskPasswordQuestion asks for the password with hidden input, it is not part of the answers so that it is not saved
+// in the run configuration
+func (q questioner) AskPasswordQuestion() (string, error) {
+	var result string
+	question := survey.Password{Message: "Password:"}
+
+	err := askOne(&question, &result, survey.WithValidator(survey.Required))
+	return result, err
+}
+
+// manualConnectionOption is offered in addition to the profiles to enter a connection string instead
+const manualConnectionOption = "(enter connection string)"
+
+// AskProfileQuestion returns the chosen profile or an empty string if the connection string should be entered
+func (q questioner) AskProfileQuestion(profiles []string) (string, error) {
+	var result string
+	question := &survey.Select{
+		Message: "Choose profile:",
+		Options: append(append([]string{}, profiles...), manualConnectionOption),
+	}
+
+	err := askOne(question, &result)
+	if err != nil || result == manualConnectionOption {
+		return "", err
+	}
+
+	return result, nil
+}
+
 func (q questioner) AskSchemaQuestion(schemas []string) ([]string, error) {
 	var result []string
 	question := &survey.MultiSelect{
@@ -42,18 +93,124 @@ func (q questioner) AskSchemaQuestion(schemas []string) ([]string, error) {
 		Options: schemas,
 	}
 
-	err := survey.AskOne(question, &result, survey.WithValidator(survey.MinItems(1)))
+	err := askOne(question, &result, survey.WithValidator(survey.MinItems(1)))
+	q.answers.Schemas = result
 	return result, err
 }
 
+// AskTableQuestion offers an option per schema to select all of its tables (e.g. public.*) besides the tables, as there
+// can be hundreds of tables. The filter is fuzzy and kept after selecting, so that <right> selects all filtered tables.
 func (q questioner) AskTableQuestion(tables []string) ([]string, error) {
 	var result []string
 	question := &survey.MultiSelect{
-		Message:  "Choose tables:",
-		Options:  tables,
+		Message:  fmt.Sprintf("Choose tables (%d):", len(tables)),
+		Options:  append(getSchemaOptions(tables), tables...),
+		Help:     "Type to filter (e.g. puord matches public.order), <right> selects all filtered tables and schema.* all tables of the schema",
 		PageSize: 15,
 	}
 
-	err := survey.AskOne(question, &result, survey.WithValidator(survey.MinItems(1)))
+	err := askOne(question, &result, survey.WithValidator(survey.MinItems(1)), survey.WithFilter(fuzzyFilter),
+		survey.WithKeepFilter(true))
+	q.answers.SelectedTables = expandSchemaOptions(tables, result)
+	return q.answers.SelectedTables, err
+}
+
+// allTablesOption is the suffix of the option that selects all tables of a schema
+const allTablesOption = ".*"
+
+// getSchemaOptions returns the options to select all tables of a schema in the order of the tables
+func getSchemaOptions(tables []string) []string {
+	var options []string
+	for _, table := range tables {
+		schema, _, found := strings.Cut(table, ".")
+		if found && !util.Contains(options, schema+allTablesOption) {
+			options = append(options, schema+allTablesOption)
+		}
+	}
+
+	return options
+}
+
+// expandSchemaOptions replaces the schema options with the tables of the schema, the tables keep their order and are
+// returned once, even if they are selected individually as well
+func expandSchemaOptions(tables []string, selectedOptions []string) []string {
+	var result []string
+	for _, table := range tables {
+		schema, _, _ := strings.Cut(table, ".")
+		if util.Contains(selectedOptions, table) || util.Contains(selectedOptions, schema+allTablesOption) {
+			result = append(result, table)
+		}
+	}
+
+	return result
+}
+
+// fuzzyFilter matches the options that contain the characters of the filter in the same order (case-insensitive),
+// e.g. ordit matches order_item
+func fuzzyFilter(filter string, value string, _ int) bool {
+	valueRunes := []rune(strings.ToLower(value))
+	position := 0
+	for _, filterRune := range strings.ToLower(filter) {
+		for position < len(valueRunes) && valueRunes[position] != filterRune {
+			position++
+		}
+
+		if position == len(valueRunes) {
+			return false
+		}
+
+		position++
+	}
+
+	return true
+}
+
+// AskSelectQuestion asks for one of the options, e.g. the output format of mermerd init
+func (q questioner) AskSelectQuestion(message string, options []string, defaultOption string) (string, error) {
+	var result string
+	question := &survey.Select{
+		Message:  message,
+		Options:  options,
+		PageSize: 15,
+	}
+
+	// survey would return an empty string instead of the first option for an empty default
+	if defaultOption != "" {
+		question.Default = defaultOption
+	}
+
+	err := askOne(question, &result)
+	return result, err
+}
+
+// AskInputQuestion asks for a free text, the default value is used if nothing is entered
+func (q questioner) AskInputQuestion(message string, defaultValue string) (string, error) {
+	var result string
+	question := &survey.Input{
+		Message: message,
+		Default: defaultValue,
+	}
+
+	err := askOne(question, &result)
+	return result, err
+}
+
+func (q questioner) AskConfirmQuestion(message string, defaultValue bool) (bool, error) {
+	var result bool
+	question := &survey.Confirm{
+		Message: message,
+		Default: defaultValue,
+	}
+
+	err := askOne(question, &result)
 	return result, err
 }
+
+// askOne asks the question on stderr if the diagram is written to stdout
+func askOne(prompt survey.Prompt, response any, options ...survey.AskOpt) error {
+	if presentation.IsDiagramOnStdout() {
+		options = append(options, survey.WithStdio(os.Stdin, os.Stderr, os.Stderr))
+	}
+
+	return survey.AskOne(prompt, response, options...)
+}
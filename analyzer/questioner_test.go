@@ -0,0 +1,64 @@
+package analyzer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyFilter(t *testing.T) {
+	testCases := []struct {
+		filter        string
+		value         string
+		expectedMatch bool
+	}{
+		{"", "public.order", true},
+		{"order", "public.order", true},
+		{"ORD", "public.order", true},
+		{"puord", "public.order", true},
+		{"ordit", "public.order_item", true},
+		{"public.*", "public.*", true},
+		{"itord", "public.order_item", false},
+		{"orders", "public.order", false},
+		{"ä", "public.bär", true},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := fuzzyFilter(testCase.filter, testCase.value, 0)
+
+			// Assert
+			assert.Equal(t, testCase.expectedMatch, result)
+		})
+	}
+}
+
+func TestSchemaOptions(t *testing.T) {
+	tables := []string{"sales.order", "public.article", "sales.customer", "public.label"}
+
+	t.Run("An option per schema is offered", func(t *testing.T) {
+		// Act
+		result := getSchemaOptions(tables)
+
+		// Assert
+		assert.Equal(t, []string{"sales.*", "public.*"}, result)
+	})
+
+	t.Run("Schema options are replaced with the tables of the schema", func(t *testing.T) {
+		// Act
+		result := expandSchemaOptions(tables, []string{"public.label", "sales.*", "sales.order"})
+
+		// Assert
+		assert.Equal(t, []string{"sales.order", "sales.customer", "public.label"}, result)
+	})
+
+	t.Run("Tables are returned if no schema option is selected", func(t *testing.T) {
+		// Act
+		result := expandSchemaOptions(tables, []string{"public.article"})
+
+		// Assert
+		assert.Equal(t, []string{"public.article"}, result)
+	})
+}
@@ -0,0 +1,50 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+// maxShownPrivileges is the number of privileges in the error of --assert-readonly, the others are only counted
+const maxShownPrivileges = 5
+
+// assertReadOnly fails if the user of the connection has privileges that allow to change the database, which is
+// required if only read-only users may be used (--assert-readonly)
+func (a analyzer) assertReadOnly(db database.Connector) error {
+	readOnlyConnector, ok := db.(database.ReadOnlyConnector)
+	if !ok {
+		// failing is safer than passing an unchecked user, the queries are still rejected if they could change the
+		// database
+		return fmt.Errorf("--assert-readonly is not supported for %s, as the privileges of the user cannot be checked (use a read-only role instead)", db.GetDbType())
+	}
+
+	var privileges []string
+	err := a.query(func(ctx context.Context) (err error) {
+		privileges, err = readOnlyConnector.GetWritePrivileges(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("could not check the privileges of the user: %w", err)
+	}
+
+	if len(privileges) == 0 {
+		logrus.Info("The user cannot change the database")
+		return nil
+	}
+
+	return fmt.Errorf("%w (%s)", ErrNotReadOnly, strings.Join(getShownPrivileges(privileges), ", "))
+}
+
+// getShownPrivileges returns the first privileges and the number of the others
+func getShownPrivileges(privileges []string) []string {
+	if len(privileges) <= maxShownPrivileges {
+		return privileges
+	}
+
+	return append(privileges[:maxShownPrivileges:maxShownPrivileges], fmt.Sprintf("%d more", len(privileges)-maxShownPrivileges))
+}
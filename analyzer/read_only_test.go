@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+// readOnlyConnectorMock is a connector that can check the privileges of the user
+type readOnlyConnectorMock struct {
+	mocks.Connector
+	mocks.ReadOnlyConnector
+}
+
+func TestAnalyzer_AssertReadOnly(t *testing.T) {
+	t.Run("User without write privileges", func(t *testing.T) {
+		// Arrange
+		connectorMock := readOnlyConnectorMock{}
+		connectorMock.ReadOnlyConnector.On("GetWritePrivileges", mock.Anything).Return(nil, nil).Once()
+
+		// Act
+		err := getAnalyzerWithConfigMock().assertReadOnly(&connectorMock)
+
+		// Assert
+		connectorMock.ReadOnlyConnector.AssertExpectations(t)
+		assert.Nil(t, err)
+	})
+
+	t.Run("User with write privileges", func(t *testing.T) {
+		// Arrange
+		connectorMock := readOnlyConnectorMock{}
+		privileges := []string{"INSERT on public.a", "INSERT on public.b", "INSERT on public.c", "INSERT on public.d", "INSERT on public.e", "INSERT on public.f", "INSERT on public.g"}
+		connectorMock.ReadOnlyConnector.On("GetWritePrivileges", mock.Anything).Return(privileges, nil).Once()
+
+		// Act
+		err := getAnalyzerWithConfigMock().assertReadOnly(&connectorMock)
+
+		// Assert
+		connectorMock.ReadOnlyConnector.AssertExpectations(t)
+		assert.ErrorIs(t, err, ErrNotReadOnly)
+		assert.Contains(t, err.Error(), "INSERT on public.e, 2 more")
+	})
+
+	t.Run("Privileges cannot be read", func(t *testing.T) {
+		// Arrange
+		connectorMock := readOnlyConnectorMock{}
+		connectorMock.ReadOnlyConnector.On("GetWritePrivileges", mock.Anything).Return(nil, errors.New("permission denied")).Once()
+
+		// Act
+		err := getAnalyzerWithConfigMock().assertReadOnly(&connectorMock)
+
+		// Assert
+		connectorMock.ReadOnlyConnector.AssertExpectations(t)
+		assert.NotNil(t, err)
+		assert.NotErrorIs(t, err, ErrNotReadOnly)
+	})
+
+	t.Run("Connector without privilege check", func(t *testing.T) {
+		// Arrange
+		connectorMock := mocks.Connector{}
+		connectorMock.On("GetDbType").Return(database.BigQuery).Once()
+
+		// Act
+		err := getAnalyzerWithConfigMock().assertReadOnly(&connectorMock)
+
+		// Assert
+		connectorMock.AssertExpectations(t)
+		assert.ErrorContains(t, err, "--assert-readonly is not supported for bigquery")
+	})
+}
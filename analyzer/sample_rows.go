@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+// maskedSampleValue replaces the values of the masked columns (--maskColumns)
+const maskedSampleValue = "***"
+
+// getSampleRows reads the first rows of the table with the values in the order of the columns. The masked and the
+// classified columns are not read, so that their values never leave the database. If all columns are masked, no rows
+// are read.
+func (a analyzer) getSampleRows(db database.SampleConnector, table database.TableDetail, columns []database.ColumnResult, limit int, maskPatterns []string) ([][]string, error) {
+	isMasked := make([]bool, len(columns))
+	var columnNames []string
+	for i, column := range columns {
+		masked := len(column.Classifications) > 0
+		if !masked {
+			var err error
+			if masked, err = database.MatchAnyColumnName(maskPatterns, table, column.Name); err != nil {
+				return nil, err
+			}
+		}
+
+		isMasked[i] = masked
+		if !masked {
+			columnNames = append(columnNames, column.Name)
+		}
+	}
+
+	if len(columnNames) == 0 {
+		return nil, nil
+	}
+
+	var rows [][]string
+	err := a.query(func(ctx context.Context) (err error) {
+		rows, err = db.GetSampleRows(ctx, table, columnNames, limit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sampleRows := make([][]string, len(rows))
+	for rowIndex, row := range rows {
+		sampleRow := make([]string, len(columns))
+		valueIndex := 0
+		for columnIndex := range columns {
+			if isMasked[columnIndex] {
+				sampleRow[columnIndex] = maskedSampleValue
+				continue
+			}
+
+			if valueIndex < len(row) {
+				sampleRow[columnIndex] = row[valueIndex]
+			}
+			valueIndex++
+		}
+
+		sampleRows[rowIndex] = sampleRow
+	}
+
+	logrus.WithFields(logrus.Fields{"schema": table.Schema, "table": table.Name, "rows": len(sampleRows)}).Debug("Got sample rows")
+	return sampleRows, nil
+}
@@ -0,0 +1,107 @@
+package analyzer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+type sampleConnectorMock struct {
+	mocks.Connector
+	mocks.SampleConnector
+}
+
+func TestAnalyzer_GetSampleRows(t *testing.T) {
+	table := database.TableDetail{Schema: "public", Name: "user"}
+	columns := []database.ColumnResult{{Name: "id"}, {Name: "email"}, {Name: "password_hash"}}
+
+	t.Run("Masked columns are not read", func(t *testing.T) {
+		// Arrange
+		connectorMock := mocks.SampleConnector{}
+		connectorMock.On("GetSampleRows", mock.Anything, table, []string{"id"}, 2).Return([][]string{{"1"}, {"2"}}, nil).Once()
+
+		// Act
+		result, err := getAnalyzerWithConfigMock().getSampleRows(&connectorMock, table, columns, 2, []string{"user.email", "*_hash"})
+
+		// Assert
+		connectorMock.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Equal(t, [][]string{{"1", "***", "***"}, {"2", "***", "***"}}, result)
+	})
+
+	t.Run("Classified columns are not read", func(t *testing.T) {
+		// Arrange
+		connectorMock := mocks.SampleConnector{}
+		classifiedColumns := []database.ColumnResult{{Name: "id"}, {Name: "email", Classifications: []string{"pii"}}}
+		connectorMock.On("GetSampleRows", mock.Anything, table, []string{"id"}, 1).Return([][]string{{"1"}}, nil).Once()
+
+		// Act
+		result, err := getAnalyzerWithConfigMock().getSampleRows(&connectorMock, table, classifiedColumns, 1, nil)
+
+		// Assert
+		connectorMock.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Equal(t, [][]string{{"1", "***"}}, result)
+	})
+
+	t.Run("All columns are masked", func(t *testing.T) {
+		// Arrange
+		connectorMock := mocks.SampleConnector{}
+
+		// Act
+		result, err := getAnalyzerWithConfigMock().getSampleRows(&connectorMock, table, columns, 2, []string{"*"})
+
+		// Assert
+		connectorMock.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Invalid pattern", func(t *testing.T) {
+		// Arrange
+		connectorMock := mocks.SampleConnector{}
+
+		// Act
+		_, err := getAnalyzerWithConfigMock().getSampleRows(&connectorMock, table, columns, 2, []string{"/[/"})
+
+		// Assert
+		assert.NotNil(t, err)
+	})
+}
+
+func TestAnalyzer_GetColumnsAndConstraintsWithSampleRows(t *testing.T) {
+	// Arrange
+	analyzer, configMock, _, _ := getAnalyzerWithMocks()
+	connectorMock := sampleConnectorMock{}
+	tableA := database.TableDetail{Schema: "public", Name: "tableA"}
+	tableB := database.TableDetail{Schema: "public", Name: "tableB"}
+	configMock.On("IncludeColumns").Return([]string{}).Once()
+	configMock.On("ExcludeColumns").Return([]string{}).Once()
+	configMock.On("InferRelationships").Return(false).Once()
+	configMock.On("ShowIndexes").Return(false).Once()
+	configMock.On("ColumnOrder").Return("").Once()
+	configMock.On("SampleRows").Return(1).Once()
+	configMock.On("Classifications").Return(nil, nil).Once()
+	configMock.On("MaskColumns").Return([]string{"secret"}).Once()
+	connectorMock.Connector.On("GetColumns", mock.Anything, tableA).Return([]database.ColumnResult{{Name: "id"}, {Name: "secret"}}, nil).Once()
+	connectorMock.Connector.On("GetColumns", mock.Anything, tableB).Return([]database.ColumnResult{{Name: "id"}}, nil).Once()
+	connectorMock.Connector.On("GetConstraints", mock.Anything, mock.Anything).Return([]database.ConstraintResult{}, nil).Twice()
+	connectorMock.SampleConnector.On("GetSampleRows", mock.Anything, tableA, []string{"id"}, 1).Return([][]string{{"1"}}, nil).Once()
+	connectorMock.SampleConnector.On("GetSampleRows", mock.Anything, tableB, []string{"id"}, 1).Return(nil, errors.New("permission denied")).Once()
+
+	// Act
+	result, err := analyzer.GetColumnsAndConstraints(&connectorMock, []database.TableDetail{tableA, tableB})
+
+	// Assert
+	configMock.AssertExpectations(t)
+	connectorMock.Connector.AssertExpectations(t)
+	connectorMock.SampleConnector.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"1", "***"}}, result[0].SampleRows)
+	assert.Nil(t, result[1].SampleRows)
+}
@@ -0,0 +1,380 @@
+package browse
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+type level int
+
+const (
+	schemaLevel level = iota
+	tableLevel
+	detailLevel
+)
+
+// defaultHeight is used until the terminal sends its size
+const defaultHeight = 24
+
+const help = "↑/↓ move · enter open · esc back · space toggle · a toggle all · g generate · q quit"
+
+// Model navigates from the schemas to their tables and to the columns and constraints of a table. The selected tables
+// are returned by Run if the diagram should be generated.
+type Model struct {
+	schemas  []string
+	tables   map[string][]database.TableResult
+	selected map[string]bool
+	level    level
+	// cursors contains the position of the cursor per level, the detail level only scrolls
+	cursors  [3]int
+	height   int
+	generate bool
+}
+
+// NewModel groups the tables of the result by schema
+func NewModel(result *database.Result) Model {
+	m := Model{tables: make(map[string][]database.TableResult), selected: make(map[string]bool), height: defaultHeight}
+	for _, table := range result.Tables {
+		if _, ok := m.tables[table.Table.Schema]; !ok {
+			m.schemas = append(m.schemas, table.Table.Schema)
+		}
+
+		m.tables[table.Table.Schema] = append(m.tables[table.Table.Schema], table)
+	}
+
+	// a single schema is opened right away
+	if len(m.schemas) == 1 {
+		m.level = tableLevel
+	}
+
+	return m
+}
+
+// Run shows the model until the diagram should be generated, it returns the result with the selected tables or nil if
+// browsing was quit
+func Run(result *database.Result, input io.Reader, output io.Writer) (*database.Result, error) {
+	finalModel, err := tea.NewProgram(NewModel(result), tea.WithInput(input), tea.WithOutput(output), tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, err
+	}
+
+	m := finalModel.(Model)
+	if !m.generate {
+		return nil, nil
+	}
+
+	return m.getSelectedResult(result), nil
+}
+
+// getSelectedResult keeps the constraints to tables that are not selected, the diagram omits them unless all
+// constraints should be shown
+func (m Model) getSelectedResult(result *database.Result) *database.Result {
+	selectedResult := &database.Result{}
+	for _, table := range result.Tables {
+		if m.selected[getTableKey(table.Table)] {
+			selectedResult.Tables = append(selectedResult.Tables, table)
+		}
+	}
+
+	return selectedResult
+}
+
+// SelectedTables returns the selected tables in the order of the model
+func (m Model) SelectedTables() []database.TableDetail {
+	var tables []database.TableDetail
+	for _, schema := range m.schemas {
+		for _, table := range m.tables[schema] {
+			if m.selected[getTableKey(table.Table)] {
+				tables = append(tables, table.Table)
+			}
+		}
+	}
+
+	return tables
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		return m.updateKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m Model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "g":
+		if len(m.SelectedTables()) > 0 {
+			m.generate = true
+			return m, tea.Quit
+		}
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "enter", "right", "l":
+		if m.level < detailLevel && m.getItemCount() > 0 {
+			m.level++
+			m.cursors[m.level] = 0
+		}
+	case "esc", "left", "h", "backspace":
+		if m.level == detailLevel || (m.level == tableLevel && len(m.schemas) > 1) {
+			m.level--
+		}
+	case " ":
+		m.toggle()
+	case "a":
+		m.toggleAll()
+	}
+
+	return m, nil
+}
+
+func (m *Model) moveCursor(delta int) {
+	count := m.getItemCount()
+	if m.level == detailLevel {
+		// the details scroll, so that the last line stays at the bottom
+		count -= m.getPageSize() - 1
+	}
+
+	cursor := m.cursors[m.level] + delta
+	if cursor >= count {
+		cursor = count - 1
+	}
+
+	if cursor < 0 {
+		cursor = 0
+	}
+
+	m.cursors[m.level] = cursor
+}
+
+// toggle selects or deselects the current table, or all tables of the current schema
+func (m *Model) toggle() {
+	switch m.level {
+	case schemaLevel:
+		if len(m.schemas) > 0 {
+			m.setSelected(m.tables[m.schemas[m.cursors[schemaLevel]]], !m.isSchemaSelected(m.schemas[m.cursors[schemaLevel]]))
+		}
+	case tableLevel, detailLevel:
+		if table, ok := m.getCurrentTable(); ok {
+			key := getTableKey(table.Table)
+			m.selected[key] = !m.selected[key]
+		}
+	}
+}
+
+// toggleAll selects all tables, or deselects them if they are already selected
+func (m *Model) toggleAll() {
+	isAllSelected := true
+	for _, schema := range m.schemas {
+		isAllSelected = isAllSelected && m.isSchemaSelected(schema)
+	}
+
+	for _, schema := range m.schemas {
+		m.setSelected(m.tables[schema], !isAllSelected)
+	}
+}
+
+func (m *Model) setSelected(tables []database.TableResult, selected bool) {
+	for _, table := range tables {
+		m.selected[getTableKey(table.Table)] = selected
+	}
+}
+
+func (m Model) isSchemaSelected(schema string) bool {
+	return m.countSelected(schema) == len(m.tables[schema])
+}
+
+func (m Model) countSelected(schema string) int {
+	count := 0
+	for _, table := range m.tables[schema] {
+		if m.selected[getTableKey(table.Table)] {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (m Model) getCurrentSchema() string {
+	if len(m.schemas) == 0 {
+		return ""
+	}
+
+	return m.schemas[m.cursors[schemaLevel]]
+}
+
+func (m Model) getCurrentTable() (database.TableResult, bool) {
+	tables := m.tables[m.getCurrentSchema()]
+	if len(tables) == 0 {
+		return database.TableResult{}, false
+	}
+
+	return tables[m.cursors[tableLevel]], true
+}
+
+func (m Model) getItemCount() int {
+	switch m.level {
+	case schemaLevel:
+		return len(m.schemas)
+	case tableLevel:
+		return len(m.tables[m.getCurrentSchema()])
+	default:
+		return len(m.getDetailLines())
+	}
+}
+
+// getPageSize is the number of lines of the list, the title, the help and the empty lines around the list take 4
+func (m Model) getPageSize() int {
+	if m.height <= 5 {
+		return 1
+	}
+
+	return m.height - 4
+}
+
+func (m Model) View() string {
+	var title string
+	var lines []string
+	switch m.level {
+	case schemaLevel:
+		title = "Schemas"
+		for _, schema := range m.schemas {
+			lines = append(lines, fmt.Sprintf("%s %s (%d/%d tables)", getCheckbox(m.countSelected(schema), len(m.tables[schema])),
+				schema, m.countSelected(schema), len(m.tables[schema])))
+		}
+	case tableLevel:
+		title = fmt.Sprintf("Tables of %s", m.getCurrentSchema())
+		for _, table := range m.tables[m.getCurrentSchema()] {
+			lines = append(lines, fmt.Sprintf("%s %s (%d columns)", getCheckbox(boolToInt(m.selected[getTableKey(table.Table)]), 1),
+				getTableLabel(table.Table), len(table.Columns)))
+		}
+	case detailLevel:
+		table, _ := m.getCurrentTable()
+		title = fmt.Sprintf("%s %s.%s", getCheckbox(boolToInt(m.selected[getTableKey(table.Table)]), 1), table.Table.Schema, table.Table.Name)
+		lines = m.getDetailLines()
+	}
+
+	var builder strings.Builder
+	builder.WriteString(title + "\n\n")
+	builder.WriteString(m.getPage(lines))
+	builder.WriteString(fmt.Sprintf("\n%d tables selected · %s\n", len(m.SelectedTables()), help))
+	return builder.String()
+}
+
+// getPage returns the visible lines, the current line is marked on the schema and table level
+func (m Model) getPage(lines []string) string {
+	cursor := m.cursors[m.level]
+	pageSize := m.getPageSize()
+	start := 0
+	if m.level == detailLevel {
+		start = cursor
+	} else if cursor >= pageSize {
+		start = cursor - pageSize + 1
+	}
+
+	var builder strings.Builder
+	for index := start; index < len(lines) && index < start+pageSize; index++ {
+		marker := "  "
+		if m.level != detailLevel && index == cursor {
+			marker = "> "
+		}
+
+		builder.WriteString(marker + lines[index] + "\n")
+	}
+
+	return builder.String()
+}
+
+// getDetailLines lists the columns with their data type and keys, followed by the foreign keys of the table
+func (m Model) getDetailLines() []string {
+	table, ok := m.getCurrentTable()
+	if !ok {
+		return nil
+	}
+
+	lines := []string{"Columns"}
+	for _, column := range table.Columns {
+		lines = append(lines, strings.TrimRight(fmt.Sprintf("  %s %s %s", column.Name, column.DataType, getColumnKeys(column)), " "))
+	}
+
+	if len(table.Constraints) > 0 {
+		lines = append(lines, "", "Constraints")
+	}
+
+	for _, constraint := range table.Constraints {
+		columns := constraint.ColumnName
+		if len(constraint.ColumnNames) > 0 {
+			columns = strings.Join(constraint.ColumnNames, ", ")
+		}
+
+		lines = append(lines, fmt.Sprintf("  %s.%s (%s) → %s.%s", constraint.FkSchema, constraint.FkTable, columns,
+			constraint.PkSchema, constraint.PkTable))
+	}
+
+	return lines
+}
+
+func getColumnKeys(column database.ColumnResult) string {
+	var keys []string
+	if column.IsPrimary {
+		keys = append(keys, "PK")
+	}
+
+	if column.IsForeign {
+		keys = append(keys, "FK")
+	}
+
+	if column.IsUnique {
+		keys = append(keys, "UK")
+	}
+
+	return strings.Join(keys, ", ")
+}
+
+func getTableLabel(table database.TableDetail) string {
+	if table.IsView {
+		return table.Name + " (view)"
+	}
+
+	return table.Name
+}
+
+// getCheckbox shows if all, some or none of the tables are selected
+func getCheckbox(selected int, total int) string {
+	switch {
+	case selected == 0:
+		return "[ ]"
+	case selected == total:
+		return "[x]"
+	default:
+		return "[-]"
+	}
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+
+	return 0
+}
+
+func getTableKey(table database.TableDetail) string {
+	return table.Schema + "." + table.Name
+}
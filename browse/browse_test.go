@@ -0,0 +1,133 @@
+package browse
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+var result = &database.Result{Tables: []database.TableResult{
+	{
+		Table:   database.TableDetail{Schema: "public", Name: "article"},
+		Columns: []database.ColumnResult{{Name: "id", DataType: "int", IsPrimary: true}, {Name: "title", DataType: "varchar"}},
+	},
+	{
+		Table:   database.TableDetail{Schema: "public", Name: "comment"},
+		Columns: []database.ColumnResult{{Name: "article_id", DataType: "int", IsForeign: true}},
+		Constraints: database.ConstraintResultList{
+			{FkSchema: "public", FkTable: "comment", PkSchema: "public", PkTable: "article", ColumnName: "article_id"},
+		},
+	},
+	{Table: database.TableDetail{Schema: "sales", Name: "order"}},
+}}
+
+// specialKeys are the keys that are no runes
+var specialKeys = map[string]tea.KeyMsg{
+	"up": {Type: tea.KeyUp}, "down": {Type: tea.KeyDown}, "enter": {Type: tea.KeyEnter}, "esc": {Type: tea.KeyEscape},
+	" ": {Type: tea.KeySpace, Runes: []rune{' '}},
+}
+
+// press sends the keys to the model, e.g. "down" or "g"
+func press(m Model, keys ...string) Model {
+	for _, key := range keys {
+		msg, ok := specialKeys[key]
+		if !ok {
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+
+		model, _ := m.Update(msg)
+		m = model.(Model)
+	}
+
+	return m
+}
+
+func getTableNames(tables []database.TableDetail) []string {
+	var names []string
+	for _, table := range tables {
+		names = append(names, getTableKey(table))
+	}
+
+	return names
+}
+
+func TestModel(t *testing.T) {
+	t.Run("Schemas are listed with their selected tables", func(t *testing.T) {
+		// Act
+		view := NewModel(result).View()
+
+		// Assert
+		assert.Contains(t, view, "> [ ] public (0/2 tables)\n")
+		assert.Contains(t, view, "  [ ] sales (0/1 tables)\n")
+	})
+
+	t.Run("Toggling a schema selects all of its tables", func(t *testing.T) {
+		// Act
+		m := press(NewModel(result), " ")
+
+		// Assert
+		assert.Equal(t, []string{"public.article", "public.comment"}, getTableNames(m.SelectedTables()))
+		assert.Contains(t, m.View(), "> [x] public (2/2 tables)\n")
+	})
+
+	t.Run("Tables are toggled individually", func(t *testing.T) {
+		// Act
+		m := press(NewModel(result), "enter", "down", " ")
+
+		// Assert
+		assert.Equal(t, []string{"public.comment"}, getTableNames(m.SelectedTables()))
+		assert.Contains(t, m.View(), "Tables of public\n")
+		assert.Contains(t, m.View(), "> [x] comment (1 columns)\n")
+		assert.Contains(t, press(m, "esc").View(), "> [-] public (1/2 tables)\n")
+	})
+
+	t.Run("Columns and constraints of a table are shown", func(t *testing.T) {
+		// Act
+		m := press(NewModel(result), "enter", "down", "enter")
+
+		// Assert
+		assert.Contains(t, m.View(), "[ ] public.comment\n")
+		assert.Contains(t, m.View(), "    article_id int FK\n")
+		assert.Contains(t, m.View(), "    public.comment (article_id) → public.article\n")
+	})
+
+	t.Run("All tables are toggled", func(t *testing.T) {
+		// Act
+		m := press(NewModel(result), "a")
+
+		// Assert
+		assert.Len(t, m.SelectedTables(), 3)
+		assert.Empty(t, press(m, "a").SelectedTables())
+	})
+
+	t.Run("The cursor stays within the list", func(t *testing.T) {
+		// Act
+		m := press(NewModel(result), "up", "down", "down", "down")
+
+		// Assert
+		assert.Contains(t, m.View(), "> [ ] sales (0/1 tables)\n")
+	})
+
+	t.Run("A single schema is opened right away", func(t *testing.T) {
+		// Act
+		m := NewModel(&database.Result{Tables: result.Tables[:2]})
+
+		// Assert
+		assert.Contains(t, m.View(), "Tables of public\n")
+		assert.Contains(t, press(m, "esc").View(), "Tables of public\n")
+	})
+
+	t.Run("The diagram is only generated with selected tables", func(t *testing.T) {
+		// Act
+		m := press(NewModel(result), "g")
+		selectedModel := press(m, "down", " ", "g")
+
+		// Assert
+		assert.False(t, m.generate)
+		assert.True(t, selectedModel.generate)
+		assert.Equal(t, []database.TableResult{result.Tables[2]}, selectedModel.getSelectedResult(result).Tables)
+	})
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aslakhellesoy/mermerd/analyzer"
+	"github.com/aslakhellesoy/mermerd/browse"
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/diagram"
+	"github.com/aslakhellesoy/mermerd/presentation"
+)
+
+var browseCmd = &cobra.Command{
+	Use:   "browse [model file]",
+	Short: "Browse the schemas, tables and columns and create the diagram of the selected tables",
+	Long:  "Navigate from the schemas to the tables and to their columns and constraints in a terminal UI, select the tables and create the diagram of the selection. The database is analyzed (all schemas and tables, unless they are configured) or a model is read that was exported with --outputFormat yaml or json.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		mermerdConfig := config.NewConfig()
+		if err := applyProfile(cmd, mermerdConfig.Profile()); err != nil {
+			exitWithError(err)
+		}
+
+		// everything is analyzed, as the tables are selected while browsing
+		if len(mermerdConfig.Schemas()) == 0 {
+			viper.Set(config.UseAllSchemasKey, true)
+		}
+
+		if len(mermerdConfig.SelectedTables()) == 0 {
+			viper.Set(config.UseAllTablesKey, true)
+		}
+
+		presentation.SetDiagramOnStdout(mermerdConfig.OutputFileName() == diagram.StdoutFileName)
+		if err := configureOutput(mermerdConfig); err != nil {
+			exitWithError(err)
+		}
+
+		var model *database.Result
+		var err error
+		if len(args) > 0 {
+			model, err = database.ReadResult(args[0])
+		} else {
+			model, err = analyzer.NewAnalyzer(mermerdConfig, analyzer.NewConnectorFactory(mermerdConfig), analyzer.NewQuestioner()).Analyze()
+		}
+		if err != nil {
+			exitWithError(err)
+		}
+
+		// the terminal UI must not end up in the diagram if it is written to stdout
+		var output io.Writer = os.Stdout
+		if presentation.IsDiagramOnStdout() {
+			output = os.Stderr
+		}
+
+		selectedModel, err := browse.Run(model, os.Stdin, output)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if selectedModel == nil {
+			color.Yellow("No tables were selected, the diagram was not created")
+			return
+		}
+
+		if err = diagram.NewDiagram(mermerdConfig).Create(selectedModel); err != nil {
+			exitWithError(err)
+		}
+
+		presentation.ShowSuccess(mermerdConfig.OutputFileName())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(browseCmd)
+}
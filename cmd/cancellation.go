@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/presentation"
+)
+
+// notifyCancellation returns a context that is cancelled on SIGINT or SIGTERM, so the analysis cancels the running
+// queries and closes the connection instead of being killed
+func notifyCancellation() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		// a second signal terminates mermerd immediately (e.g. if the database does not react to the cancellation)
+		stop()
+	}()
+
+	return ctx, stop
+}
+
+// writePartialModel writes the tables that were analyzed until the analysis was cancelled (--partialModel), so they
+// can be inspected or rendered with mermerd render
+func writePartialModel(config config.MermerdConfig, result *database.Result) {
+	fileName := config.PartialModel()
+	if fileName == "" || result == nil {
+		return
+	}
+
+	if err := database.WriteResult(fileName, result); err != nil {
+		logrus.WithField("file", fileName).Warn("Could not write the partial model", " | ", err)
+		return
+	}
+
+	presentation.ShowPartialModel(fileName, len(result.Tables))
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aslakhellesoy/mermerd/analyzer"
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/diagram"
+	"github.com/aslakhellesoy/mermerd/diff"
+	"github.com/aslakhellesoy/mermerd/presentation"
+)
+
+var diffDiagram bool
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [source] [target]",
+	Short: "Show the changes between two databases or exported models",
+	Long:  "Show the added, removed and changed tables, columns and constraints between two sources. A source is either a connection string or a model that was exported with --outputFormat yaml (or json)",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		config := config.NewConfig()
+		if err := applyProfile(cmd, config.Profile()); err != nil {
+			exitWithError(err)
+		}
+
+		connectorFactory := analyzer.NewConnectorFactory(config)
+		questioner := analyzer.NewQuestioner()
+		analyzer := analyzer.NewAnalyzer(config, connectorFactory, questioner)
+		diagram := diagram.NewDiagram(config)
+
+		if err := configureOutput(config); err != nil {
+			exitWithError(err)
+		}
+
+		source, err := getDiffSource(analyzer, args[0])
+		if err != nil {
+			exitWithError(err)
+		}
+
+		target, err := getDiffSource(analyzer, args[1])
+		if err != nil {
+			exitWithError(err)
+		}
+
+		result := diff.Compare(source, target)
+		if err = result.WriteReport(color.Output); err != nil {
+			exitWithError(err)
+		}
+
+		if diffDiagram {
+			if err = diagram.CreateDiff(result); err != nil {
+				exitWithError(err)
+			}
+
+			presentation.ShowSuccess(config.OutputFileName())
+		}
+	},
+}
+
+// getDiffSource reads the model if the source is an existing file, otherwise the source is used as connection string
+func getDiffSource(analyzer analyzer.Analyzer, source string) (*database.Result, error) {
+	if _, err := os.Stat(source); err == nil {
+		return database.ReadResult(source)
+	}
+
+	viper.Set(config.ConnectionStringKey, source)
+	return analyzer.Analyze()
+}
+
+func init() {
+	diffCmd.Flags().BoolVar(&diffDiagram, "diagram", false, "create a diagram in which the changed tables are highlighted")
+	rootCmd.AddCommand(diffCmd)
+}
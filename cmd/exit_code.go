@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aslakhellesoy/mermerd/analyzer"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/lint"
+	"github.com/aslakhellesoy/mermerd/presentation"
+)
+
+// The exit codes allow scripts to distinguish the causes of a failure (see the exit codes in the readme)
+const (
+	exitCodeError              = 1
+	exitCodeConnection         = 2
+	exitCodeUnsupportedDialect = 3
+	exitCodeNoSchemas          = 4
+	exitCodeNoTables           = 5
+	exitCodeMissingInput       = 6
+	exitCodeLintFindings       = 7
+	exitCodeNotReadOnly        = 8
+	exitCodeMissingPermissions = 9
+	// exitCodeCancelled is the exit code of the shells for SIGINT (128 + 2)
+	exitCodeCancelled = 130
+)
+
+func getExitCode(err error) int {
+	switch {
+	// the cancellation is the cause of the error of the cancelled step (e.g. the connection)
+	case errors.Is(err, analyzer.ErrCancelled):
+		return exitCodeCancelled
+	case errors.Is(err, analyzer.ErrConnection):
+		return exitCodeConnection
+	case errors.Is(err, database.ErrUnsupportedDialect):
+		return exitCodeUnsupportedDialect
+	case errors.Is(err, analyzer.ErrNoSchemas):
+		return exitCodeNoSchemas
+	case errors.Is(err, analyzer.ErrNoTables):
+		return exitCodeNoTables
+	case errors.Is(err, analyzer.ErrMissingInput):
+		return exitCodeMissingInput
+	case errors.Is(err, lint.ErrFindings):
+		return exitCodeLintFindings
+	case errors.Is(err, analyzer.ErrNotReadOnly):
+		return exitCodeNotReadOnly
+	case errors.Is(err, analyzer.ErrMissingPermissions):
+		return exitCodeMissingPermissions
+	default:
+		return exitCodeError
+	}
+}
+
+// exitWithError shows the error and exits with the exit code of its cause
+func exitWithError(err error) {
+	logrus.Error(err)
+	presentation.ShowError()
+	os.Exit(getExitCode(err))
+}
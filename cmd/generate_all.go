@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aslakhellesoy/mermerd/analyzer"
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/presentation"
+)
+
+var generateAllConcurrency int
+
+var generateAllCmd = &cobra.Command{
+	Use:   "generate-all",
+	Short: "Create the diagrams of all runs of the configuration file",
+	Long:  "Create the diagrams of all runs of the configuration file (see runs) in one invocation, one after the other or concurrently via --concurrency. A failed run does not stop the other runs.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// there is nobody to answer the questions of several runs
+		viper.Set(config.NonInteractiveKey, true)
+		mermerdConfig := config.NewConfig()
+		if err := applyProfile(cmd, mermerdConfig.Profile()); err != nil {
+			exitWithError(err)
+		}
+
+		if err := configureOutput(mermerdConfig); err != nil {
+			exitWithError(err)
+		}
+
+		if err := generateAll(cmd, mermerdConfig.RunNames(), generateAllConcurrency); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+// generateAll creates the diagrams of the runs with at most concurrency runs at the same time. The error of the first
+// failed run is returned, as it determines the exit code.
+func generateAll(cmd *cobra.Command, runs []string, concurrency int) error {
+	if len(runs) == 0 {
+		return errors.New("the configuration file does not contain any runs")
+	}
+
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d (at least 1)", concurrency)
+	}
+
+	if concurrency > 1 {
+		// the spinners of concurrent runs would overwrite each other
+		presentation.SetQuiet(true)
+	}
+
+	errs := make([]error, len(runs))
+	semaphore := make(chan struct{}, concurrency)
+	summaryFileRuns := map[string]string{}
+	var waitGroup sync.WaitGroup
+	for index, run := range runs {
+		runConfig, err := config.NewRunConfig(run, func(key string) bool {
+			return isFlagChanged(cmd, key)
+		})
+		if err != nil {
+			errs[index] = err
+			continue
+		}
+
+		// the runs would overwrite the summaries of each other
+		if summaryFile := runConfig.SummaryFile(); summaryFile != "" {
+			if otherRun, ok := summaryFileRuns[summaryFile]; ok {
+				errs[index] = fmt.Errorf("the summary file %s is already written by run %s (set summaryFile per run)", summaryFile, otherRun)
+				continue
+			}
+
+			summaryFileRuns[summaryFile] = run
+		}
+
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+		go func(index int, runConfig config.MermerdConfig) {
+			defer waitGroup.Done()
+			defer func() { <-semaphore }()
+			errs[index] = createDiagram(runConfig, analyzer.NewQuestioner())
+		}(index, runConfig)
+	}
+
+	waitGroup.Wait()
+
+	var firstErr error
+	failed := 0
+	for index, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		color.Red("X Run %s failed: %v", runs[index], err)
+		if firstErr == nil {
+			firstErr = fmt.Errorf("run %s: %w", runs[index], err)
+		}
+
+		failed++
+	}
+
+	if firstErr != nil {
+		return fmt.Errorf("%d of %d runs failed, %w", failed, len(runs), firstErr)
+	}
+
+	return nil
+}
+
+func init() {
+	generateAllCmd.Flags().IntVar(&generateAllConcurrency, "concurrency", 1, "number of runs that are created at the same time")
+	rootCmd.AddCommand(generateAllCmd)
+}
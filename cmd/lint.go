@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aslakhellesoy/mermerd/analyzer"
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/lint"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [model file]",
+	Short: "Check the database model against lint rules",
+	Long:  "Check the tables of the database (or of a model that was exported with --outputFormat yaml or json) for missing primary keys, foreign keys without index and inconsistent naming. Mermerd exits with code 7 if a rule is violated.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		// the foreign-key-index rule needs the indexes of the tables
+		viper.Set(config.ShowIndexesKey, true)
+		config := config.NewConfig()
+		if err := applyProfile(cmd, config.Profile()); err != nil {
+			exitWithError(err)
+		}
+
+		rules, err := lint.GetRules(config.LintRules())
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if err = configureOutput(config); err != nil {
+			exitWithError(err)
+		}
+
+		var model *database.Result
+		if len(args) > 0 {
+			model, err = database.ReadResult(args[0])
+		} else {
+			model, err = analyzer.NewAnalyzer(config, analyzer.NewConnectorFactory(config), analyzer.NewQuestioner()).Analyze()
+		}
+		if err != nil {
+			exitWithError(err)
+		}
+
+		result := lint.Check(model, rules)
+		if err = result.WriteReport(color.Output); err != nil {
+			exitWithError(err)
+		}
+
+		if result.HasFindings() {
+			exitWithError(fmt.Errorf("%w (%d findings)", lint.ErrFindings, len(result.Findings)))
+		}
+	},
+}
+
+func init() {
+	lintCmd.Flags().StringSlice(config.LintRulesKey, []string{""}, "rules that are checked (primary-key, foreign-key-index and naming, default: all)")
+	_ = viper.BindPFlag(config.LintRulesKey, lintCmd.Flags().Lookup(config.LintRulesKey))
+	rootCmd.AddCommand(lintCmd)
+}
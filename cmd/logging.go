@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/diagram"
+	"github.com/aslakhellesoy/mermerd/presentation"
+)
+
+const (
+	logFormatText = "text"
+	logFormatJson = "json"
+)
+
+// configureOutput sets the output level and the format of the logs. Text logs are only shown in debug mode, json logs
+// are always written (to stderr), so they can be collected by a log aggregation.
+func configureOutput(config config.MermerdConfig) error {
+	verbose := config.Debug() || config.Verbose()
+	if config.Quiet() && verbose {
+		return errors.New("--quiet cannot be combined with --verbose or --debug")
+	}
+
+	presentation.SetQuiet(config.Quiet())
+	presentation.SetDiagramOnStdout(config.OutputFileName() == diagram.StdoutFileName)
+	switch {
+	case verbose:
+		logrus.SetLevel(logrus.DebugLevel)
+	case config.Quiet():
+		logrus.SetLevel(logrus.WarnLevel)
+	}
+
+	switch config.LogFormat() {
+	case "", logFormatText:
+		if !verbose {
+			logrus.SetOutput(io.Discard)
+		}
+	case logFormatJson:
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return fmt.Errorf("unsupported log format %q (text or json)", config.LogFormat())
+	}
+
+	return configureSqlTrace(config.TraceSql())
+}
+
+// sqlTraceFile is the open file of --trace-sql, which is kept open for the following runs (e.g. of --watch)
+var sqlTraceFile *os.File
+
+// configureSqlTrace appends every executed query to the file of --trace-sql, so that it can be audited what was
+// executed against the database
+func configureSqlTrace(fileName string) error {
+	if sqlTraceFile != nil && sqlTraceFile.Name() == fileName {
+		return nil
+	}
+
+	if sqlTraceFile != nil {
+		database.SetQueryTrace(nil)
+		_ = sqlTraceFile.Close()
+		sqlTraceFile = nil
+	}
+
+	if fileName == "" {
+		return nil
+	}
+
+	file, err := os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("could not open the sql trace file: %w", err)
+	}
+
+	sqlTraceFile = file
+	database.SetQueryTrace(file)
+	return nil
+}
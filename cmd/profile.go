@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/aslakhellesoy/mermerd/analyzer"
+	"github.com/aslakhellesoy/mermerd/config"
+)
+
+// selectProfile applies the profile of the --profile flag. If neither a profile nor a connection string is given,
+// the user can choose one of the configured profiles (unless in non-interactive mode).
+func selectProfile(cmd *cobra.Command, mermerdConfig config.MermerdConfig, questioner analyzer.Questioner) error {
+	profile := mermerdConfig.Profile()
+	profileNames := mermerdConfig.ProfileNames()
+	if profile == "" && mermerdConfig.ConnectionString() == "" && mermerdConfig.Connection().Type == "" && len(profileNames) > 0 && !mermerdConfig.NonInteractive() {
+		var err error
+		if profile, err = questioner.AskProfileQuestion(profileNames); err != nil {
+			return err
+		}
+	}
+
+	return applyProfile(cmd, profile)
+}
+
+// applyProfile applies the profile (if any), the flags that were given explicitly take precedence over its settings
+func applyProfile(cmd *cobra.Command, profile string) error {
+	if profile == "" {
+		return nil
+	}
+
+	return config.UseProfile(profile, func(key string) bool {
+		return isFlagChanged(cmd, key)
+	})
+}
+
+// applyRun applies the run of the configuration file like a profile, the returned function restores the previous
+// settings for the next run
+func applyRun(cmd *cobra.Command, run string) (func(), error) {
+	return config.UseRun(run, func(key string) bool {
+		return isFlagChanged(cmd, key)
+	})
+}
+
+// isFlagChanged ignores the case of the key, as viper returns the keys of the configuration file in lower case
+func isFlagChanged(cmd *cobra.Command, key string) bool {
+	changed := false
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if strings.EqualFold(flag.Name, key) {
+			changed = true
+		}
+	})
+
+	return changed
+}
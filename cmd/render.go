@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/diagram"
+	"github.com/aslakhellesoy/mermerd/presentation"
+)
+
+var renderCmd = &cobra.Command{
+	Use:   "render [model file]",
+	Short: "Create the diagram from an exported model",
+	Long:  "Create the diagram from a model that was exported with --outputFormat yaml (or json), without connecting to the database",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		config := config.NewConfig()
+		if err := applyProfile(cmd, config.Profile()); err != nil {
+			exitWithError(err)
+		}
+
+		diagram := diagram.NewDiagram(config)
+
+		if err := configureOutput(config); err != nil {
+			exitWithError(err)
+		}
+
+		result, err := database.ReadResult(args[0])
+		if err != nil {
+			exitWithError(err)
+		}
+
+		err = diagram.Create(result)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		presentation.ShowSuccess(config.OutputFileName())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+}
@@ -1,12 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/aslakhellesoy/mermerd/database"
 	"github.com/aslakhellesoy/mermerd/diagram"
 	"github.com/aslakhellesoy/mermerd/presentation"
+	"github.com/aslakhellesoy/mermerd/util"
 )
 
 var runConfig string
@@ -24,33 +26,97 @@ var rootCmd = &cobra.Command{
 	Short: "Create Mermaid ERD diagrams from existing tables",
 	Long:  "Create Mermaid ERD diagrams from existing tables",
 	Run: func(cmd *cobra.Command, args []string) {
-		presentation.ShowIntro()
 		config := config.NewConfig()
-		connectorFactory := database.NewConnectorFactory()
+		// the intro is shown before the profile is applied, the output is configured again afterwards
+		presentation.SetQuiet(config.Quiet())
+		presentation.SetDiagramOnStdout(config.OutputFileName() == diagram.StdoutFileName)
+		presentation.ShowIntro()
 		questioner := analyzer.NewQuestioner()
-		analyzer := analyzer.NewAnalyzer(config, connectorFactory, questioner)
-		diagram := diagram.NewDiagram(config)
+		if err := selectProfile(cmd, config, questioner); err != nil {
+			exitWithError(err)
+		}
 
-		if !config.Debug() {
-			logrus.SetOutput(io.Discard)
+		runs := util.Filter(config.Run(), func(run string) bool { return run != "" })
+		if len(runs) == 0 {
+			createConfiguredDiagram(config, questioner)
+			if err := rememberAnswers(config, questioner); err != nil {
+				exitWithError(err)
+			}
+
+			return
 		}
 
-		result, err := analyzer.Analyze()
-		if err != nil {
-			logrus.Error(err)
-			presentation.ShowError()
-			os.Exit(1)
+		if len(runs) > 1 && config.Watch() {
+			exitWithError(errors.New("--watch cannot be used with more than one run"))
 		}
 
-		err = diagram.Create(result)
-		if err != nil {
-			logrus.Error(err)
-			presentation.ShowError()
-			os.Exit(1)
+		for _, run := range runs {
+			restore, err := applyRun(cmd, run)
+			if err != nil {
+				exitWithError(fmt.Errorf("%w (available runs: %s)", err, strings.Join(config.RunNames(), ", ")))
+			}
+
+			createConfiguredDiagram(config, questioner)
+			restore()
+		}
+	},
+}
+
+// createConfiguredDiagram configures the output with the current settings (e.g. of a run) and creates the diagram
+func createConfiguredDiagram(config config.MermerdConfig, questioner analyzer.Questioner) {
+	if err := configureOutput(config); err != nil {
+		exitWithError(err)
+	}
+
+	if err := createDiagram(config, questioner); err != nil {
+		exitWithError(err)
+	}
+}
+
+// createDiagram analyzes the database and writes the diagram, the analyzer is created for every run as the connector
+// reads the connection settings when it is created
+func createDiagram(config config.MermerdConfig, questioner analyzer.Questioner) error {
+	connectorFactory := analyzer.NewConnectorFactory(config)
+	mermerdAnalyzer := analyzer.NewAnalyzer(config, connectorFactory, questioner)
+	if config.Cache() {
+		mermerdAnalyzer = analyzer.NewCachingAnalyzer(config, mermerdAnalyzer)
+	}
+
+	diagram := diagram.NewDiagram(config)
+	summaryRecorder := presentation.NewSummaryRecorder()
+	createOutput := func(result *database.Result) error {
+		start := time.Now()
+		if err := diagram.Create(result); err != nil {
+			return err
 		}
 
+		summaryRecorder.RecordPhase("output", start)
 		presentation.ShowSuccess(config.OutputFileName())
-	},
+		return showSummary(config, summaryRecorder, result)
+	}
+
+	ctx, stop := notifyCancellation()
+	defer stop()
+	ctx = presentation.ContextWithSummaryRecorder(ctx, summaryRecorder)
+	if config.Watch() {
+		result, err := mermerdAnalyzer.Watch(ctx, config.WatchInterval(), createOutput)
+		if errors.Is(err, analyzer.ErrCancelled) {
+			writePartialModel(config, result)
+		}
+
+		return err
+	}
+
+	result, err := mermerdAnalyzer.AnalyzeContext(ctx)
+	if errors.Is(err, analyzer.ErrCancelled) {
+		writePartialModel(config, result)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return createOutput(result)
 }
 
 func Execute() {
@@ -62,45 +128,187 @@ func Execute() {
 func init() {
 	cobra.OnInitialize(initConfig)
 
-	rootCmd.Flags().StringVar(&runConfig, "runConfig", "", "run configuration (replaces global configuration)")
-	rootCmd.Flags().Bool(config.ShowAllConstraintsKey, false, "show all constraints, even though the table of the resulting constraint was not selected")
-	rootCmd.Flags().Bool(config.UseAllTablesKey, false, "use all available tables")
-	rootCmd.Flags().Bool(config.UseAllSchemasKey, false, "use all available schemas")
-	rootCmd.Flags().Bool(config.DebugKey, false, "show debug logs")
-	rootCmd.Flags().Bool(config.OmitConstraintLabelsKey, false, "omit the constraint labels")
-	rootCmd.Flags().Bool(config.OmitAttributeKeysKey, false, "omit the attribute keys (PK, FK)")
-	rootCmd.Flags().Bool(config.ShowSchemaPrefix, false, "show schema prefix in table name")
-	rootCmd.Flags().BoolP(config.EncloseWithMermaidBackticksKey, "e", false, "enclose output with mermaid backticks (needed for e.g. in markdown viewer)")
-	rootCmd.Flags().StringP(config.ConnectionStringKey, "c", "", "connection string that should be used")
-	rootCmd.Flags().StringP(config.SchemaKey, "s", "", "schema that should be used")
-	rootCmd.Flags().StringP(config.OutputFileNameKey, "o", "result.mmd", "output file name")
-	rootCmd.Flags().String(config.SchemaPrefixSeparator, ".", "the separator that should be used between schema and table name")
-	rootCmd.Flags().StringSlice(config.ShowDescriptionsKey, []string{""}, "show 'enumValues' and/or 'columnComments' in the description column")
-	rootCmd.Flags().StringSlice(config.SelectedTablesKey, []string{""}, "tables to include")
-
-	bindFlagToViper(config.ShowAllConstraintsKey)
-	bindFlagToViper(config.UseAllTablesKey)
-	bindFlagToViper(config.UseAllSchemasKey)
-	bindFlagToViper(config.DebugKey)
-	bindFlagToViper(config.OmitConstraintLabelsKey)
-	bindFlagToViper(config.OmitAttributeKeysKey)
-	bindFlagToViper(config.EncloseWithMermaidBackticksKey)
-	bindFlagToViper(config.ConnectionStringKey)
-	bindFlagToViper(config.SchemaKey)
-	bindFlagToViper(config.OutputFileNameKey)
-	bindFlagToViper(config.SelectedTablesKey)
-	bindFlagToViper(config.ShowDescriptionsKey)
-	bindFlagToViper(config.ShowSchemaPrefix)
-	bindFlagToViper(config.SchemaPrefixSeparator)
+	rootCmd.PersistentFlags().StringVar(&runConfig, "runConfig", "", "run configuration (replaces global configuration)")
+	rootCmd.PersistentFlags().Bool(config.ShowAllConstraintsKey, false, "show all constraints, even though the table of the resulting constraint was not selected")
+	rootCmd.PersistentFlags().Bool(config.UseAllTablesKey, false, "use all available tables")
+	rootCmd.PersistentFlags().Bool(config.UseAllSchemasKey, false, "use all available schemas")
+	rootCmd.PersistentFlags().Bool(config.DebugKey, false, "show debug logs including every executed query with its duration")
+	rootCmd.PersistentFlags().Bool(config.OmitConstraintLabelsKey, false, "omit the constraint labels")
+	rootCmd.PersistentFlags().Bool(config.OmitAttributeKeysKey, false, "omit the attribute keys (PK, FK)")
+	rootCmd.PersistentFlags().Bool(config.ShowSchemaPrefix, false, "show schema prefix in table name")
+	rootCmd.Flags().Bool(config.WatchKey, false, "keep running and update the output file whenever the tables or columns change")
+	rootCmd.PersistentFlags().BoolP(config.EncloseWithMermaidBackticksKey, "e", false, "enclose output with mermaid backticks (needed for e.g. in markdown viewer)")
+	rootCmd.PersistentFlags().StringP(config.ConnectionStringKey, "c", "", "connection string that should be used")
+	rootCmd.PersistentFlags().StringP(config.SchemaKey, "s", "", "schema that should be used")
+	rootCmd.PersistentFlags().StringP(config.OutputFileNameKey, "o", "result.mmd", "output file name, - writes the diagram to stdout")
+	rootCmd.PersistentFlags().String(config.SchemaPrefixSeparator, ".", "the separator that should be used between schema and table name")
+	rootCmd.PersistentFlags().StringSlice(config.ShowDescriptionsKey, []string{""}, "show 'enumValues', 'columnComments', 'checkConstraints', 'defaultValues' and/or 'keyTypes' (surrogate or natural primary key) in the description column")
+	rootCmd.PersistentFlags().StringSlice(config.SelectedTablesKey, []string{""}, "tables to include (exact names, glob patterns or regular expressions enclosed in slashes)")
+	rootCmd.Flags().Duration(config.WatchIntervalKey, 5*time.Second, "interval in which the database is checked for changes in watch mode")
+	rootCmd.PersistentFlags().String(config.OutputFormatKey, "mermaid", "output format of the diagram (mermaid, dot, markdown, html, prisma, gorm, sql, csv, tsv, openapi, json or yaml)")
+	rootCmd.PersistentFlags().StringSlice(config.ExcludeTablesKey, []string{""}, "tables that should be excluded (glob patterns or regular expressions enclosed in slashes)")
+	rootCmd.PersistentFlags().StringSlice(config.IncludeColumnsKey, []string{""}, "columns to include (column, table.column, glob patterns or regular expressions enclosed in slashes)")
+	rootCmd.PersistentFlags().StringSlice(config.ExcludeColumnsKey, []string{""}, "columns that should be excluded (column, table.column, glob patterns or regular expressions enclosed in slashes)")
+	rootCmd.PersistentFlags().StringSlice(config.FocusKey, []string{""}, "tables from which related tables are selected via their foreign keys (exact names or patterns)")
+	rootCmd.PersistentFlags().Int(config.DepthKey, 1, "number of foreign key levels that are followed from the focused tables")
+	rootCmd.PersistentFlags().Bool(config.IncludeViewsKey, false, "include views (and materialized views) in the available tables")
+	rootCmd.PersistentFlags().Bool(config.SkipFailingTablesKey, false, "leave out the tables whose columns or constraints cannot be read (e.g. without permission) instead of failing")
+	rootCmd.PersistentFlags().Bool(config.CollapseJoinTablesKey, false, "show join tables as many-to-many relation between the joined tables")
+	rootCmd.PersistentFlags().Bool(config.InferRelationshipsKey, false, "infer relations that are not declared as foreign keys from the column names (e.g. customer_id -> customer)")
+	rootCmd.PersistentFlags().StringSlice(config.InferRelationshipPatternsKey, []string{"{table}_id"}, "naming patterns of the columns for inferred relations ({table} is the referenced table)")
+	rootCmd.PersistentFlags().Bool(config.ShowIndexesKey, false, "read the indexes, which also shows the columns with a unique index as unique key (UK) if the database does not report them")
+	rootCmd.PersistentFlags().Bool(config.ShowNullableKey, false, "show NULL or NOT NULL in the description column")
+	rootCmd.PersistentFlags().Bool(config.OmitColumnsKey, false, "omit the columns in the diagram to show only the tables and their relations")
+	rootCmd.PersistentFlags().String(config.ColumnOrderKey, "alphabetical", "order of the columns in the diagram (alphabetical or ordinal, which is the order of the table definition)")
+	rootCmd.PersistentFlags().Duration(config.QueryTimeoutKey, 0, "timeout of each query to the database, e.g. 30s (no timeout if 0)")
+	rootCmd.PersistentFlags().Int(config.ConnectionAttemptsKey, 1, "number of attempts to connect or query the database if the connection fails (waits 1s, 2s, 4s, ... between the attempts)")
+	rootCmd.PersistentFlags().String(config.SshKey, "", "connect to the database through an ssh tunnel ([user@]host[:port] of the ssh server, e.g. a bastion host)")
+	rootCmd.PersistentFlags().String(config.SshIdentityFileKey, "", "private key to authenticate at the ssh server (uses the ssh agent if not set)")
+	rootCmd.PersistentFlags().String(config.TlsCaCertKey, "", "CA certificate to verify the certificate of the database server (PostgreSQL, CockroachDB, MySQL, MariaDB and MSSQL)")
+	rootCmd.PersistentFlags().String(config.TlsClientCertKey, "", "client certificate to authenticate at the database (PostgreSQL, CockroachDB, MySQL and MariaDB)")
+	rootCmd.PersistentFlags().String(config.TlsClientKeyKey, "", "private key of the client certificate")
+	rootCmd.PersistentFlags().Bool(config.TlsSkipVerifyKey, false, "encrypt the connection without verifying the certificate of the database server")
+	rootCmd.PersistentFlags().String(config.PasswordFileKey, "", "file that contains the password, which is added to the user of the connection string")
+	rootCmd.PersistentFlags().String(config.CredentialCommandKey, "", "command that prints the password (e.g. of a vault or the keychain), which is added to the user of the connection string")
+	rootCmd.PersistentFlags().Bool(config.AskPasswordKey, false, "ask for the password with hidden input, which is added to the user of the connection string")
+	rootCmd.PersistentFlags().Bool(config.AssertReadOnlyKey, false, "fail if the user of the connection can change the database and make the session read-only where supported")
+	rootCmd.PersistentFlags().String(config.AuthModeKey, "", "authentication mode, azure-ad authenticates at Azure SQL with the DefaultAzureCredential (default: credentials of the connection string)")
+	rootCmd.PersistentFlags().String(config.ProfileKey, "", "profile of the configuration file that should be used (see profiles)")
+	rootCmd.PersistentFlags().Bool(config.NonInteractiveKey, false, "fail with an error instead of asking for missing values (e.g. in CI)")
+	rootCmd.PersistentFlags().String(config.LogFormatKey, "text", "format of the logs (text or json), json logs are written even without --debug")
+	rootCmd.PersistentFlags().String(config.TraceSqlKey, "", "file that records every executed query with its arguments and duration")
+	rootCmd.PersistentFlags().Bool(config.SummaryKey, false, "show the duration of every phase, the number of tables, columns and constraints and the output size at the end")
+	rootCmd.PersistentFlags().String(config.SummaryFileKey, "", "file to which the summary of --summary is written as json")
+	rootCmd.PersistentFlags().String(config.PartialModelKey, "", "file to which the analyzed tables are written as json if the analysis is cancelled (SIGINT or SIGTERM)")
+	rootCmd.PersistentFlags().Bool(config.QuietKey, false, "only print the path of the output file (no intro, spinner or logs)")
+	rootCmd.PersistentFlags().Bool(config.VerboseKey, false, "show debug logs including every executed query with its duration (same as --debug)")
+	rootCmd.PersistentFlags().String(config.RenderKey, "", "also render the diagram as svg or png image next to the output file (requires the mermaid cli mmdc or GraphViz for dot diagrams)")
+	rootCmd.PersistentFlags().String(config.MermaidThemeKey, "", "mermaid theme of the diagram (e.g. default, neutral, dark or forest), which is added as frontmatter")
+	rootCmd.PersistentFlags().String(config.MermaidLayoutKey, "", "mermaid layout engine of the diagram (e.g. elk), which is added as frontmatter")
+	rootCmd.PersistentFlags().Int(config.MermaidMaxTextSizeKey, 0, "maximum text size of the mermaid diagram (e.g. for large schemas), which is added as frontmatter")
+	rootCmd.PersistentFlags().Bool(config.MermaidInitDirectiveKey, false, "add the mermaid configuration as init directive instead of frontmatter (for renderers without frontmatter support)")
+	rootCmd.PersistentFlags().String(config.GroupByKey, "", "group the tables in the diagram by schema (tables of the tableGroups of the configuration file are grouped first)")
+	rootCmd.PersistentFlags().String(config.RelationshipLabelKey, "columnName", "label of the relationships (columnName, constraintName, none or a template like \"{{.FkTable}} -> {{.PkTable}}\")")
+	rootCmd.PersistentFlags().Bool(config.ShowDataTypePrecisionKey, false, "show the length or precision of the data types (e.g. varchar(255) or numeric(10,2))")
+	rootCmd.PersistentFlags().Bool(config.ShowEnumsAsEntitiesKey, false, "show every enum type as entity with its values, which is related to the columns that use it")
+	rootCmd.PersistentFlags().Bool(config.ShowIdentityKey, false, "show identity, auto increment and sequence columns in the description column")
+	rootCmd.PersistentFlags().Bool(config.ShowPartitionsKey, false, "show the partitions of partitioned tables (postgres), which are hidden by default")
+	rootCmd.PersistentFlags().Bool(config.ShowGhostTablesKey, false, "show the tables that are referenced by the selected tables, but not selected, as ghost tables without columns")
+	rootCmd.PersistentFlags().StringSlice(config.ConnectorPluginsKey, []string{""}, "Go plugins (.so files) that register connectors for further databases, see RegisterConnector of the database package")
+	rootCmd.PersistentFlags().String(config.SqlDialectKey, "portable", "dialect of the sql output format (portable, postgres, mysql, sqlserver or sqlite)")
+	rootCmd.PersistentFlags().Int(config.SampleRowsKey, 0, "number of sample rows per table, which are shown in the markdown and html output")
+	rootCmd.PersistentFlags().StringSlice(config.MaskColumnsKey, []string{""}, "columns whose sample values are masked (column, table.column, glob patterns or regular expressions enclosed in slashes)")
+	rootCmd.Flags().StringSlice(config.RunKey, []string{""}, "runs of the configuration file that should be created one after the other (see runs)")
+	rootCmd.PersistentFlags().Bool(config.InjectKey, false, "replace the content between <!-- mermerd:begin --> and <!-- mermerd:end --> of the existing output file (e.g. a readme) instead of overwriting it")
+	rootCmd.PersistentFlags().Bool(config.StableOutputKey, false, "normalize the whitespace of the output (line endings, trailing spaces and blank lines), so regenerated diagrams only differ in their changes")
+	rootCmd.PersistentFlags().Bool(config.CacheKey, false, "cache the analyzed model, so further runs with the same connection and tables only change the diagram options without querying the database")
+	rootCmd.PersistentFlags().Duration(config.CacheTtlKey, time.Hour, "time after which the cached model is read from the database again (never if 0)")
+	rootCmd.PersistentFlags().Bool(config.IncrementalKey, false, "keep the model and only analyze the tables that changed since the previous run (SQL Server, Snowflake and file, the other databases are analyzed completely)")
+	rootCmd.PersistentFlags().Bool(config.OmitOrphanTablesKey, false, "omit the tables without relationships to other tables from the diagram (the omitted tables are logged and listed in the markdown and html reports)")
+	rootCmd.PersistentFlags().Int(config.MaxColumnsPerTableKey, 0, "maximum number of columns per table in the diagram, the keys are shown first and the other columns are summarized in a placeholder row (0 shows all columns)")
+	rootCmd.PersistentFlags().String(config.NameCaseKey, "", "case of the table and column names in the diagram (snake, camel, pascal or upper, default: names of the database)")
+	rootCmd.PersistentFlags().String(config.MermaidCommentStyleKey, "", "style of the attribute comments in the mermaid diagram (entity escapes quote marks as #quot;, native replaces them with single quote marks, default: entity)")
+	rootCmd.PersistentFlags().String(config.RelationshipDirectionKey, "child", "table that is written first in the relationships of the mermaid diagram (child, which has the foreign key, or parent)")
+	rootCmd.PersistentFlags().String(config.RelationshipOrderKey, "table", "order of the relationships in the diagram (table, parent or alphabetical), which influences the layout of mermaid")
+	rootCmd.PersistentFlags().Bool(config.MergeRelationshipsKey, false, "merge the relationships between the same tables into one relationship, whose label lists the labels of all relationships")
+	rootCmd.PersistentFlags().Bool(config.MultipleAttributeKeysKey, false, "show all keys of a column, e.g. PK,FK (only the first of PK, FK and UK is shown by default)")
+
+	bindPersistentFlagToViper(config.ShowAllConstraintsKey)
+	bindPersistentFlagToViper(config.UseAllTablesKey)
+	bindPersistentFlagToViper(config.UseAllSchemasKey)
+	bindPersistentFlagToViper(config.DebugKey)
+	bindPersistentFlagToViper(config.OmitConstraintLabelsKey)
+	bindPersistentFlagToViper(config.OmitAttributeKeysKey)
+	bindPersistentFlagToViper(config.EncloseWithMermaidBackticksKey)
+	bindPersistentFlagToViper(config.ConnectionStringKey)
+	bindPersistentFlagToViper(config.SchemaKey)
+	bindPersistentFlagToViper(config.OutputFileNameKey)
+	bindPersistentFlagToViper(config.SelectedTablesKey)
+	bindPersistentFlagToViper(config.ShowDescriptionsKey)
+	bindPersistentFlagToViper(config.ShowSchemaPrefix)
+	bindPersistentFlagToViper(config.SchemaPrefixSeparator)
+	bindFlagToViper(config.WatchKey)
+	bindFlagToViper(config.WatchIntervalKey)
+	bindPersistentFlagToViper(config.OutputFormatKey)
+	bindPersistentFlagToViper(config.ExcludeTablesKey)
+	bindPersistentFlagToViper(config.IncludeColumnsKey)
+	bindPersistentFlagToViper(config.ExcludeColumnsKey)
+	bindPersistentFlagToViper(config.FocusKey)
+	bindPersistentFlagToViper(config.DepthKey)
+	bindPersistentFlagToViper(config.IncludeViewsKey)
+	bindPersistentFlagToViper(config.SkipFailingTablesKey)
+	bindPersistentFlagToViper(config.CollapseJoinTablesKey)
+	bindPersistentFlagToViper(config.InferRelationshipsKey)
+	bindPersistentFlagToViper(config.InferRelationshipPatternsKey)
+	bindPersistentFlagToViper(config.ShowIndexesKey)
+	bindPersistentFlagToViper(config.ShowNullableKey)
+	bindPersistentFlagToViper(config.OmitColumnsKey)
+	bindPersistentFlagToViper(config.ColumnOrderKey)
+	bindPersistentFlagToViper(config.QueryTimeoutKey)
+	bindPersistentFlagToViper(config.ConnectionAttemptsKey)
+	bindPersistentFlagToViper(config.SshKey)
+	bindPersistentFlagToViper(config.SshIdentityFileKey)
+	bindPersistentFlagToViper(config.TlsCaCertKey)
+	bindPersistentFlagToViper(config.TlsClientCertKey)
+	bindPersistentFlagToViper(config.TlsClientKeyKey)
+	bindPersistentFlagToViper(config.TlsSkipVerifyKey)
+	bindPersistentFlagToViper(config.PasswordFileKey)
+	bindPersistentFlagToViper(config.CredentialCommandKey)
+	bindPersistentFlagToViper(config.AskPasswordKey)
+	bindPersistentFlagToViper(config.AssertReadOnlyKey)
+	bindPersistentFlagToViper(config.AuthModeKey)
+	bindPersistentFlagToViper(config.ProfileKey)
+	bindPersistentFlagToViper(config.NonInteractiveKey)
+	bindPersistentFlagToViper(config.LogFormatKey)
+	bindPersistentFlagToViper(config.TraceSqlKey)
+	bindPersistentFlagToViper(config.SummaryKey)
+	bindPersistentFlagToViper(config.SummaryFileKey)
+	bindPersistentFlagToViper(config.PartialModelKey)
+	bindPersistentFlagToViper(config.QuietKey)
+	bindPersistentFlagToViper(config.VerboseKey)
+	bindPersistentFlagToViper(config.RenderKey)
+	bindPersistentFlagToViper(config.MermaidThemeKey)
+	bindPersistentFlagToViper(config.MermaidLayoutKey)
+	bindPersistentFlagToViper(config.MermaidMaxTextSizeKey)
+	bindPersistentFlagToViper(config.MermaidInitDirectiveKey)
+	bindPersistentFlagToViper(config.GroupByKey)
+	bindPersistentFlagToViper(config.RelationshipLabelKey)
+	bindPersistentFlagToViper(config.ShowDataTypePrecisionKey)
+	bindPersistentFlagToViper(config.ShowEnumsAsEntitiesKey)
+	bindPersistentFlagToViper(config.ShowIdentityKey)
+	bindPersistentFlagToViper(config.ShowPartitionsKey)
+	bindPersistentFlagToViper(config.ShowGhostTablesKey)
+	bindPersistentFlagToViper(config.ConnectorPluginsKey)
+	bindPersistentFlagToViper(config.SqlDialectKey)
+	bindPersistentFlagToViper(config.SampleRowsKey)
+	bindPersistentFlagToViper(config.MaskColumnsKey)
+	bindFlagToViper(config.RunKey)
+	bindPersistentFlagToViper(config.InjectKey)
+	bindPersistentFlagToViper(config.StableOutputKey)
+	bindPersistentFlagToViper(config.CacheKey)
+	bindPersistentFlagToViper(config.CacheTtlKey)
+	bindPersistentFlagToViper(config.IncrementalKey)
+	bindPersistentFlagToViper(config.OmitOrphanTablesKey)
+	bindPersistentFlagToViper(config.MaxColumnsPerTableKey)
+	bindPersistentFlagToViper(config.NameCaseKey)
+	bindPersistentFlagToViper(config.MermaidCommentStyleKey)
+	bindPersistentFlagToViper(config.RelationshipDirectionKey)
+	bindPersistentFlagToViper(config.RelationshipOrderKey)
+	bindPersistentFlagToViper(config.MergeRelationshipsKey)
+	bindPersistentFlagToViper(config.MultipleAttributeKeysKey)
 }
 
 func bindFlagToViper(key string) {
 	_ = viper.BindPFlag(key, rootCmd.Flags().Lookup(key))
 }
 
+// bindPersistentFlagToViper binds the flags that are shared with the subcommands (e.g. the render options)
+func bindPersistentFlagToViper(key string) {
+	_ = viper.BindPFlag(key, rootCmd.PersistentFlags().Lookup(key))
+}
+
 func initConfig() {
 	if runConfig != "" {
-		color.Blue(fmt.Sprintf("Using run configuration (from %s)", runConfig))
 		viper.SetConfigFile(runConfig)
 	} else {
 		home, err := os.UserHomeDir()
@@ -112,6 +320,16 @@ func initConfig() {
 	}
 
 	_ = viper.ReadInConfig()
+	if runConfig != "" {
+		// the message must not end up in the diagram if it is written to stdout
+		presentation.SetDiagramOnStdout(viper.GetString(config.OutputFileNameKey) == diagram.StdoutFileName)
+		color.Blue(fmt.Sprintf("Using run configuration (from %s)", runConfig))
+	}
+
+	// viper ignores unknown keys and values of the wrong type, so typos would silently use the defaults
+	if configFile := viper.ConfigFileUsed(); configFile != "" {
+		cobra.CheckErr(config.ValidateFile(configFile))
+	}
 
 	// expand all environment variables (https://github.com/spf13/viper/issues/119#issuecomment-417638360)
 	for _, k := range viper.AllKeys() {
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/aslakhellesoy/mermerd/analyzer"
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/presentation"
+	"github.com/aslakhellesoy/mermerd/server"
+)
+
+var serveAddress string
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the diagram of the database via http",
+	Long:  "Start an http server that analyzes the database on every request of GET /erd?schema=public&tables=a,b&format=mermaid, e.g. to embed always up-to-date diagrams in a developer portal",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config := config.NewConfig()
+		if err := applyProfile(cmd, config.Profile()); err != nil {
+			exitWithError(err)
+		}
+
+		if err := configureOutput(config); err != nil {
+			exitWithError(err)
+		}
+
+		// the spinners of concurrent requests would overwrite each other
+		presentation.SetQuiet(true)
+
+		httpServer := &http.Server{
+			Addr:              serveAddress,
+			Handler:           server.NewHandler(config, analyzer.NewConnectorFactory(config)),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		color.Green("Serving the diagram on %s/erd", serveAddress)
+		exitWithError(httpServer.ListenAndServe())
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddress, "address", "localhost:8080", "address on which the http server listens (the server has no authentication, so only listen on other interfaces behind a proxy)")
+	rootCmd.AddCommand(serveCmd)
+}
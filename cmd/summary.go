@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/diagram"
+	"github.com/aslakhellesoy/mermerd/presentation"
+)
+
+// showSummary shows the durations of the phases, the counts of the result and the size of the output (--summary) and
+// writes them as json (--summaryFile). The recorder is reset afterwards, so every diagram of the watch mode has its
+// own summary.
+func showSummary(config config.MermerdConfig, recorder *presentation.SummaryRecorder, result *database.Result) error {
+	defer recorder.Reset()
+	if !config.Summary() && config.SummaryFile() == "" {
+		return nil
+	}
+
+	columnCount, constraintCount := 0, 0
+	for _, table := range result.Tables {
+		columnCount += len(table.Columns)
+		constraintCount += len(table.Constraints)
+	}
+
+	recorder.RecordResult(len(result.Tables), columnCount, constraintCount, getOutputSize(config.OutputFileName()))
+	summary := recorder.GetSummary()
+	if config.Summary() {
+		presentation.ShowSummary(summary)
+	}
+
+	if config.SummaryFile() == "" {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(config.SummaryFile(), append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("could not write the summary: %w", err)
+	}
+
+	return nil
+}
+
+// getOutputSize returns the size of the output file, which is unknown (0) if the diagram is written to stdout
+func getOutputSize(fileName string) int64 {
+	if fileName == diagram.StdoutFileName {
+		return 0
+	}
+
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return 0
+	}
+
+	return info.Size()
+}
@@ -1,6 +1,14 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 const (
 	ShowAllConstraintsKey          = "showAllConstraints"
@@ -18,9 +26,131 @@ const (
 	UseAllSchemasKey               = "useAllSchemas"
 	ShowSchemaPrefix               = "showSchemaPrefix"
 	SchemaPrefixSeparator          = "schemaPrefixSeparator"
+	WatchKey                       = "watch"
+	WatchIntervalKey               = "watchInterval"
+	OutputFormatKey                = "outputFormat"
+	ExcludeTablesKey               = "excludeTables"
+	IncludeColumnsKey              = "includeColumns"
+	ExcludeColumnsKey              = "excludeColumns"
+	FocusKey                       = "focus"
+	DepthKey                       = "depth"
+	IncludeViewsKey                = "includeViews"
+	CollapseJoinTablesKey          = "collapseJoinTables"
+	InferRelationshipsKey          = "inferRelationships"
+	InferRelationshipPatternsKey   = "inferRelationshipPatterns"
+	ShowIndexesKey                 = "showIndexes"
+	ShowNullableKey                = "showNullable"
+	OmitColumnsKey                 = "omitColumns"
+	ColumnOrderKey                 = "columnOrder"
+	QueryTimeoutKey                = "queryTimeout"
+	ConnectionAttemptsKey          = "connectionAttempts"
+	SshKey                         = "ssh"
+	SshIdentityFileKey             = "sshIdentityFile"
+	TlsCaCertKey                   = "tlsCaCert"
+	TlsClientCertKey               = "tlsClientCert"
+	TlsClientKeyKey                = "tlsClientKey"
+	TlsSkipVerifyKey               = "tlsSkipVerify"
+	PasswordFileKey                = "passwordFile"
+	AuthModeKey                    = "authMode"
+	ProfileKey                     = "profile"
+	ProfilesKey                    = "profiles"
+	NonInteractiveKey              = "non-interactive"
+	LogFormatKey                   = "log-format"
+	QuietKey                       = "quiet"
+	VerboseKey                     = "verbose"
+	RenderKey                      = "render"
+	MermaidThemeKey                = "mermaidTheme"
+	MermaidLayoutKey               = "mermaidLayout"
+	MermaidMaxTextSizeKey          = "mermaidMaxTextSize"
+	MermaidInitDirectiveKey        = "mermaidInitDirective"
+	TableStylesKey                 = "tableStyles"
+	GroupByKey                     = "groupBy"
+	TableGroupsKey                 = "tableGroups"
+	RelationshipLabelKey           = "relationshipLabel"
+	DataTypesKey                   = "dataTypes"
+	ShowDataTypePrecisionKey       = "showDataTypePrecision"
+	ShowEnumsAsEntitiesKey         = "showEnumsAsEntities"
+	ShowIdentityKey                = "showIdentity"
+	ShowPartitionsKey              = "showPartitions"
+	ShowGhostTablesKey             = "showGhostTables"
+	ConnectorPluginsKey            = "connectorPlugins"
+	SqlDialectKey                  = "sqlDialect"
+	SampleRowsKey                  = "sampleRows"
+	MaskColumnsKey                 = "maskColumns"
+	ClassificationsKey             = "classifications"
+	RunKey                         = "run"
+	RunsKey                        = "runs"
+	InjectKey                      = "inject"
+	StableOutputKey                = "stable-output"
+	CacheKey                       = "cache"
+	CacheTtlKey                    = "cache-ttl"
+	IncrementalKey                 = "incremental"
+	LintRulesKey                   = "lintRules"
+	OmitOrphanTablesKey            = "omitOrphanTables"
+	MaxColumnsPerTableKey          = "maxColumnsPerTable"
+	TableAliasesKey                = "tableAliases"
+	ColumnAliasesKey               = "columnAliases"
+	NameCaseKey                    = "nameCase"
+	MermaidCommentStyleKey         = "mermaidCommentStyle"
+	RelationshipDirectionKey       = "relationshipDirection"
+	RelationshipOrderKey           = "relationshipOrder"
+	MergeRelationshipsKey          = "mergeRelationships"
+	MultipleAttributeKeysKey       = "multipleAttributeKeys"
+	ConnectionKey                  = "connection"
+	AskPasswordKey                 = "ask-password"
+	CredentialCommandKey           = "credentialCommand"
+	AssertReadOnlyKey              = "assert-readonly"
+	TraceSqlKey                    = "trace-sql"
+	SummaryKey                     = "summary"
+	SummaryFileKey                 = "summaryFile"
+	PartialModelKey                = "partialModel"
+	SkipFailingTablesKey           = "skip-failing-tables"
 )
 
-type config struct{}
+// TableStyle assigns the mermaid class Name with the css Style (e.g. fill:#eee,stroke:#999) to all tables that match
+// one of the Tables patterns (see database.MatchTableName)
+type TableStyle struct {
+	Name   string   `mapstructure:"name"`
+	Style  string   `mapstructure:"style"`
+	Tables []string `mapstructure:"tables"`
+}
+
+// TableGroup groups all tables that match one of the Tables patterns (see database.MatchTableName) under the Name
+type TableGroup struct {
+	Name   string   `mapstructure:"name"`
+	Tables []string `mapstructure:"tables"`
+}
+
+// Classification tags all columns that match one of the Columns patterns (see database.MatchColumnName) with the
+// Name, e.g. pii for the columns that contain personal data
+type Classification struct {
+	Name    string   `mapstructure:"name"`
+	Columns []string `mapstructure:"columns"`
+}
+
+// Alias renames the tables (tableAliases) or the columns (columnAliases) that match the Pattern in the diagram, see
+// database.ReplaceTableName and database.ReplaceColumnName
+type Alias struct {
+	Pattern string `mapstructure:"pattern"`
+	Alias   string `mapstructure:"alias"`
+}
+
+// Connection are the parts of a connection string, which are assembled to the connection string of the Type (see
+// database.BuildConnectionString) if no connection string is given. Params is a query string (e.g. sslmode=disable),
+// as the keys of a map would be lower case.
+type Connection struct {
+	Type     string
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Database string
+	Params   string
+}
+
+type config struct {
+	viper *viper.Viper
+}
 
 type MermerdConfig interface {
 	ShowAllConstraints() bool
@@ -29,6 +159,7 @@ type MermerdConfig interface {
 	ConnectionString() string
 	OutputFileName() string
 	ConnectionStringSuggestions() []string
+	Connection() Connection
 	SelectedTables() []string
 	EncloseWithMermaidBackticks() bool
 	Debug() bool
@@ -38,68 +169,652 @@ type MermerdConfig interface {
 	UseAllSchemas() bool
 	ShowSchemaPrefix() bool
 	SchemaPrefixSeparator() string
+	Watch() bool
+	WatchInterval() time.Duration
+	OutputFormat() string
+	ExcludeTables() []string
+	IncludeColumns() []string
+	ExcludeColumns() []string
+	Focus() []string
+	Depth() int
+	IncludeViews() bool
+	CollapseJoinTables() bool
+	InferRelationships() bool
+	InferRelationshipPatterns() []string
+	ShowIndexes() bool
+	ShowNullable() bool
+	OmitColumns() bool
+	ColumnOrder() string
+	QueryTimeout() time.Duration
+	ConnectionAttempts() int
+	Ssh() string
+	SshIdentityFile() string
+	TlsCaCert() string
+	TlsClientCert() string
+	TlsClientKey() string
+	TlsSkipVerify() bool
+	PasswordFile() string
+	AuthMode() string
+	Profile() string
+	ProfileNames() []string
+	NonInteractive() bool
+	LogFormat() string
+	Quiet() bool
+	Verbose() bool
+	Render() string
+	MermaidTheme() string
+	MermaidLayout() string
+	MermaidMaxTextSize() int
+	MermaidInitDirective() bool
+	TableStyles() ([]TableStyle, error)
+	GroupBy() string
+	TableGroups() ([]TableGroup, error)
+	Classifications() ([]Classification, error)
+	RelationshipLabel() string
+	DataTypes() map[string]string
+	ShowDataTypePrecision() bool
+	ShowEnumsAsEntities() bool
+	ShowIdentity() bool
+	ShowPartitions() bool
+	ShowGhostTables() bool
+	ConnectorPlugins() []string
+	SqlDialect() string
+	SampleRows() int
+	MaskColumns() []string
+	Run() []string
+	RunNames() []string
+	Inject() bool
+	StableOutput() bool
+	Cache() bool
+	CacheTtl() time.Duration
+	Incremental() bool
+	LintRules() []string
+	OmitOrphanTables() bool
+	MaxColumnsPerTable() int
+	TableAliases() ([]Alias, error)
+	ColumnAliases() ([]Alias, error)
+	NameCase() string
+	MermaidCommentStyle() string
+	RelationshipDirection() string
+	RelationshipOrder() string
+	MergeRelationships() bool
+	MultipleAttributeKeys() bool
+	AskPassword() bool
+	CredentialCommand() string
+	AssertReadOnly() bool
+	TraceSql() string
+	Summary() bool
+	SummaryFile() string
+	PartialModel() string
+	SkipFailingTables() bool
 }
 
 func NewConfig() MermerdConfig {
-	return config{}
+	return config{viper.GetViper()}
+}
+
+// NewConfigWithValues returns a configuration that only contains the given values (by their keys, e.g.
+// ConnectionStringKey) instead of the flags and the configuration file, e.g. for the library api
+func NewConfigWithValues(values map[string]any) MermerdConfig {
+	v := viper.New()
+	for key, value := range values {
+		v.Set(key, value)
+	}
+
+	return config{v}
 }
 
 func (c config) ShowAllConstraints() bool {
-	return viper.GetBool(ShowAllConstraintsKey)
+	return c.viper.GetBool(ShowAllConstraintsKey)
 }
 
 func (c config) UseAllTables() bool {
-	return viper.GetBool(UseAllTablesKey)
+	return c.viper.GetBool(UseAllTablesKey)
 }
 
 func (c config) Schemas() []string {
-	return viper.GetStringSlice(SchemaKey)
+	return c.viper.GetStringSlice(SchemaKey)
 }
 
 func (c config) ConnectionString() string {
-	return viper.GetString(ConnectionStringKey)
+	return c.viper.GetString(ConnectionStringKey)
 }
 
 func (c config) OutputFileName() string {
-	return viper.GetString(OutputFileNameKey)
+	return c.viper.GetString(OutputFileNameKey)
 }
 
 func (c config) ConnectionStringSuggestions() []string {
-	return viper.GetStringSlice(ConnectionStringSuggestionsKey)
+	return c.viper.GetStringSlice(ConnectionStringSuggestionsKey)
+}
+
+// Connection reads the fields one by one, so the fields of a profile or run override the single fields of the
+// configuration
+func (c config) Connection() Connection {
+	return Connection{
+		Type:     c.viper.GetString(ConnectionKey + ".type"),
+		Host:     c.viper.GetString(ConnectionKey + ".host"),
+		Port:     c.viper.GetInt(ConnectionKey + ".port"),
+		User:     c.viper.GetString(ConnectionKey + ".user"),
+		Password: c.viper.GetString(ConnectionKey + ".password"),
+		Database: c.viper.GetString(ConnectionKey + ".database"),
+		Params:   c.viper.GetString(ConnectionKey + ".params"),
+	}
 }
 
 func (c config) SelectedTables() []string {
-	return viper.GetStringSlice(SelectedTablesKey)
+	return c.viper.GetStringSlice(SelectedTablesKey)
 }
 
 func (c config) EncloseWithMermaidBackticks() bool {
-	return viper.GetBool(EncloseWithMermaidBackticksKey)
+	return c.viper.GetBool(EncloseWithMermaidBackticksKey)
 }
 
 func (c config) Debug() bool {
-	return viper.GetBool(DebugKey)
+	return c.viper.GetBool(DebugKey)
 }
 
 func (c config) OmitConstraintLabels() bool {
-	return viper.GetBool(OmitConstraintLabelsKey)
+	return c.viper.GetBool(OmitConstraintLabelsKey)
 }
 
 func (c config) OmitAttributeKeys() bool {
-	return viper.GetBool(OmitAttributeKeysKey)
+	return c.viper.GetBool(OmitAttributeKeysKey)
 }
 
 func (c config) ShowDescriptions() []string {
-	return viper.GetStringSlice(ShowDescriptionsKey)
+	return c.viper.GetStringSlice(ShowDescriptionsKey)
 }
 
 func (c config) UseAllSchemas() bool {
-	return viper.GetBool(UseAllSchemasKey)
+	return c.viper.GetBool(UseAllSchemasKey)
 }
 
 func (c config) ShowSchemaPrefix() bool {
-	return viper.GetBool(ShowSchemaPrefix)
+	return c.viper.GetBool(ShowSchemaPrefix)
 }
 
 func (c config) SchemaPrefixSeparator() string {
-	return viper.GetString(SchemaPrefixSeparator)
+	return c.viper.GetString(SchemaPrefixSeparator)
+}
+
+func (c config) Watch() bool {
+	return c.viper.GetBool(WatchKey)
+}
+
+func (c config) WatchInterval() time.Duration {
+	return c.viper.GetDuration(WatchIntervalKey)
+}
+
+func (c config) OutputFormat() string {
+	return c.viper.GetString(OutputFormatKey)
+}
+
+func (c config) ExcludeTables() []string {
+	return c.viper.GetStringSlice(ExcludeTablesKey)
+}
+
+func (c config) IncludeColumns() []string {
+	return c.viper.GetStringSlice(IncludeColumnsKey)
+}
+
+func (c config) ExcludeColumns() []string {
+	return c.viper.GetStringSlice(ExcludeColumnsKey)
+}
+
+func (c config) Focus() []string {
+	return c.viper.GetStringSlice(FocusKey)
+}
+
+func (c config) Depth() int {
+	return c.viper.GetInt(DepthKey)
+}
+
+func (c config) IncludeViews() bool {
+	return c.viper.GetBool(IncludeViewsKey)
+}
+
+func (c config) CollapseJoinTables() bool {
+	return c.viper.GetBool(CollapseJoinTablesKey)
+}
+
+func (c config) InferRelationships() bool {
+	return c.viper.GetBool(InferRelationshipsKey)
+}
+
+func (c config) InferRelationshipPatterns() []string {
+	return c.viper.GetStringSlice(InferRelationshipPatternsKey)
+}
+
+func (c config) ShowIndexes() bool {
+	return c.viper.GetBool(ShowIndexesKey)
+}
+
+func (c config) ShowNullable() bool {
+	return c.viper.GetBool(ShowNullableKey)
+}
+
+func (c config) OmitColumns() bool {
+	return c.viper.GetBool(OmitColumnsKey)
+}
+
+func (c config) ColumnOrder() string {
+	return c.viper.GetString(ColumnOrderKey)
+}
+
+func (c config) QueryTimeout() time.Duration {
+	return c.viper.GetDuration(QueryTimeoutKey)
+}
+
+func (c config) ConnectionAttempts() int {
+	return c.viper.GetInt(ConnectionAttemptsKey)
+}
+
+func (c config) Ssh() string {
+	return c.viper.GetString(SshKey)
+}
+
+func (c config) SshIdentityFile() string {
+	return c.viper.GetString(SshIdentityFileKey)
+}
+
+func (c config) TlsCaCert() string {
+	return c.viper.GetString(TlsCaCertKey)
+}
+
+func (c config) TlsClientCert() string {
+	return c.viper.GetString(TlsClientCertKey)
+}
+
+func (c config) TlsClientKey() string {
+	return c.viper.GetString(TlsClientKeyKey)
+}
+
+func (c config) TlsSkipVerify() bool {
+	return c.viper.GetBool(TlsSkipVerifyKey)
+}
+
+func (c config) PasswordFile() string {
+	return c.viper.GetString(PasswordFileKey)
+}
+
+func (c config) AuthMode() string {
+	return c.viper.GetString(AuthModeKey)
+}
+
+func (c config) Profile() string {
+	return c.viper.GetString(ProfileKey)
+}
+
+// ProfileNames returns the sorted names of the profiles of the configuration file
+func (c config) ProfileNames() []string {
+	return getSectionNames(c.viper, ProfilesKey)
+}
+
+// RunNames returns the sorted names of the runs of the configuration file
+func (c config) RunNames() []string {
+	return getSectionNames(c.viper, RunsKey)
+}
+
+// getSectionNames returns the names of the sections of the key (e.g. staging of profiles.staging.schema)
+func getSectionNames(v *viper.Viper, sectionsKey string) []string {
+	names := make(map[string]bool)
+	prefix := strings.ToLower(sectionsKey + ".")
+	for _, key := range v.AllKeys() {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		if name, _, ok := strings.Cut(strings.TrimPrefix(key, prefix), "."); ok {
+			names[name] = true
+		}
+	}
+
+	var result []string
+	for name := range names {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// UseProfile applies the settings of the profile (e.g. the connection string and the schemas), which replace the
+// other settings of the configuration file, but not the flags that were given explicitly
+func UseProfile(name string, isFlagChanged func(key string) bool) error {
+	values, found := getSectionValues(ProfilesKey, name, isFlagChanged)
+	if !found {
+		return fmt.Errorf("profile %q not found", name)
+	}
+
+	setValues(values)
+	return nil
+}
+
+// UseRun applies the settings of the run like UseProfile. The returned function restores the previous settings, so
+// that the next run starts from the same configuration.
+func UseRun(name string, isFlagChanged func(key string) bool) (func(), error) {
+	values, found := getSectionValues(RunsKey, name, isFlagChanged)
+	if !found {
+		return nil, fmt.Errorf("run %q not found", name)
+	}
+
+	previousValues := setValues(values)
+	return func() {
+		setValues(previousValues)
+	}, nil
+}
+
+// NewRunConfig returns a copy of the current settings with the settings of the run, which is independent of the
+// other runs (e.g. to create several runs concurrently)
+func NewRunConfig(name string, isFlagChanged func(key string) bool) (MermerdConfig, error) {
+	runValues, found := getSectionValues(RunsKey, name, isFlagChanged)
+	if !found {
+		return nil, fmt.Errorf("run %q not found", name)
+	}
+
+	values := make(map[string]any)
+	for _, key := range viper.AllKeys() {
+		// the other profiles and runs are not needed by the run
+		if !strings.HasPrefix(key, strings.ToLower(ProfilesKey+".")) && !strings.HasPrefix(key, strings.ToLower(RunsKey+".")) {
+			values[key] = viper.Get(key)
+		}
+	}
+
+	for key, value := range runValues {
+		values[key] = value
+	}
+
+	return NewConfigWithValues(values), nil
+}
+
+// getSectionValues returns the values of the named section (e.g. profiles.staging) by their keys, except the keys of
+// the flags that were given explicitly
+func getSectionValues(sectionsKey string, name string, isFlagChanged func(key string) bool) (map[string]any, bool) {
+	// the keys are read one by one, as the expanded environment variables of initConfig only override the string
+	// values of the sections
+	prefix := strings.ToLower(sectionsKey + "." + name + ".")
+	found := false
+	values := make(map[string]any)
+	for _, sectionKey := range viper.AllKeys() {
+		if !strings.HasPrefix(sectionKey, prefix) {
+			continue
+		}
+
+		found = true
+		key := strings.TrimPrefix(sectionKey, prefix)
+		if isFlagChanged(key) {
+			continue
+		}
+
+		value := viper.Get(sectionKey)
+		if stringValue, ok := value.(string); ok {
+			value = os.ExpandEnv(stringValue)
+		}
+
+		values[key] = value
+	}
+
+	return values, found
+}
+
+// setValues sets the values and returns the previous values of their keys
+func setValues(values map[string]any) map[string]any {
+	previousValues := make(map[string]any)
+	for key, value := range values {
+		previousValues[key] = viper.Get(key)
+		viper.Set(key, value)
+	}
+
+	return previousValues
+}
+
+func (c config) NonInteractive() bool {
+	return c.viper.GetBool(NonInteractiveKey)
+}
+
+func (c config) LogFormat() string {
+	return c.viper.GetString(LogFormatKey)
+}
+
+func (c config) Quiet() bool {
+	return c.viper.GetBool(QuietKey)
+}
+
+func (c config) Verbose() bool {
+	return c.viper.GetBool(VerboseKey)
+}
+
+func (c config) Render() string {
+	return c.viper.GetString(RenderKey)
+}
+
+func (c config) MermaidTheme() string {
+	return c.viper.GetString(MermaidThemeKey)
+}
+
+func (c config) MermaidLayout() string {
+	return c.viper.GetString(MermaidLayoutKey)
+}
+
+func (c config) MermaidMaxTextSize() int {
+	return c.viper.GetInt(MermaidMaxTextSizeKey)
+}
+
+func (c config) MermaidInitDirective() bool {
+	return c.viper.GetBool(MermaidInitDirectiveKey)
+}
+
+// TableStyles returns the table styles of the configuration file, which have no flag
+func (c config) TableStyles() ([]TableStyle, error) {
+	var tableStyles []TableStyle
+	if err := c.viper.UnmarshalKey(TableStylesKey, &tableStyles); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", TableStylesKey, err)
+	}
+
+	for _, tableStyle := range tableStyles {
+		if tableStyle.Name == "" || tableStyle.Style == "" {
+			return nil, fmt.Errorf("invalid %s: every style needs a name and a style", TableStylesKey)
+		}
+	}
+
+	return tableStyles, nil
+}
+
+func (c config) GroupBy() string {
+	return c.viper.GetString(GroupByKey)
+}
+
+// TableGroups returns the table groups of the configuration file, which have no flag
+func (c config) TableGroups() ([]TableGroup, error) {
+	var tableGroups []TableGroup
+	if err := c.viper.UnmarshalKey(TableGroupsKey, &tableGroups); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", TableGroupsKey, err)
+	}
+
+	for _, tableGroup := range tableGroups {
+		if tableGroup.Name == "" {
+			return nil, fmt.Errorf("invalid %s: every group needs a name", TableGroupsKey)
+		}
+	}
+
+	return tableGroups, nil
+}
+
+// Classifications returns the column classifications of the configuration file, which have no flag
+func (c config) Classifications() ([]Classification, error) {
+	var classifications []Classification
+	if err := c.viper.UnmarshalKey(ClassificationsKey, &classifications); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ClassificationsKey, err)
+	}
+
+	for _, classification := range classifications {
+		if classification.Name == "" {
+			return nil, fmt.Errorf("invalid %s: every classification needs a name", ClassificationsKey)
+		}
+	}
+
+	return classifications, nil
+}
+
+func (c config) RelationshipLabel() string {
+	return c.viper.GetString(RelationshipLabelKey)
+}
+
+// DataTypes returns the data types of the configuration file, which have no flag
+func (c config) DataTypes() map[string]string {
+	return c.viper.GetStringMapString(DataTypesKey)
+}
+
+func (c config) ShowDataTypePrecision() bool {
+	return c.viper.GetBool(ShowDataTypePrecisionKey)
+}
+
+func (c config) ShowEnumsAsEntities() bool {
+	return c.viper.GetBool(ShowEnumsAsEntitiesKey)
+}
+
+func (c config) ShowIdentity() bool {
+	return c.viper.GetBool(ShowIdentityKey)
+}
+
+func (c config) ShowPartitions() bool {
+	return c.viper.GetBool(ShowPartitionsKey)
+}
+
+func (c config) ShowGhostTables() bool {
+	return c.viper.GetBool(ShowGhostTablesKey)
+}
+
+func (c config) ConnectorPlugins() []string {
+	return c.viper.GetStringSlice(ConnectorPluginsKey)
+}
+
+func (c config) SqlDialect() string {
+	return c.viper.GetString(SqlDialectKey)
+}
+
+func (c config) SampleRows() int {
+	return c.viper.GetInt(SampleRowsKey)
+}
+
+func (c config) MaskColumns() []string {
+	return c.viper.GetStringSlice(MaskColumnsKey)
+}
+
+func (c config) Run() []string {
+	return c.viper.GetStringSlice(RunKey)
+}
+
+func (c config) Inject() bool {
+	return c.viper.GetBool(InjectKey)
+}
+
+func (c config) StableOutput() bool {
+	return c.viper.GetBool(StableOutputKey)
+}
+
+func (c config) Cache() bool {
+	return c.viper.GetBool(CacheKey)
+}
+
+func (c config) CacheTtl() time.Duration {
+	return c.viper.GetDuration(CacheTtlKey)
+}
+
+func (c config) Incremental() bool {
+	return c.viper.GetBool(IncrementalKey)
+}
+
+func (c config) LintRules() []string {
+	return c.viper.GetStringSlice(LintRulesKey)
+}
+
+func (c config) OmitOrphanTables() bool {
+	return c.viper.GetBool(OmitOrphanTablesKey)
+}
+
+func (c config) MaxColumnsPerTable() int {
+	return c.viper.GetInt(MaxColumnsPerTableKey)
+}
+
+// TableAliases returns the table aliases of the configuration file, which have no flag
+func (c config) TableAliases() ([]Alias, error) {
+	return c.getAliases(TableAliasesKey)
+}
+
+// ColumnAliases returns the column aliases of the configuration file, which have no flag
+func (c config) ColumnAliases() ([]Alias, error) {
+	return c.getAliases(ColumnAliasesKey)
+}
+
+func (c config) getAliases(key string) ([]Alias, error) {
+	var aliases []Alias
+	if err := c.viper.UnmarshalKey(key, &aliases); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	for _, alias := range aliases {
+		if alias.Pattern == "" {
+			return nil, fmt.Errorf("invalid %s: every alias needs a pattern", key)
+		}
+	}
+
+	return aliases, nil
+}
+
+func (c config) NameCase() string {
+	return c.viper.GetString(NameCaseKey)
+}
+
+func (c config) MermaidCommentStyle() string {
+	return c.viper.GetString(MermaidCommentStyleKey)
+}
+
+func (c config) RelationshipDirection() string {
+	return c.viper.GetString(RelationshipDirectionKey)
+}
+
+func (c config) RelationshipOrder() string {
+	return c.viper.GetString(RelationshipOrderKey)
+}
+
+func (c config) MergeRelationships() bool {
+	return c.viper.GetBool(MergeRelationshipsKey)
+}
+
+func (c config) MultipleAttributeKeys() bool {
+	return c.viper.GetBool(MultipleAttributeKeysKey)
+}
+
+func (c config) AskPassword() bool {
+	return c.viper.GetBool(AskPasswordKey)
+}
+
+func (c config) CredentialCommand() string {
+	return c.viper.GetString(CredentialCommandKey)
+}
+
+func (c config) AssertReadOnly() bool {
+	return c.viper.GetBool(AssertReadOnlyKey)
+}
+
+func (c config) TraceSql() string {
+	return c.viper.GetString(TraceSqlKey)
+}
+
+func (c config) Summary() bool {
+	return c.viper.GetBool(SummaryKey)
+}
+
+func (c config) SummaryFile() string {
+	return c.viper.GetString(SummaryFileKey)
+}
+
+func (c config) PartialModel() string {
+	return c.viper.GetString(PartialModelKey)
+}
+
+func (c config) SkipFailingTables() bool {
+	return c.viper.GetBool(SkipFailingTablesKey)
 }
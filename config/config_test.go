@@ -2,9 +2,11 @@ package config
 
 import (
 	"bytes"
+	"testing"
+	"time"
+
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
-	"testing"
 )
 
 func TestYamlConfig(t *testing.T) {
@@ -34,6 +36,85 @@ showDescriptions:
 useAllSchemas: true
 showSchemaPrefix: true
 schemaPrefixSeparator: "_"
+watch: true
+watchInterval: 10s
+outputFormat: dot
+excludeTables:
+  - flyway_*
+  - /_audit$/
+includeColumns:
+  - article.*
+excludeColumns:
+  - "*_at"
+  - user.password_hash
+focus:
+  - article
+depth: 2
+includeViews: true
+collapseJoinTables: true
+inferRelationships: true
+inferRelationshipPatterns:
+  - "{table}_id"
+  - "{table}Id"
+showIndexes: true
+showNullable: true
+omitColumns: true
+columnOrder: ordinal
+queryTimeout: 30s
+connectionAttempts: 3
+ssh: deploy@bastion.example.com
+sshIdentityFile: ~/.ssh/id_ed25519
+tlsCaCert: /etc/ssl/db-ca.pem
+tlsClientCert: /etc/ssl/client.pem
+tlsClientKey: /etc/ssl/client-key.pem
+tlsSkipVerify: true
+passwordFile: /run/secrets/db-password
+authMode: azure-ad
+non-interactive: true
+log-format: json
+quiet: true
+verbose: true
+render: svg
+mermaidTheme: neutral
+mermaidLayout: elk
+mermaidMaxTextSize: 90000
+mermaidInitDirective: true
+tableStyles:
+  - name: audit
+    style: fill:#eee,stroke:#999
+    tables:
+      - "*_audit"
+groupBy: schema
+tableGroups:
+  - name: audit
+    tables:
+      - "*_audit"
+classifications:
+  - name: pii
+    columns:
+      - "*.email"
+      - /ssn/
+tableAliases:
+  - pattern: /^tbl_/
+    alias: ""
+columnAliases:
+  - pattern: customer.very_long_column_name
+    alias: short
+relationshipLabel: "{{.FkTable}} -> {{.PkTable}}"
+dataTypes:
+  timestamptz: datetime
+  "character varying": string
+showDataTypePrecision: true
+showEnumsAsEntities: true
+showIdentity: true
+showPartitions: true
+showGhostTables: true
+connectorPlugins:
+  - ./db2.so
+sqlDialect: mysql
+sampleRows: 3
+maskColumns:
+  - "*.email"
 
 # These connection strings are available as suggestions in the cli (use tab to access)
 connectionStringSuggestions:
@@ -62,4 +143,216 @@ connectionStringSuggestions:
 	assert.True(t, config.UseAllSchemas())
 	assert.True(t, config.ShowSchemaPrefix())
 	assert.Equal(t, "_", config.SchemaPrefixSeparator())
+	assert.True(t, config.Watch())
+	assert.Equal(t, 10*time.Second, config.WatchInterval())
+	assert.Equal(t, "dot", config.OutputFormat())
+	assert.ElementsMatch(t, []string{"flyway_*", "/_audit$/"}, config.ExcludeTables())
+	assert.ElementsMatch(t, []string{"article.*"}, config.IncludeColumns())
+	assert.ElementsMatch(t, []string{"*_at", "user.password_hash"}, config.ExcludeColumns())
+	assert.ElementsMatch(t, []string{"article"}, config.Focus())
+	assert.Equal(t, 2, config.Depth())
+	assert.True(t, config.IncludeViews())
+	assert.True(t, config.CollapseJoinTables())
+	assert.True(t, config.InferRelationships())
+	assert.ElementsMatch(t, []string{"{table}_id", "{table}Id"}, config.InferRelationshipPatterns())
+	assert.True(t, config.ShowIndexes())
+	assert.True(t, config.ShowNullable())
+	assert.True(t, config.OmitColumns())
+	assert.Equal(t, "ordinal", config.ColumnOrder())
+	assert.Equal(t, 30*time.Second, config.QueryTimeout())
+	assert.Equal(t, 3, config.ConnectionAttempts())
+	assert.Equal(t, "deploy@bastion.example.com", config.Ssh())
+	assert.Equal(t, "~/.ssh/id_ed25519", config.SshIdentityFile())
+	assert.Equal(t, "/etc/ssl/db-ca.pem", config.TlsCaCert())
+	assert.Equal(t, "/etc/ssl/client.pem", config.TlsClientCert())
+	assert.Equal(t, "/etc/ssl/client-key.pem", config.TlsClientKey())
+	assert.True(t, config.TlsSkipVerify())
+	assert.Equal(t, "/run/secrets/db-password", config.PasswordFile())
+	assert.Equal(t, "azure-ad", config.AuthMode())
+	assert.True(t, config.NonInteractive())
+	assert.Equal(t, "json", config.LogFormat())
+	assert.True(t, config.Quiet())
+	assert.True(t, config.Verbose())
+	assert.Equal(t, "svg", config.Render())
+	assert.Equal(t, "neutral", config.MermaidTheme())
+	assert.Equal(t, "elk", config.MermaidLayout())
+	assert.Equal(t, 90000, config.MermaidMaxTextSize())
+	assert.True(t, config.MermaidInitDirective())
+	tableStyles, err := config.TableStyles()
+	assert.Nil(t, err)
+	assert.Equal(t, []TableStyle{{Name: "audit", Style: "fill:#eee,stroke:#999", Tables: []string{"*_audit"}}}, tableStyles)
+	assert.Equal(t, "schema", config.GroupBy())
+	tableGroups, err := config.TableGroups()
+	assert.Nil(t, err)
+	assert.Equal(t, []TableGroup{{Name: "audit", Tables: []string{"*_audit"}}}, tableGroups)
+	classifications, err := config.Classifications()
+	assert.Nil(t, err)
+	assert.Equal(t, []Classification{{Name: "pii", Columns: []string{"*.email", "/ssn/"}}}, classifications)
+	tableAliases, err := config.TableAliases()
+	assert.Nil(t, err)
+	assert.Equal(t, []Alias{{Pattern: "/^tbl_/", Alias: ""}}, tableAliases)
+	columnAliases, err := config.ColumnAliases()
+	assert.Nil(t, err)
+	assert.Equal(t, []Alias{{Pattern: "customer.very_long_column_name", Alias: "short"}}, columnAliases)
+	assert.Equal(t, "{{.FkTable}} -> {{.PkTable}}", config.RelationshipLabel())
+	assert.Equal(t, map[string]string{"timestamptz": "datetime", "character varying": "string"}, config.DataTypes())
+	assert.True(t, config.ShowDataTypePrecision())
+	assert.True(t, config.ShowEnumsAsEntities())
+	assert.True(t, config.ShowIdentity())
+	assert.True(t, config.ShowPartitions())
+	assert.True(t, config.ShowGhostTables())
+	assert.ElementsMatch(t, []string{"./db2.so"}, config.ConnectorPlugins())
+	assert.Equal(t, "mysql", config.SqlDialect())
+	assert.Equal(t, 3, config.SampleRows())
+	assert.ElementsMatch(t, []string{"*.email"}, config.MaskColumns())
+}
+
+func TestProfiles(t *testing.T) {
+	// Arrange
+	t.Cleanup(viper.Reset)
+	t.Setenv("MERMERD_TEST_SCHEMA", "staging_schema")
+	config := NewConfig()
+	var configYaml = []byte(`
+connectionString: "connectionStringExample"
+schema: "public"
+profiles:
+  staging:
+    connectionString: "postgresql://user@staging:5432/db"
+    schema: "${MERMERD_TEST_SCHEMA}"
+    useAllTables: true
+  prod:
+    connectionString: "postgresql://user@prod:5432/db"
+`)
+	viper.SetConfigType("yaml")
+	assert.Nil(t, viper.ReadConfig(bytes.NewBuffer(configYaml)))
+
+	t.Run("Profile names", func(t *testing.T) {
+		// Act
+		result := config.ProfileNames()
+
+		// Assert
+		assert.Equal(t, []string{"prod", "staging"}, result)
+	})
+
+	t.Run("Unknown profile", func(t *testing.T) {
+		// Act
+		err := UseProfile("unknown", func(key string) bool { return false })
+
+		// Assert
+		assert.NotNil(t, err)
+		assert.Equal(t, "connectionStringExample", config.ConnectionString())
+	})
+
+	t.Run("Flags take precedence over the profile", func(t *testing.T) {
+		// Act
+		err := UseProfile("staging", func(key string) bool { return key == "schema" })
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "postgresql://user@staging:5432/db", config.ConnectionString())
+		assert.Equal(t, []string{"public"}, config.Schemas())
+		assert.True(t, config.UseAllTables())
+	})
+
+	t.Run("Environment variables are expanded", func(t *testing.T) {
+		// Act
+		err := UseProfile("staging", func(key string) bool { return false })
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"staging_schema"}, config.Schemas())
+	})
+}
+
+func TestRuns(t *testing.T) {
+	// Arrange
+	t.Cleanup(viper.Reset)
+	config := NewConfig()
+	var configYaml = []byte(`
+connectionString: "connectionStringExample"
+outputFileName: "result.mmd"
+runs:
+  overview:
+    useAllTables: true
+    omitColumns: true
+    outputFileName: "overview.mmd"
+  billing:
+    selectedTables:
+      - invoice
+      - payment
+    outputFileName: "billing.mmd"
+`)
+	viper.SetConfigType("yaml")
+	assert.Nil(t, viper.ReadConfig(bytes.NewBuffer(configYaml)))
+
+	t.Run("Run names", func(t *testing.T) {
+		// Act
+		result := config.RunNames()
+
+		// Assert
+		assert.Equal(t, []string{"billing", "overview"}, result)
+	})
+
+	t.Run("Unknown run", func(t *testing.T) {
+		// Act
+		restore, err := UseRun("unknown", func(key string) bool { return false })
+
+		// Assert
+		assert.NotNil(t, err)
+		assert.Nil(t, restore)
+		assert.Equal(t, "result.mmd", config.OutputFileName())
+	})
+
+	t.Run("Settings of the run are restored", func(t *testing.T) {
+		// Act
+		restore, err := UseRun("overview", func(key string) bool { return false })
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "connectionStringExample", config.ConnectionString())
+		assert.Equal(t, "overview.mmd", config.OutputFileName())
+		assert.True(t, config.UseAllTables())
+		assert.True(t, config.OmitColumns())
+
+		restore()
+		assert.Equal(t, "result.mmd", config.OutputFileName())
+		assert.False(t, config.UseAllTables())
+		assert.False(t, config.OmitColumns())
+	})
+
+	t.Run("Flags take precedence over the run", func(t *testing.T) {
+		// Act
+		restore, err := UseRun("billing", func(key string) bool { return key == "outputfilename" })
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "result.mmd", config.OutputFileName())
+		assert.ElementsMatch(t, []string{"invoice", "payment"}, config.SelectedTables())
+		restore()
+	})
+
+	t.Run("Run configuration is independent of the other runs", func(t *testing.T) {
+		// Act
+		overviewConfig, overviewErr := NewRunConfig("overview", func(key string) bool { return false })
+		billingConfig, billingErr := NewRunConfig("billing", func(key string) bool { return false })
+
+		// Assert
+		assert.Nil(t, overviewErr)
+		assert.Nil(t, billingErr)
+		assert.Equal(t, "overview.mmd", overviewConfig.OutputFileName())
+		assert.True(t, overviewConfig.OmitColumns())
+		assert.Equal(t, "billing.mmd", billingConfig.OutputFileName())
+		assert.False(t, billingConfig.OmitColumns())
+		assert.Equal(t, "connectionStringExample", billingConfig.ConnectionString())
+		assert.Empty(t, billingConfig.RunNames())
+		assert.Equal(t, "result.mmd", config.OutputFileName())
+	})
+
+	t.Run("Unknown run configuration", func(t *testing.T) {
+		// Act
+		_, err := NewRunConfig("unknown", func(key string) bool { return false })
+
+		// Assert
+		assert.NotNil(t, err)
+	})
 }
@@ -0,0 +1,45 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"io"
+	"text/template"
+)
+
+//go:embed run_config_template.goyaml
+var runConfigTemplate string
+
+// RunConfig contains the answers of mermerd init, which are written as run configuration (see WriteRunConfig)
+type RunConfig struct {
+	// FileName is the name of the run configuration, which is shown in the usage comment
+	FileName                    string
+	ConnectionString            string
+	UseAllSchemas               bool
+	Schemas                     []string
+	UseAllTables                bool
+	SelectedTables              []string
+	OutputFormat                string
+	OutputFileName              string
+	EncloseWithMermaidBackticks bool
+	ShowDescriptions            []string
+	ShowIndexes                 bool
+	ShowSchemaPrefix            bool
+}
+
+// WriteRunConfig writes the run configuration with a comment for every section. Further options are commented out,
+// so that they can be enabled without looking up their keys.
+func WriteRunConfig(w io.Writer, runConfig RunConfig) error {
+	tmpl, err := template.New("run_config_template").Funcs(template.FuncMap{"yaml": yamlScalar}).Parse(runConfigTemplate)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(w, runConfig)
+}
+
+// yamlScalar quotes the value as json string, which is also a valid yaml string (e.g. for patterns like *_at)
+func yamlScalar(value string) (string, error) {
+	quoted, err := json.Marshal(value)
+	return string(quoted), err
+}
@@ -0,0 +1,398 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// valueKind is the type that a key of the configuration file expects
+type valueKind int
+
+const (
+	stringKind valueKind = iota
+	boolKind
+	intKind
+	durationKind
+	// stringListKind also accepts a single string, as viper converts it to a list
+	stringListKind
+	stringMapKind
+	objectKind
+	objectListKind
+	// sectionsKind is a map of named sections with further keys (profiles and runs)
+	sectionsKind
+)
+
+var valueKindNames = map[valueKind]string{
+	stringKind:     "a string",
+	boolKind:       "a boolean (true or false)",
+	intKind:        "an integer",
+	durationKind:   "a duration (e.g. 30s)",
+	stringListKind: "a list of strings",
+	stringMapKind:  "a map of strings",
+	objectKind:     "an object",
+	objectListKind: "a list of objects",
+	sectionsKind:   "a map of named sections",
+}
+
+// configFileKeys are the keys of the configuration file and their types
+var configFileKeys = map[string]valueKind{
+	ConnectionStringKey: stringKind, OutputFileNameKey: stringKind, SchemaPrefixSeparator: stringKind,
+	OutputFormatKey: stringKind, ColumnOrderKey: stringKind, SshKey: stringKind, SshIdentityFileKey: stringKind,
+	TlsCaCertKey: stringKind, TlsClientCertKey: stringKind, TlsClientKeyKey: stringKind, PasswordFileKey: stringKind,
+	AuthModeKey: stringKind, ProfileKey: stringKind, LogFormatKey: stringKind, RenderKey: stringKind,
+	MermaidThemeKey: stringKind, MermaidLayoutKey: stringKind, GroupByKey: stringKind, MermaidCommentStyleKey: stringKind,
+	RelationshipLabelKey: stringKind, SqlDialectKey: stringKind, NameCaseKey: stringKind, RelationshipDirectionKey: stringKind,
+	RelationshipOrderKey: stringKind, CredentialCommandKey: stringKind, TraceSqlKey: stringKind, SummaryFileKey: stringKind,
+	PartialModelKey: stringKind,
+
+	ShowAllConstraintsKey: boolKind, UseAllTablesKey: boolKind, UseAllSchemasKey: boolKind, DebugKey: boolKind,
+	OmitConstraintLabelsKey: boolKind, OmitAttributeKeysKey: boolKind, ShowSchemaPrefix: boolKind, WatchKey: boolKind,
+	EncloseWithMermaidBackticksKey: boolKind, IncludeViewsKey: boolKind, CollapseJoinTablesKey: boolKind,
+	InferRelationshipsKey: boolKind, ShowIndexesKey: boolKind, ShowNullableKey: boolKind, OmitColumnsKey: boolKind,
+	TlsSkipVerifyKey: boolKind, NonInteractiveKey: boolKind, QuietKey: boolKind, VerboseKey: boolKind,
+	MermaidInitDirectiveKey: boolKind, ShowDataTypePrecisionKey: boolKind, ShowEnumsAsEntitiesKey: boolKind,
+	ShowIdentityKey: boolKind, ShowPartitionsKey: boolKind, ShowGhostTablesKey: boolKind, InjectKey: boolKind,
+	StableOutputKey: boolKind, CacheKey: boolKind, IncrementalKey: boolKind, OmitOrphanTablesKey: boolKind,
+	MergeRelationshipsKey: boolKind, MultipleAttributeKeysKey: boolKind, AskPasswordKey: boolKind,
+	AssertReadOnlyKey: boolKind, SummaryKey: boolKind, SkipFailingTablesKey: boolKind,
+
+	DepthKey: intKind, ConnectionAttemptsKey: intKind, MermaidMaxTextSizeKey: intKind, SampleRowsKey: intKind,
+	MaxColumnsPerTableKey: intKind,
+	WatchIntervalKey:      durationKind, QueryTimeoutKey: durationKind, CacheTtlKey: durationKind,
+
+	SchemaKey: stringListKind, ConnectionStringSuggestionsKey: stringListKind, ShowDescriptionsKey: stringListKind,
+	SelectedTablesKey: stringListKind, ExcludeTablesKey: stringListKind, IncludeColumnsKey: stringListKind,
+	ExcludeColumnsKey: stringListKind, FocusKey: stringListKind, InferRelationshipPatternsKey: stringListKind,
+	ConnectorPluginsKey: stringListKind, MaskColumnsKey: stringListKind, RunKey: stringListKind,
+	LintRulesKey: stringListKind,
+
+	DataTypesKey:       stringMapKind,
+	ConnectionKey:      objectKind,
+	TableStylesKey:     objectListKind,
+	TableGroupsKey:     objectListKind,
+	ClassificationsKey: objectListKind,
+	TableAliasesKey:    objectListKind,
+	ColumnAliasesKey:   objectListKind,
+	ProfilesKey:        sectionsKind,
+	RunsKey:            sectionsKind,
+}
+
+// objectFields are the fields of the keys with an object or a list of objects
+var objectFields = map[string]map[string]valueKind{
+	ConnectionKey: {
+		"type": stringKind, "host": stringKind, "port": intKind, "user": stringKind, "password": stringKind,
+		"database": stringKind, "params": stringKind,
+	},
+	TableStylesKey:     {"name": stringKind, "style": stringKind, "tables": stringListKind},
+	TableGroupsKey:     {"name": stringKind, "tables": stringListKind},
+	ClassificationsKey: {"name": stringKind, "columns": stringListKind},
+	TableAliasesKey:    {"pattern": stringKind, "alias": stringKind},
+	ColumnAliasesKey:   {"pattern": stringKind, "alias": stringKind},
+}
+
+// conflictingKeys are the options that cannot be used together, as the second one is ignored
+var conflictingKeys = [][2]string{
+	{SelectedTablesKey, UseAllTablesKey},
+	{FocusKey, SelectedTablesKey},
+	{FocusKey, UseAllTablesKey},
+	{SchemaKey, UseAllSchemasKey},
+}
+
+// ValidateFile checks the configuration file, as viper ignores unknown keys (e.g. typos) and values of the wrong
+// type. All problems are returned as one error with the line numbers of the file.
+func ValidateFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if problems := validateYaml(content); len(problems) > 0 {
+		return fmt.Errorf("invalid configuration file %s:\n  %s", path, strings.Join(problems, "\n  "))
+	}
+
+	return nil
+}
+
+func validateYaml(content []byte) []string {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return []string{err.Error()}
+	}
+
+	if len(document.Content) == 0 {
+		return nil
+	}
+
+	root := resolveAlias(document.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return []string{lineProblem(root, "the configuration must be a map of keys and values")}
+	}
+
+	return validateKeys(root, "")
+}
+
+// validateKeys checks the keys of the configuration or of a section (prefix is the path of the profile or run)
+func validateKeys(mapping *yaml.Node, prefix string) []string {
+	var problems []string
+	values := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		keyNode, valueNode := mapping.Content[i], resolveAlias(mapping.Content[i+1])
+		key := keyNode.Value
+		// viper ignores the case of the keys
+		knownKey, kind, ok := findConfigFileKey(key)
+		if !ok {
+			problems = append(problems, lineProblem(keyNode, "unknown key %q%s", prefix+key, suggestKey(key)))
+			continue
+		}
+
+		// the profile and the runs are chosen before the sections are applied
+		if prefix != "" && (kind == sectionsKind || knownKey == ProfileKey || knownKey == RunKey) {
+			problems = append(problems, lineProblem(keyNode, "%s cannot be used in %s", key, strings.TrimSuffix(prefix, ".")))
+			continue
+		}
+
+		if _, exists := values[knownKey]; exists {
+			problems = append(problems, lineProblem(keyNode, "duplicate key %q", prefix+key))
+			continue
+		}
+
+		values[knownKey] = valueNode
+		problems = append(problems, validateValue(valueNode, prefix+key, knownKey, kind)...)
+	}
+
+	for _, keys := range conflictingKeys {
+		first, second := values[keys[0]], values[keys[1]]
+		if isSet(first) && isSet(second) {
+			problems = append(problems, lineProblem(second, "%s%s cannot be used together with %s%s, which takes precedence", prefix, keys[1], prefix, keys[0]))
+		}
+	}
+
+	return problems
+}
+
+func validateValue(node *yaml.Node, path string, key string, kind valueKind) []string {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return nil
+	}
+
+	switch kind {
+	case stringKind, boolKind, intKind, durationKind:
+		if node.Kind != yaml.ScalarNode || !isScalarOfKind(node, kind) {
+			return []string{lineProblem(node, "%s must be %s", path, valueKindNames[kind])}
+		}
+	case stringListKind:
+		if node.Kind == yaml.ScalarNode {
+			return nil
+		}
+
+		if node.Kind != yaml.SequenceNode || !allScalars(node.Content) {
+			return []string{lineProblem(node, "%s must be %s", path, valueKindNames[kind])}
+		}
+	case stringMapKind:
+		if node.Kind != yaml.MappingNode || !allScalars(node.Content) {
+			return []string{lineProblem(node, "%s must be %s", path, valueKindNames[kind])}
+		}
+	case objectKind:
+		return validateObject(node, path, objectFields[key])
+	case objectListKind:
+		return validateObjectList(node, path, objectFields[key])
+	case sectionsKind:
+		if node.Kind != yaml.MappingNode {
+			return []string{lineProblem(node, "%s must be %s", path, valueKindNames[kind])}
+		}
+
+		var problems []string
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			section := resolveAlias(node.Content[i+1])
+			sectionPath := path + "." + node.Content[i].Value
+			if section.Kind != yaml.MappingNode {
+				problems = append(problems, lineProblem(section, "%s must be a map of keys and values", sectionPath))
+				continue
+			}
+
+			problems = append(problems, validateKeys(section, sectionPath+".")...)
+		}
+
+		return problems
+	}
+
+	return nil
+}
+
+func validateObjectList(node *yaml.Node, path string, fields map[string]valueKind) []string {
+	if node.Kind != yaml.SequenceNode {
+		return []string{lineProblem(node, "%s must be %s", path, valueKindNames[objectListKind])}
+	}
+
+	var problems []string
+	for index, item := range node.Content {
+		problems = append(problems, validateObject(resolveAlias(item), fmt.Sprintf("%s[%d]", path, index), fields)...)
+	}
+
+	return problems
+}
+
+func validateObject(node *yaml.Node, path string, fields map[string]valueKind) []string {
+	if node.Kind != yaml.MappingNode {
+		return []string{lineProblem(node, "%s must be an object with the fields %s", path, strings.Join(getSortedKeys(fields), ", "))}
+	}
+
+	var problems []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		field := node.Content[i].Value
+		kind, ok := fields[field]
+		if !ok {
+			problems = append(problems, lineProblem(node.Content[i], "unknown field %q of %s (%s)", field, path, strings.Join(getSortedKeys(fields), ", ")))
+			continue
+		}
+
+		problems = append(problems, validateValue(resolveAlias(node.Content[i+1]), path+"."+field, field, kind)...)
+	}
+
+	return problems
+}
+
+// isScalarOfKind checks the value like viper converts it, environment variables (e.g. $PORT) are only expanded at
+// runtime and therefore accepted
+func isScalarOfKind(node *yaml.Node, kind valueKind) bool {
+	if node.Tag == "!!str" && strings.Contains(node.Value, "$") {
+		return true
+	}
+
+	switch kind {
+	case boolKind:
+		_, err := strconv.ParseBool(node.Value)
+		return node.Tag == "!!bool" || (node.Tag == "!!str" && err == nil)
+	case intKind:
+		_, err := strconv.Atoi(node.Value)
+		return (node.Tag == "!!int" || node.Tag == "!!str") && err == nil
+	case durationKind:
+		if node.Tag == "!!int" {
+			return true
+		}
+
+		_, err := time.ParseDuration(node.Value)
+		return node.Tag == "!!str" && err == nil
+	default:
+		return true
+	}
+}
+
+func isSet(node *yaml.Node) bool {
+	if node == nil {
+		return false
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!bool" {
+			value, _ := strconv.ParseBool(node.Value)
+			return value
+		}
+
+		return node.Tag != "!!null" && node.Value != ""
+	default:
+		return len(node.Content) > 0
+	}
+}
+
+func allScalars(nodes []*yaml.Node) bool {
+	for _, node := range nodes {
+		if resolveAlias(node).Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+
+	return true
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	return node
+}
+
+func findConfigFileKey(key string) (string, valueKind, bool) {
+	for knownKey, kind := range configFileKeys {
+		if strings.EqualFold(knownKey, key) {
+			return knownKey, kind, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// suggestKey returns a hint with the most similar known key, which is probably meant if only a few letters differ
+func suggestKey(key string) string {
+	suggestion := ""
+	minDistance := 4
+	for _, knownKey := range getSortedKeys(configFileKeys) {
+		if distance := getEditDistance(strings.ToLower(key), strings.ToLower(knownKey)); distance < minDistance {
+			suggestion, minDistance = knownKey, distance
+		}
+	}
+
+	if suggestion == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(" (did you mean %q?)", suggestion)
+}
+
+// getEditDistance returns the levenshtein distance, which is the number of inserted, deleted or replaced characters
+func getEditDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+
+	return result
+}
+
+func getSortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func lineProblem(node *yaml.Node, format string, args ...any) string {
+	return fmt.Sprintf("line %d: %s", node.Line, fmt.Sprintf(format, args...))
+}
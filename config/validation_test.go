@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateYaml(t *testing.T) {
+	testCases := []struct {
+		yaml             string
+		expectedProblems []string
+	}{
+		{"", nil},
+		{"connectionString: postgresql://localhost\nuseAllTables: true\nschema: public\n", nil},
+		{"showAllContraints: true\n", []string{`line 1: unknown key "showAllContraints" (did you mean "showAllConstraints"?)`}},
+		{"colors: true\n", []string{`line 1: unknown key "colors"`}},
+		{"ShowAllConstraints: true\n", nil},
+		{"useAllTables: yes\n", []string{"line 1: useAllTables must be a boolean (true or false)"}},
+		{"depth: two\n", []string{"line 1: depth must be an integer"}},
+		{"depth: \"2\"\nqueryTimeout: 30s\nwatchInterval: 1m\n", nil},
+		{"queryTimeout: 30 seconds\n", []string{"line 1: queryTimeout must be a duration (e.g. 30s)"}},
+		{"connectionAttempts: ${ATTEMPTS}\n", nil},
+		{"outputFormat:\n  - mermaid\n", []string{"line 2: outputFormat must be a string"}},
+		{"selectedTables:\n  - name: article\n", []string{"line 2: selectedTables must be a list of strings"}},
+		{"dataTypes:\n  - timestamptz\n", []string{"line 2: dataTypes must be a map of strings"}},
+		{"debug:\n", nil},
+		{"useAllTables: true\nselectedTables:\n  - article\n", []string{"line 1: useAllTables cannot be used together with selectedTables, which takes precedence"}},
+		{"useAllTables: false\nselectedTables:\n  - article\n", nil},
+		{"schema: public\nuseAllSchemas: true\n", []string{"line 2: useAllSchemas cannot be used together with schema, which takes precedence"}},
+		{"debug: true\ndebug: false\n", []string{`line 2: duplicate key "debug"`}},
+		{"tableStyles:\n  - name: audit\n    style: fill:#eee\n    table:\n      - \"*_audit\"\n", []string{`line 4: unknown field "table" of tableStyles[0] (name, style, tables)`}},
+		{"tableGroups: blog\n", []string{"line 1: tableGroups must be a list of objects"}},
+		{"classifications:\n  - pii\n", []string{"line 2: classifications[0] must be an object with the fields columns, name"}},
+		{"connection:\n  type: postgresql\n  host: localhost\n  port: 5432\n  password: $DB_PASSWORD\n", nil},
+		{"connection:\n  type: mysql\n  port: default\n", []string{"line 3: connection.port must be an integer"}},
+		{"connection:\n  hostname: localhost\n", []string{`line 2: unknown field "hostname" of connection (database, host, params, password, port, type, user)`}},
+		{"connection: postgresql://localhost\n", []string{"line 1: connection must be an object with the fields database, host, params, password, port, type, user"}},
+		{"profiles:\n  staging:\n    connectionString: postgresql://staging\n    showIndex: true\n", []string{`line 4: unknown key "profiles.staging.showIndex" (did you mean "showIndexes"?)`}},
+		{"profiles:\n  staging:\n    profiles: {}\n", []string{"line 3: profiles cannot be used in profiles.staging"}},
+		{"runs:\n  overview:\n    omitColumns: true\n    outputFileName: overview.mmd\n", nil},
+		{"runs:\n  billing:\n    profile: prod\n", []string{"line 3: profile cannot be used in runs.billing"}},
+		{"runs:\n  billing:\n    selectedTable: invoice\n", []string{`line 3: unknown key "runs.billing.selectedTable" (did you mean "selectedTables"?)`}},
+		{"run: overview\n", nil},
+		{"profiles:\n  staging: postgresql://staging\n", []string{"line 2: profiles.staging must be a map of keys and values"}},
+		{"- debug\n", []string{"line 1: the configuration must be a map of keys and values"}},
+		{"debug: [\n", []string{"yaml: line 1: did not find expected node content"}},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := validateYaml([]byte(testCase.yaml))
+
+			// Assert
+			assert.Equal(t, testCase.expectedProblems, result)
+		})
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	t.Run("Valid file", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), ".mermerd")
+		_ = os.WriteFile(path, []byte("showAllConstraints: true\n"), 0o600)
+
+		// Act
+		err := ValidateFile(path)
+
+		// Assert
+		assert.Nil(t, err)
+	})
+
+	t.Run("Invalid file", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), ".mermerd")
+		_ = os.WriteFile(path, []byte("showAllContraints: true\ndepth: two\n"), 0o600)
+
+		// Act
+		err := ValidateFile(path)
+
+		// Assert
+		assert.EqualError(t, err, "invalid configuration file "+path+":\n"+
+			`  line 1: unknown key "showAllContraints" (did you mean "showAllConstraints"?)`+"\n"+
+			"  line 2: depth must be an integer")
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		// Act
+		err := ValidateFile(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		// Assert
+		assert.NotNil(t, err)
+	})
+}
+
+// TestConfigFileKeys ensures that new keys are added to the validation, as the configuration file would reject them
+func TestConfigFileKeys(t *testing.T) {
+	// Arrange
+	file, err := parser.ParseFile(token.NewFileSet(), "config.go", nil, 0)
+	assert.Nil(t, err)
+
+	// Act
+	var keys []string
+	for _, declaration := range file.Decls {
+		if genDecl, ok := declaration.(*ast.GenDecl); ok && genDecl.Tok == token.CONST {
+			for _, spec := range genDecl.Specs {
+				for _, value := range spec.(*ast.ValueSpec).Values {
+					if literal, ok := value.(*ast.BasicLit); ok && literal.Kind == token.STRING {
+						key, _ := strconv.Unquote(literal.Value)
+						keys = append(keys, key)
+					}
+				}
+			}
+		}
+	}
+
+	// Assert
+	assert.NotEmpty(t, keys)
+	for _, key := range keys {
+		assert.Contains(t, configFileKeys, key)
+	}
+}
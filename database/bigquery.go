@@ -0,0 +1,458 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// bigQueryConnector reads the datasets of a BigQuery project as schemas. BigQuery is not queried via database/sql,
+// but with the client of Google Cloud, which authenticates with the application default credentials or the
+// credentialsFile of the connection string. The primary and foreign keys are the (not enforced) key constraints
+// of the tables.
+type bigQueryConnector struct {
+	dbType          DbType
+	projectId       string
+	location        string
+	credentialsFile string
+	client          *bigquery.Client
+	dataTypes       map[string]string
+}
+
+// bigQueryConstraintRow is one column of a foreign key with the number of primary key columns of the table
+type bigQueryConstraintRow struct {
+	FkSchema              string `bigquery:"fk_schema"`
+	FkTable               string `bigquery:"fk_table"`
+	PkSchema              string `bigquery:"pk_schema"`
+	PkTable               string `bigquery:"pk_table"`
+	ConstraintName        string `bigquery:"constraint_name"`
+	ColumnName            string `bigquery:"column_name"`
+	IsPrimary             bool   `bigquery:"is_primary"`
+	IsNullable            bool   `bigquery:"is_nullable"`
+	PrimaryKeyColumnCount int64  `bigquery:"primary_key_column_count"`
+}
+
+func init() {
+	RegisterConnector("bigquery", newBigQueryConnector)
+}
+
+func newBigQueryConnector(connectionString string, _ io.Closer, options ConnectorOptions) (Connector, error) {
+	projectId, parameters, err := parseBigQueryConnectionString(connectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bigQueryConnector{
+		dbType:          BigQuery,
+		projectId:       projectId,
+		location:        parameters.Get("location"),
+		credentialsFile: parameters.Get("credentialsFile"),
+		dataTypes:       options.DataTypes,
+	}, nil
+}
+
+// parseBigQueryConnectionString returns the project and the parameters of bigquery://yourProject?location=EU
+func parseBigQueryConnectionString(connectionString string) (string, url.Values, error) {
+	projectId, query, _ := strings.Cut(strings.TrimPrefix(connectionString, "bigquery://"), "?")
+	projectId = strings.TrimSuffix(projectId, "/")
+	if projectId == "" {
+		return "", nil, errors.New("the bigquery connection string requires a project, e.g. bigquery://yourProject")
+	}
+
+	parameters, err := url.ParseQuery(query)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid parameters of the bigquery connection string: %w", err)
+	}
+
+	return projectId, parameters, nil
+}
+
+func (c *bigQueryConnector) GetDbType() DbType {
+	return c.dbType
+}
+
+func (c *bigQueryConnector) Connect(ctx context.Context) error {
+	var clientOptions []option.ClientOption
+	if c.credentialsFile != "" {
+		clientOptions = append(clientOptions, option.WithCredentialsFile(c.credentialsFile))
+	}
+
+	client, err := bigquery.NewClient(ctx, c.projectId, clientOptions...)
+	if err != nil {
+		return err
+	}
+
+	client.Location = c.location
+	// listing the datasets checks the credentials, as the client does not connect until the first request
+	if _, err := client.Datasets(ctx).Next(); err != nil && !errors.Is(err, iterator.Done) {
+		_ = client.Close()
+		return err
+	}
+
+	c.client = client
+	return nil
+}
+
+func (c *bigQueryConnector) Close() {
+	if err := c.client.Close(); err != nil {
+		fmt.Println("could not close bigquery client", err)
+	}
+}
+
+// query runs the query with the named parameters (e.g. @table) and logs it like queryContext, which also rejects the
+// queries that could change the database
+func (c *bigQueryConnector) query(ctx context.Context, query string, parameters ...bigquery.QueryParameter) (*bigquery.RowIterator, error) {
+	if err := assertReadOnlyQuery(query); err != nil {
+		return nil, err
+	}
+
+	q := c.client.Query(query)
+	q.Parameters = parameters
+
+	args := make([]any, len(parameters))
+	for i, parameter := range parameters {
+		args[i] = parameter.Value
+	}
+
+	start := time.Now()
+	rows, err := q.Read(ctx)
+	logQuery(query, args, start, err)
+	return rows, err
+}
+
+// informationSchema returns the INFORMATION_SCHEMA view of the dataset, as datasets cannot be query parameters
+func (c *bigQueryConnector) informationSchema(dataset string, view string) string {
+	return "`" + strings.ReplaceAll(c.projectId+"."+dataset, "`", "") + "`.INFORMATION_SCHEMA." + view
+}
+
+func (c *bigQueryConnector) GetSchemas(ctx context.Context) ([]string, error) {
+	var schemas []string
+	datasets := c.client.Datasets(ctx)
+	for {
+		dataset, err := datasets.Next()
+		if errors.Is(err, iterator.Done) {
+			return schemas, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		schemas = append(schemas, dataset.DatasetID)
+	}
+}
+
+func (c *bigQueryConnector) GetTables(ctx context.Context, schemaNames []string) ([]TableDetail, error) {
+	var tables []TableDetail
+	for _, schemaName := range schemaNames {
+		rows, err := c.query(ctx, `
+			select t.table_schema,
+				   t.table_name,
+				   t.table_type in ('VIEW', 'MATERIALIZED VIEW') as is_view,
+				   coalesce(o.option_value, '')                  as description
+			from `+c.informationSchema(schemaName, "TABLES")+` t
+					 left join `+c.informationSchema(schemaName, "TABLE_OPTIONS")+` o
+							   on o.table_name = t.table_name and o.option_name = 'description'
+			order by t.table_name`)
+		if err != nil {
+			return nil, err
+		}
+
+		for {
+			var row struct {
+				TableSchema string `bigquery:"table_schema"`
+				TableName   string `bigquery:"table_name"`
+				IsView      bool   `bigquery:"is_view"`
+				Description string `bigquery:"description"`
+			}
+			if err := rows.Next(&row); errors.Is(err, iterator.Done) {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+
+			tables = append(tables, TableDetail{
+				Schema:  row.TableSchema,
+				Name:    SanitizeValue(row.TableName),
+				IsView:  row.IsView,
+				Comment: unquoteBigQueryOptionValue(row.Description),
+			})
+		}
+	}
+
+	return tables, nil
+}
+
+func (c *bigQueryConnector) GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	tableColumns, err := c.queryColumns(ctx, tableName.Schema, "c.table_name = @table", bigquery.QueryParameter{Name: "table", Value: tableName.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	return tableColumns[TableDetail{Schema: tableName.Schema, Name: tableName.Name}], nil
+}
+
+// GetAllColumns reads the columns of all tables of the datasets with one query per dataset
+func (c *bigQueryConnector) GetAllColumns(ctx context.Context, schemaNames []string) (map[TableDetail][]ColumnResult, error) {
+	tableColumns := make(map[TableDetail][]ColumnResult)
+	for _, schemaName := range schemaNames {
+		schemaColumns, err := c.queryColumns(ctx, schemaName, "true")
+		if err != nil {
+			return nil, err
+		}
+
+		for table, columns := range schemaColumns {
+			tableColumns[table] = columns
+		}
+	}
+
+	return tableColumns, nil
+}
+
+// queryColumns reads the columns of the tables of the dataset that match the filter and groups them by table
+func (c *bigQueryConnector) queryColumns(ctx context.Context, schemaName string, filter string, parameters ...bigquery.QueryParameter) (map[TableDetail][]ColumnResult, error) {
+	rows, err := c.query(ctx, `
+		select c.table_name,
+			   c.column_name,
+			   c.data_type,
+			   exists(select 1
+					  from `+c.informationSchema(schemaName, "TABLE_CONSTRAINTS")+` tc
+							   inner join `+c.informationSchema(schemaName, "KEY_COLUMN_USAGE")+` kcu
+										  on kcu.constraint_name = tc.constraint_name and kcu.table_name = tc.table_name
+					  where tc.table_name = c.table_name
+						and tc.constraint_type = 'PRIMARY KEY'
+						and kcu.column_name = c.column_name) as is_primary,
+			   exists(select 1
+					  from `+c.informationSchema(schemaName, "TABLE_CONSTRAINTS")+` tc
+							   inner join `+c.informationSchema(schemaName, "KEY_COLUMN_USAGE")+` kcu
+										  on kcu.constraint_name = tc.constraint_name and kcu.table_name = tc.table_name
+					  where tc.table_name = c.table_name
+						and tc.constraint_type = 'FOREIGN KEY'
+						and kcu.column_name = c.column_name) as is_foreign,
+			   coalesce(p.description, '')                    as comment,
+			   c.is_generated = 'ALWAYS'                      as is_generated,
+			   c.is_nullable = 'YES'                          as is_nullable,
+			   -- the default is the string NULL if the column has no default
+			   if(c.column_default = 'NULL', '', c.column_default) as default_value,
+			   c.ordinal_position
+		from `+c.informationSchema(schemaName, "COLUMNS")+` c
+				 left join `+c.informationSchema(schemaName, "COLUMN_FIELD_PATHS")+` p
+						   on p.table_name = c.table_name and p.column_name = c.column_name and p.field_path = c.column_name
+		where `+filter+`
+		order by c.table_name, c.ordinal_position`, parameters...)
+	if err != nil {
+		return nil, err
+	}
+
+	tableColumns := make(map[TableDetail][]ColumnResult)
+	for {
+		var row struct {
+			TableName       string `bigquery:"table_name"`
+			ColumnName      string `bigquery:"column_name"`
+			DataType        string `bigquery:"data_type"`
+			IsPrimary       bool   `bigquery:"is_primary"`
+			IsForeign       bool   `bigquery:"is_foreign"`
+			Comment         string `bigquery:"comment"`
+			IsGenerated     bool   `bigquery:"is_generated"`
+			IsNullable      bool   `bigquery:"is_nullable"`
+			DefaultValue    string `bigquery:"default_value"`
+			OrdinalPosition int64  `bigquery:"ordinal_position"`
+		}
+		if err := rows.Next(&row); errors.Is(err, iterator.Done) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		column := ColumnResult{
+			Name:            SanitizeValue(row.ColumnName),
+			IsPrimary:       row.IsPrimary,
+			IsForeign:       row.IsForeign,
+			Comment:         row.Comment,
+			IsGenerated:     row.IsGenerated,
+			IsNullable:      row.IsNullable,
+			DefaultValue:    row.DefaultValue,
+			OrdinalPosition: int(row.OrdinalPosition),
+		}
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, getBigQueryDataType(&column, row.DataType))
+
+		table := TableDetail{Schema: schemaName, Name: SanitizeValue(row.TableName)}
+		tableColumns[table] = append(tableColumns[table], column)
+	}
+
+	return tableColumns, nil
+}
+
+// GetConstraints reads the foreign keys of the table and the foreign keys of its dataset that reference it
+func (c *bigQueryConnector) GetConstraints(ctx context.Context, tableName TableDetail) ([]ConstraintResult, error) {
+	return c.queryConstraints(ctx, tableName.Schema, "kcu.table_name = @table or (ref.table_schema = @schema and ref.table_name = @table)",
+		bigquery.QueryParameter{Name: "table", Value: tableName.Name},
+		bigquery.QueryParameter{Name: "schema", Value: tableName.Schema})
+}
+
+// GetAllConstraints reads the foreign keys of all tables of the datasets with one query per dataset
+func (c *bigQueryConnector) GetAllConstraints(ctx context.Context, schemaNames []string) ([]ConstraintResult, error) {
+	var constraints []ConstraintResult
+	for _, schemaName := range schemaNames {
+		schemaConstraints, err := c.queryConstraints(ctx, schemaName, "true")
+		if err != nil {
+			return nil, err
+		}
+
+		constraints = append(constraints, schemaConstraints...)
+	}
+
+	return constraints, nil
+}
+
+// queryConstraints reads the foreign keys of the dataset that match the filter
+func (c *bigQueryConnector) queryConstraints(ctx context.Context, schemaName string, filter string, parameters ...bigquery.QueryParameter) ([]ConstraintResult, error) {
+	rows, err := c.query(ctx, `
+		select kcu.table_schema as fk_schema,
+			   kcu.table_name   as fk_table,
+			   ref.table_schema as pk_schema,
+			   ref.table_name   as pk_table,
+			   tc.constraint_name,
+			   kcu.column_name,
+			   exists(select 1
+					  from `+c.informationSchema(schemaName, "TABLE_CONSTRAINTS")+` ptc
+							   inner join `+c.informationSchema(schemaName, "KEY_COLUMN_USAGE")+` pk
+										  on pk.constraint_name = ptc.constraint_name and pk.table_name = ptc.table_name
+					  where ptc.table_name = kcu.table_name
+						and ptc.constraint_type = 'PRIMARY KEY'
+						and pk.column_name = kcu.column_name) as is_primary,
+			   col.is_nullable = 'YES'                         as is_nullable,
+			   (select count(*)
+				from `+c.informationSchema(schemaName, "TABLE_CONSTRAINTS")+` ptc
+						 inner join `+c.informationSchema(schemaName, "KEY_COLUMN_USAGE")+` pk
+									on pk.constraint_name = ptc.constraint_name and pk.table_name = ptc.table_name
+				where ptc.table_name = kcu.table_name
+				  and ptc.constraint_type = 'PRIMARY KEY')    as primary_key_column_count
+		from `+c.informationSchema(schemaName, "TABLE_CONSTRAINTS")+` tc
+				 inner join `+c.informationSchema(schemaName, "KEY_COLUMN_USAGE")+` kcu
+							on kcu.constraint_name = tc.constraint_name and kcu.table_name = tc.table_name
+				 -- the referenced table of the foreign key
+				 inner join (select distinct constraint_name, table_schema, table_name
+							 from `+c.informationSchema(schemaName, "CONSTRAINT_COLUMN_USAGE")+`) ref
+							on ref.constraint_name = tc.constraint_name
+				 inner join `+c.informationSchema(schemaName, "COLUMNS")+` col
+							on col.table_name = kcu.table_name and col.column_name = kcu.column_name
+		where tc.constraint_type = 'FOREIGN KEY'
+		  and (`+filter+`)
+		order by kcu.table_name, tc.constraint_name, kcu.ordinal_position`, parameters...)
+	if err != nil {
+		return nil, err
+	}
+
+	var constraintRows []bigQueryConstraintRow
+	for {
+		var row bigQueryConstraintRow
+		if err := rows.Next(&row); errors.Is(err, iterator.Done) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		constraintRows = append(constraintRows, row)
+	}
+
+	return getBigQueryConstraints(constraintRows), nil
+}
+
+// GetIndexes returns the primary key, as BigQuery has no other indexes that relate to the diagram
+func (c *bigQueryConnector) GetIndexes(ctx context.Context, tableName TableDetail) ([]IndexResult, error) {
+	rows, err := c.query(ctx, `
+		select tc.constraint_name, kcu.column_name
+		from `+c.informationSchema(tableName.Schema, "TABLE_CONSTRAINTS")+` tc
+				 inner join `+c.informationSchema(tableName.Schema, "KEY_COLUMN_USAGE")+` kcu
+							on kcu.constraint_name = tc.constraint_name and kcu.table_name = tc.table_name
+		where tc.table_name = @table
+		  and tc.constraint_type = 'PRIMARY KEY'
+		order by kcu.ordinal_position`, bigquery.QueryParameter{Name: "table", Value: tableName.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []IndexResult
+	for {
+		var row struct {
+			ConstraintName string `bigquery:"constraint_name"`
+			ColumnName     string `bigquery:"column_name"`
+		}
+		if err := rows.Next(&row); errors.Is(err, iterator.Done) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if len(indexes) == 0 {
+			indexes = append(indexes, IndexResult{Name: row.ConstraintName, IsUnique: true, IsPrimary: true})
+		}
+
+		indexes[0].ColumnNames = append(indexes[0].ColumnNames, SanitizeValue(row.ColumnName))
+	}
+
+	return indexes, nil
+}
+
+// getBigQueryConstraints converts the rows of the foreign key columns. A foreign key is unique, if its columns are
+// exactly the primary key of the table, as BigQuery has no unique constraints.
+func getBigQueryConstraints(rows []bigQueryConstraintRow) []ConstraintResult {
+	constraints := make([]ConstraintResult, len(rows))
+	for i, row := range rows {
+		constraints[i] = ConstraintResult{
+			FkTable:        row.FkTable,
+			FkSchema:       row.FkSchema,
+			PkTable:        row.PkTable,
+			PkSchema:       row.PkSchema,
+			ConstraintName: row.ConstraintName,
+			ColumnName:     row.ColumnName,
+			IsPrimary:      row.IsPrimary,
+			HasMultiplePK:  row.PrimaryKeyColumnCount > 1,
+			IsNullable:     row.IsNullable,
+		}
+	}
+
+	for i := range constraints {
+		columnCount := 0
+		allPrimary := true
+		for _, other := range constraints {
+			if other.ConstraintName == constraints[i].ConstraintName && other.FkSchema == constraints[i].FkSchema && other.FkTable == constraints[i].FkTable {
+				columnCount++
+				allPrimary = allPrimary && other.IsPrimary
+			}
+		}
+
+		constraints[i].IsUnique = allPrimary && int64(columnCount) == rows[i].PrimaryKeyColumnCount
+	}
+
+	return constraints
+}
+
+// getBigQueryDataType returns the data type without the types of the elements and fields (e.g. ARRAY<STRING> ->
+// ARRAY) and sets the precision of parameterized types (e.g. NUMERIC(10, 2))
+func getBigQueryDataType(column *ColumnResult, declaredDataType string) string {
+	if dataType, _, isNested := strings.Cut(declaredDataType, "<"); isNested {
+		return strings.TrimSpace(dataType)
+	}
+
+	setDataTypeParameters(column, declaredDataType)
+	return declaredDataType
+}
+
+// unquoteBigQueryOptionValue returns the value of the string literals of the table options, e.g. "my table" -> my table
+func unquoteBigQueryOptionValue(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+
+	return value
+}
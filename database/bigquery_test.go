@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBigQueryConnectionString(t *testing.T) {
+	t.Run("Project with parameters", func(t *testing.T) {
+		// Act
+		projectId, parameters, err := parseBigQueryConnectionString("bigquery://your-project/?location=EU&credentialsFile=/path/to/key.json")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "your-project", projectId)
+		assert.Equal(t, "EU", parameters.Get("location"))
+		assert.Equal(t, "/path/to/key.json", parameters.Get("credentialsFile"))
+	})
+
+	t.Run("Missing project", func(t *testing.T) {
+		// Act
+		_, _, err := parseBigQueryConnectionString("bigquery://?location=EU")
+
+		// Assert
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetBigQueryDataType(t *testing.T) {
+	testCases := []struct {
+		declaredDataType  string
+		expectedDataType  string
+		expectedPrecision int
+		expectedScale     int
+	}{
+		{"INT64", "INT64", 0, 0},
+		{"NUMERIC(10, 2)", "NUMERIC(10, 2)", 10, 2},
+		{"ARRAY<STRING>", "ARRAY", 0, 0},
+		{"STRUCT<amount NUMERIC(10, 2), currency STRING>", "STRUCT", 0, 0},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Arrange
+			var column ColumnResult
+
+			// Act
+			result := getBigQueryDataType(&column, testCase.declaredDataType)
+
+			// Assert
+			assert.Equal(t, testCase.expectedDataType, result)
+			assert.Equal(t, testCase.expectedPrecision, column.NumericPrecision)
+			assert.Equal(t, testCase.expectedScale, column.NumericScale)
+		})
+	}
+}
+
+func TestUnquoteBigQueryOptionValue(t *testing.T) {
+	testCases := []struct {
+		value          string
+		expectedResult string
+	}{
+		{`"All orders"`, "All orders"},
+		{`"Say \"hi\""`, `Say "hi"`},
+		{"", ""},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := unquoteBigQueryOptionValue(testCase.value)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestGetBigQueryConstraints(t *testing.T) {
+	t.Run("Foreign key that is the primary key", func(t *testing.T) {
+		// Arrange
+		rows := []bigQueryConstraintRow{
+			{FkSchema: "shop", FkTable: "order_detail", PkSchema: "shop", PkTable: "order", ConstraintName: "fk_order", ColumnName: "order_id", IsPrimary: true, PrimaryKeyColumnCount: 1},
+		}
+
+		// Act
+		result := getBigQueryConstraints(rows)
+
+		// Assert
+		assert.Equal(t, []ConstraintResult{
+			{FkTable: "order_detail", FkSchema: "shop", PkTable: "order", PkSchema: "shop", ConstraintName: "fk_order", ColumnName: "order_id", IsPrimary: true, IsUnique: true},
+		}, result)
+	})
+
+	t.Run("Foreign key that is part of the primary key", func(t *testing.T) {
+		// Arrange
+		rows := []bigQueryConstraintRow{
+			{FkSchema: "shop", FkTable: "order_item", PkSchema: "shop", PkTable: "order", ConstraintName: "fk_order", ColumnName: "order_id", IsPrimary: true, PrimaryKeyColumnCount: 2},
+			{FkSchema: "shop", FkTable: "order_item", PkSchema: "catalog", PkTable: "product", ConstraintName: "fk_product", ColumnName: "product_id", IsNullable: true, PrimaryKeyColumnCount: 2},
+		}
+
+		// Act
+		result := getBigQueryConstraints(rows)
+
+		// Assert
+		assert.Equal(t, []ConstraintResult{
+			{FkTable: "order_item", FkSchema: "shop", PkTable: "order", PkSchema: "shop", ConstraintName: "fk_order", ColumnName: "order_id", IsPrimary: true, HasMultiplePK: true},
+			{FkTable: "order_item", FkSchema: "shop", PkTable: "product", PkSchema: "catalog", ConstraintName: "fk_product", ColumnName: "product_id", HasMultiplePK: true, IsNullable: true},
+		}, result)
+	})
+}
+
+func TestBigQueryRejectsWriteQuery(t *testing.T) {
+	// Arrange
+	connector := &bigQueryConnector{}
+
+	// Act
+	_, err := connector.query(context.Background(), "delete from `project.dataset.article` where true")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrWriteQuery)
+}
@@ -0,0 +1,249 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"regexp"
+	"strings"
+
+	_ "github.com/ClickHouse/clickhouse-go/v2"
+)
+
+// clickHouseEnumValueRegex matches the quoted values of Enum8 and Enum16 types, e.g. 'draft' in Enum8('draft' = 1)
+var clickHouseEnumValueRegex = regexp.MustCompile(`'((?:[^'\\]|\\.)*)'\s*=`)
+
+// clickHouseConnector reads the databases of ClickHouse as schemas. ClickHouse has no foreign keys, so the relations
+// can only be inferred from the column names (--inferRelationships).
+type clickHouseConnector baseConnector
+
+func init() {
+	RegisterConnector("clickhouse", newClickHouseConnector)
+}
+
+func newClickHouseConnector(connectionString string, tunnel io.Closer, options ConnectorOptions) (Connector, error) {
+	return &clickHouseConnector{
+		dbType:           ClickHouse,
+		connectionString: connectionString,
+		tunnel:           tunnel,
+		dataTypes:        options.DataTypes,
+	}, nil
+}
+
+func (c *clickHouseConnector) GetDbType() DbType {
+	return c.dbType
+}
+
+func (c *clickHouseConnector) Connect(ctx context.Context) error {
+	db, err := sql.Open(c.dbType.String(), c.connectionString)
+	if err != nil {
+		return err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	c.db = db
+	return nil
+}
+
+func (c *clickHouseConnector) Close() {
+	closeConnection(c.db, c.tunnel)
+}
+
+func (c *clickHouseConnector) GetSchemas(ctx context.Context) ([]string, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select name
+		from system.databases
+		where name not in ('system', 'information_schema', 'INFORMATION_SCHEMA')
+		order by name`)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err = rows.Scan(&schema); err != nil {
+			return nil, err
+		}
+
+		schemas = append(schemas, schema)
+	}
+
+	return schemas, nil
+}
+
+func (c *clickHouseConnector) GetTables(ctx context.Context, schemaNames []string) ([]TableDetail, error) {
+	schemaFilter, args := getMySqlSchemaFilter("database", schemaNames)
+	rows, err := queryContext(ctx, c.db, `
+		select database,
+			   name,
+			   engine,
+			   engine in ('View', 'MaterializedView', 'LiveView', 'WindowView') as is_view
+		from system.tables
+		where not is_temporary
+		  -- the data of materialized views without TO table is stored in hidden inner tables
+		  and not startsWith(name, '.inner')
+		  and `+schemaFilter+`
+		order by database, name`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []TableDetail
+	for rows.Next() {
+		var table TableDetail
+		if err = rows.Scan(&table.Schema, &table.Name, &table.Engine, &table.IsView); err != nil {
+			return nil, err
+		}
+
+		table.Name = SanitizeValue(table.Name)
+
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
+
+func (c *clickHouseConnector) GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	tableColumns, err := c.queryColumns(ctx, "database = ? and table = ?", tableName.Schema, tableName.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return tableColumns[TableDetail{Schema: tableName.Schema, Name: tableName.Name}], nil
+}
+
+// GetAllColumns reads the columns of all tables of the schemas with one query
+func (c *clickHouseConnector) GetAllColumns(ctx context.Context, schemaNames []string) (map[TableDetail][]ColumnResult, error) {
+	schemaFilter, args := getMySqlSchemaFilter("database", schemaNames)
+	return c.queryColumns(ctx, schemaFilter, args...)
+}
+
+// queryColumns reads the columns of the tables that match the filter and groups them by table. The columns of the
+// primary key (which is not unique in ClickHouse) are marked as primary key.
+func (c *clickHouseConnector) queryColumns(ctx context.Context, filter string, args ...any) (map[TableDetail][]ColumnResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select database,
+			   table,
+			   name,
+			   type,
+			   is_in_primary_key = 1 as is_primary,
+			   comment,
+			   default_kind,
+			   default_expression,
+			   position
+		from system.columns
+		where `+filter+`
+		order by database, table, position`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tableColumns := make(map[TableDetail][]ColumnResult)
+	for rows.Next() {
+		var table TableDetail
+		var column ColumnResult
+		var declaredDataType, defaultKind, defaultExpression string
+		if err = rows.Scan(&table.Schema, &table.Name, &column.Name, &declaredDataType, &column.IsPrimary, &column.Comment, &defaultKind, &defaultExpression, &column.OrdinalPosition); err != nil {
+			return nil, err
+		}
+
+		table.Name = SanitizeValue(table.Name)
+		column.Name = SanitizeValue(column.Name)
+		setClickHouseDataType(&column, declaredDataType)
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, column.DataType)
+		switch defaultKind {
+		case "MATERIALIZED", "ALIAS":
+			column.IsGenerated = true
+		case "DEFAULT":
+			column.DefaultValue = defaultExpression
+		}
+
+		tableColumns[table] = append(tableColumns[table], column)
+	}
+
+	return tableColumns, nil
+}
+
+// GetConstraints returns no constraints, as ClickHouse has no foreign keys
+func (c *clickHouseConnector) GetConstraints(_ context.Context, _ TableDetail) ([]ConstraintResult, error) {
+	return nil, nil
+}
+
+// GetAllConstraints returns no constraints, as ClickHouse has no foreign keys
+func (c *clickHouseConnector) GetAllConstraints(_ context.Context, _ []string) ([]ConstraintResult, error) {
+	return nil, nil
+}
+
+// GetIndexes reads the primary key and the data skipping indexes, whose expression is used as column name. None of
+// them are unique in ClickHouse.
+func (c *clickHouseConnector) GetIndexes(ctx context.Context, tableName TableDetail) ([]IndexResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select index_name, column_name, is_unique, is_primary
+		from (
+			select 'PRIMARY' as index_name, name as column_name, false as is_unique, true as is_primary, position
+			from system.columns
+			where database = ? and table = ? and is_in_primary_key = 1
+			union all
+			select name, expr, false, false, toUInt64(0)
+			from system.data_skipping_indices
+			where database = ? and table = ?
+		)
+		order by is_primary desc, index_name, position`, tableName.Schema, tableName.Name, tableName.Schema, tableName.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
+
+func (c *clickHouseConnector) GetSampleRows(ctx context.Context, tableName TableDetail, columnNames []string, limit int) ([][]string, error) {
+	return querySampleRows(ctx, c.db, c.dbType, tableName, columnNames, limit)
+}
+
+// setClickHouseDataType sets the data type, the nullability, the enum values and the precision of the declared
+// ClickHouse type. The Nullable and LowCardinality wrappers are removed and the parameters of nested types are cut
+// off (e.g. Array(Nullable(String)) -> Array), as they cannot be shown in the diagram.
+func setClickHouseDataType(column *ColumnResult, declaredDataType string) {
+	dataType := strings.TrimSpace(declaredDataType)
+	for {
+		if inner, ok := unwrapClickHouseDataType(dataType, "LowCardinality"); ok {
+			dataType = inner
+			continue
+		}
+
+		if inner, ok := unwrapClickHouseDataType(dataType, "Nullable"); ok {
+			dataType = inner
+			column.IsNullable = true
+			continue
+		}
+
+		break
+	}
+
+	name, parameters, _ := strings.Cut(dataType, "(")
+	if strings.HasPrefix(name, "Enum") {
+		var values []string
+		for _, match := range clickHouseEnumValueRegex.FindAllStringSubmatch(parameters, -1) {
+			values = append(values, strings.ReplaceAll(match[1], `\'`, "'"))
+		}
+
+		column.EnumValues = strings.Join(values, ",")
+	} else if !strings.Contains(parameters, "(") {
+		setDataTypeParameters(column, dataType)
+	}
+
+	column.DataType = name
+}
+
+func unwrapClickHouseDataType(dataType string, wrapper string) (string, bool) {
+	if !strings.HasPrefix(dataType, wrapper+"(") || !strings.HasSuffix(dataType, ")") {
+		return "", false
+	}
+
+	return strings.TrimSpace(dataType[len(wrapper)+1 : len(dataType)-1]), true
+}
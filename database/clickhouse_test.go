@@ -0,0 +1,38 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetClickHouseDataType(t *testing.T) {
+	testCases := []struct {
+		declaredDataType string
+		expectedResult   ColumnResult
+	}{
+		{"UInt64", ColumnResult{DataType: "UInt64"}},
+		{"Nullable(String)", ColumnResult{DataType: "String", IsNullable: true}},
+		{"LowCardinality(Nullable(String))", ColumnResult{DataType: "String", IsNullable: true}},
+		{"Decimal(10, 2)", ColumnResult{DataType: "Decimal", NumericPrecision: 10, NumericScale: 2}},
+		{"DateTime64(3, 'UTC')", ColumnResult{DataType: "DateTime64"}},
+		{"Array(Nullable(String))", ColumnResult{DataType: "Array"}},
+		{"Map(String, UInt64)", ColumnResult{DataType: "Map"}},
+		{"Enum8('draft' = 1, 'published' = 2)", ColumnResult{DataType: "Enum8", EnumValues: "draft,published"}},
+		{`Nullable(Enum16('it\'s' = 1, 'b' = 2))`, ColumnResult{DataType: "Enum16", EnumValues: "it's,b", IsNullable: true}},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Arrange
+			var column ColumnResult
+
+			// Act
+			setClickHouseDataType(&column, testCase.declaredDataType)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, column)
+		})
+	}
+}
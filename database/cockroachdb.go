@@ -0,0 +1,193 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"strings"
+
+	"github.com/jackc/pgx/v4/stdlib"
+)
+
+// cockroachDbConnector reuses the postgres connector, but handles the crdb specific information_schema quirks
+type cockroachDbConnector struct {
+	postgresConnector
+}
+
+func init() {
+	// CockroachDB uses the pgx driver, it is registered under its own name so that the DbType can be used to connect
+	sql.Register(CockroachDb.String(), stdlib.GetDefaultDriver())
+	RegisterConnector("cockroachdb", newCockroachDbConnector)
+}
+
+func newCockroachDbConnector(connectionString string, tunnel io.Closer, options ConnectorOptions) (Connector, error) {
+	return &cockroachDbConnector{postgresConnector{
+		dbType:           CockroachDb,
+		connectionString: strings.Replace(connectionString, "cockroachdb://", "postgresql://", 1),
+		tunnel:           tunnel,
+		dataTypes:        options.DataTypes,
+	}}, nil
+}
+
+// GetTables also reads the locality of multi-region tables (e.g. REGIONAL BY ROW)
+func (c *cockroachDbConnector) GetTables(ctx context.Context, schemaNames []string) ([]TableDetail, error) {
+	schemaSearch := "{" + strings.Join(schemaNames, ",") + "}"
+	rows, err := queryContext(ctx, c.db, `
+		select t.table_schema, t.table_name, coalesce(ct.locality, ''), t.table_type in ('VIEW', 'MATERIALIZED VIEW')
+		from information_schema.tables t
+				 left join crdb_internal.tables ct
+						   on ct.database_name = t.table_catalog
+							   and ct.schema_name = t.table_schema
+							   and ct.name = t.table_name
+		where t.table_type in ('BASE TABLE', 'VIEW', 'MATERIALIZED VIEW')
+		  and t.table_schema = ANY($1::varchar[])
+		`, schemaSearch)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []TableDetail
+	for rows.Next() {
+		var table TableDetail
+		if err = rows.Scan(&table.Schema, &table.Name, &table.Locality, &table.IsView); err != nil {
+			return nil, err
+		}
+
+		table.Name = SanitizeValue(table.Name)
+
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
+
+// GetColumns does not return hidden columns, e.g. the implicit rowid column of tables without a primary key
+func (c *cockroachDbConnector) GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+        select c.column_name,
+               (case
+                    when c.data_type = 'USER-DEFINED'
+                        then c.udt_name
+                    else c.data_type
+                   end)                                                        as data_type,
+               coalesce(c.character_maximum_length, 0)                         as character_maximum_length,
+               coalesce(c.numeric_precision, 0)                                as numeric_precision,
+               coalesce(c.numeric_scale, 0)                                    as numeric_scale,
+               (select count(*) > 0
+                from information_schema.key_column_usage cu
+                         left join information_schema.table_constraints tc on tc.constraint_name = cu.constraint_name
+                where cu.column_name = c.column_name
+                  and cu.table_name = c.table_name
+                  and tc.constraint_type = 'PRIMARY KEY')                      as is_primary,
+               (select count(*) > 0
+                from information_schema.key_column_usage cu
+                         left join information_schema.table_constraints tc on tc.constraint_name = cu.constraint_name
+                where cu.column_name = c.column_name
+                  and cu.table_name = c.table_name
+                  and tc.constraint_type = 'FOREIGN KEY')                      as is_foreign,
+               (select count(*) > 0
+                from information_schema.statistics s
+                where s.table_schema = c.table_schema
+                  and s.table_name = c.table_name
+                  and s.column_name = c.column_name
+                  and s.non_unique = 'NO'
+                  and s.storing = 'NO'
+                  and s.implicit = 'NO'
+                  and not exists(select 1
+                                 from information_schema.table_constraints tc
+                                 where tc.table_schema = s.table_schema
+                                   and tc.table_name = s.table_name
+                                   and tc.constraint_name = s.index_name
+                                   and tc.constraint_type = 'PRIMARY KEY')
+                  -- only the unique keys of the single column
+                  and not exists(select 1
+                                 from information_schema.statistics s2
+                                 where s2.table_schema = s.table_schema
+                                   and s2.table_name = s.table_name
+                                   and s2.index_name = s.index_name
+                                   and s2.storing = 'NO'
+                                   and s2.implicit = 'NO'
+                                   and s2.column_name <> s.column_name))       as is_unique,
+               coalesce(string_agg(enumlabel, ',' order by enumsortorder), '') as enum_values,
+               coalesce(pd.description, '')                                    as comment,
+               -- serial columns use unique_rowid() instead of a sequence by default
+               c.is_identity = 'YES' or c.column_default = 'unique_rowid()'    as is_auto_increment,
+               c.is_nullable = 'YES'                                           as is_nullable,
+               coalesce((select string_agg(cc.check_clause, ' and ' order by cc.constraint_name)
+                         from information_schema.constraint_column_usage ccu
+                                  inner join information_schema.check_constraints cc
+                                             on cc.constraint_schema = ccu.constraint_schema and
+                                                cc.constraint_name = ccu.constraint_name
+                         where ccu.table_schema = c.table_schema
+                           and ccu.table_name = c.table_name
+                           and ccu.column_name = c.column_name), '')  as check_constraints,
+               coalesce(c.column_default, '')                                  as default_value,
+               c.ordinal_position
+        from information_schema.columns c
+                 left join pg_type typ on c.udt_name = typ.typname
+                 left join pg_enum enu on typ.oid = enu.enumtypid
+                 left join pg_class cls on c.table_name = cls.relname
+                 left join pg_namespace ns on cls.relnamespace = ns.oid
+                 left join pg_description pd on cls.oid = pd.objoid and c.ordinal_position = pd.objsubid
+        where c.table_name = $1 and c.table_schema = $2 and c.is_hidden = 'NO'
+        group by c.column_name,
+                 c.table_schema,
+                 c.table_name,
+                 c.data_type,
+                 c.udt_name,
+                 c.character_maximum_length,
+                 c.numeric_precision,
+                 c.numeric_scale,
+                 c.is_identity,
+                 c.is_nullable,
+                 c.column_default,
+                 c.ordinal_position,
+                 pd.description
+        order by c.ordinal_position;
+		`, tableName.Name, tableName.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []ColumnResult
+	for rows.Next() {
+		var column ColumnResult
+		if err = rows.Scan(&column.Name, &column.DataType, &column.CharacterMaxLength, &column.NumericPrecision, &column.NumericScale, &column.IsPrimary, &column.IsForeign, &column.IsUnique, &column.EnumValues, &column.Comment, &column.IsAutoIncrement, &column.IsNullable, &column.CheckConstraints, &column.DefaultValue, &column.OrdinalPosition); err != nil {
+			return nil, err
+		}
+
+		column.Name = SanitizeValue(column.Name)
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, column.DataType)
+		column.SequenceName = parsePostgresSequenceName(column.DefaultValue)
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// GetIndexes uses the information_schema, as crdb does not support all pg_index columns. Stored columns are not part
+// of the index key and hidden columns (e.g. the implicit region column) are skipped.
+func (c *cockroachDbConnector) GetIndexes(ctx context.Context, tableName TableDetail) ([]IndexResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+        select s.index_name,
+               s.column_name,
+               s.non_unique = 'NO',
+               exists(select 1
+                      from information_schema.table_constraints tc
+                      where tc.table_schema = s.table_schema
+                        and tc.table_name = s.table_name
+                        and tc.constraint_name = s.index_name
+                        and tc.constraint_type = 'PRIMARY KEY')
+        from information_schema.statistics s
+        where s.table_name = $1 and s.table_schema = $2
+          and s.storing = 'NO'
+          and s.implicit = 'NO'
+        order by s.index_name, s.seq_in_index;
+		`, tableName.Name, tableName.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
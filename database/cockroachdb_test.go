@@ -0,0 +1,30 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCockroachDbHiddenColumns(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	// Arrange
+	connector, _ := NewConnectorFactory().NewConnector(testConnectionCockroachDb.connectionString)
+	if err := connector.Connect(context.Background()); err != nil {
+		logrus.Error(err)
+		t.FailNow()
+	}
+
+	// Act
+	columns, err := connector.GetColumns(context.Background(), TableDetail{Schema: "public", Name: "test_crdb_no_pk"})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, columns, 1)
+	assert.Equal(t, "title", columns[0].Name)
+}
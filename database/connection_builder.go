@@ -0,0 +1,132 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ConnectionDetails are the parts of a connection string, which BuildConnectionString assembles in the format of the
+// database Type (e.g. postgresql or mysql)
+type ConnectionDetails struct {
+	Type string
+	Host string
+	// Port is 0 for the default port of the database
+	Port     int
+	User     string
+	Password string
+	// Database is the path of the database file for sqlite, duckdb and file
+	Database string
+	// Params is a query string with further parameters of the driver (e.g. sslmode=disable)
+	Params string
+}
+
+// BuildConnectionString assembles the connection string of the details, the user, the password and the database are
+// escaped like the driver of the database expects them (e.g. a password with @ or / characters)
+func BuildConnectionString(details ConnectionDetails) (string, error) {
+	scheme := strings.ToLower(details.Type)
+	params, err := url.ParseQuery(details.Params)
+	if err != nil {
+		return "", fmt.Errorf("invalid connection params: %w", err)
+	}
+
+	switch scheme {
+	case "sqlite", "duckdb", "file":
+		if details.Database == "" {
+			return "", fmt.Errorf("the %s connection needs the path of the database", scheme)
+		}
+
+		return scheme + "://" + details.Database + encodeConnectionParams(params), nil
+	case "postgresql", "postgres", "cockroachdb", "redshift", "mysql", "mariadb", "sqlserver", "snowflake", "clickhouse":
+	default:
+		return "", fmt.Errorf("unsupported connection type %q (postgresql, cockroachdb, redshift, mysql, mariadb, sqlserver, snowflake, clickhouse, sqlite, duckdb or file)", details.Type)
+	}
+
+	if details.Host == "" {
+		return "", fmt.Errorf("the %s connection needs a host", scheme)
+	}
+
+	address := details.Host
+	if details.Port != 0 {
+		address = net.JoinHostPort(details.Host, strconv.Itoa(details.Port))
+	} else if strings.Contains(details.Host, ":") {
+		// an IPv6 address has to be enclosed in brackets like with a port
+		address = "[" + details.Host + "]"
+	}
+
+	userInfo, err := getConnectionUserInfo(scheme, details.User, details.Password)
+	if err != nil {
+		return "", err
+	}
+
+	switch scheme {
+	case "mysql", "mariadb":
+		// the mysql driver takes the database name as it is
+		address = "tcp(" + address + ")/" + details.Database
+	case "sqlserver":
+		if details.Database != "" {
+			params.Set("database", details.Database)
+		}
+	default:
+		if details.Database != "" {
+			address += "/" + escapeConnectionValue(details.Database)
+		}
+	}
+
+	return scheme + "://" + userInfo + address + encodeConnectionParams(params), nil
+}
+
+// getConnectionUserInfo returns the user and the password followed by an @, or nothing without a user
+func getConnectionUserInfo(scheme string, user string, password string) (string, error) {
+	if user == "" {
+		if password != "" {
+			return "", errors.New("the connection needs a user for the password")
+		}
+
+		return "", nil
+	}
+
+	if scheme != "mysql" && scheme != "mariadb" {
+		user = escapeConnectionValue(user)
+	}
+
+	if password == "" {
+		return user + "@", nil
+	}
+
+	escapedPassword, err := escapeConnectionPassword(scheme, password)
+	if err != nil {
+		return "", err
+	}
+
+	return user + ":" + escapedPassword + "@", nil
+}
+
+// escapeConnectionPassword escapes the password like the driver of the scheme unescapes it
+func escapeConnectionPassword(scheme string, password string) (string, error) {
+	switch scheme {
+	case "postgresql", "postgres", "cockroachdb", "redshift", "sqlserver", "snowflake", "clickhouse":
+		return escapeConnectionValue(password), nil
+	case "mysql", "mariadb":
+		// the mysql driver takes everything between the first colon and the last @ as password
+		return password, nil
+	default:
+		return "", fmt.Errorf("a password is not supported for %s", scheme)
+	}
+}
+
+// escapeConnectionValue escapes spaces as %20, as the url parsers of the drivers do not treat + as space
+func escapeConnectionValue(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+func encodeConnectionParams(params url.Values) string {
+	if len(params) == 0 {
+		return ""
+	}
+
+	return "?" + params.Encode()
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// SetConnectionPassword adds the password to the user of the connection string (e.g. postgresql://user@host/db), so
+// the password does not have to be part of the configured connection string
+func SetConnectionPassword(connectionString string, password string) (string, error) {
+	scheme, rest, ok := strings.Cut(connectionString, "://")
+	if !ok {
+		return "", errors.New("invalid connection string")
+	}
+
+	escapedPassword, err := escapeConnectionPassword(scheme, password)
+	if err != nil {
+		return "", err
+	}
+
+	authorityEnd := strings.IndexAny(rest, "/?#")
+	if authorityEnd < 0 {
+		authorityEnd = len(rest)
+	}
+
+	userEnd := strings.LastIndex(rest[:authorityEnd], "@")
+	if userEnd <= 0 {
+		return "", errors.New("the connection string has to contain a user to add the password (e.g. postgresql://user@host/db)")
+	}
+
+	if strings.Contains(rest[:userEnd], ":") {
+		return "", errors.New("the connection string already contains a password")
+	}
+
+	return scheme + "://" + rest[:userEnd] + ":" + escapedPassword + rest[userEnd:], nil
+}
+
+// ReplaceConnectionPassword replaces the password of the connection string (e.g. with a placeholder of an environment
+// variable), connection strings without password are returned unchanged
+func ReplaceConnectionPassword(connectionString string, password string) string {
+	scheme, rest, ok := strings.Cut(connectionString, "://")
+	if !ok {
+		return connectionString
+	}
+
+	authorityEnd := strings.IndexAny(rest, "/?#")
+	if authorityEnd < 0 {
+		authorityEnd = len(rest)
+	}
+
+	userEnd := strings.LastIndex(rest[:authorityEnd], "@")
+	if userEnd <= 0 {
+		return connectionString
+	}
+
+	user, _, found := strings.Cut(rest[:userEnd], ":")
+	if !found {
+		return connectionString
+	}
+
+	return scheme + "://" + user + ":" + password + rest[userEnd:]
+}
+
+// GetConnectionTarget returns the connection string without the password (e.g. postgresql://user@host:5432/db), so
+// it identifies the database and the user independent of the credentials
+func GetConnectionTarget(connectionString string) string {
+	scheme, rest, ok := strings.Cut(connectionString, "://")
+	if !ok {
+		return connectionString
+	}
+
+	authorityEnd := strings.IndexAny(rest, "/?#")
+	if authorityEnd < 0 {
+		authorityEnd = len(rest)
+	}
+
+	if userEnd := strings.LastIndex(rest[:authorityEnd], "@"); userEnd > 0 {
+		user, _, _ := strings.Cut(rest[:userEnd], ":")
+		rest = user + rest[userEnd:]
+	}
+
+	rest, rawQuery, found := strings.Cut(rest, "?")
+	if !found {
+		return scheme + "://" + rest
+	}
+
+	// the password can also be a parameter (e.g. sqlserver://sa@localhost?database=db&password=secret)
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return scheme + "://" + rest
+	}
+
+	for key := range query {
+		if lowerKey := strings.ToLower(key); lowerKey == "password" || lowerKey == "pwd" {
+			query.Del(key)
+		}
+	}
+
+	if len(query) == 0 {
+		return scheme + "://" + rest
+	}
+
+	return scheme + "://" + rest + "?" + query.Encode()
+}
@@ -1,19 +1,137 @@
 package database
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var sqlLineCommentRegex = regexp.MustCompile(`--[^\n]*`)
 
 type baseConnector struct {
 	dbType           DbType
 	connectionString string
 	db               *sql.DB
+	tunnel           io.Closer
+	dataTypes        map[string]string
 }
 
 type Connector interface {
-	Connect() error
+	Connect(ctx context.Context) error
 	Close()
 	GetDbType() DbType
-	GetSchemas() ([]string, error)
-	GetTables(schemaNames []string) ([]TableDetail, error)
-	GetColumns(tableName TableDetail) ([]ColumnResult, error)
-	GetConstraints(tableName TableDetail) ([]ConstraintResult, error)
+	GetSchemas(ctx context.Context) ([]string, error)
+	GetTables(ctx context.Context, schemaNames []string) ([]TableDetail, error)
+	GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error)
+	GetConstraints(ctx context.Context, tableName TableDetail) ([]ConstraintResult, error)
+	GetIndexes(ctx context.Context, tableName TableDetail) ([]IndexResult, error)
+}
+
+// BulkConnector is implemented by the connectors that can read the columns and foreign keys of all tables of the
+// schemas with one query each, which avoids a round trip per table on large schemas. The columns are grouped by
+// the schema and name of the table (without the other details).
+type BulkConnector interface {
+	GetAllColumns(ctx context.Context, schemaNames []string) (map[TableDetail][]ColumnResult, error)
+	GetAllConstraints(ctx context.Context, schemaNames []string) ([]ConstraintResult, error)
+}
+
+// closeConnection closes the database connection and the ssh tunnel of the connection (if any)
+func closeConnection(db *sql.DB, tunnel io.Closer) {
+	if err := db.Close(); err != nil {
+		fmt.Println("could not close database connection", err)
+	}
+
+	if tunnel != nil {
+		if err := tunnel.Close(); err != nil {
+			fmt.Println("could not close ssh tunnel", err)
+		}
+	}
+}
+
+// queryContext runs the query and logs it with its duration on debug level (--verbose), queries that could change the
+// database are rejected (see assertReadOnlyQuery)
+func queryContext(ctx context.Context, db *sql.DB, query string, args ...any) (*sql.Rows, error) {
+	if err := assertReadOnlyQuery(query); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	rows, err := db.QueryContext(ctx, query, args...)
+	logQuery(query, args, start, err)
+	return rows, err
+}
+
+// queriedRow is the row of queryRowContext, which returns the error of a rejected query on Scan
+type queriedRow struct {
+	*sql.Row
+	err error
+}
+
+func (r queriedRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	return r.Row.Scan(dest...)
+}
+
+// queryRowContext is the single row variant of queryContext
+func queryRowContext(ctx context.Context, db *sql.DB, query string, args ...any) queriedRow {
+	if err := assertReadOnlyQuery(query); err != nil {
+		return queriedRow{err: err}
+	}
+
+	start := time.Now()
+	row := db.QueryRowContext(ctx, query, args...)
+	logQuery(query, args, start, row.Err())
+	return queriedRow{Row: row}
+}
+
+func logQuery(query string, args []any, start time.Time, err error) {
+	fields := logrus.Fields{
+		"query":      getQueryLogLine(query),
+		"args":       args,
+		"durationMs": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields[logrus.ErrorKey] = err
+	}
+
+	logrus.WithFields(fields).Debug("Executed query")
+	traceQuery(query, args, start, err)
+}
+
+// getQueryLogLine removes the comments and collapses the whitespace of the multiline queries to log them in one line
+func getQueryLogLine(query string) string {
+	return strings.Join(strings.Fields(sqlLineCommentRegex.ReplaceAllString(query, "")), " ")
+}
+
+// scanIndexes reads the rows (index name, column name, is unique, is primary) of the connectors, which return one
+// row per column ordered by the index name and the position of the column
+func scanIndexes(rows *sql.Rows) ([]IndexResult, error) {
+	var indexes []IndexResult
+	for rows.Next() {
+		var index IndexResult
+		var columnName string
+		if err := rows.Scan(&index.Name, &columnName, &index.IsUnique, &index.IsPrimary); err != nil {
+			return nil, err
+		}
+
+		columnName = SanitizeValue(columnName)
+		if len(indexes) > 0 && indexes[len(indexes)-1].Name == index.Name {
+			indexes[len(indexes)-1].ColumnNames = append(indexes[len(indexes)-1].ColumnNames, columnName)
+			continue
+		}
+
+		index.ColumnNames = []string{columnName}
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
 }
@@ -2,10 +2,34 @@ package database
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"strings"
 )
 
-type connectorFactory struct{}
+type connectorFactory struct {
+	options ConnectorOptions
+}
+
+// ConnectorOptions are applied to the connection strings of all connectors of the factory
+type ConnectorOptions struct {
+	// SshTunnel connects to the database through an ssh tunnel, so the connection string can target a host that is
+	// only reachable from the ssh server
+	SshTunnel SshTunnelConfig
+	Tls       TlsConfig
+	// AuthMode is empty for the authentication via the connection string or AuthModeAzureAd
+	AuthMode string
+	// ReadOnlySession makes the session read-only where supported (see applyReadOnlySession)
+	ReadOnlySession bool
+	// DataTypes replace the data types of the columns, the keys are the data types of the database in lower case
+	// (e.g. "timestamp with time zone") or the normalized data types (e.g. timestamptz)
+	DataTypes map[string]string
+	// Plugins are the file names of Go plugins that register further connectors (see RegisterConnector)
+	Plugins []string
+}
+
+// AuthModeAzureAd authenticates at Azure SQL with the DefaultAzureCredential of Azure Active Directory
+const AuthModeAzureAd = "azure-ad"
 
 type ConnectorFactory interface {
 	NewConnector(connectionString string) (Connector, error)
@@ -15,24 +39,64 @@ func NewConnectorFactory() ConnectorFactory {
 	return connectorFactory{}
 }
 
-func (connectorFactory) NewConnector(connectionString string) (Connector, error) {
-	switch {
-	case strings.HasPrefix(connectionString, "postgresql") || strings.HasPrefix(connectionString, "postgres"):
-		return &postgresConnector{
-			dbType:           Postgres,
-			connectionString: connectionString,
-		}, nil
-	case strings.HasPrefix(connectionString, "mysql"):
-		return &mySqlConnector{
-			dbType:           MySql,
-			connectionString: strings.ReplaceAll(connectionString, "mysql://", ""),
-		}, nil
-	case strings.HasPrefix(connectionString, "sqlserver"):
-		return &mssqlConnector{
-			dbType:           MsSql,
-			connectionString: connectionString,
-		}, nil
+func NewConnectorFactoryWithOptions(options ConnectorOptions) ConnectorFactory {
+	return connectorFactory{options: options}
+}
+
+func (f connectorFactory) NewConnector(connectionString string) (Connector, error) {
+	if err := loadConnectorPlugins(f.options.Plugins); err != nil {
+		return nil, err
+	}
+
+	switch f.options.AuthMode {
+	case "":
+	case AuthModeAzureAd:
+		if !strings.HasPrefix(connectionString, "sqlserver") {
+			return nil, errors.New("azure-ad authentication is only supported for sqlserver")
+		}
 	default:
-		return nil, errors.New("could not create connector for db")
+		return nil, fmt.Errorf("unsupported auth mode %q (azure-ad)", f.options.AuthMode)
+	}
+
+	connectionString, err := applyTlsConfig(connectionString, f.options.Tls)
+	if err != nil {
+		return nil, err
 	}
+
+	if f.options.ReadOnlySession {
+		connectionString = applyReadOnlySession(connectionString)
+	}
+
+	if f.options.SshTunnel.Destination == "" {
+		return f.newConnector(connectionString, nil)
+	}
+
+	targetAddress, err := getConnectionAddress(connectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	tunnel, err := openSshTunnel(f.options.SshTunnel, targetAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	connector, err := f.newConnector(replaceConnectionAddress(connectionString, tunnel.localAddress()), tunnel)
+	if err != nil {
+		_ = tunnel.Close()
+		return nil, err
+	}
+
+	return connector, nil
+}
+
+// newConnector creates the connector that is registered for the scheme of the connection string. The tunnel (if any)
+// is closed together with the connector.
+func (f connectorFactory) newConnector(connectionString string, tunnel io.Closer) (Connector, error) {
+	constructor, ok := getConnectorConstructor(connectionString)
+	if !ok {
+		return nil, fmt.Errorf("could not create connector for db: %w", ErrUnsupportedDialect)
+	}
+
+	return constructor(connectionString, tunnel, f.options)
 }
@@ -0,0 +1,22 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetQueryLogLine(t *testing.T) {
+	// Arrange
+	query := `
+		select ti.name,
+		       -- primary key columns are not null
+		       ti.pk > 0 as is_primary
+		from pragma_table_info(?1, ?2) ti`
+
+	// Act
+	result := getQueryLogLine(query)
+
+	// Assert
+	assert.Equal(t, "select ti.name, ti.pk > 0 as is_primary from pragma_table_info(?1, ?2) ti", result)
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// RunCredentialCommand runs the credential command (e.g. vault kv get -field=password secret/db) and returns the
+// password it prints, so the password can stay in a vault or in the keychain of the operating system. The command runs
+// with the shell of the operating system, so it can contain arguments and pipes.
+func RunCredentialCommand(credentialCommand string) (string, error) {
+	command := exec.Command("sh", "-c", credentialCommand)
+	if runtime.GOOS == "windows" {
+		command = exec.Command("cmd", "/C", credentialCommand)
+	}
+
+	output, err := command.Output()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) && len(exitError.Stderr) > 0 {
+			return "", fmt.Errorf("credential command failed: %w (%s)", err, strings.TrimSpace(string(exitError.Stderr)))
+		}
+
+		return "", fmt.Errorf("credential command failed: %w", err)
+	}
+
+	// the trailing line break of the output is not part of the password
+	password := strings.TrimRight(string(output), "\r\n")
+	if password == "" {
+		return "", errors.New("the credential command did not print a password")
+	}
+
+	return password, nil
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCredentialCommand(t *testing.T) {
+	testCases := []struct {
+		credentialCommand string
+		expectedPassword  string
+		expectError       bool
+	}{
+		{credentialCommand: "echo 'p@ss word'", expectedPassword: "p@ss word"},
+		{credentialCommand: "printf 'secret\\n' | tr a-z A-Z", expectedPassword: "SECRET"},
+		{credentialCommand: "echo 'not found' >&2; exit 1", expectError: true},
+		{credentialCommand: "true", expectError: true},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result, err := RunCredentialCommand(testCase.credentialCommand)
+
+			// Assert
+			if testCase.expectError {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedPassword, result)
+		})
+	}
+}
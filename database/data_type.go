@@ -0,0 +1,125 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	dataTypeLengthRegex     = regexp.MustCompile(`\s*\([^)]*\)`)
+	dataTypeWhitespaceRegex = regexp.MustCompile(`\s+`)
+	dataTypeParameterRegex  = regexp.MustCompile(`\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\)`)
+)
+
+// dataTypeAliases are the short names of the standard sql data types, which are used by all dialects
+var dataTypeAliases = map[string]string{
+	"character varying":          "varchar",
+	"character":                  "char",
+	"national character varying": "nvarchar",
+	"national character":         "nchar",
+	"double precision":           "double",
+	"timestamp with time zone":   "timestamptz",
+	"time with time zone":        "timetz",
+}
+
+// dialectDataTypeAliases replace the aliases of all dialects for the data types of the dialect
+var dialectDataTypeAliases = map[DbType]map[string]string{
+	Postgres: {
+		"timestamp without time zone": "timestamp",
+		"time without time zone":      "time",
+		"bit varying":                 "varbit",
+	},
+	CockroachDb: {
+		"timestamp without time zone": "timestamp",
+		"time without time zone":      "time",
+		"bit varying":                 "varbit",
+	},
+	Redshift: {
+		"timestamp without time zone": "timestamp",
+		"time without time zone":      "time",
+	},
+	File: {
+		"timestamp without time zone": "timestamp",
+		"time without time zone":      "time",
+		"bit varying":                 "varbit",
+	},
+	Sqlite: {
+		"unsigned big int":  "bigint",
+		"big int":           "bigint",
+		"varying character": "varchar",
+		"native character":  "nchar",
+	},
+}
+
+// normalizeDataType converts the data type of the column to a name that can be shown in the diagram. The length and
+// precision are removed (e.g. varchar(255) -> varchar) and long names are shortened (e.g. timestamp(6) with time zone
+// -> timestamptz). The configured data types (keys in lower case) replace the data type before or after the
+// normalization.
+func normalizeDataType(dbType DbType, dataTypes map[string]string, value string) string {
+	withoutLength := strings.TrimSpace(dataTypeWhitespaceRegex.ReplaceAllString(dataTypeLengthRegex.ReplaceAllString(value, ""), " "))
+	dataType := withoutLength
+	if alias, ok := dialectDataTypeAliases[dbType][strings.ToLower(withoutLength)]; ok {
+		dataType = alias
+	} else if alias, ok := dataTypeAliases[strings.ToLower(withoutLength)]; ok {
+		dataType = alias
+	}
+
+	for _, key := range []string{value, withoutLength, dataType} {
+		if configuredDataType, ok := dataTypes[strings.ToLower(strings.TrimSpace(key))]; ok {
+			return SanitizeValue(configuredDataType)
+		}
+	}
+
+	return SanitizeValue(dataType)
+}
+
+// DataTypeWithPrecision returns the data type with the declared length of character types (e.g. varchar(255)) or the
+// precision and scale of decimal types (e.g. numeric(10,2)). The precision of other numeric types is never shown, as
+// the databases also return it for integers (e.g. 32 for integer).
+func (c ColumnResult) DataTypeWithPrecision() string {
+	switch {
+	case c.CharacterMaxLength > 0 && isCharacterDataType(c.DataType):
+		return fmt.Sprintf("%s(%d)", c.DataType, c.CharacterMaxLength)
+	case c.NumericPrecision > 0 && c.NumericScale > 0 && isDecimalDataType(c.DataType):
+		return fmt.Sprintf("%s(%d,%d)", c.DataType, c.NumericPrecision, c.NumericScale)
+	case c.NumericPrecision > 0 && isDecimalDataType(c.DataType):
+		return fmt.Sprintf("%s(%d)", c.DataType, c.NumericPrecision)
+	default:
+		return c.DataType
+	}
+}
+
+// setDataTypeParameters sets the length or the precision and scale of the declared data type (e.g. varchar(255) or
+// numeric(10,2)), for the connectors that do not read them from the information_schema
+func setDataTypeParameters(column *ColumnResult, declaredDataType string) {
+	matches := dataTypeParameterRegex.FindStringSubmatch(declaredDataType)
+	if matches == nil {
+		return
+	}
+
+	first, _ := strconv.Atoi(matches[1])
+	second, _ := strconv.Atoi(matches[2])
+	if isCharacterDataType(declaredDataType) {
+		column.CharacterMaxLength = first
+		return
+	}
+
+	column.NumericPrecision = first
+	column.NumericScale = second
+}
+
+func isCharacterDataType(dataType string) bool {
+	dataType = strings.ToLower(dataType)
+	return strings.Contains(dataType, "char") || strings.Contains(dataType, "binary")
+}
+
+func isDecimalDataType(dataType string) bool {
+	switch strings.ToLower(dataType) {
+	case "numeric", "decimal", "dec", "number":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeDataType(t *testing.T) {
+	testCases := []struct {
+		dbType           DbType
+		dataTypes        map[string]string
+		value            string
+		expectedDataType string
+	}{
+		{dbType: Postgres, value: "character varying", expectedDataType: "varchar"},
+		{dbType: Postgres, value: "timestamp with time zone", expectedDataType: "timestamptz"},
+		{dbType: Postgres, value: "timestamp without time zone", expectedDataType: "timestamp"},
+		{dbType: Postgres, value: "integer", expectedDataType: "integer"},
+		{dbType: File, value: "character varying(255)", expectedDataType: "varchar"},
+		{dbType: File, value: "timestamp(6) with time zone", expectedDataType: "timestamptz"},
+		{dbType: File, value: "numeric(10, 2)", expectedDataType: "numeric"},
+		{dbType: MySql, value: "double precision", expectedDataType: "double"},
+		{dbType: MySql, value: "timestamp without time zone", expectedDataType: "timestamp_without_time_zone"},
+		{dbType: Sqlite, value: "VARCHAR(255)", expectedDataType: "VARCHAR"},
+		{dbType: Sqlite, value: "UNSIGNED BIG INT", expectedDataType: "bigint"},
+		{dbType: Sqlite, value: "", expectedDataType: ""},
+		{dbType: MsSql, value: "nvarchar", expectedDataType: "nvarchar"},
+		{dbType: Postgres, dataTypes: map[string]string{"timestamptz": "datetime"}, value: "timestamp with time zone", expectedDataType: "datetime"},
+		{dbType: Postgres, dataTypes: map[string]string{"character varying": "string"}, value: "character varying", expectedDataType: "string"},
+		{dbType: Sqlite, dataTypes: map[string]string{"decimal(10,5)": "money"}, value: "DECIMAL(10,5)", expectedDataType: "money"},
+		{dbType: Postgres, dataTypes: map[string]string{"jsonb": "json document"}, value: "jsonb", expectedDataType: "json_document"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(string(testCase.dbType)+" "+testCase.value, func(t *testing.T) {
+			// Act
+			result := normalizeDataType(testCase.dbType, testCase.dataTypes, testCase.value)
+
+			// Assert
+			assert.Equal(t, testCase.expectedDataType, result)
+		})
+	}
+}
+
+func TestDataTypeWithPrecision(t *testing.T) {
+	testCases := []struct {
+		column           ColumnResult
+		expectedDataType string
+	}{
+		{column: ColumnResult{DataType: "varchar", CharacterMaxLength: 255}, expectedDataType: "varchar(255)"},
+		{column: ColumnResult{DataType: "nvarchar", CharacterMaxLength: -1}, expectedDataType: "nvarchar"},
+		{column: ColumnResult{DataType: "text", CharacterMaxLength: 65535}, expectedDataType: "text"},
+		{column: ColumnResult{DataType: "numeric", NumericPrecision: 10, NumericScale: 2}, expectedDataType: "numeric(10,2)"},
+		{column: ColumnResult{DataType: "NUMBER", NumericPrecision: 38}, expectedDataType: "NUMBER(38)"},
+		{column: ColumnResult{DataType: "integer", NumericPrecision: 32}, expectedDataType: "integer"},
+		{column: ColumnResult{DataType: "numeric"}, expectedDataType: "numeric"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.expectedDataType, func(t *testing.T) {
+			// Act
+			result := testCase.column.DataTypeWithPrecision()
+
+			// Assert
+			assert.Equal(t, testCase.expectedDataType, result)
+		})
+	}
+}
+
+func TestSetDataTypeParameters(t *testing.T) {
+	testCases := []struct {
+		declaredDataType string
+		expectedColumn   ColumnResult
+	}{
+		{declaredDataType: "VARCHAR(255)", expectedColumn: ColumnResult{CharacterMaxLength: 255}},
+		{declaredDataType: "character varying(100)", expectedColumn: ColumnResult{CharacterMaxLength: 100}},
+		{declaredDataType: "DECIMAL(10, 5)", expectedColumn: ColumnResult{NumericPrecision: 10, NumericScale: 5}},
+		{declaredDataType: "numeric(12)", expectedColumn: ColumnResult{NumericPrecision: 12}},
+		{declaredDataType: "integer", expectedColumn: ColumnResult{}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.declaredDataType, func(t *testing.T) {
+			// Arrange
+			column := ColumnResult{}
+
+			// Act
+			setDataTypeParameters(&column, testCase.declaredDataType)
+
+			// Assert
+			assert.Equal(t, testCase.expectedColumn, column)
+		})
+	}
+}
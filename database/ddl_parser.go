@@ -0,0 +1,858 @@
+package database
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ddlDefaultSchema is used for all tables that are not qualified with a schema in the DDL file
+const ddlDefaultSchema = "public"
+
+type ddlTokenKind int
+
+const (
+	ddlWord ddlTokenKind = iota
+	ddlQuotedIdentifier
+	ddlString
+	ddlSymbol
+)
+
+type ddlToken struct {
+	kind  ddlTokenKind
+	value string
+}
+
+type ddlModel struct {
+	tables   []*ddlTable
+	enums    map[string]string
+	comments map[string]string
+}
+
+type ddlTable struct {
+	detail         TableDetail
+	columns        []ddlColumn
+	primaryKeys    []string
+	primaryKeyName string
+	indexes        []ddlIndex
+	foreignKeys    []ddlForeignKey
+	partitionOf    string
+	inherits       [][]string
+}
+
+type ddlColumn struct {
+	name         string
+	dataType     string
+	enumValues   string
+	comment      string
+	notNull      bool
+	checks       []string
+	defaultValue string
+	// isAutoIncrement is true for identity, serial and auto_increment columns
+	isAutoIncrement bool
+}
+
+type ddlIndex struct {
+	name     string
+	columns  []string
+	isUnique bool
+}
+
+type ddlForeignKey struct {
+	name    string
+	columns []string
+	pkTable []string
+}
+
+// parseDdl parses the CREATE TABLE, ALTER TABLE, CREATE INDEX, CREATE TYPE (enums) and COMMENT ON COLUMN
+// statements of a sql dump. All other statements are ignored, as they are not relevant for the diagram.
+func parseDdl(content string) *ddlModel {
+	model := &ddlModel{enums: map[string]string{}, comments: map[string]string{}}
+	for _, statement := range splitDdlStatements(tokenizeDdl(content)) {
+		switch {
+		case isDdlStatement(statement, "create") && ddlIndexOfWord(statement, "table") > 0:
+			model.parseCreateTable(statement)
+		case isDdlStatement(statement, "create", "type"):
+			model.parseCreateType(statement)
+		case isDdlStatement(statement, "create") && ddlIndexOfWord(statement, "index") > 0:
+			model.parseCreateIndex(statement)
+		case isDdlStatement(statement, "alter", "table"):
+			model.parseAlterTable(statement)
+		case isDdlStatement(statement, "comment", "on", "column"):
+			model.parseColumnComment(statement)
+		}
+	}
+
+	return model
+}
+
+func (m *ddlModel) parseCreateTable(statement []ddlToken) {
+	index := ddlIndexOfWord(statement, "table")
+	// only modifiers like "or replace", "temporary" or "unlogged" are allowed between create and table
+	for i := 1; i < index; i++ {
+		if !isDdlWord(statement, i, "or", "replace", "temp", "temporary", "unlogged", "global", "local", "transient") {
+			return
+		}
+	}
+
+	rest := skipDdlWords(statement[index+1:], "if", "not", "exists")
+	name, rest := parseDdlQualifiedName(rest)
+	if len(name) == 0 || len(rest) == 0 || rest[0].value != "(" {
+		return
+	}
+
+	table := m.getOrCreateTable(name)
+	body, rest := splitDdlParenthesis(rest)
+	for _, element := range splitDdlList(body) {
+		m.parseTableElement(table, element)
+	}
+
+	if isDdlWord(rest, 0, "inherits") {
+		parents, _ := splitDdlParenthesis(rest[1:])
+		for _, parent := range splitDdlList(parents) {
+			if parentName, _ := parseDdlQualifiedName(parent); len(parentName) > 0 {
+				table.inherits = append(table.inherits, parentName)
+			}
+		}
+	}
+}
+
+func (m *ddlModel) parseAlterTable(statement []ddlToken) {
+	rest := skipDdlWords(statement[2:], "only", "if", "exists")
+	name, rest := parseDdlQualifiedName(rest)
+	if len(name) == 0 {
+		return
+	}
+
+	table := m.findTable(name, ddlDefaultSchema)
+	if table == nil {
+		return
+	}
+
+	for _, action := range splitDdlList(rest) {
+		// pg_dump creates the partitions like tables and attaches them afterwards
+		if isDdlWord(action, 0, "attach") && isDdlWord(action, 1, "partition") {
+			partitionName, _ := parseDdlQualifiedName(action[2:])
+			if partition := m.findTable(partitionName, table.detail.Schema); partition != nil {
+				partition.partitionOf = table.detail.Name
+			}
+			continue
+		}
+
+		index := ddlIndexOfWord(action, "add")
+		if index < 0 {
+			continue
+		}
+
+		element := skipDdlWords(action[index+1:], "column", "if", "not", "exists")
+		m.parseTableElement(table, element)
+	}
+}
+
+func (m *ddlModel) parseCreateIndex(statement []ddlToken) {
+	index := ddlIndexOfWord(statement, "index")
+	// only modifiers like "unique" or "clustered" are allowed between create and index
+	isUnique := false
+	for i := 1; i < index; i++ {
+		if !isDdlWord(statement, i, "unique", "clustered", "nonclustered") {
+			return
+		}
+		isUnique = isUnique || isDdlWord(statement, i, "unique")
+	}
+
+	onIndex := ddlIndexOfWord(statement, "on")
+	if onIndex < 0 {
+		return
+	}
+
+	// the index name is optional in postgres
+	indexName, _ := parseDdlQualifiedName(skipDdlWords(statement[index+1:onIndex], "concurrently", "if", "not", "exists"))
+	name, rest := parseDdlQualifiedName(skipDdlWords(statement[onIndex+1:], "only"))
+	table := m.findTable(name, ddlDefaultSchema)
+	if table == nil {
+		return
+	}
+
+	// e.g. "using btree (column)" in pg_dump
+	if isDdlWord(rest, 0, "using") && len(rest) > 1 {
+		rest = rest[2:]
+	}
+
+	columns, _ := splitDdlParenthesis(rest)
+	table.addIndex(getDdlLastName(indexName), getDdlIdentifiers(columns), isUnique)
+}
+
+func (m *ddlModel) parseCreateType(statement []ddlToken) {
+	name, rest := parseDdlQualifiedName(statement[2:])
+	rest = skipDdlWords(rest, "as")
+	if len(name) == 0 || !isDdlWord(rest, 0, "enum") {
+		return
+	}
+
+	values, _ := splitDdlParenthesis(rest[1:])
+	m.enums[strings.ToLower(name[len(name)-1])] = joinDdlStrings(values)
+}
+
+func (m *ddlModel) parseColumnComment(statement []ddlToken) {
+	name, rest := parseDdlQualifiedName(statement[3:])
+	if len(name) < 2 || !isDdlWord(rest, 0, "is") || len(rest) < 2 || rest[1].kind != ddlString {
+		return
+	}
+
+	table := m.findTable(name[:len(name)-1], ddlDefaultSchema)
+	if table == nil {
+		return
+	}
+
+	m.comments[ddlColumnKey(table.detail, name[len(name)-1])] = rest[1].value
+}
+
+func (m *ddlModel) parseTableElement(table *ddlTable, element []ddlToken) {
+	if len(element) == 0 {
+		return
+	}
+
+	var constraintName string
+	if isDdlWord(element, 0, "constraint") && len(element) > 1 {
+		constraintName = element[1].value
+		element = element[2:]
+	}
+
+	switch {
+	case isDdlWord(element, 0, "primary"):
+		columns, _ := splitDdlParenthesis(skipDdlWords(element[1:], "key", "clustered", "nonclustered"))
+		table.primaryKeys = append(table.primaryKeys, getDdlIdentifiers(columns)...)
+		table.primaryKeyName = constraintName
+	case isDdlWord(element, 0, "foreign"):
+		columns, rest := splitDdlParenthesis(skipDdlWords(element[1:], "key"))
+		table.addForeignKey(constraintName, getDdlIdentifiers(columns), rest)
+	case isDdlWord(element, 0, "check"):
+		expression, _ := splitDdlParenthesis(element[1:])
+		table.addCheck(expression)
+	case isDdlWord(element, 0, "unique", "key", "index"):
+		isUnique := isDdlWord(element, 0, "unique")
+		rest := skipDdlWords(element[1:], "key", "index", "nulls", "not", "distinct", "clustered", "nonclustered")
+		// mysql allows an index name before the columns
+		if len(rest) > 0 && rest[0].value != "(" {
+			constraintName = rest[0].value
+			rest = rest[1:]
+		}
+		columns, _ := splitDdlParenthesis(rest)
+		table.addIndex(constraintName, getDdlIdentifiers(columns), isUnique)
+	case constraintName != "" || isDdlWord(element, 0, "check", "fulltext", "spatial", "exclude", "like", "period"):
+		// other constraints and indexes are not needed for the diagram
+	default:
+		m.parseColumn(table, element)
+	}
+}
+
+func (m *ddlModel) parseColumn(table *ddlTable, element []ddlToken) {
+	column := ddlColumn{name: element[0].value}
+	var dataType []string
+	index := 1
+	for ; index < len(element); index++ {
+		token := element[index]
+		if token.value == "(" {
+			values, _ := splitDdlParenthesis(element[index:])
+			if strings.EqualFold(strings.Join(dataType, ""), "enum") {
+				column.enumValues = joinDdlStrings(values)
+			}
+			index += len(values) + 1
+			continue
+		}
+
+		if token.kind == ddlString || (token.kind == ddlWord && isDdlColumnModifier(element, index)) {
+			break
+		}
+
+		// only the name of a qualified type is used (e.g. mood instead of public.mood)
+		if token.value == "." && len(dataType) > 0 {
+			dataType = dataType[:len(dataType)-1]
+			continue
+		}
+
+		dataType = append(dataType, strings.ToLower(token.value))
+	}
+	column.dataType = strings.Join(dataType, " ")
+	column.isAutoIncrement = ddlContains([]string{"smallserial", "serial", "bigserial", "serial2", "serial4", "serial8"}, column.dataType)
+	if enumValues, ok := m.enums[column.dataType]; ok && column.enumValues == "" {
+		column.enumValues = enumValues
+	}
+
+	for ; index < len(element); index++ {
+		switch {
+		case isDdlWord(element, index, "check") && index+1 < len(element) && element[index+1].value == "(":
+			expression, _ := splitDdlParenthesis(element[index+1:])
+			column.checks = append(column.checks, formatDdlExpression(expression))
+			index += len(expression) + 2
+		case isDdlWord(element, index, "generated"):
+			// the default of "generated by default as identity" is no default value
+			for isDdlWord(element, index+1, "always", "by", "default", "as") {
+				index++
+			}
+		case isDdlWord(element, index, "default") && index+1 < len(element):
+			expression := getDdlDefaultExpression(element[index+1:])
+			// like in the information_schema, a default of null is not a default value
+			if !isDdlWord(expression, 0, "null") || len(expression) > 1 {
+				column.defaultValue = formatDdlExpression(expression)
+			}
+			index += len(expression)
+		case element[index].value == "(":
+			// skip other expressions, e.g. generated always as (price * 2)
+			values, _ := splitDdlParenthesis(element[index:])
+			index += len(values) + 1
+		case isDdlWord(element, index, "not") && isDdlWord(element, index+1, "null"):
+			column.notNull = true
+		case isDdlWord(element, index, "auto_increment", "autoincrement", "identity"):
+			column.isAutoIncrement = true
+		case isDdlWord(element, index, "primary"):
+			table.primaryKeys = append(table.primaryKeys, column.name)
+		case isDdlWord(element, index, "unique"):
+			table.addIndex("", []string{column.name}, true)
+		case isDdlWord(element, index, "references"):
+			table.addForeignKey("", []string{column.name}, element[index:])
+		case isDdlWord(element, index, "comment") && index+1 < len(element) && element[index+1].kind == ddlString:
+			column.comment = element[index+1].value
+		}
+	}
+
+	table.columns = append(table.columns, column)
+}
+
+// addForeignKey adds the foreign key defined by the tokens "references table (columns)"
+func (t *ddlTable) addForeignKey(name string, columns []string, references []ddlToken) {
+	if !isDdlWord(references, 0, "references") {
+		return
+	}
+
+	pkTable, _ := parseDdlQualifiedName(references[1:])
+	if len(pkTable) == 0 {
+		return
+	}
+
+	if name == "" {
+		name = t.detail.Name + "_" + strings.Join(columns, "_") + "_fkey"
+	}
+
+	t.foreignKeys = append(t.foreignKeys, ddlForeignKey{name: name, columns: columns, pkTable: pkTable})
+}
+
+// addCheck adds a table check constraint to all columns that are used in the expression
+func (t *ddlTable) addCheck(expression []ddlToken) {
+	formattedExpression := formatDdlExpression(expression)
+	for index := range t.columns {
+		for _, token := range expression {
+			if (token.kind == ddlWord || token.kind == ddlQuotedIdentifier) && ddlNameEquals(token.value, t.columns[index].name) {
+				t.columns[index].checks = append(t.columns[index].checks, formattedExpression)
+				break
+			}
+		}
+	}
+}
+
+// getCheckConstraints returns all check constraints of the column joined with "and"
+func (t *ddlTable) getCheckConstraints(columnName string) string {
+	for _, column := range t.columns {
+		if ddlNameEquals(column.name, columnName) {
+			return strings.Join(column.checks, " and ")
+		}
+	}
+
+	return ""
+}
+
+func (t *ddlTable) isAutoIncrement(columnName string) bool {
+	for _, column := range t.columns {
+		if ddlNameEquals(column.name, columnName) {
+			return column.isAutoIncrement
+		}
+	}
+
+	return false
+}
+
+// addIndex adds an index or unique constraint, unnamed ones get the default name of postgres (e.g. table_column_key)
+func (t *ddlTable) addIndex(name string, columns []string, isUnique bool) {
+	if len(columns) == 0 {
+		return
+	}
+
+	if name == "" {
+		suffix := "idx"
+		if isUnique {
+			suffix = "key"
+		}
+		name = t.detail.Name + "_" + strings.Join(columns, "_") + "_" + suffix
+	}
+
+	t.indexes = append(t.indexes, ddlIndex{name: name, columns: columns, isUnique: isUnique})
+}
+
+func (m *ddlModel) getOrCreateTable(name []string) *ddlTable {
+	detail := getDdlTableDetail(name, ddlDefaultSchema)
+	for _, table := range m.tables {
+		if strings.EqualFold(table.detail.Schema, detail.Schema) && strings.EqualFold(table.detail.Name, detail.Name) {
+			return table
+		}
+	}
+
+	table := &ddlTable{detail: detail}
+	m.tables = append(m.tables, table)
+	return table
+}
+
+// findTable finds the table by its (qualified) name, unqualified names are searched in the fallback schema first
+func (m *ddlModel) findTable(name []string, fallbackSchema string) *ddlTable {
+	detail := getDdlTableDetail(name, fallbackSchema)
+	var candidate *ddlTable
+	for _, table := range m.tables {
+		if !ddlNameEquals(table.detail.Name, detail.Name) {
+			continue
+		}
+
+		if strings.EqualFold(table.detail.Schema, detail.Schema) {
+			return table
+		}
+
+		if len(name) == 1 && candidate == nil {
+			candidate = table
+		}
+	}
+
+	return candidate
+}
+
+// isUniqueColumn checks if the column alone is a unique key besides the primary key
+func (t *ddlTable) isUniqueColumn(column string) bool {
+	for _, index := range t.indexes {
+		if index.isUnique && len(index.columns) == 1 && ddlContains(index.columns, column) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isUniqueKey checks if the columns are exactly the primary key or the columns of a unique key
+func (t *ddlTable) isUniqueKey(columns []string) bool {
+	keys := [][]string{t.primaryKeys}
+	for _, index := range t.indexes {
+		if index.isUnique {
+			keys = append(keys, index.columns)
+		}
+	}
+
+	for _, key := range keys {
+		if len(key) != len(columns) {
+			continue
+		}
+
+		matches := true
+		for _, column := range columns {
+			matches = matches && ddlContains(key, column)
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNullable checks if the column allows null values, primary key columns are never nullable
+func (t *ddlTable) isNullable(columnName string) bool {
+	if ddlContains(t.primaryKeys, columnName) {
+		return false
+	}
+
+	for _, column := range t.columns {
+		if ddlNameEquals(column.name, columnName) {
+			return !column.notNull
+		}
+	}
+
+	return true
+}
+
+func (t *ddlTable) hasForeignKey(columnName string) bool {
+	for _, foreignKey := range t.foreignKeys {
+		if ddlContains(foreignKey.columns, columnName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ddlNameEquals compares the names case-insensitive, the other name may also be the sanitized name
+func ddlNameEquals(name string, other string) bool {
+	return strings.EqualFold(name, other) || SanitizeValue(name) == other
+}
+
+func getDdlTableDetail(name []string, fallbackSchema string) TableDetail {
+	if len(name) == 1 {
+		return TableDetail{Schema: fallbackSchema, Name: name[0]}
+	}
+
+	return TableDetail{Schema: name[len(name)-2], Name: name[len(name)-1]}
+}
+
+// getDdlLastName returns the unqualified name (e.g. the index of schema.index) or an empty string
+func getDdlLastName(name []string) string {
+	if len(name) == 0 {
+		return ""
+	}
+
+	return name[len(name)-1]
+}
+
+func ddlColumnKey(table TableDetail, columnName string) string {
+	return strings.ToLower(table.Schema + "." + table.Name + "." + columnName)
+}
+
+func ddlContains(values []string, value string) bool {
+	for _, item := range values {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getDdlDefaultExpression returns the tokens of the default value, which ends before the next column modifier
+// (e.g. 0 of "default 0 not null")
+func getDdlDefaultExpression(tokens []ddlToken) []ddlToken {
+	depth := 0
+	for index, token := range tokens {
+		switch {
+		case token.value == "(":
+			depth++
+		case token.value == ")":
+			depth--
+		case depth == 0 && index > 0 && token.kind == ddlWord && isDdlColumnModifier(tokens, index):
+			return tokens[:index]
+		}
+	}
+
+	return tokens
+}
+
+// isDdlColumnModifier checks if the token at the index ends the data type of a column definition
+func isDdlColumnModifier(tokens []ddlToken, index int) bool {
+	if isDdlWord(tokens, index, "character", "char") {
+		return isDdlWord(tokens, index+1, "set")
+	}
+
+	return isDdlWord(tokens, index, "not", "null", "default", "primary", "references", "unique", "check",
+		"constraint", "comment", "auto_increment", "autoincrement", "identity", "generated", "collate", "charset",
+		"on", "as", "stored", "virtual", "invisible", "visible", "encode", "sparse", "distkey", "sortkey")
+}
+
+func isDdlStatement(statement []ddlToken, words ...string) bool {
+	for index, word := range words {
+		if !isDdlWord(statement, index, word) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDdlWord checks if the token at the index is an unquoted word that matches one of the given values
+func isDdlWord(tokens []ddlToken, index int, values ...string) bool {
+	if index < 0 || index >= len(tokens) || tokens[index].kind != ddlWord {
+		return false
+	}
+
+	for _, value := range values {
+		if strings.EqualFold(tokens[index].value, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ddlIndexOfWord(tokens []ddlToken, word string) int {
+	for index := range tokens {
+		if tokens[index].value == "(" {
+			return -1
+		}
+
+		if isDdlWord(tokens, index, word) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func skipDdlWords(tokens []ddlToken, words ...string) []ddlToken {
+	for len(tokens) > 0 && isDdlWord(tokens, 0, words...) {
+		tokens = tokens[1:]
+	}
+
+	return tokens
+}
+
+// parseDdlQualifiedName parses names like schema.table and returns the remaining tokens
+func parseDdlQualifiedName(tokens []ddlToken) ([]string, []ddlToken) {
+	var name []string
+	for len(tokens) > 0 && (tokens[0].kind == ddlWord || tokens[0].kind == ddlQuotedIdentifier) {
+		name = append(name, tokens[0].value)
+		tokens = tokens[1:]
+		if len(tokens) == 0 || tokens[0].value != "." {
+			break
+		}
+		tokens = tokens[1:]
+	}
+
+	return name, tokens
+}
+
+// splitDdlParenthesis returns the tokens inside the leading parenthesis and the tokens after it
+func splitDdlParenthesis(tokens []ddlToken) ([]ddlToken, []ddlToken) {
+	if len(tokens) == 0 || tokens[0].value != "(" {
+		return nil, tokens
+	}
+
+	depth := 0
+	for index, token := range tokens {
+		switch token.value {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return tokens[1:index], tokens[index+1:]
+			}
+		}
+	}
+
+	return tokens[1:], nil
+}
+
+// splitDdlList splits the tokens by all commas that are not inside a parenthesis
+func splitDdlList(tokens []ddlToken) [][]ddlToken {
+	var result [][]ddlToken
+	depth, start := 0, 0
+	for index, token := range tokens {
+		switch {
+		case token.value == "(":
+			depth++
+		case token.value == ")":
+			depth--
+		case token.value == "," && depth == 0:
+			result = append(result, tokens[start:index])
+			start = index + 1
+		}
+	}
+
+	return append(result, tokens[start:])
+}
+
+// splitDdlStatements splits the tokens by semicolons and the GO batch separator of MSSQL scripts
+func splitDdlStatements(tokens []ddlToken) [][]ddlToken {
+	var result [][]ddlToken
+	start := 0
+	for index, token := range tokens {
+		if (token.kind == ddlSymbol && token.value == ";") || isDdlWord(tokens, index, "go") {
+			result = append(result, tokens[start:index])
+			start = index + 1
+		}
+	}
+
+	return append(result, tokens[start:])
+}
+
+func getDdlIdentifiers(tokens []ddlToken) []string {
+	var result []string
+	for _, element := range splitDdlList(tokens) {
+		if len(element) > 0 {
+			result = append(result, element[0].value)
+		}
+	}
+
+	return result
+}
+
+func joinDdlStrings(tokens []ddlToken) string {
+	var values []string
+	for _, token := range tokens {
+		if token.kind == ddlString {
+			values = append(values, token.value)
+		}
+	}
+
+	return strings.Join(values, ",")
+}
+
+// ddlOperators are the operators that consist of multiple symbols
+var ddlOperators = []string{">=", "<=", "<>", "!=", "::", "||"}
+
+// ddlExpressionKeywords are followed by a space, even if a parenthesis follows (in contrast to function calls)
+var ddlExpressionKeywords = []string{"and", "or", "not", "in", "exists", "any", "all", "some", "like", "between", "is"}
+
+// formatDdlExpression converts the tokens of an expression back to sql (e.g. status IN ('new', 'paid')).
+// Redundant parenthesis around the whole expression are removed.
+func formatDdlExpression(tokens []ddlToken) string {
+	for len(tokens) > 0 {
+		inner, rest := splitDdlParenthesis(tokens)
+		if inner == nil || len(rest) > 0 {
+			break
+		}
+		tokens = inner
+	}
+
+	var parts []ddlToken
+	for index := 0; index < len(tokens); index++ {
+		part := tokens[index]
+		if part.kind == ddlString {
+			part.value = "'" + strings.ReplaceAll(part.value, "'", "''") + "'"
+		}
+
+		if part.kind == ddlSymbol && index+1 < len(tokens) && tokens[index+1].kind == ddlSymbol &&
+			ddlContains(ddlOperators, part.value+tokens[index+1].value) {
+			part.value += tokens[index+1].value
+			index++
+		}
+
+		// signed numbers, e.g. -1
+		isUnary := len(parts) == 0 || (parts[len(parts)-1].kind == ddlSymbol && parts[len(parts)-1].value != ")")
+		if (part.value == "-" || part.value == "+") && isUnary && index+1 < len(tokens) && tokens[index+1].kind == ddlWord {
+			part = ddlToken{kind: ddlWord, value: part.value + tokens[index+1].value}
+			index++
+		}
+
+		parts = append(parts, part)
+	}
+
+	var result strings.Builder
+	for index, part := range parts {
+		if index > 0 && needsDdlExpressionSpace(parts[index-1], part) {
+			result.WriteString(" ")
+		}
+		result.WriteString(part.value)
+	}
+
+	return result.String()
+}
+
+func needsDdlExpressionSpace(previous ddlToken, current ddlToken) bool {
+	switch {
+	case previous.value == "(" || previous.value == "." || previous.value == "::":
+		return false
+	case current.value == ")" || current.value == "," || current.value == "." || current.value == "::":
+		return false
+	case current.value == "(" && previous.kind == ddlWord:
+		// function calls, e.g. length(name)
+		return ddlContains(ddlExpressionKeywords, previous.value)
+	default:
+		return true
+	}
+}
+
+// tokenizeDdl splits the sql into words, quoted identifiers, strings and symbols. Comments are removed.
+func tokenizeDdl(content string) []ddlToken {
+	var tokens []ddlToken
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-', r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			end := indexDdlRunes(runes, i+2, []rune("*/"))
+			if end < 0 {
+				return tokens
+			}
+			i = end + 1
+		case r == '\'':
+			value, end := readDdlQuoted(runes, i, '\'')
+			tokens = append(tokens, ddlToken{kind: ddlString, value: value})
+			i = end
+		case r == '"' || r == '`':
+			value, end := readDdlQuoted(runes, i, r)
+			tokens = append(tokens, ddlToken{kind: ddlQuotedIdentifier, value: value})
+			i = end
+		case r == '[' && i+1 < len(runes) && runes[i+1] != ']':
+			value, end := readDdlQuoted(runes, i, ']')
+			tokens = append(tokens, ddlToken{kind: ddlQuotedIdentifier, value: value})
+			i = end
+		case r == '$' && getDollarQuoteTag(runes, i) != nil:
+			// dollar quoted strings (e.g. function bodies) are not needed and only their end is searched
+			tag := getDollarQuoteTag(runes, i)
+			end := indexDdlRunes(runes, i+len(tag), tag)
+			if end < 0 {
+				return tokens
+			}
+			i = end + len(tag) - 1
+			tokens = append(tokens, ddlToken{kind: ddlString})
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			start := i
+			for i+1 < len(runes) && (unicode.IsLetter(runes[i+1]) || unicode.IsDigit(runes[i+1]) || runes[i+1] == '_' || runes[i+1] == '$') {
+				i++
+			}
+			tokens = append(tokens, ddlToken{kind: ddlWord, value: string(runes[start : i+1])})
+		default:
+			tokens = append(tokens, ddlToken{kind: ddlSymbol, value: string(r)})
+		}
+	}
+
+	return tokens
+}
+
+// readDdlQuoted reads the quoted value starting at index start, doubled closing characters are unescaped
+func readDdlQuoted(runes []rune, start int, closing rune) (string, int) {
+	var value strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] == '\\' && closing == '\'' && i+1 < len(runes) {
+			i++
+			value.WriteRune(runes[i])
+			continue
+		}
+
+		if runes[i] == closing {
+			if i+1 < len(runes) && runes[i+1] == closing {
+				value.WriteRune(closing)
+				i++
+				continue
+			}
+
+			return value.String(), i
+		}
+
+		value.WriteRune(runes[i])
+	}
+
+	return value.String(), len(runes)
+}
+
+// getDollarQuoteTag returns the tag of a dollar quoted string (e.g. $$ or $body$) or nil if there is none
+func getDollarQuoteTag(runes []rune, start int) []rune {
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] == '$' {
+			return runes[start : i+1]
+		}
+
+		if !unicode.IsLetter(runes[i]) && runes[i] != '_' {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func indexDdlRunes(runes []rune, start int, pattern []rune) int {
+	for i := start; i+len(pattern) <= len(runes); i++ {
+		if string(runes[i:i+len(pattern)]) == string(pattern) {
+			return i
+		}
+	}
+
+	return -1
+}
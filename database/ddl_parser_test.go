@@ -0,0 +1,312 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDdl(t *testing.T) {
+	t.Run("MySQL dump", func(t *testing.T) {
+		// Arrange
+		ddl := "/*!40101 SET NAMES utf8mb4 */;\n" +
+			"DROP TABLE IF EXISTS `order`;\n" +
+			"CREATE TABLE `order` (\n" +
+			"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+			"  `customer_id` int DEFAULT NULL COMMENT 'the \"buyer\"',\n" +
+			"  `state` enum('new','paid') CHARACTER SET utf8mb4 NOT NULL,\n" +
+			"  `total` decimal(10,2) NOT NULL,\n" +
+			"  PRIMARY KEY (`id`),\n" +
+			"  KEY `fk_customer` (`customer_id`),\n" +
+			"  CONSTRAINT `fk_customer` FOREIGN KEY (`customer_id`) REFERENCES `customer` (`id`)\n" +
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;"
+
+		// Act
+		model := parseDdl(ddl)
+
+		// Assert
+		assert.Len(t, model.tables, 1)
+		table := model.tables[0]
+		assert.Equal(t, TableDetail{Schema: ddlDefaultSchema, Name: "order"}, table.detail)
+		assert.Equal(t, []ddlColumn{
+			{name: "id", dataType: "int", notNull: true, isAutoIncrement: true},
+			{name: "customer_id", dataType: "int", comment: `the "buyer"`},
+			{name: "state", dataType: "enum", enumValues: "new,paid", notNull: true},
+			{name: "total", dataType: "decimal", notNull: true},
+		}, table.columns)
+		assert.Equal(t, []string{"id"}, table.primaryKeys)
+		assert.Equal(t, []ddlForeignKey{{name: "fk_customer", columns: []string{"customer_id"}, pkTable: []string{"customer"}}}, table.foreignKeys)
+	})
+
+	t.Run("MSSQL script", func(t *testing.T) {
+		// Arrange
+		ddl := `
+CREATE TABLE [dbo].[article_comment](
+	[id] [int] IDENTITY(1,1) NOT NULL,
+	[article_id] [int] NOT NULL,
+ CONSTRAINT [PK_article_comment] PRIMARY KEY CLUSTERED ([id] ASC)
+) ON [PRIMARY]
+GO
+ALTER TABLE [dbo].[article_comment] WITH CHECK ADD CONSTRAINT [FK_article] FOREIGN KEY([article_id]) REFERENCES [dbo].[article] ([id]);`
+
+		// Act
+		model := parseDdl(ddl)
+
+		// Assert
+		assert.Len(t, model.tables, 1)
+		table := model.tables[0]
+		assert.Equal(t, TableDetail{Schema: "dbo", Name: "article_comment"}, table.detail)
+		assert.Equal(t, []ddlColumn{{name: "id", dataType: "int", notNull: true, isAutoIncrement: true}, {name: "article_id", dataType: "int", notNull: true}}, table.columns)
+		assert.Equal(t, []string{"id"}, table.primaryKeys)
+		assert.Equal(t, []ddlForeignKey{{name: "FK_article", columns: []string{"article_id"}, pkTable: []string{"dbo", "article"}}}, table.foreignKeys)
+	})
+
+	t.Run("pg_dump", func(t *testing.T) {
+		// Arrange
+		ddl := `
+CREATE TYPE public.mood AS ENUM ('sad', 'happy');
+CREATE FUNCTION public.touch() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+    CREATE TABLE not_a_table (id int);
+END;
+$$;
+CREATE TABLE public.person (
+    id integer NOT NULL,
+    current_mood public.mood,
+    created_at timestamp(6) with time zone DEFAULT now() NOT NULL,
+    manager_id integer REFERENCES public.person
+);
+COMMENT ON COLUMN public.person.id IS 'unique id';
+ALTER TABLE ONLY public.person
+    ADD CONSTRAINT person_pkey PRIMARY KEY (id);`
+
+		// Act
+		model := parseDdl(ddl)
+
+		// Assert
+		assert.Len(t, model.tables, 1)
+		table := model.tables[0]
+		assert.Equal(t, TableDetail{Schema: "public", Name: "person"}, table.detail)
+		assert.Equal(t, []ddlColumn{
+			{name: "id", dataType: "integer", notNull: true},
+			{name: "current_mood", dataType: "mood", enumValues: "sad,happy"},
+			{name: "created_at", dataType: "timestamp with time zone", notNull: true, defaultValue: "now()"},
+			{name: "manager_id", dataType: "integer"},
+		}, table.columns)
+		assert.Equal(t, []string{"id"}, table.primaryKeys)
+		assert.Equal(t, []ddlForeignKey{{name: "person_manager_id_fkey", columns: []string{"manager_id"}, pkTable: []string{"public", "person"}}}, table.foreignKeys)
+		assert.Equal(t, "unique id", model.comments["public.person.id"])
+	})
+	t.Run("Identity columns", func(t *testing.T) {
+		// Arrange
+		ddl := `
+CREATE TABLE "public"."article" (
+  "id" BIGINT GENERATED BY DEFAULT AS IDENTITY NOT NULL,
+  "total" DECIMAL(10,2) GENERATED ALWAYS AS (price * amount) STORED,
+  PRIMARY KEY ("id")
+);`
+
+		// Act
+		model := parseDdl(ddl)
+
+		// Assert
+		assert.Len(t, model.tables, 1)
+		assert.Equal(t, []ddlColumn{
+			{name: "id", dataType: "bigint", notNull: true, isAutoIncrement: true},
+			{name: "total", dataType: "decimal"},
+		}, model.tables[0].columns)
+	})
+
+	t.Run("Unique keys", func(t *testing.T) {
+		// Arrange
+		ddl := `
+CREATE TABLE user_profile (
+    id int PRIMARY KEY,
+    user_id int NOT NULL UNIQUE REFERENCES users (id),
+    tenant_id int,
+    code varchar(10),
+    UNIQUE KEY uq_tenant_code (tenant_id, code)
+);
+CREATE UNIQUE INDEX user_profile_code ON ONLY public.user_profile USING btree (code);`
+
+		// Act
+		model := parseDdl(ddl)
+
+		// Assert
+		assert.Len(t, model.tables, 1)
+		table := model.tables[0]
+		assert.Equal(t, []ddlIndex{
+			{name: "user_profile_user_id_key", columns: []string{"user_id"}, isUnique: true},
+			{name: "uq_tenant_code", columns: []string{"tenant_id", "code"}, isUnique: true},
+			{name: "user_profile_code", columns: []string{"code"}, isUnique: true},
+		}, table.indexes)
+		assert.True(t, table.isUniqueKey([]string{"user_id"}))
+		assert.True(t, table.isUniqueKey([]string{"code", "tenant_id"}))
+		assert.False(t, table.isUniqueKey([]string{"tenant_id"}))
+		assert.True(t, table.isUniqueColumn("code"))
+		assert.False(t, table.isUniqueColumn("id"))
+		assert.False(t, table.isUniqueColumn("tenant_id"))
+	})
+	t.Run("Indexes", func(t *testing.T) {
+		// Arrange
+		ddl := `
+CREATE TABLE article (
+    id int,
+    title varchar(255),
+    author_id int,
+    CONSTRAINT article_pk PRIMARY KEY (id),
+    KEY idx_author (author_id)
+);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS article_title_idx ON public.article USING btree (title);
+CREATE NONCLUSTERED INDEX ON article (title, author_id);`
+
+		// Act
+		model := parseDdl(ddl)
+
+		// Assert
+		assert.Len(t, model.tables, 1)
+		table := model.tables[0]
+		assert.Equal(t, "article_pk", table.primaryKeyName)
+		assert.Equal(t, []ddlIndex{
+			{name: "idx_author", columns: []string{"author_id"}},
+			{name: "article_title_idx", columns: []string{"title"}},
+			{name: "article_title_author_id_idx", columns: []string{"title", "author_id"}},
+		}, table.indexes)
+		assert.False(t, table.isUniqueKey([]string{"title"}))
+	})
+	t.Run("Nullable columns", func(t *testing.T) {
+		// Arrange
+		ddl := `
+CREATE TABLE employee (
+    id int,
+    manager_id int REFERENCES employee (id),
+    department_id int NOT NULL,
+    name varchar(100) CHECK (name IS NOT NULL),
+    PRIMARY KEY (id)
+);`
+
+		// Act
+		model := parseDdl(ddl)
+
+		// Assert
+		assert.Len(t, model.tables, 1)
+		table := model.tables[0]
+		assert.False(t, table.isNullable("id"))
+		assert.True(t, table.isNullable("manager_id"))
+		assert.False(t, table.isNullable("department_id"))
+		assert.True(t, table.isNullable("name"))
+	})
+
+	t.Run("Check constraints", func(t *testing.T) {
+		// Arrange
+		ddl := `
+CREATE TABLE public.orders (
+    id int PRIMARY KEY,
+    status varchar(10) CHECK (status IN ('new', 'it''s paid')),
+    price numeric(10, 2),
+    discount numeric(10, 2) CHECK ((discount >= 0)),
+    CONSTRAINT price_check CHECK ((price > (0)::numeric)),
+    CHECK (discount <= "price")
+);`
+
+		// Act
+		model := parseDdl(ddl)
+
+		// Assert
+		assert.Len(t, model.tables, 1)
+		table := model.tables[0]
+		assert.Equal(t, "", table.getCheckConstraints("id"))
+		assert.Equal(t, "status IN ('new', 'it''s paid')", table.getCheckConstraints("status"))
+		assert.Equal(t, "price > (0)::numeric and discount <= price", table.getCheckConstraints("price"))
+		assert.Equal(t, "discount >= 0 and discount <= price", table.getCheckConstraints("discount"))
+		assert.True(t, table.isNullable("status"))
+	})
+
+	t.Run("Default values", func(t *testing.T) {
+		// Arrange
+		ddl := `
+CREATE TABLE account (
+    id int NOT NULL DEFAULT nextval('account_id_seq'::regclass),
+    balance numeric(10, 2) DEFAULT (-1.5) NOT NULL,
+    state varchar(10) DEFAULT 'it''s new' CHECK (state <> ''),
+    updated_at datetime DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at datetime DEFAULT NULL
+);`
+
+		// Act
+		model := parseDdl(ddl)
+
+		// Assert
+		assert.Len(t, model.tables, 1)
+		columns := model.tables[0].columns
+		assert.Len(t, columns, 5)
+		assert.Equal(t, "nextval('account_id_seq'::regclass)", columns[0].defaultValue)
+		assert.True(t, columns[0].notNull)
+		assert.Equal(t, "-1.5", columns[1].defaultValue)
+		assert.True(t, columns[1].notNull)
+		assert.Equal(t, "'it''s new'", columns[2].defaultValue)
+		assert.Equal(t, []string{"state <> ''"}, columns[2].checks)
+		assert.Equal(t, "CURRENT_TIMESTAMP", columns[3].defaultValue)
+		assert.Equal(t, "", columns[4].defaultValue)
+	})
+
+	t.Run("Identity columns", func(t *testing.T) {
+		// Arrange
+		ddl := `
+CREATE TABLE account (
+    id bigserial PRIMARY KEY,
+    number int GENERATED ALWAYS AS IDENTITY,
+    legacy_id integer PRIMARY KEY AUTOINCREMENT,
+    balance numeric(10, 2) NOT NULL
+);`
+
+		// Act
+		model := parseDdl(ddl)
+
+		// Assert
+		table := model.tables[0]
+		assert.True(t, table.isAutoIncrement("id"))
+		assert.True(t, table.isAutoIncrement("number"))
+		assert.True(t, table.isAutoIncrement("legacy_id"))
+		assert.False(t, table.isAutoIncrement("balance"))
+		assert.False(t, table.isAutoIncrement("missing"))
+	})
+
+	t.Run("Partitions", func(t *testing.T) {
+		// Arrange
+		ddl := `
+CREATE TABLE public.measurement (
+    city_id int NOT NULL,
+    logdate date NOT NULL
+)
+PARTITION BY RANGE (logdate);
+CREATE TABLE public.measurement_y2024 (
+    city_id int NOT NULL,
+    logdate date NOT NULL
+);
+ALTER TABLE ONLY public.measurement ATTACH PARTITION public.measurement_y2024 FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');`
+
+		// Act
+		model := parseDdl(ddl)
+
+		// Assert
+		assert.Len(t, model.tables, 2)
+		assert.Equal(t, "", model.tables[0].partitionOf)
+		assert.Equal(t, "measurement", model.tables[1].partitionOf)
+	})
+
+	t.Run("Inheritance", func(t *testing.T) {
+		// Arrange
+		ddl := `
+CREATE TABLE city (name text, population real);
+CREATE TABLE capital (state char(2)) INHERITS (city, public.landmark);`
+
+		// Act
+		model := parseDdl(ddl)
+
+		// Assert
+		assert.Len(t, model.tables, 2)
+		assert.Nil(t, model.tables[0].inherits)
+		assert.Equal(t, [][]string{{"city"}, {"public", "landmark"}}, model.tables[1].inherits)
+	})
+}
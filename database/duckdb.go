@@ -0,0 +1,451 @@
+//go:build duckdb
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"regexp"
+	"strings"
+
+	_ "github.com/marcboeker/go-duckdb"
+)
+
+var (
+	// duckDbReferencesRegex matches the referenced table of the constraint text of foreign keys, e.g.
+	// FOREIGN KEY (article_id) REFERENCES article(id)
+	duckDbReferencesRegex = regexp.MustCompile(`(?i)REFERENCES\s+(?:"?[^"(.]+"?\.)?"?([^"(]+?)"?\s*\(`)
+	duckDbEnumValueRegex  = regexp.MustCompile(`'((?:[^']|'')*)'`)
+	// duckDbIndexColumnsRegex matches the column list of a CREATE INDEX statement
+	duckDbIndexColumnsRegex = regexp.MustCompile(`(?s)\((.*)\)\s*;?\s*$`)
+)
+
+// duckDbConnector reads the tables of a DuckDB database file, which is opened read-only (unless the access_mode is
+// given). It is only available in builds with the duckdb tag, as the driver requires cgo. The bundled DuckDB version
+// (0.9) has no comments.
+type duckDbConnector baseConnector
+
+// duckDbConstraintRow is a primary key, unique constraint or foreign key of duckdb_constraints(), which names neither
+// the constraint nor the referenced table of foreign keys (only in the constraint text)
+type duckDbConstraintRow struct {
+	schema         string
+	table          string
+	constraintType string
+	constraintText string
+	columnNames    []string
+}
+
+func init() {
+	RegisterConnector("duckdb", newDuckDbConnector)
+}
+
+func newDuckDbConnector(connectionString string, _ io.Closer, options ConnectorOptions) (Connector, error) {
+	return &duckDbConnector{
+		dbType:           DuckDb,
+		connectionString: addQueryParameters(strings.ReplaceAll(connectionString, "duckdb://", ""), []queryParameter{{"access_mode", "READ_ONLY"}}),
+		dataTypes:        options.DataTypes,
+	}, nil
+}
+
+func (c *duckDbConnector) GetDbType() DbType {
+	return c.dbType
+}
+
+func (c *duckDbConnector) Connect(ctx context.Context) error {
+	db, err := sql.Open(c.dbType.String(), c.connectionString)
+	if err != nil {
+		return err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	c.db = db
+	return nil
+}
+
+func (c *duckDbConnector) Close() {
+	closeConnection(c.db, c.tunnel)
+}
+
+func (c *duckDbConnector) GetSchemas(ctx context.Context) ([]string, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select schema_name
+		from duckdb_schemas()
+		where database_name = current_database()
+		  and schema_name not in ('information_schema', 'pg_catalog')
+		order by schema_name`)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err = rows.Scan(&schema); err != nil {
+			return nil, err
+		}
+
+		schemas = append(schemas, schema)
+	}
+
+	return schemas, nil
+}
+
+// GetWritePrivileges returns no privileges, as the database is opened read-only
+func (c *duckDbConnector) GetWritePrivileges(context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func (c *duckDbConnector) GetTables(ctx context.Context, schemaNames []string) ([]TableDetail, error) {
+	tableSchemaFilter, tableArgs := getMySqlSchemaFilter("schema_name", schemaNames)
+	viewSchemaFilter, viewArgs := getMySqlSchemaFilter("schema_name", schemaNames)
+	rows, err := queryContext(ctx, c.db, `
+		select schema_name, table_name, false as is_view
+		from duckdb_tables()
+		where database_name = current_database() and not temporary and `+tableSchemaFilter+`
+		union all
+		select schema_name, view_name, true
+		from duckdb_views()
+		where database_name = current_database() and not internal and not temporary and `+viewSchemaFilter+`
+		order by 1, 2`, append(tableArgs, viewArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []TableDetail
+	for rows.Next() {
+		var table TableDetail
+		if err = rows.Scan(&table.Schema, &table.Name, &table.IsView); err != nil {
+			return nil, err
+		}
+
+		table.Name = SanitizeValue(table.Name)
+
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
+
+func (c *duckDbConnector) GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	tableColumns, err := c.queryColumns(ctx, "c.schema_name = ? and c.table_name = ?", tableName.Schema, tableName.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return tableColumns[TableDetail{Schema: tableName.Schema, Name: tableName.Name}], nil
+}
+
+// GetAllColumns reads the columns of all tables of the schemas with one query
+func (c *duckDbConnector) GetAllColumns(ctx context.Context, schemaNames []string) (map[TableDetail][]ColumnResult, error) {
+	schemaFilter, args := getMySqlSchemaFilter("c.schema_name", schemaNames)
+	return c.queryColumns(ctx, schemaFilter, args...)
+}
+
+// queryColumns reads the columns of the tables that match the filter and groups them by table
+func (c *duckDbConnector) queryColumns(ctx context.Context, filter string, args ...any) (map[TableDetail][]ColumnResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select c.schema_name,
+			   c.table_name,
+			   c.column_name,
+			   c.data_type,
+			   coalesce(c.character_maximum_length, 0) as character_maximum_length,
+			   coalesce(c.numeric_precision, 0)        as numeric_precision,
+			   coalesce(c.numeric_scale, 0)            as numeric_scale,
+			   exists(select 1
+					  from duckdb_constraints() k
+					  where k.database_name = c.database_name
+						and k.schema_name = c.schema_name
+						and k.table_name = c.table_name
+						and k.constraint_type = 'PRIMARY KEY'
+						and list_contains(k.constraint_column_names, c.column_name)) as is_primary,
+			   exists(select 1
+					  from duckdb_constraints() k
+					  where k.database_name = c.database_name
+						and k.schema_name = c.schema_name
+						and k.table_name = c.table_name
+						and k.constraint_type = 'FOREIGN KEY'
+						and list_contains(k.constraint_column_names, c.column_name)) as is_foreign,
+			   exists(select 1
+					  from duckdb_constraints() k
+					  where k.database_name = c.database_name
+						and k.schema_name = c.schema_name
+						and k.table_name = c.table_name
+						and k.constraint_type = 'UNIQUE'
+						and k.constraint_column_names = [c.column_name]) as is_unique,
+			   c.is_nullable,
+			   (select coalesce(string_agg(k.expression, ' and '), '')
+				from duckdb_constraints() k
+				where k.database_name = c.database_name
+				  and k.schema_name = c.schema_name
+				  and k.table_name = c.table_name
+				  and k.constraint_type = 'CHECK'
+				  and list_contains(k.constraint_column_names, c.column_name)) as check_constraints,
+			   coalesce(c.column_default, '') as default_value,
+			   c.column_index
+		from duckdb_columns() c
+		where c.database_name = current_database()
+		  and `+filter+`
+		order by c.schema_name, c.table_name, c.column_index`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tableColumns := make(map[TableDetail][]ColumnResult)
+	for rows.Next() {
+		var table TableDetail
+		var column ColumnResult
+		if err = rows.Scan(&table.Schema, &table.Name, &column.Name, &column.DataType, &column.CharacterMaxLength, &column.NumericPrecision, &column.NumericScale, &column.IsPrimary, &column.IsForeign, &column.IsUnique, &column.IsNullable, &column.CheckConstraints, &column.DefaultValue, &column.OrdinalPosition); err != nil {
+			return nil, err
+		}
+
+		table.Name = SanitizeValue(table.Name)
+		column.Name = SanitizeValue(column.Name)
+		column.EnumValues = parseDuckDbEnumValues(column.DataType)
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, column.DataType)
+		column.SequenceName = parsePostgresSequenceName(column.DefaultValue)
+
+		tableColumns[table] = append(tableColumns[table], column)
+	}
+
+	return tableColumns, nil
+}
+
+func (c *duckDbConnector) GetConstraints(ctx context.Context, tableName TableDetail) ([]ConstraintResult, error) {
+	// foreign keys cannot reference tables of other schemas in DuckDB
+	constraints, err := c.GetAllConstraints(ctx, []string{tableName.Schema})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ConstraintResult
+	for _, constraint := range constraints {
+		if constraint.FkTable == tableName.Name || constraint.PkTable == tableName.Name {
+			result = append(result, constraint)
+		}
+	}
+
+	return result, nil
+}
+
+// GetAllConstraints reads the foreign keys of all tables of the schemas, which are derived from the keys and the
+// nullable columns of the schemas
+func (c *duckDbConnector) GetAllConstraints(ctx context.Context, schemaNames []string) ([]ConstraintResult, error) {
+	constraintRows, err := c.queryConstraintRows(ctx, schemaNames)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaFilter, args := getMySqlSchemaFilter("schema_name", schemaNames)
+	rows, err := queryContext(ctx, c.db, `
+		select schema_name, table_name, column_name
+		from duckdb_columns()
+		where database_name = current_database() and is_nullable and `+schemaFilter, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	nullableColumns := make(map[string]bool)
+	for rows.Next() {
+		var schema, table, column string
+		if err = rows.Scan(&schema, &table, &column); err != nil {
+			return nil, err
+		}
+
+		nullableColumns[schema+"."+table+"."+column] = true
+	}
+
+	return getDuckDbConstraints(constraintRows, nullableColumns), nil
+}
+
+func (c *duckDbConnector) queryConstraintRows(ctx context.Context, schemaNames []string) ([]duckDbConstraintRow, error) {
+	schemaFilter, args := getMySqlSchemaFilter("schema_name", schemaNames)
+	rows, err := queryContext(ctx, c.db, `
+		select schema_name, table_name, constraint_type, constraint_text, constraint_column_names
+		from duckdb_constraints()
+		where database_name = current_database()
+		  and constraint_type in ('PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY')
+		  and `+schemaFilter+`
+		order by schema_name, table_name, constraint_index`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var constraintRows []duckDbConstraintRow
+	for rows.Next() {
+		var row duckDbConstraintRow
+		var columnNames any
+		if err = rows.Scan(&row.schema, &row.table, &row.constraintType, &row.constraintText, &columnNames); err != nil {
+			return nil, err
+		}
+
+		values, _ := columnNames.([]any)
+		for _, value := range values {
+			if columnName, ok := value.(string); ok {
+				row.columnNames = append(row.columnNames, columnName)
+			}
+		}
+
+		constraintRows = append(constraintRows, row)
+	}
+
+	return constraintRows, nil
+}
+
+// GetIndexes reads the primary key and unique constraints and the indexes, whose columns are taken from the CREATE
+// INDEX statement
+func (c *duckDbConnector) GetIndexes(ctx context.Context, tableName TableDetail) ([]IndexResult, error) {
+	constraintRows, err := c.queryConstraintRows(ctx, []string{tableName.Schema})
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []IndexResult
+	for _, row := range constraintRows {
+		if row.table != tableName.Name || row.constraintType == "FOREIGN KEY" {
+			continue
+		}
+
+		indexes = append(indexes, getDuckDbKeyIndex(row))
+	}
+
+	rows, err := queryContext(ctx, c.db, `
+		select index_name, is_unique, is_primary, coalesce(sql, '')
+		from duckdb_indexes()
+		where database_name = current_database() and schema_name = ? and table_name = ?
+		order by index_name`, tableName.Schema, tableName.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var index IndexResult
+		var createStatement string
+		if err = rows.Scan(&index.Name, &index.IsUnique, &index.IsPrimary, &createStatement); err != nil {
+			return nil, err
+		}
+
+		index.ColumnNames = parseDuckDbIndexColumns(createStatement)
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
+}
+
+func (c *duckDbConnector) GetSampleRows(ctx context.Context, tableName TableDetail, columnNames []string, limit int) ([][]string, error) {
+	return querySampleRows(ctx, c.db, c.dbType, tableName, columnNames, limit)
+}
+
+// getDuckDbConstraints returns one result per column of the foreign keys. The constraint names follow the naming of
+// postgres (e.g. article_label_article_id_fkey), as DuckDB does not name the constraints.
+func getDuckDbConstraints(rows []duckDbConstraintRow, nullableColumns map[string]bool) []ConstraintResult {
+	var constraints []ConstraintResult
+	for _, row := range rows {
+		if row.constraintType != "FOREIGN KEY" {
+			continue
+		}
+
+		match := duckDbReferencesRegex.FindStringSubmatch(row.constraintText)
+		if match == nil {
+			continue
+		}
+
+		var primaryKeyColumns []string
+		isUnique := false
+		for _, key := range rows {
+			if key.schema != row.schema || key.table != row.table || key.constraintType == "FOREIGN KEY" {
+				continue
+			}
+
+			if key.constraintType == "PRIMARY KEY" {
+				primaryKeyColumns = key.columnNames
+			}
+
+			isUnique = isUnique || sameColumns(key.columnNames, row.columnNames)
+		}
+
+		for _, columnName := range row.columnNames {
+			constraints = append(constraints, ConstraintResult{
+				FkTable:        row.table,
+				FkSchema:       row.schema,
+				PkTable:        match[1],
+				PkSchema:       row.schema,
+				ConstraintName: row.table + "_" + strings.Join(row.columnNames, "_") + "_fkey",
+				ColumnName:     columnName,
+				IsPrimary:      containsString(primaryKeyColumns, columnName),
+				HasMultiplePK:  len(primaryKeyColumns) > 1,
+				IsUnique:       isUnique,
+				IsNullable:     nullableColumns[row.schema+"."+row.table+"."+columnName],
+			})
+		}
+	}
+
+	return constraints
+}
+
+// getDuckDbKeyIndex returns the index of a primary key or unique constraint, which is named like in postgres
+func getDuckDbKeyIndex(row duckDbConstraintRow) IndexResult {
+	if row.constraintType == "PRIMARY KEY" {
+		return IndexResult{Name: row.table + "_pkey", ColumnNames: row.columnNames, IsUnique: true, IsPrimary: true}
+	}
+
+	return IndexResult{Name: row.table + "_" + strings.Join(row.columnNames, "_") + "_key", ColumnNames: row.columnNames, IsUnique: true}
+}
+
+// parseDuckDbEnumValues returns the values of enum data types, e.g. ENUM('draft', 'published') -> draft,published
+func parseDuckDbEnumValues(dataType string) string {
+	if !strings.HasPrefix(strings.ToUpper(dataType), "ENUM(") {
+		return ""
+	}
+
+	var values []string
+	for _, match := range duckDbEnumValueRegex.FindAllStringSubmatch(dataType, -1) {
+		values = append(values, strings.ReplaceAll(match[1], "''", "'"))
+	}
+
+	return strings.Join(values, ",")
+}
+
+// parseDuckDbIndexColumns returns the columns (or expressions) of a CREATE INDEX statement
+func parseDuckDbIndexColumns(createStatement string) []string {
+	match := duckDbIndexColumnsRegex.FindStringSubmatch(createStatement)
+	if match == nil {
+		return nil
+	}
+
+	var columnNames []string
+	depth, start := 0, 0
+	for i, char := range match[1] {
+		switch char {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				columnNames = append(columnNames, SanitizeValue(strings.Trim(strings.TrimSpace(match[1][start:i]), `"`)))
+				start = i + 1
+			}
+		}
+	}
+
+	return append(columnNames, SanitizeValue(strings.Trim(strings.TrimSpace(match[1][start:]), `"`)))
+}
+
+func sameColumns(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, column := range b {
+		if !containsString(a, column) {
+			return false
+		}
+	}
+
+	return true
+}
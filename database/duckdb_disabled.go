@@ -0,0 +1,17 @@
+//go:build !duckdb
+
+package database
+
+import (
+	"fmt"
+	"io"
+)
+
+func init() {
+	RegisterConnector("duckdb", newDuckDbConnector)
+}
+
+// newDuckDbConnector fails in builds without the duckdb tag, as the DuckDB driver requires cgo
+func newDuckDbConnector(_ string, _ io.Closer, _ ConnectorOptions) (Connector, error) {
+	return nil, fmt.Errorf("duckdb requires a build with cgo and the duckdb tag (go install -tags duckdb): %w", ErrUnsupportedDialect)
+}
@@ -0,0 +1,21 @@
+//go:build !duckdb
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuckDbWithoutBuildTag(t *testing.T) {
+	// Arrange
+	connectorFactory := NewConnectorFactory()
+
+	// Act
+	connector, err := connectorFactory.NewConnector("duckdb:///path/to/db.duckdb")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnsupportedDialect)
+	assert.Nil(t, connector)
+}
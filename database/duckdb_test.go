@@ -0,0 +1,90 @@
+//go:build duckdb
+
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDuckDbConstraints(t *testing.T) {
+	t.Run("Composite foreign key", func(t *testing.T) {
+		// Arrange
+		rows := []duckDbConstraintRow{
+			{schema: "main", table: "parent", constraintType: "PRIMARY KEY", constraintText: "PRIMARY KEY(a, b)", columnNames: []string{"a", "b"}},
+			{schema: "main", table: "child", constraintType: "PRIMARY KEY", constraintText: "PRIMARY KEY(id)", columnNames: []string{"id"}},
+			{schema: "main", table: "child", constraintType: "FOREIGN KEY", constraintText: "FOREIGN KEY (a, b) REFERENCES parent(a, b)", columnNames: []string{"a", "b"}},
+		}
+		nullableColumns := map[string]bool{"main.child.b": true}
+
+		// Act
+		result := getDuckDbConstraints(rows, nullableColumns)
+
+		// Assert
+		assert.Equal(t, []ConstraintResult{
+			{FkTable: "child", FkSchema: "main", PkTable: "parent", PkSchema: "main", ConstraintName: "child_a_b_fkey", ColumnName: "a"},
+			{FkTable: "child", FkSchema: "main", PkTable: "parent", PkSchema: "main", ConstraintName: "child_a_b_fkey", ColumnName: "b", IsNullable: true},
+		}, result)
+	})
+
+	t.Run("Foreign key that is part of the primary key and unique", func(t *testing.T) {
+		// Arrange
+		rows := []duckDbConstraintRow{
+			{schema: "main", table: "profile", constraintType: "PRIMARY KEY", constraintText: "PRIMARY KEY(user_id)", columnNames: []string{"user_id"}},
+			{schema: "main", table: "profile", constraintType: "FOREIGN KEY", constraintText: `FOREIGN KEY (user_id) REFERENCES "user"(id)`, columnNames: []string{"user_id"}},
+		}
+
+		// Act
+		result := getDuckDbConstraints(rows, map[string]bool{})
+
+		// Assert
+		assert.Equal(t, []ConstraintResult{
+			{FkTable: "profile", FkSchema: "main", PkTable: "user", PkSchema: "main", ConstraintName: "profile_user_id_fkey", ColumnName: "user_id", IsPrimary: true, IsUnique: true},
+		}, result)
+	})
+}
+
+func TestParseDuckDbEnumValues(t *testing.T) {
+	testCases := []struct {
+		dataType       string
+		expectedResult string
+	}{
+		{"INTEGER", ""},
+		{"ENUM('sad', 'ok', 'happy')", "sad,ok,happy"},
+		{"ENUM('it''s', 'b')", "it's,b"},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := parseDuckDbEnumValues(testCase.dataType)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestParseDuckDbIndexColumns(t *testing.T) {
+	testCases := []struct {
+		createStatement string
+		expectedResult  []string
+	}{
+		{"create unique index idx_title on article(title);", []string{"title"}},
+		{`CREATE INDEX idx_x ON "comment"(a, "b");`, []string{"a", "b"}},
+		{"CREATE INDEX idx_expr ON t(lower(name), id);", []string{"lowername", "id"}},
+		{"", nil},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := parseDuckDbIndexColumns(testCase.createStatement)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package database
+
+import "errors"
+
+var (
+	// ErrUnsupportedDialect is returned if there is no connector for the scheme of the connection string
+	ErrUnsupportedDialect = errors.New("unsupported database")
+	// ErrWriteQuery is returned instead of executing a query that could change the database
+	ErrWriteQuery = errors.New("query is not read-only")
+)
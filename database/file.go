@@ -0,0 +1,228 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// fileConnector reads the tables from a sql file with CREATE TABLE statements (e.g. a schema dump), so that no
+// running database is needed
+type fileConnector struct {
+	dbType    DbType
+	fileName  string
+	model     *ddlModel
+	dataTypes map[string]string
+}
+
+func init() {
+	RegisterConnector("file", newFileConnector)
+}
+
+func newFileConnector(connectionString string, _ io.Closer, options ConnectorOptions) (Connector, error) {
+	return &fileConnector{
+		dbType:    File,
+		fileName:  strings.ReplaceAll(connectionString, "file://", ""),
+		dataTypes: options.DataTypes,
+	}, nil
+}
+
+func (c *fileConnector) GetDbType() DbType {
+	return c.dbType
+}
+
+func (c *fileConnector) Connect(ctx context.Context) error {
+	content, err := os.ReadFile(c.fileName)
+	if err != nil {
+		return err
+	}
+
+	c.model = parseDdl(string(content))
+	if len(c.model.tables) == 0 {
+		return errors.New("no CREATE TABLE statements found in " + c.fileName)
+	}
+
+	return nil
+}
+
+func (c *fileConnector) Close() {}
+
+func (c *fileConnector) GetSchemas(ctx context.Context) ([]string, error) {
+	var schemas []string
+	for _, table := range c.model.tables {
+		if !ddlContains(schemas, table.detail.Schema) {
+			schemas = append(schemas, table.detail.Schema)
+		}
+	}
+
+	return schemas, nil
+}
+
+// GetWritePrivileges returns no privileges, as the schema dump is only read
+func (c *fileConnector) GetWritePrivileges(context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func (c *fileConnector) GetTables(ctx context.Context, schemaNames []string) ([]TableDetail, error) {
+	var tables []TableDetail
+	for _, table := range c.model.tables {
+		if ddlContains(schemaNames, table.detail.Schema) {
+			tables = append(tables, TableDetail{
+				Schema:      table.detail.Schema,
+				Name:        SanitizeValue(table.detail.Name),
+				PartitionOf: SanitizeValue(table.partitionOf),
+			})
+		}
+	}
+
+	return tables, nil
+}
+
+// GetModificationTimes returns the modification time of the file for every table, as a changed dump may change any
+// of its tables
+func (c *fileConnector) GetModificationTimes(ctx context.Context, schemaNames []string) (map[TableDetail]time.Time, error) {
+	info, err := os.Stat(c.fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := c.GetTables(ctx, schemaNames)
+	if err != nil {
+		return nil, err
+	}
+
+	modificationTimes := make(map[TableDetail]time.Time)
+	for _, table := range tables {
+		modificationTimes[TableDetail{Schema: table.Schema, Name: table.Name}] = info.ModTime()
+	}
+
+	return modificationTimes, nil
+}
+
+func (c *fileConnector) GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	table := c.model.findTable([]string{tableName.Schema, tableName.Name}, tableName.Schema)
+	if table == nil {
+		return nil, errors.New("could not find table " + tableName.Schema + "." + tableName.Name)
+	}
+
+	var columns []ColumnResult
+	for index, column := range table.columns {
+		columnResult := ColumnResult{
+			Name:             SanitizeValue(column.name),
+			DataType:         normalizeDataType(c.dbType, c.dataTypes, column.dataType),
+			IsPrimary:        ddlContains(table.primaryKeys, column.name),
+			IsForeign:        table.hasForeignKey(column.name),
+			IsUnique:         table.isUniqueColumn(column.name),
+			EnumValues:       column.enumValues,
+			Comment:          c.getComment(table, column),
+			IsNullable:       table.isNullable(column.name),
+			CheckConstraints: table.getCheckConstraints(column.name),
+			DefaultValue:     column.defaultValue,
+			OrdinalPosition:  index + 1,
+			IsAutoIncrement:  column.isAutoIncrement,
+			SequenceName:     parsePostgresSequenceName(column.defaultValue),
+		}
+		setDataTypeParameters(&columnResult, column.dataType)
+		columns = append(columns, columnResult)
+	}
+
+	return columns, nil
+}
+
+func (c *fileConnector) GetConstraints(ctx context.Context, tableName TableDetail) ([]ConstraintResult, error) {
+	var constraints []ConstraintResult
+	for _, fkTable := range c.model.tables {
+		for _, foreignKey := range fkTable.foreignKeys {
+			pkTable := c.model.findTable(foreignKey.pkTable, fkTable.detail.Schema)
+			if pkTable == nil {
+				pkTable = &ddlTable{detail: getDdlTableDetail(foreignKey.pkTable, fkTable.detail.Schema)}
+			}
+
+			if !isSameDdlTable(fkTable.detail, tableName) && !isSameDdlTable(pkTable.detail, tableName) {
+				continue
+			}
+
+			for _, columnName := range foreignKey.columns {
+				constraints = append(constraints, ConstraintResult{
+					FkTable:        SanitizeValue(fkTable.detail.Name),
+					FkSchema:       fkTable.detail.Schema,
+					PkTable:        SanitizeValue(pkTable.detail.Name),
+					PkSchema:       pkTable.detail.Schema,
+					ConstraintName: foreignKey.name,
+					ColumnName:     SanitizeValue(columnName),
+					IsPrimary:      ddlContains(fkTable.primaryKeys, columnName),
+					HasMultiplePK:  len(fkTable.primaryKeys) > 1,
+					IsUnique:       fkTable.isUniqueKey(foreignKey.columns),
+					IsNullable:     fkTable.isNullable(columnName),
+				})
+			}
+		}
+
+		for _, parentName := range fkTable.inherits {
+			pkTable := c.model.findTable(parentName, fkTable.detail.Schema)
+			if pkTable == nil {
+				pkTable = &ddlTable{detail: getDdlTableDetail(parentName, fkTable.detail.Schema)}
+			}
+
+			if !isSameDdlTable(fkTable.detail, tableName) && !isSameDdlTable(pkTable.detail, tableName) {
+				continue
+			}
+
+			constraints = append(constraints, ConstraintResult{
+				FkTable:       SanitizeValue(fkTable.detail.Name),
+				FkSchema:      fkTable.detail.Schema,
+				PkTable:       SanitizeValue(pkTable.detail.Name),
+				PkSchema:      pkTable.detail.Schema,
+				IsInheritance: true,
+			})
+		}
+	}
+
+	return constraints, nil
+}
+
+func (c *fileConnector) GetIndexes(ctx context.Context, tableName TableDetail) ([]IndexResult, error) {
+	table := c.model.findTable([]string{tableName.Schema, tableName.Name}, tableName.Schema)
+	if table == nil {
+		return nil, errors.New("could not find table " + tableName.Schema + "." + tableName.Name)
+	}
+
+	var indexes []IndexResult
+	if len(table.primaryKeys) > 0 {
+		name := table.primaryKeyName
+		if name == "" {
+			name = table.detail.Name + "_pkey"
+		}
+		indexes = append(indexes, IndexResult{Name: name, ColumnNames: sanitizeValues(table.primaryKeys), IsUnique: true, IsPrimary: true})
+	}
+
+	for _, index := range table.indexes {
+		indexes = append(indexes, IndexResult{Name: index.name, ColumnNames: sanitizeValues(index.columns), IsUnique: index.isUnique})
+	}
+
+	return indexes, nil
+}
+
+func (c *fileConnector) getComment(table *ddlTable, column ddlColumn) string {
+	if comment, ok := c.model.comments[ddlColumnKey(table.detail, column.name)]; ok {
+		return comment
+	}
+
+	return column.comment
+}
+
+func isSameDdlTable(table TableDetail, other TableDetail) bool {
+	return strings.EqualFold(table.Schema, other.Schema) && ddlNameEquals(table.Name, other.Name)
+}
+
+func sanitizeValues(values []string) []string {
+	result := make([]string, len(values))
+	for index, value := range values {
+		result[index] = SanitizeValue(value)
+	}
+
+	return result
+}
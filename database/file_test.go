@@ -0,0 +1,170 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// getFileConnector creates a schema dump from the postgres setup scripts of the integration tests
+func getFileConnector(t *testing.T) Connector {
+	var content []string
+	for _, fileName := range []string{
+		"../test/db-table-setup.sql",
+		"../test/postgres/postgres-enum-setup.sql",
+		"../test/postgres/postgres-multiple-databases.sql",
+	} {
+		fileContent, err := os.ReadFile(fileName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content = append(content, string(fileContent))
+	}
+
+	dumpFile := filepath.Join(t.TempDir(), "schema.sql")
+	if err := os.WriteFile(dumpFile, []byte(strings.Join(content, ";\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	connector, err := NewConnectorFactory().NewConnector("file://" + dumpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = connector.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return connector
+}
+
+func TestFileConnector(t *testing.T) {
+	connector := getFileConnector(t)
+
+	t.Run("Missing file", func(t *testing.T) {
+		// Arrange
+		connector, _ := NewConnectorFactory().NewConnector("file://missing.sql")
+
+		// Act
+		err := connector.Connect(context.Background())
+
+		// Assert
+		assert.NotNil(t, err)
+	})
+
+	t.Run("GetSchemas", func(t *testing.T) {
+		// Act
+		schemas, err := connector.GetSchemas(context.Background())
+
+		// Assert
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []string{"public", "other_db"}, schemas)
+	})
+
+	t.Run("GetTables", func(t *testing.T) {
+		// Act
+		tables, err := connector.GetTables(context.Background(), []string{"public", "other_db"})
+
+		// Assert
+		expectedResult := []TableDetail{
+			{Schema: "public", Name: "article"},
+			{Schema: "public", Name: "article_detail"},
+			{Schema: "public", Name: "article_comment"},
+			{Schema: "public", Name: "label"},
+			{Schema: "public", Name: "article_label"},
+			{Schema: "public", Name: "test_1_a"},
+			{Schema: "public", Name: "test_1_b"},
+			{Schema: "public", Name: "test_2_enum"},
+			{Schema: "public", Name: "test_3_a"},
+			{Schema: "other_db", Name: "test_3_b"},
+			{Schema: "other_db", Name: "test_3_c"},
+		}
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, expectedResult, tables)
+	})
+
+	t.Run("GetModificationTimes", func(t *testing.T) {
+		// Act
+		modificationTimes, err := connector.(ModificationTimeConnector).GetModificationTimes(context.Background(), []string{"other_db"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, modificationTimes, 2)
+		assert.Contains(t, modificationTimes, TableDetail{Schema: "other_db", Name: "test_3_b"})
+		assert.False(t, modificationTimes[TableDetail{Schema: "other_db", Name: "test_3_c"}].IsZero())
+	})
+
+	t.Run("GetColumns", func(t *testing.T) {
+		// Act
+		columns, err := connector.GetColumns(context.Background(), TableDetail{Schema: "public", Name: "article_label"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, []ColumnResult{
+			{Name: "article_id", DataType: "int", IsPrimary: true, IsForeign: true, OrdinalPosition: 1},
+			{Name: "label_id", DataType: "int", IsPrimary: true, IsForeign: true, OrdinalPosition: 2},
+		}, columns)
+	})
+
+	t.Run("GetColumns with enum", func(t *testing.T) {
+		// Act
+		columns, err := connector.GetColumns(context.Background(), TableDetail{Schema: "public", Name: "test_2_enum"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, columns, 1)
+		assert.Equal(t, "apple,banana", columns[0].EnumValues)
+	})
+
+	t.Run("One-to-one relation", func(t *testing.T) {
+		// Act
+		constraintResults, err := connector.GetConstraints(context.Background(), TableDetail{Schema: "public", Name: "article_detail"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, constraintResults, 1)
+		assert.Equal(t, "fk_article_detail_id", constraintResults[0].ConstraintName)
+		assert.True(t, constraintResults[0].IsPrimary)
+		assert.False(t, constraintResults[0].HasMultiplePK)
+	})
+
+	t.Run("Multiple primary keys (Issue #8)", func(t *testing.T) {
+		// Act
+		constraintResults, err := connector.GetConstraints(context.Background(), TableDetail{Schema: "public", Name: "test_1_b"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, constraintResults, 2)
+		assert.True(t, constraintResults[0].IsPrimary)
+		assert.True(t, constraintResults[0].HasMultiplePK)
+		assert.Equal(t, "aid", constraintResults[0].ColumnName)
+		assert.Equal(t, "bid", constraintResults[1].ColumnName)
+	})
+
+	t.Run("Cross-schema constraints (Issue #23)", func(t *testing.T) {
+		// Act
+		constraintResults, err := connector.GetConstraints(context.Background(), TableDetail{Schema: "other_db", Name: "test_3_b"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, constraintResults, 1)
+		assert.Equal(t, "test_3_b", constraintResults[0].FkTable)
+		assert.Equal(t, "other_db", constraintResults[0].FkSchema)
+		assert.Equal(t, "test_3_a", constraintResults[0].PkTable)
+		assert.Equal(t, "public", constraintResults[0].PkSchema)
+	})
+
+	t.Run("GetIndexes", func(t *testing.T) {
+		// Act
+		indexes, err := connector.GetIndexes(context.Background(), TableDetail{Schema: "public", Name: "article_label"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, []IndexResult{
+			{Name: "article_label_pkey", ColumnNames: []string{"article_id", "label_id"}, IsUnique: true, IsPrimary: true},
+		}, indexes)
+	})
+}
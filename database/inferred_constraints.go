@@ -0,0 +1,152 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// InferredTablePlaceholder is replaced by the referenced table name in the naming patterns (e.g. "{table}_id")
+const InferredTablePlaceholder = "{table}"
+
+// InferConstraints adds the relations that are not declared as foreign keys, but follow one of the naming patterns
+// (e.g. the column customer_id references the primary key of the table customer or customers). Only tables with
+// a single primary key column can be referenced. Inferred constraints are marked with IsInferred.
+func InferConstraints(tables []TableResult, patterns []string) ([]TableResult, error) {
+	regexes, err := getInferredColumnRegexes(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var inferredConstraints []ConstraintResult
+	for _, table := range tables {
+		for _, column := range table.Columns {
+			if column.IsForeign {
+				continue
+			}
+
+			pkTable, ok := findInferredPkTable(tables, table.Table, column.Name, regexes)
+			if !ok || (isSameTable(pkTable.Table, table.Table) && column.IsPrimary) {
+				continue
+			}
+
+			inferredConstraints = append(inferredConstraints, ConstraintResult{
+				FkTable:        table.Table.Name,
+				FkSchema:       table.Table.Schema,
+				PkTable:        pkTable.Table.Name,
+				PkSchema:       pkTable.Table.Schema,
+				ConstraintName: table.Table.Name + "_" + column.Name + "_inferred",
+				ColumnName:     column.Name,
+				IsPrimary:      column.IsPrimary,
+				HasMultiplePK:  len(getPrimaryKeyColumns(table)) > 1,
+				IsNullable:     column.IsNullable,
+				ColumnNames:    []string{column.Name},
+				IsInferred:     true,
+			})
+		}
+	}
+
+	result := make([]TableResult, len(tables))
+	for index, table := range tables {
+		result[index] = table
+		// copy the constraints, so that the original result is not modified
+		result[index].Constraints = append(ConstraintResultList{}, table.Constraints...)
+		for _, constraint := range inferredConstraints {
+			fkTable := TableDetail{Schema: constraint.FkSchema, Name: constraint.FkTable}
+			pkTable := TableDetail{Schema: constraint.PkSchema, Name: constraint.PkTable}
+			if isSameTable(fkTable, table.Table) || isSameTable(pkTable, table.Table) {
+				result[index].Constraints = result[index].Constraints.AppendIfNotExists(constraint)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// getInferredColumnRegexes converts the naming patterns to case-insensitive regular expressions, in which the
+// table name is the first group
+func getInferredColumnRegexes(patterns []string) ([]*regexp.Regexp, error) {
+	var regexes []*regexp.Regexp
+	for _, pattern := range patterns {
+		if strings.Count(pattern, InferredTablePlaceholder) != 1 {
+			return nil, fmt.Errorf("the naming pattern %q must contain %s exactly once", pattern, InferredTablePlaceholder)
+		}
+
+		parts := strings.Split(pattern, InferredTablePlaceholder)
+		regex, err := regexp.Compile("(?i)^" + regexp.QuoteMeta(parts[0]) + "(.+)" + regexp.QuoteMeta(parts[1]) + "$")
+		if err != nil {
+			return nil, err
+		}
+
+		regexes = append(regexes, regex)
+	}
+
+	return regexes, nil
+}
+
+// findInferredPkTable finds the table that is referenced by the column name. Tables of the same schema are
+// preferred and plural table names (e.g. customers for customer_id) are also taken into account.
+func findInferredPkTable(tables []TableResult, fkTable TableDetail, columnName string, regexes []*regexp.Regexp) (TableResult, bool) {
+	for _, regex := range regexes {
+		match := regex.FindStringSubmatch(columnName)
+		if match == nil {
+			continue
+		}
+
+		names := getInferredTableNames(match[1])
+		var candidate *TableResult
+		for index, table := range tables {
+			if !containsTableName(names, table.Table.Name) || len(getPrimaryKeyColumns(table)) != 1 {
+				continue
+			}
+
+			if table.Table.Schema == fkTable.Schema {
+				return table, true
+			}
+
+			if candidate == nil {
+				candidate = &tables[index]
+			}
+		}
+
+		if candidate != nil {
+			return *candidate, true
+		}
+	}
+
+	return TableResult{}, false
+}
+
+func getInferredTableNames(name string) []string {
+	names := []string{name, name + "s", name + "es"}
+	if strings.HasSuffix(strings.ToLower(name), "y") {
+		names = append(names, name[:len(name)-1]+"ies")
+	}
+
+	return names
+}
+
+func containsTableName(names []string, tableName string) bool {
+	for _, name := range names {
+		if strings.EqualFold(name, tableName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getPrimaryKeyColumns(table TableResult) []ColumnResult {
+	var columns []ColumnResult
+	for _, column := range table.Columns {
+		if column.IsPrimary {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+func isSameTable(table TableDetail, other TableDetail) bool {
+	return table.Schema == other.Schema && table.Name == other.Name
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferConstraints(t *testing.T) {
+	customer := TableResult{
+		Table:   TableDetail{Schema: "public", Name: "customers"},
+		Columns: []ColumnResult{{Name: "id", IsPrimary: true}},
+	}
+	category := TableResult{
+		Table:   TableDetail{Schema: "public", Name: "category"},
+		Columns: []ColumnResult{{Name: "id", IsPrimary: true}},
+	}
+	product := TableResult{
+		Table:   TableDetail{Schema: "public", Name: "product"},
+		Columns: []ColumnResult{{Name: "id", IsPrimary: true}, {Name: "categoryId", IsNullable: true}},
+	}
+	order := TableResult{
+		Table: TableDetail{Schema: "public", Name: "order"},
+		Columns: []ColumnResult{
+			{Name: "id", IsPrimary: true},
+			{Name: "customer_id"},
+			{Name: "category_id", IsForeign: true},
+			{Name: "unknown_id"},
+		},
+		Constraints: []ConstraintResult{{FkTable: "order", FkSchema: "public", PkTable: "category", PkSchema: "public", ConstraintName: "fk_category", ColumnName: "category_id"}},
+	}
+
+	t.Run("Infers the relations of columns that are no foreign keys", func(t *testing.T) {
+		// Act
+		result, err := InferConstraints([]TableResult{customer, order}, []string{"{table}_id"})
+
+		// Assert
+		assert.Nil(t, err)
+		expectedConstraint := ConstraintResult{
+			FkTable:        "order",
+			FkSchema:       "public",
+			PkTable:        "customers",
+			PkSchema:       "public",
+			ConstraintName: "order_customer_id_inferred",
+			ColumnName:     "customer_id",
+			ColumnNames:    []string{"customer_id"},
+			IsInferred:     true,
+		}
+		assert.Equal(t, ConstraintResultList{expectedConstraint}, result[0].Constraints)
+		assert.Equal(t, ConstraintResultList{order.Constraints[0], expectedConstraint}, result[1].Constraints)
+		assert.Len(t, order.Constraints, 1)
+	})
+
+	t.Run("Uses all naming patterns", func(t *testing.T) {
+		// Act
+		result, err := InferConstraints([]TableResult{category, product}, []string{"{table}_id", "{table}Id"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, result[0].Constraints, 1)
+		assert.Equal(t, "categoryId", result[0].Constraints[0].ColumnName)
+		assert.Equal(t, "category", result[0].Constraints[0].PkTable)
+		assert.True(t, result[0].Constraints[0].IsNullable)
+	})
+
+	t.Run("Invalid naming pattern", func(t *testing.T) {
+		// Act
+		result, err := InferConstraints([]TableResult{customer, order}, []string{"fk_id"})
+
+		// Assert
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestGetInferredTableNames(t *testing.T) {
+	assert.Equal(t, []string{"customer", "customers", "customeres"}, getInferredTableNames("customer"))
+	assert.Equal(t, []string{"category", "categorys", "categoryes", "categories"}, getInferredTableNames("category"))
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mariaDbConnector reuses the mysql connector, but reads the MariaDB specific column metadata
+type mariaDbConnector struct {
+	mySqlConnector
+}
+
+func init() {
+	// MariaDB uses the mysql driver, it is registered under its own name so that the DbType can be used to connect
+	sql.Register(MariaDb.String(), &mysql.MySQLDriver{})
+	RegisterConnector("mariadb", newMariaDbConnector)
+}
+
+func newMariaDbConnector(connectionString string, tunnel io.Closer, options ConnectorOptions) (Connector, error) {
+	return &mariaDbConnector{mySqlConnector{
+		dbType:           MariaDb,
+		connectionString: strings.ReplaceAll(connectionString, "mariadb://", ""),
+		tunnel:           tunnel,
+		dataTypes:        options.DataTypes,
+	}}, nil
+}
+
+func (c *mariaDbConnector) GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	tableColumns, err := c.queryColumns(ctx, "c.table_name = ? and c.TABLE_SCHEMA = ?", tableName.Name, tableName.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return tableColumns[TableDetail{Schema: tableName.Schema, Name: tableName.Name}], nil
+}
+
+// GetAllColumns reads the columns of all tables of the schemas with one query. It needs to be overwritten, as the
+// mysql connector does not read the MariaDB specific column metadata.
+func (c *mariaDbConnector) GetAllColumns(ctx context.Context, schemaNames []string) (map[TableDetail][]ColumnResult, error) {
+	schemaFilter, args := getMySqlSchemaFilter("c.TABLE_SCHEMA", schemaNames)
+	return c.queryColumns(ctx, schemaFilter, args...)
+}
+
+// queryColumns reads the columns of the tables that match the filter and groups them by table
+func (c *mariaDbConnector) queryColumns(ctx context.Context, filter string, args ...any) (map[TableDetail][]ColumnResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select c.TABLE_SCHEMA,
+			   c.TABLE_NAME,
+			   c.column_name,
+			   c.data_type,
+			   coalesce(c.character_maximum_length, 0) as character_maximum_length,
+			   coalesce(c.numeric_precision, 0)        as numeric_precision,
+			   coalesce(c.numeric_scale, 0)            as numeric_scale,
+			   (select count(*) > 0
+				from information_schema.KEY_COLUMN_USAGE
+				where table_name = c.table_name
+				  and column_name = c.column_name
+				  and constraint_name = 'PRIMARY')        as is_primary,
+			   (select count(*) > 0
+				from information_schema.key_column_usage cu
+						 left join information_schema.table_constraints tc on tc.constraint_name = cu.constraint_name
+				where cu.column_name = c.column_name
+				  and cu.table_name = c.table_name
+				  and tc.constraint_type = 'FOREIGN KEY') as is_foreign,
+			   (select count(*) > 0
+				from information_schema.STATISTICS s
+				where s.TABLE_SCHEMA = c.TABLE_SCHEMA
+				  and s.TABLE_NAME = c.TABLE_NAME
+				  and s.COLUMN_NAME = c.COLUMN_NAME
+				  and s.NON_UNIQUE = 0
+				  and s.INDEX_NAME <> 'PRIMARY'
+				  -- only the unique keys of the single column
+				  and not exists(select 1
+								 from information_schema.STATISTICS s2
+								 where s2.TABLE_SCHEMA = s.TABLE_SCHEMA
+								   and s2.TABLE_NAME = s.TABLE_NAME
+								   and s2.INDEX_NAME = s.INDEX_NAME
+								   and s2.SEQ_IN_INDEX > 1)) as is_unique,
+        case when c.data_type = 'enum' then REPLACE(REPLACE(REPLACE(REPLACE(c.column_type, 'enum', ''), '\'', ''), '(', ''), ')', '') else '' end as enum_values,
+		c.column_comment as comment,
+		c.is_generated = 'ALWAYS' as is_generated,
+		c.extra like '%auto_increment%' as is_auto_increment,
+		case when c.column_default like 'nextval(%' then c.column_default else '' end as sequence_default,
+		c.is_nullable = 'YES' as is_nullable,
+		(select coalesce(group_concat(cc.CHECK_CLAUSE order by cc.CONSTRAINT_NAME separator ' and '), '')
+		 from information_schema.TABLE_CONSTRAINTS tc
+				  inner join information_schema.CHECK_CONSTRAINTS cc
+							 on cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA and cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		 where tc.TABLE_SCHEMA = c.TABLE_SCHEMA
+		   and tc.TABLE_NAME = c.TABLE_NAME
+		   and tc.CONSTRAINT_TYPE = 'CHECK'
+		   -- the check clauses contain the column names quoted with backticks (char 96)
+		   and cc.CHECK_CLAUSE like concat('%', char(96 using utf8mb4), c.COLUMN_NAME, char(96 using utf8mb4), '%')) as check_constraints,
+		coalesce(c.column_default, '') as default_value,
+		c.ordinal_position
+		from information_schema.columns c
+		where `+filter+`
+		order by c.TABLE_SCHEMA, c.TABLE_NAME, c.ordinal_position;
+		`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tableColumns := make(map[TableDetail][]ColumnResult)
+	for rows.Next() {
+		var table TableDetail
+		var column ColumnResult
+		var sequenceDefault string
+		if err = rows.Scan(&table.Schema, &table.Name, &column.Name, &column.DataType, &column.CharacterMaxLength, &column.NumericPrecision, &column.NumericScale, &column.IsPrimary, &column.IsForeign, &column.IsUnique, &column.EnumValues, &column.Comment, &column.IsGenerated, &column.IsAutoIncrement, &sequenceDefault, &column.IsNullable, &column.CheckConstraints, &column.DefaultValue, &column.OrdinalPosition); err != nil {
+			return nil, err
+		}
+
+		column.Name = SanitizeValue(column.Name)
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, column.DataType)
+		column.SequenceName = parseMariaDbSequenceName(sequenceDefault)
+
+		table.Name = SanitizeValue(table.Name)
+		tableColumns[table] = append(tableColumns[table], column)
+	}
+
+	return tableColumns, nil
+}
+
+var mariaDbSequenceRegex = regexp.MustCompile(`(?i)^nextval\((.+)\)$`)
+
+// parseMariaDbSequenceName extracts the sequence name of a column default like nextval(`schema`.`sequence`)
+func parseMariaDbSequenceName(columnDefault string) string {
+	match := mariaDbSequenceRegex.FindStringSubmatch(strings.TrimSpace(columnDefault))
+	if match == nil {
+		return ""
+	}
+
+	return strings.ReplaceAll(match[1], "`", "")
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMariaDbEnums(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	// Arrange
+	var enumValues string
+
+	// Act
+	connector, _ := NewConnectorFactory().NewConnector(testConnectionMariaDb.connectionString)
+	if err := connector.Connect(context.Background()); err != nil {
+		logrus.Error(err)
+		t.FailNow()
+	}
+	columns, err := connector.GetColumns(context.Background(), TableDetail{Schema: "mermerd_test", Name: "test_2_enum"})
+
+	// Assert
+	for _, column := range columns {
+		if column.Name == "fruit" {
+			enumValues = column.EnumValues
+		}
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, "apple,banana", enumValues)
+}
+
+func TestMariaDbColumns(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	// Arrange
+	connector, _ := NewConnectorFactory().NewConnector(testConnectionMariaDb.connectionString)
+	if err := connector.Connect(context.Background()); err != nil {
+		logrus.Error(err)
+		t.FailNow()
+	}
+
+	// Act
+	columns, err := connector.GetColumns(context.Background(), TableDetail{Schema: "mermerd_test", Name: "test_mariadb_columns"})
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Len(t, columns, 5)
+	assert.True(t, columns[0].IsAutoIncrement)
+	assert.Equal(t, "mermerd_test.test_mariadb_sequence", columns[1].SequenceName)
+	assert.False(t, columns[3].IsGenerated)
+	assert.True(t, columns[4].IsGenerated)
+}
+
+func TestParseMariaDbSequenceName(t *testing.T) {
+	testCases := []struct {
+		columnDefault        string
+		expectedSequenceName string
+	}{
+		{"nextval(`mermerd_test`.`test_mariadb_sequence`)", "mermerd_test.test_mariadb_sequence"},
+		{"NEXTVAL(`seq`)", "seq"},
+		{"0", ""},
+		{"", ""},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := parseMariaDbSequenceName(testCase.columnDefault)
+
+			// Assert
+			assert.Equal(t, testCase.expectedSequenceName, result)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// ModificationTimeConnector is implemented by the connectors whose catalog contains the time of the last change of
+// every table (--incremental), so that only the changed tables have to be analyzed again. The keys of the times
+// only contain the schema and the name of the tables.
+type ModificationTimeConnector interface {
+	GetModificationTimes(ctx context.Context, schemaNames []string) (map[TableDetail]time.Time, error)
+}
+
+// scanModificationTimes reads the rows of schema, table name and modification time
+func scanModificationTimes(rows *sql.Rows) (map[TableDetail]time.Time, error) {
+	defer rows.Close()
+	modificationTimes := make(map[TableDetail]time.Time)
+	for rows.Next() {
+		var table TableDetail
+		var modificationTime time.Time
+		if err := rows.Scan(&table.Schema, &table.Name, &modificationTime); err != nil {
+			return nil, err
+		}
+
+		table.Name = SanitizeValue(table.Name)
+		modificationTimes[table] = modificationTime
+	}
+
+	return modificationTimes, rows.Err()
+}
@@ -1,26 +1,50 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"io"
+	"regexp"
 	"strings"
+	"time"
 
 	_ "github.com/denisenkom/go-mssqldb"
 )
 
 type mssqlConnector baseConnector
 
+func init() {
+	RegisterConnector("sqlserver", newMssqlConnector)
+}
+
+// newMssqlConnector creates the connector with the Azure AD authentication if it is configured
+func newMssqlConnector(connectionString string, tunnel io.Closer, options ConnectorOptions) (Connector, error) {
+	connector := mssqlConnector{
+		dbType:           MsSql,
+		connectionString: connectionString,
+		tunnel:           tunnel,
+		dataTypes:        options.DataTypes,
+	}
+	if options.AuthMode == AuthModeAzureAd {
+		return &mssqlAzureAdConnector{connector}, nil
+	}
+
+	return &connector, nil
+}
+
 func (c *mssqlConnector) GetDbType() DbType {
 	return c.dbType
 }
 
-func (c *mssqlConnector) Connect() error {
+func (c *mssqlConnector) Connect(ctx context.Context) error {
 	db, err := sql.Open(c.dbType.String(), c.connectionString)
 	if err != nil {
 		return err
 	}
 
-	if err := db.Ping(); err != nil {
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
 		return err
 	}
 
@@ -29,14 +53,11 @@ func (c *mssqlConnector) Connect() error {
 }
 
 func (c *mssqlConnector) Close() {
-	err := c.db.Close()
-	if err != nil {
-		fmt.Println("could not close database connection", err)
-	}
+	closeConnection(c.db, c.tunnel)
 }
 
-func (c *mssqlConnector) GetSchemas() ([]string, error) {
-	rows, err := c.db.Query("select schema_name from information_schema.schemata")
+func (c *mssqlConnector) GetSchemas(ctx context.Context) ([]string, error) {
+	rows, err := queryContext(ctx, c.db, "select schema_name from information_schema.schemata")
 	if err != nil {
 		return nil, err
 	}
@@ -54,17 +75,45 @@ func (c *mssqlConnector) GetSchemas() ([]string, error) {
 	return schemas, nil
 }
 
-func (c *mssqlConnector) GetTables(schemaNames []string) ([]TableDetail, error) {
+// GetWritePrivileges returns the effective insert, update and delete permissions of the user for the database and the
+// tables, including the permissions of the roles (e.g. db_datawriter)
+func (c *mssqlConnector) GetWritePrivileges(ctx context.Context) ([]string, error) {
+	return queryPrivileges(ctx, c.db, `
+		select permission_name + ' on database'
+		from fn_my_permissions(null, 'DATABASE')
+		where permission_name in ('INSERT', 'UPDATE', 'DELETE')
+		union all
+		select p.permission_name + ' on ' + s.name + '.' + t.name
+		from sys.tables t
+				 join sys.schemas s on s.schema_id = t.schema_id
+				 cross apply fn_my_permissions(quotename(s.name) + '.' + quotename(t.name), 'OBJECT') p
+		where p.subentity_name = ''
+		  and p.permission_name in ('INSERT', 'UPDATE', 'DELETE')`)
+}
+
+// GetMissingPermissions returns the schemas whose tables are not visible, as the user has neither the VIEW DEFINITION
+// or SELECT permission on the schema nor a permission on one of its tables (a schema that does not exist cannot be
+// told apart)
+func (c *mssqlConnector) GetMissingPermissions(ctx context.Context, schemaNames []string) ([]string, error) {
+	return getMissingSchemaPermissions(ctx, c.db, schemaNames, `
+		select cast(case
+						when has_perms_by_name(@p1, 'SCHEMA', 'VIEW DEFINITION') = 1
+							or has_perms_by_name(@p1, 'SCHEMA', 'SELECT') = 1
+							or exists(select 1 from information_schema.tables where table_schema = @p1) then 1
+						else 0 end as bit)`, "VIEW DEFINITION on schema %s")
+}
+
+func (c *mssqlConnector) GetTables(ctx context.Context, schemaNames []string) ([]TableDetail, error) {
 	args := make([]any, len(schemaNames))
 	searchPlaceholder := make([]string, len(schemaNames))
 	for i, schemaName := range schemaNames {
 		args[i] = schemaName
 		searchPlaceholder[i] = fmt.Sprintf("@p%d", i+1)
 	}
-	rows, err := c.db.Query(`
-		select table_schema, table_name
+	rows, err := queryContext(ctx, c.db, `
+		select table_schema, table_name, cast(case when table_type = 'VIEW' then 1 else 0 end as bit)
 		from information_schema.tables
-		where table_type = 'BASE TABLE'
+		where table_type in ('BASE TABLE', 'VIEW')
 		  and table_schema in(`+strings.Join(searchPlaceholder, ",")+`) 
 		`, args...)
 	if err != nil {
@@ -74,7 +123,7 @@ func (c *mssqlConnector) GetTables(schemaNames []string) ([]TableDetail, error)
 	var tables []TableDetail
 	for rows.Next() {
 		var table TableDetail
-		if err = rows.Scan(&table.Schema, &table.Name); err != nil {
+		if err = rows.Scan(&table.Schema, &table.Name, &table.IsView); err != nil {
 			return nil, err
 		}
 
@@ -86,10 +135,13 @@ func (c *mssqlConnector) GetTables(schemaNames []string) ([]TableDetail, error)
 	return tables, nil
 }
 
-func (c *mssqlConnector) GetColumns(tableName TableDetail) ([]ColumnResult, error) {
-	rows, err := c.db.Query(`
+func (c *mssqlConnector) GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	rows, err := queryContext(ctx, c.db, `
 		select c.column_name,
 			   c.data_type,
+			   ISNULL(c.character_maximum_length, 0) as character_maximum_length,
+			   ISNULL(c.numeric_precision, 0)        as numeric_precision,
+			   ISNULL(c.numeric_scale, 0)            as numeric_scale,
 			   (select IIF(count(*) > 0, 1, 0)
 				from information_schema.key_column_usage cu
 						 left join information_schema.table_constraints tc on tc.constraint_name = cu.constraint_name
@@ -102,10 +154,38 @@ func (c *mssqlConnector) GetColumns(tableName TableDetail) ([]ColumnResult, erro
 				where cu.column_name = c.column_name
 				  and cu.table_name = c.table_name
 				  and tc.constraint_type = 'FOREIGN KEY') as is_foreign,
+			   (select IIF(count(*) > 0, 1, 0)
+				from sys.indexes i
+						 inner join sys.index_columns ic on ic.object_id = i.object_id and ic.index_id = i.index_id
+						 inner join sys.columns col on col.object_id = ic.object_id and col.column_id = ic.column_id
+				where i.object_id = OBJECT_ID(QUOTENAME(c.table_schema) + '.' + QUOTENAME(c.table_name))
+				  and col.name = c.column_name
+				  and i.is_unique = 1
+				  and i.is_primary_key = 0
+				  -- filtered indexes are not unique for all rows
+				  and i.has_filter = 0
+				  and ic.is_included_column = 0
+				  -- only the unique keys of the single column
+				  and (select count(*)
+					   from sys.index_columns ic2
+					   where ic2.object_id = i.object_id
+						 and ic2.index_id = i.index_id
+						 and ic2.is_included_column = 0) = 1) as is_unique,
 			   (select ISNULL(ep.value, '') from sys.tables t
 			      inner join sys.columns col on col.object_id = t.object_id and col.name = c.column_name
 				  left join sys.extended_properties ep on ep.major_id = t.object_id and ep.minor_id = col.column_id
-				  where t.name = c.table_name and SCHEMA_NAME(t.schema_id) = c.TABLE_SCHEMA) as comment
+				  where t.name = c.table_name and SCHEMA_NAME(t.schema_id) = c.TABLE_SCHEMA) as comment,
+			   ISNULL(COLUMNPROPERTY(OBJECT_ID(QUOTENAME(c.table_schema) + '.' + QUOTENAME(c.table_name)), c.column_name, 'IsIdentity'), 0) as is_auto_increment,
+			   IIF(c.is_nullable = 'YES', 1, 0) as is_nullable,
+			   (select ISNULL(STRING_AGG(cc.check_clause, ' and ') WITHIN GROUP (order by cc.constraint_name), '')
+				from information_schema.constraint_column_usage ccu
+						 inner join information_schema.check_constraints cc
+									on cc.constraint_schema = ccu.constraint_schema and cc.constraint_name = ccu.constraint_name
+				where ccu.table_schema = c.table_schema
+				  and ccu.table_name = c.table_name
+				  and ccu.column_name = c.column_name) as check_constraints,
+			   ISNULL(c.column_default, '') as default_value,
+			   c.ordinal_position
 		from information_schema.columns c
 		where c.table_name = @p1 and c.TABLE_SCHEMA = @p2
 		order by c.ordinal_position;
@@ -117,12 +197,14 @@ func (c *mssqlConnector) GetColumns(tableName TableDetail) ([]ColumnResult, erro
 	var columns []ColumnResult
 	for rows.Next() {
 		var column ColumnResult
-		if err = rows.Scan(&column.Name, &column.DataType, &column.IsPrimary, &column.IsForeign, &column.Comment); err != nil {
+		if err = rows.Scan(&column.Name, &column.DataType, &column.CharacterMaxLength, &column.NumericPrecision, &column.NumericScale, &column.IsPrimary, &column.IsForeign, &column.IsUnique, &column.Comment, &column.IsAutoIncrement, &column.IsNullable, &column.CheckConstraints, &column.DefaultValue, &column.OrdinalPosition); err != nil {
 			return nil, err
 		}
 
 		column.Name = SanitizeValue(column.Name)
-		column.DataType = SanitizeValue(column.DataType)
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, column.DataType)
+		column.DefaultValue = trimMssqlParenthesis(column.DefaultValue)
+		column.SequenceName = parseMssqlSequenceName(column.DefaultValue)
 
 		columns = append(columns, column)
 	}
@@ -130,8 +212,8 @@ func (c *mssqlConnector) GetColumns(tableName TableDetail) ([]ColumnResult, erro
 	return columns, nil
 }
 
-func (c *mssqlConnector) GetConstraints(tableName TableDetail) ([]ConstraintResult, error) {
-	rows, err := c.db.Query(`
+func (c *mssqlConnector) GetConstraints(ctx context.Context, tableName TableDetail) ([]ConstraintResult, error) {
+	rows, err := queryContext(ctx, c.db, `
 select fk.table_name,
        fk.table_schema,
        pk.table_name,
@@ -142,24 +224,61 @@ select fk.table_name,
                (select IIF(tc.constraint_type is not null, 'true', 'false')
                 from information_schema.key_column_usage kc
                          inner join information_schema.key_column_usage kc2
-                                    ON kc2.column_name = kc.column_name and kc2.table_name = kc.table_name
+                                    ON kc2.column_name = kc.column_name and kc2.table_name = kc.table_name and
+                                       kc2.table_schema = kc.table_schema
                          inner join information_schema.table_constraints tc
-                                    on kc2.constraint_name = tc.constraint_name and
+                                    on kc2.constraint_schema = tc.constraint_schema and
+                                       kc2.constraint_name = tc.constraint_name and
                                        tc.constraint_type = 'PRIMARY KEY'
-                where kc.constraint_name = c.constraint_name
+                where kc.constraint_schema = c.constraint_schema
+                  and kc.constraint_name = c.constraint_name
                   and kc.column_name = kcu.column_name), 'false') "isPrimary",
        (select IIF(COUNT(*) > 1, 'true', 'false')
         from information_schema.table_constraints tc
                  -- one constraint can have multiple columns
                  inner join information_schema.key_column_usage kc
-                            on kc.constraint_name = tc.constraint_name
-        where tc.table_name = fk.table_name
-          and tc.constraint_type = 'PRIMARY KEY') "hasMultiplePk"
+                            on kc.constraint_schema = tc.constraint_schema and kc.constraint_name = tc.constraint_name
+        where tc.table_schema = fk.table_schema
+          and tc.table_name = fk.table_name
+          and tc.constraint_type = 'PRIMARY KEY') "hasMultiplePk",
+       -- the foreign key is unique if there is a unique index with exactly the same columns
+       IIF(exists(select i.index_id
+                  from sys.indexes i
+                  where i.object_id = object_id(quotename(fk.table_schema) + '.' + quotename(fk.table_name))
+                    and i.is_unique = 1
+                    and not exists(select ic.column_id
+                                   from sys.index_columns ic
+                                   where ic.object_id = i.object_id
+                                     and ic.index_id = i.index_id
+                                     and ic.is_included_column = 0
+                                   except
+                                   select fkc.parent_column_id
+                                   from sys.foreign_key_columns fkc
+                                   where fkc.constraint_object_id = object_id(quotename(c.constraint_schema) + '.' + quotename(c.constraint_name)))
+                    and not exists(select fkc.parent_column_id
+                                   from sys.foreign_key_columns fkc
+                                   where fkc.constraint_object_id = object_id(quotename(c.constraint_schema) + '.' + quotename(c.constraint_name))
+                                   except
+                                   select ic.column_id
+                                   from sys.index_columns ic
+                                   where ic.object_id = i.object_id
+                                     and ic.index_id = i.index_id
+                                     and ic.is_included_column = 0)), 'true', 'false') "isUnique",
+       coalesce(
+               (select IIF(col.is_nullable = 'YES', 'true', 'false')
+                from information_schema.columns col
+                where col.table_schema = fk.table_schema
+                  and col.table_name = fk.table_name
+                  and col.column_name = kcu.column_name), 'false') "isNullable"
 from information_schema.referential_constraints c
-         inner join information_schema.table_constraints fk on c.constraint_name = fk.constraint_name
-         inner join information_schema.table_constraints pk on c.unique_constraint_name = pk.constraint_name
-         inner join information_schema.key_column_usage kcu on c.constraint_name = kcu.constraint_name
-where c.CONSTRAINT_SCHEMA = @p1 and (fk.table_name = @p2 or pk.table_name = @p2);
+         -- the constraints are joined by schema and name, as the referenced table can be in another schema
+         inner join information_schema.table_constraints fk
+                    on c.constraint_schema = fk.constraint_schema and c.constraint_name = fk.constraint_name
+         inner join information_schema.table_constraints pk
+                    on c.unique_constraint_schema = pk.constraint_schema and c.unique_constraint_name = pk.constraint_name
+         inner join information_schema.key_column_usage kcu
+                    on c.constraint_schema = kcu.constraint_schema and c.constraint_name = kcu.constraint_name
+where (fk.table_schema = @p1 and fk.table_name = @p2) or (pk.table_schema = @p1 and pk.table_name = @p2);
 		`, tableName.Schema, tableName.Name)
 	if err != nil {
 		return nil, err
@@ -177,6 +296,8 @@ where c.CONSTRAINT_SCHEMA = @p1 and (fk.table_name = @p2 or pk.table_name = @p2)
 			&constraint.ColumnName,
 			&constraint.IsPrimary,
 			&constraint.HasMultiplePK,
+			&constraint.IsUnique,
+			&constraint.IsNullable,
 		)
 
 		if err != nil {
@@ -188,3 +309,89 @@ where c.CONSTRAINT_SCHEMA = @p1 and (fk.table_name = @p2 or pk.table_name = @p2)
 
 	return constraints, nil
 }
+
+// GetModificationTimes returns the latest modify_date of the tables and their constraints, which changes with every
+// DDL statement (e.g. a new column or foreign key)
+func (c *mssqlConnector) GetModificationTimes(ctx context.Context, schemaNames []string) (map[TableDetail]time.Time, error) {
+	args := make([]any, len(schemaNames))
+	searchPlaceholder := make([]string, len(schemaNames))
+	for i, schemaName := range schemaNames {
+		args[i] = schemaName
+		searchPlaceholder[i] = fmt.Sprintf("@p%d", i+1)
+	}
+	rows, err := queryContext(ctx, c.db, `
+		select s.name, o.name,
+		       case when max(c.modify_date) > o.modify_date then max(c.modify_date) else o.modify_date end
+		from sys.objects o
+		         join sys.schemas s on s.schema_id = o.schema_id
+		         left join sys.objects c on c.parent_object_id = o.object_id
+		where o.type in ('U', 'V')
+		  and s.name in(`+strings.Join(searchPlaceholder, ",")+`)
+		group by s.name, o.name, o.modify_date
+		`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanModificationTimes(rows)
+}
+
+func (c *mssqlConnector) GetIndexes(ctx context.Context, tableName TableDetail) ([]IndexResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select i.name,
+			   col.name,
+			   i.is_unique,
+			   i.is_primary_key
+		from sys.indexes i
+				 inner join sys.index_columns ic on ic.object_id = i.object_id and ic.index_id = i.index_id
+				 inner join sys.columns col on col.object_id = ic.object_id and col.column_id = ic.column_id
+		where i.object_id = object_id(quotename(@p2) + '.' + quotename(@p1))
+		  and i.name is not null
+		  and ic.is_included_column = 0
+		order by i.name, ic.key_ordinal;
+		`, tableName.Name, tableName.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
+
+func (c *mssqlConnector) GetSampleRows(ctx context.Context, tableName TableDetail, columnNames []string, limit int) ([][]string, error) {
+	return querySampleRows(ctx, c.db, c.dbType, tableName, columnNames, limit)
+}
+
+// trimMssqlParenthesis removes the parenthesis that SQL Server adds around default values (e.g. ((0)) or (getdate()))
+func trimMssqlParenthesis(value string) string {
+	for strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		depth := 0
+		for index, r := range value {
+			if r == '(' {
+				depth++
+			} else if r == ')' {
+				depth--
+			}
+
+			// the first parenthesis is closed before the end, e.g. (1) + (2)
+			if depth == 0 && index < len(value)-1 {
+				return value
+			}
+		}
+
+		value = value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+var mssqlSequenceRegex = regexp.MustCompile(`(?i)^next value for (.+)$`)
+
+// parseMssqlSequenceName extracts the sequence name of a column default like NEXT VALUE FOR [dbo].[sequence]
+func parseMssqlSequenceName(columnDefault string) string {
+	match := mssqlSequenceRegex.FindStringSubmatch(strings.TrimSpace(columnDefault))
+	if match == nil {
+		return ""
+	}
+
+	return strings.NewReplacer("[", "", "]", "").Replace(match[1])
+}
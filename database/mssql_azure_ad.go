@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	mssql "github.com/denisenkom/go-mssqldb"
+)
+
+// azureSqlScope is the scope of the tokens that are accepted by Azure SQL
+const azureSqlScope = "https://database.windows.net/.default"
+
+// mssqlAzureAdConnector reuses the mssql connector, but authenticates with an Azure Active Directory token of the
+// DefaultAzureCredential (e.g. environment variables, managed identity or the login of the Azure CLI)
+type mssqlAzureAdConnector struct {
+	mssqlConnector
+}
+
+func (c *mssqlAzureAdConnector) Connect(ctx context.Context) error {
+	credential, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return err
+	}
+
+	// the token is requested for every new connection of the pool, which may outlive the context of Connect
+	connector, err := mssql.NewAccessTokenConnector(c.connectionString, func() (string, error) {
+		token, err := credential.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{azureSqlScope}})
+		if err != nil {
+			return "", err
+		}
+
+		return token.Token, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	db := sql.OpenDB(connector)
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	c.db = db
+	return nil
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimMssqlParenthesis(t *testing.T) {
+	testCases := []struct {
+		value         string
+		expectedValue string
+	}{
+		{"((0))", "0"},
+		{"(getdate())", "getdate()"},
+		{"(N'new')", "N'new'"},
+		{"((1)+(2))", "(1)+(2)"},
+		{"(1)+(2)", "(1)+(2)"},
+		{"", ""},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := trimMssqlParenthesis(testCase.value)
+
+			// Assert
+			assert.Equal(t, testCase.expectedValue, result)
+		})
+	}
+}
+
+func TestParseMssqlSequenceName(t *testing.T) {
+	testCases := []struct {
+		columnDefault        string
+		expectedSequenceName string
+	}{
+		{"NEXT VALUE FOR [dbo].[order_number]", "dbo.order_number"},
+		{"next value for order_number", "order_number"},
+		{"getdate()", ""},
+		{"", ""},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := parseMssqlSequenceName(testCase.columnDefault)
+
+			// Assert
+			assert.Equal(t, testCase.expectedSequenceName, result)
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"io"
 	"strings"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -10,17 +12,31 @@ import (
 
 type mySqlConnector baseConnector
 
+func init() {
+	RegisterConnector("mysql", newMySqlConnector)
+}
+
+func newMySqlConnector(connectionString string, tunnel io.Closer, options ConnectorOptions) (Connector, error) {
+	return &mySqlConnector{
+		dbType:           MySql,
+		connectionString: strings.ReplaceAll(connectionString, "mysql://", ""),
+		tunnel:           tunnel,
+		dataTypes:        options.DataTypes,
+	}, nil
+}
+
 func (c *mySqlConnector) GetDbType() DbType {
 	return c.dbType
 }
 
-func (c *mySqlConnector) Connect() error {
+func (c *mySqlConnector) Connect(ctx context.Context) error {
 	db, err := sql.Open(c.dbType.String(), c.connectionString)
 	if err != nil {
 		return err
 	}
 
-	if err := db.Ping(); err != nil {
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
 		return err
 	}
 
@@ -29,14 +45,11 @@ func (c *mySqlConnector) Connect() error {
 }
 
 func (c *mySqlConnector) Close() {
-	err := c.db.Close()
-	if err != nil {
-		fmt.Println("could not close database connection", err)
-	}
+	closeConnection(c.db, c.tunnel)
 }
 
-func (c *mySqlConnector) GetSchemas() ([]string, error) {
-	rows, err := c.db.Query("select schema_name from information_schema.schemata")
+func (c *mySqlConnector) GetSchemas(ctx context.Context) ([]string, error) {
+	rows, err := queryContext(ctx, c.db, "select schema_name from information_schema.schemata")
 	if err != nil {
 		return nil, err
 	}
@@ -54,17 +67,52 @@ func (c *mySqlConnector) GetSchemas() ([]string, error) {
 	return schemas, nil
 }
 
-func (c *mySqlConnector) GetTables(schemaNames []string) ([]TableDetail, error) {
-	args := make([]any, len(schemaNames))
-	for i, schemaName := range schemaNames {
-		args[i] = schemaName
+// GetWritePrivileges returns the insert, update and delete privileges that are granted to the user globally, for a
+// schema or for a table
+func (c *mySqlConnector) GetWritePrivileges(ctx context.Context) ([]string, error) {
+	var currentUser string
+	if err := queryRowContext(ctx, c.db, "select current_user()").Scan(&currentUser); err != nil {
+		return nil, err
 	}
-	rows, err := c.db.Query(`
-		select table_schema, table_name
+
+	// the grantee is quoted like 'user'@'host', the user name can contain an @ but the host cannot
+	user, host := currentUser, "%"
+	if separator := strings.LastIndex(currentUser, "@"); separator >= 0 {
+		user, host = currentUser[:separator], currentUser[separator+1:]
+	}
+
+	grantee := fmt.Sprintf("'%s'@'%s'", user, host)
+	return queryPrivileges(ctx, c.db, `
+		select concat(privilege_type, ' on *.*')
+		from information_schema.user_privileges
+		where grantee = ?
+		  and privilege_type in ('INSERT', 'UPDATE', 'DELETE')
+		union all
+		select concat(privilege_type, ' on ', table_schema, '.*')
+		from information_schema.schema_privileges
+		where grantee = ?
+		  and privilege_type in ('INSERT', 'UPDATE', 'DELETE')
+		union all
+		select concat(privilege_type, ' on ', table_schema, '.', table_name)
+		from information_schema.table_privileges
+		where grantee = ?
+		  and privilege_type in ('INSERT', 'UPDATE', 'DELETE')`, grantee, grantee, grantee)
+}
+
+// GetMissingPermissions returns the schemas that are not visible in the information_schema, which only contains the
+// schemas that the user has a privilege on (a schema that does not exist cannot be told apart)
+func (c *mySqlConnector) GetMissingPermissions(ctx context.Context, schemaNames []string) ([]string, error) {
+	return getMissingSchemaPermissions(ctx, c.db, schemaNames,
+		"select exists(select 1 from information_schema.schemata where schema_name = ?)", "SELECT on %s.*")
+}
+
+func (c *mySqlConnector) GetTables(ctx context.Context, schemaNames []string) ([]TableDetail, error) {
+	schemaFilter, args := getMySqlSchemaFilter("table_schema", schemaNames)
+	rows, err := queryContext(ctx, c.db, `
+		select table_schema, table_name, table_type = 'VIEW'
 		from information_schema.tables
-		where table_type = 'BASE TABLE'
-		  and table_schema in (?`+strings.Repeat(",?", len(schemaNames)-1)+`)
-		`, args...)
+		where table_type in ('BASE TABLE', 'VIEW')
+		  and `+schemaFilter, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +120,7 @@ func (c *mySqlConnector) GetTables(schemaNames []string) ([]TableDetail, error)
 	var tables []TableDetail
 	for rows.Next() {
 		var table TableDetail
-		if err = rows.Scan(&table.Schema, &table.Name); err != nil {
+		if err = rows.Scan(&table.Schema, &table.Name, &table.IsView); err != nil {
 			return nil, err
 		}
 
@@ -84,10 +132,31 @@ func (c *mySqlConnector) GetTables(schemaNames []string) ([]TableDetail, error)
 	return tables, nil
 }
 
-func (c *mySqlConnector) GetColumns(tableName TableDetail) ([]ColumnResult, error) {
-	rows, err := c.db.Query(`
-		select c.column_name,
+func (c *mySqlConnector) GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	tableColumns, err := c.queryColumns(ctx, "c.table_name = ? and c.TABLE_SCHEMA = ?", tableName.Name, tableName.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return tableColumns[TableDetail{Schema: tableName.Schema, Name: tableName.Name}], nil
+}
+
+// GetAllColumns reads the columns of all tables of the schemas with one query
+func (c *mySqlConnector) GetAllColumns(ctx context.Context, schemaNames []string) (map[TableDetail][]ColumnResult, error) {
+	schemaFilter, args := getMySqlSchemaFilter("c.TABLE_SCHEMA", schemaNames)
+	return c.queryColumns(ctx, schemaFilter, args...)
+}
+
+// queryColumns reads the columns of the tables that match the filter and groups them by table
+func (c *mySqlConnector) queryColumns(ctx context.Context, filter string, args ...any) (map[TableDetail][]ColumnResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select c.TABLE_SCHEMA,
+			   c.TABLE_NAME,
+			   c.column_name,
 			   c.data_type,
+			   coalesce(c.character_maximum_length, 0) as character_maximum_length,
+			   coalesce(c.numeric_precision, 0)        as numeric_precision,
+			   coalesce(c.numeric_scale, 0)            as numeric_scale,
 			   (select count(*) > 0
 				from information_schema.KEY_COLUMN_USAGE
 				where table_name = c.table_name
@@ -99,34 +168,74 @@ func (c *mySqlConnector) GetColumns(tableName TableDetail) ([]ColumnResult, erro
 				where cu.column_name = c.column_name
 				  and cu.table_name = c.table_name
 				  and tc.constraint_type = 'FOREIGN KEY') as is_foreign,
+			   (select count(*) > 0
+				from information_schema.STATISTICS s
+				where s.TABLE_SCHEMA = c.TABLE_SCHEMA
+				  and s.TABLE_NAME = c.TABLE_NAME
+				  and s.COLUMN_NAME = c.COLUMN_NAME
+				  and s.NON_UNIQUE = 0
+				  and s.INDEX_NAME <> 'PRIMARY'
+				  -- only the unique keys of the single column
+				  and not exists(select 1
+								 from information_schema.STATISTICS s2
+								 where s2.TABLE_SCHEMA = s.TABLE_SCHEMA
+								   and s2.TABLE_NAME = s.TABLE_NAME
+								   and s2.INDEX_NAME = s.INDEX_NAME
+								   and s2.SEQ_IN_INDEX > 1)) as is_unique,
         case when c.data_type = 'enum' then REPLACE(REPLACE(REPLACE(REPLACE(c.column_type, 'enum', ''), '\'', ''), '(', ''), ')', '') else '' end as enum_values,
-		c.column_comment as comment
+		c.column_comment as comment,
+		c.extra like '%auto_increment%' as is_auto_increment,
+		c.is_nullable = 'YES' as is_nullable,
+		(select coalesce(group_concat(cc.CHECK_CLAUSE order by cc.CONSTRAINT_NAME separator ' and '), '')
+		 from information_schema.TABLE_CONSTRAINTS tc
+				  inner join information_schema.CHECK_CONSTRAINTS cc
+							 on cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA and cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		 where tc.TABLE_SCHEMA = c.TABLE_SCHEMA
+		   and tc.TABLE_NAME = c.TABLE_NAME
+		   and tc.CONSTRAINT_TYPE = 'CHECK'
+		   -- the check clauses contain the column names quoted with backticks (char 96)
+		   and cc.CHECK_CLAUSE like concat('%', char(96 using utf8mb4), c.COLUMN_NAME, char(96 using utf8mb4), '%')) as check_constraints,
+		coalesce(c.column_default, '') as default_value,
+		c.ordinal_position
 		from information_schema.columns c
-		where c.table_name = ? and c.TABLE_SCHEMA = ?
-		order by c.ordinal_position;
-		`, tableName.Name, tableName.Schema)
+		where `+filter+`
+		order by c.TABLE_SCHEMA, c.TABLE_NAME, c.ordinal_position;
+		`, args...)
 	if err != nil {
 		return nil, err
 	}
 
-	var columns []ColumnResult
+	tableColumns := make(map[TableDetail][]ColumnResult)
 	for rows.Next() {
+		var table TableDetail
 		var column ColumnResult
-		if err = rows.Scan(&column.Name, &column.DataType, &column.IsPrimary, &column.IsForeign, &column.EnumValues, &column.Comment); err != nil {
+		if err = rows.Scan(&table.Schema, &table.Name, &column.Name, &column.DataType, &column.CharacterMaxLength, &column.NumericPrecision, &column.NumericScale, &column.IsPrimary, &column.IsForeign, &column.IsUnique, &column.EnumValues, &column.Comment, &column.IsAutoIncrement, &column.IsNullable, &column.CheckConstraints, &column.DefaultValue, &column.OrdinalPosition); err != nil {
 			return nil, err
 		}
 
+		table.Name = SanitizeValue(table.Name)
 		column.Name = SanitizeValue(column.Name)
-		column.DataType = SanitizeValue(column.DataType)
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, column.DataType)
 
-		columns = append(columns, column)
+		tableColumns[table] = append(tableColumns[table], column)
 	}
 
-	return columns, nil
+	return tableColumns, nil
+}
+
+func (c *mySqlConnector) GetConstraints(ctx context.Context, tableName TableDetail) ([]ConstraintResult, error) {
+	return c.queryConstraints(ctx, "(c.CONSTRAINT_SCHEMA = ? and c.TABLE_NAME = ?) or (c.UNIQUE_CONSTRAINT_SCHEMA = ? and c.REFERENCED_TABLE_NAME = ?)", tableName.Schema, tableName.Name, tableName.Schema, tableName.Name)
+}
+
+// GetAllConstraints reads the foreign keys of all tables of the schemas with one query
+func (c *mySqlConnector) GetAllConstraints(ctx context.Context, schemaNames []string) ([]ConstraintResult, error) {
+	fkSchemaFilter, fkArgs := getMySqlSchemaFilter("c.CONSTRAINT_SCHEMA", schemaNames)
+	pkSchemaFilter, pkArgs := getMySqlSchemaFilter("c.UNIQUE_CONSTRAINT_SCHEMA", schemaNames)
+	return c.queryConstraints(ctx, fkSchemaFilter+" or "+pkSchemaFilter, append(fkArgs, pkArgs...)...)
 }
 
-func (c *mySqlConnector) GetConstraints(tableName TableDetail) ([]ConstraintResult, error) {
-	rows, err := c.db.Query(`
+func (c *mySqlConnector) queryConstraints(ctx context.Context, filter string, args ...any) ([]ConstraintResult, error) {
+	rows, err := queryContext(ctx, c.db, `
 		select c.TABLE_NAME,
          kcu.TABLE_SCHEMA,
 			   c.REFERENCED_TABLE_NAME,
@@ -138,19 +247,48 @@ func (c *mySqlConnector) GetConstraints(tableName TableDetail) ([]ConstraintResu
 				   from information_schema.KEY_COLUMN_USAGE kc
 							left join information_schema.KEY_COLUMN_USAGE kc2
 									  ON kc.COLUMN_NAME = kc2.COLUMN_NAME AND kc2.CONSTRAINT_NAME = 'PRIMARY' AND
-										 kc2.TABLE_NAME = kc.TABLE_NAME
-           		    where kc.CONSTRAINT_NAME = c.CONSTRAINT_NAME and kc.COLUMN_NAME = kcu.COLUMN_NAME
+										 kc2.TABLE_NAME = kc.TABLE_NAME AND kc2.TABLE_SCHEMA = kc.TABLE_SCHEMA
+           		    where kc.CONSTRAINT_SCHEMA = c.CONSTRAINT_SCHEMA and kc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+           		      and kc.COLUMN_NAME = kcu.COLUMN_NAME
 			   ) "isPrimary",
 			   (
 				   select COUNT(*) > 1
 				   from information_schema.KEY_COLUMN_USAGE kc
-				   where kc.TABLE_NAME = c.TABLE_NAME
+				   where kc.TABLE_SCHEMA = c.CONSTRAINT_SCHEMA
+					 and kc.TABLE_NAME = c.TABLE_NAME
 					 and kc.CONSTRAINT_NAME = 'PRIMARY'
-			   ) "hasMultiplePk"
+			   ) "hasMultiplePk",
+			   -- the foreign key is unique if there is a unique index with exactly the same columns
+			   exists(
+				   select s.INDEX_NAME
+				   from information_schema.STATISTICS s
+				   where s.TABLE_SCHEMA = kcu.TABLE_SCHEMA
+					 and s.TABLE_NAME = c.TABLE_NAME
+					 and s.NON_UNIQUE = 0
+				   group by s.INDEX_NAME
+				   having count(*) = sum(s.COLUMN_NAME in (select kc.COLUMN_NAME
+														  from information_schema.KEY_COLUMN_USAGE kc
+														  where kc.CONSTRAINT_SCHEMA = c.CONSTRAINT_SCHEMA
+															and kc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+															and kc.TABLE_NAME = c.TABLE_NAME))
+					  and count(*) = (select count(*)
+									  from information_schema.KEY_COLUMN_USAGE kc
+									  where kc.CONSTRAINT_SCHEMA = c.CONSTRAINT_SCHEMA
+										and kc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+										and kc.TABLE_NAME = c.TABLE_NAME)
+			   ) "isUnique",
+			   coalesce((
+				   select col.IS_NULLABLE = 'YES'
+				   from information_schema.COLUMNS col
+				   where col.TABLE_SCHEMA = kcu.TABLE_SCHEMA
+					 and col.TABLE_NAME = c.TABLE_NAME
+					 and col.COLUMN_NAME = kcu.COLUMN_NAME
+			   ), false) "isNullable"
 		from information_schema.REFERENTIAL_CONSTRAINTS c
-    		inner join information_schema.KEY_COLUMN_USAGE kcu on c.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
-		where c.CONSTRAINT_SCHEMA = ? and (c.TABLE_NAME = ? or c.REFERENCED_TABLE_NAME = ?)
-		`, tableName.Schema, tableName.Name, tableName.Name)
+    		inner join information_schema.KEY_COLUMN_USAGE kcu
+    		           on c.CONSTRAINT_SCHEMA = kcu.CONSTRAINT_SCHEMA and c.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME
+		where `+filter+`
+		`, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -167,6 +305,8 @@ func (c *mySqlConnector) GetConstraints(tableName TableDetail) ([]ConstraintResu
 			&constraint.ColumnName,
 			&constraint.IsPrimary,
 			&constraint.HasMultiplePK,
+			&constraint.IsUnique,
+			&constraint.IsNullable,
 		)
 
 		if err != nil {
@@ -178,3 +318,41 @@ func (c *mySqlConnector) GetConstraints(tableName TableDetail) ([]ConstraintResu
 
 	return constraints, nil
 }
+
+func (c *mySqlConnector) GetIndexes(ctx context.Context, tableName TableDetail) ([]IndexResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select s.INDEX_NAME,
+			   s.COLUMN_NAME,
+			   s.NON_UNIQUE = 0,
+			   s.INDEX_NAME = 'PRIMARY'
+		from information_schema.STATISTICS s
+		where s.TABLE_NAME = ? and s.TABLE_SCHEMA = ?
+		  -- functional key parts have no column
+		  and s.COLUMN_NAME is not null
+		order by s.INDEX_NAME, s.SEQ_IN_INDEX;
+		`, tableName.Name, tableName.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
+
+func (c *mySqlConnector) GetSampleRows(ctx context.Context, tableName TableDetail, columnNames []string, limit int) ([][]string, error) {
+	return querySampleRows(ctx, c.db, c.dbType, tableName, columnNames, limit)
+}
+
+// getMySqlSchemaFilter returns the condition that the column is one of the schema names and the matching arguments.
+// Without schema names the condition matches no rows, as "in ()" is not valid sql.
+func getMySqlSchemaFilter(columnName string, schemaNames []string) (string, []any) {
+	if len(schemaNames) == 0 {
+		return "1 = 0", nil
+	}
+
+	args := make([]any, len(schemaNames))
+	for i, schemaName := range schemaNames {
+		args[i] = schemaName
+	}
+
+	return columnName + " in (?" + strings.Repeat(",?", len(schemaNames)-1) + ")", args
+}
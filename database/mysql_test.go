@@ -1,6 +1,8 @@
 package database
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -17,11 +19,11 @@ func TestMysqlEnums(t *testing.T) {
 
 	// Act
 	connector, _ := NewConnectorFactory().NewConnector(testConnectionMySql.connectionString)
-	if err := connector.Connect(); err != nil {
+	if err := connector.Connect(context.Background()); err != nil {
 		logrus.Error(err)
 		t.FailNow()
 	}
-	columns, err := connector.GetColumns(TableDetail{Schema: "mermerd_test", Name: "test_2_enum"})
+	columns, err := connector.GetColumns(context.Background(), TableDetail{Schema: "mermerd_test", Name: "test_2_enum"})
 
 	// Assert
 	for _, column := range columns {
@@ -33,3 +35,27 @@ func TestMysqlEnums(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "apple,banana", enumValues)
 }
+
+func TestGetMySqlSchemaFilter(t *testing.T) {
+	testCases := []struct {
+		schemaNames    []string
+		expectedFilter string
+		expectedArgs   []any
+	}{
+		{[]string{"public"}, "table_schema in (?)", []any{"public"}},
+		{[]string{"public", "sales"}, "table_schema in (?,?)", []any{"public", "sales"}},
+		{[]string{}, "1 = 0", nil},
+		{nil, "1 = 0", nil},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			filter, args := getMySqlSchemaFilter("table_schema", testCase.schemaNames)
+
+			// Assert
+			assert.Equal(t, testCase.expectedFilter, filter)
+			assert.Equal(t, testCase.expectedArgs, args)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// PermissionConnector is implemented by the connectors that can check whether the user of the connection can read the
+// catalogs of the schemas, so that missing grants are reported before the analysis instead of failing midway
+type PermissionConnector interface {
+	// GetMissingPermissions returns the grants that the user needs to analyze the schemas (e.g. USAGE on schema
+	// sales), which are empty if the schemas can be read
+	GetMissingPermissions(ctx context.Context, schemaNames []string) ([]string, error)
+}
+
+// getMissingSchemaPermissions returns the grant (a format with the schema, e.g. SELECT on %s.*) for every schema for
+// which the query returns false, the query gets the schema as only argument
+func getMissingSchemaPermissions(ctx context.Context, db *sql.DB, schemaNames []string, query string, grant string) ([]string, error) {
+	var missingPermissions []string
+	for _, schemaName := range schemaNames {
+		var readable bool
+		if err := queryRowContext(ctx, db, query, schemaName).Scan(&readable); err != nil {
+			return nil, err
+		}
+
+		if !readable {
+			missingPermissions = append(missingPermissions, fmt.Sprintf(grant, schemaName))
+		}
+	}
+
+	return missingPermissions, nil
+}
@@ -1,8 +1,10 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
+	"io"
+	"regexp"
 	"strings"
 
 	_ "github.com/jackc/pgx/v4/stdlib"
@@ -10,17 +12,32 @@ import (
 
 type postgresConnector baseConnector
 
+func init() {
+	RegisterConnector("postgresql", newPostgresConnector)
+	RegisterConnector("postgres", newPostgresConnector)
+}
+
+func newPostgresConnector(connectionString string, tunnel io.Closer, options ConnectorOptions) (Connector, error) {
+	return &postgresConnector{
+		dbType:           Postgres,
+		connectionString: connectionString,
+		tunnel:           tunnel,
+		dataTypes:        options.DataTypes,
+	}, nil
+}
+
 func (c *postgresConnector) GetDbType() DbType {
 	return c.dbType
 }
 
-func (c *postgresConnector) Connect() error {
+func (c *postgresConnector) Connect(ctx context.Context) error {
 	db, err := sql.Open(c.dbType.String(), c.connectionString)
 	if err != nil {
 		return err
 	}
 
-	if err := db.Ping(); err != nil {
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
 		return err
 	}
 
@@ -29,14 +46,11 @@ func (c *postgresConnector) Connect() error {
 }
 
 func (c *postgresConnector) Close() {
-	err := c.db.Close()
-	if err != nil {
-		fmt.Println("could not close database connection", err)
-	}
+	closeConnection(c.db, c.tunnel)
 }
 
-func (c *postgresConnector) GetSchemas() ([]string, error) {
-	rows, err := c.db.Query("select schema_name from information_schema.schemata")
+func (c *postgresConnector) GetSchemas(ctx context.Context) ([]string, error) {
+	rows, err := queryContext(ctx, c.db, "select schema_name from information_schema.schemata")
 	if err != nil {
 		return nil, err
 	}
@@ -54,13 +68,57 @@ func (c *postgresConnector) GetSchemas() ([]string, error) {
 	return schemas, nil
 }
 
-func (c *postgresConnector) GetTables(schemaNames []string) ([]TableDetail, error) {
+// GetWritePrivileges returns the superuser and the insert, update and delete privileges of the tables, the query is
+// also supported by CockroachDB and Redshift
+func (c *postgresConnector) GetWritePrivileges(ctx context.Context) ([]string, error) {
+	return queryPrivileges(ctx, c.db, `
+		select 'superuser'
+		from pg_user
+		where usename = current_user
+		  and usesuper
+		union all
+		select p.privilege_type || ' on ' || t.schemaname || '.' || t.tablename
+		from pg_tables t
+				 cross join (select 'INSERT' as privilege_type
+							 union all
+							 select 'UPDATE'
+							 union all
+							 select 'DELETE') p
+		where t.schemaname not in ('pg_catalog', 'information_schema', 'crdb_internal')
+		  and has_table_privilege(quote_ident(t.schemaname) || '.' || quote_ident(t.tablename), p.privilege_type)`)
+}
+
+// GetMissingPermissions returns the schemas without the USAGE privilege, which is needed to read the constraints and
+// indexes of their tables (also supported by CockroachDB and Redshift). Schemas that do not exist are left to the
+// analysis.
+func (c *postgresConnector) GetMissingPermissions(ctx context.Context, schemaNames []string) ([]string, error) {
+	return queryPrivileges(ctx, c.db, `
+		select 'USAGE on schema ' || nspname
+		from pg_namespace
+		where nspname = ANY($1::varchar[])
+		  and not has_schema_privilege(nspname, 'USAGE')`, "{"+strings.Join(schemaNames, ",")+"}")
+}
+
+func (c *postgresConnector) GetTables(ctx context.Context, schemaNames []string) ([]TableDetail, error) {
 	schemaSearch := "{" + strings.Join(schemaNames, ",") + "}"
-	rows, err := c.db.Query(`
-		select table_schema, table_name
-		from information_schema.tables
-		where table_type = 'BASE TABLE'
-    and table_schema = ANY($1::varchar[])
+	rows, err := queryContext(ctx, c.db, `
+		select t.table_schema, t.table_name, t.table_type = 'VIEW',
+		       coalesce((select parent.relname
+		                 from pg_inherits inh
+		                          inner join pg_class child on inh.inhrelid = child.oid
+		                          inner join pg_namespace ns on child.relnamespace = ns.oid
+		                          inner join pg_class parent on inh.inhparent = parent.oid
+		                 where child.relispartition
+		                   and ns.nspname = t.table_schema
+		                   and child.relname = t.table_name), '') as partition_of
+		from information_schema.tables t
+		where t.table_type in ('BASE TABLE', 'VIEW')
+    and t.table_schema = ANY($1::varchar[])
+		union all
+		-- materialized views are not part of the information_schema
+		select schemaname, matviewname, true, ''
+		from pg_matviews
+		where schemaname = ANY($1::varchar[])
 		`, schemaSearch)
 	if err != nil {
 		return nil, err
@@ -69,11 +127,12 @@ func (c *postgresConnector) GetTables(schemaNames []string) ([]TableDetail, erro
 	var tables []TableDetail
 	for rows.Next() {
 		var table TableDetail
-		if err = rows.Scan(&table.Schema, &table.Name); err != nil {
+		if err = rows.Scan(&table.Schema, &table.Name, &table.IsView, &table.PartitionOf); err != nil {
 			return nil, err
 		}
 
 		table.Name = SanitizeValue(table.Name)
+		table.PartitionOf = SanitizeValue(table.PartitionOf)
 
 		tables = append(tables, table)
 	}
@@ -81,14 +140,40 @@ func (c *postgresConnector) GetTables(schemaNames []string) ([]TableDetail, erro
 	return tables, nil
 }
 
-func (c *postgresConnector) GetColumns(tableName TableDetail) ([]ColumnResult, error) {
-	rows, err := c.db.Query(`
-        select c.column_name,
+func (c *postgresConnector) GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	tableColumns, err := c.queryColumns(ctx, "c.table_name = $1 and c.table_schema = $2", tableName.Name, tableName.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := tableColumns[TableDetail{Schema: tableName.Schema, Name: tableName.Name}]
+	if len(columns) == 0 {
+		return c.getMaterializedViewColumns(ctx, tableName)
+	}
+
+	return columns, nil
+}
+
+// GetAllColumns reads the columns of all tables of the schemas with one query. The columns of materialized views
+// are not part of the result, as they are not part of the information_schema.
+func (c *postgresConnector) GetAllColumns(ctx context.Context, schemaNames []string) (map[TableDetail][]ColumnResult, error) {
+	return c.queryColumns(ctx, "c.table_schema = any($1::varchar[])", "{"+strings.Join(schemaNames, ",")+"}")
+}
+
+// queryColumns reads the columns of the tables that match the filter and groups them by table
+func (c *postgresConnector) queryColumns(ctx context.Context, filter string, args ...any) (map[TableDetail][]ColumnResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+        select c.table_schema,
+               c.table_name,
+               c.column_name,
                (case
                     when c.data_type = 'USER-DEFINED'
                         then c.udt_name
                     else c.data_type
                    end)                                                        as data_type,
+               coalesce(c.character_maximum_length, 0)                         as character_maximum_length,
+               coalesce(c.numeric_precision, 0)                                as numeric_precision,
+               coalesce(c.numeric_scale, 0)                                    as numeric_scale,
                (select count(*) > 0
                 from information_schema.key_column_usage cu
                          left join information_schema.table_constraints tc on tc.constraint_name = cu.constraint_name
@@ -101,22 +186,98 @@ func (c *postgresConnector) GetColumns(tableName TableDetail) ([]ColumnResult, e
                 where cu.column_name = c.column_name
                   and cu.table_name = c.table_name
                   and tc.constraint_type = 'FOREIGN KEY')                      as is_foreign,
+               (select count(*) > 0
+                from pg_index ix
+                         inner join pg_class ucls on ucls.oid = ix.indrelid
+                         inner join pg_namespace uns on ucls.relnamespace = uns.oid
+                         inner join pg_attribute uatt on uatt.attrelid = ucls.oid and uatt.attnum = ix.indkey[0]
+                where uns.nspname = c.table_schema
+                  and ucls.relname = c.table_name
+                  and uatt.attname = c.column_name
+                  and ix.indisunique
+                  and not ix.indisprimary
+                  -- only the unique keys of the single column, partial indexes are not unique for all rows
+                  and ix.indnatts = 1
+                  and ix.indpred is null)                                      as is_unique,
                coalesce(string_agg(enumlabel, ',' order by enumsortorder), '') as enum_values,
-               coalesce(pd.description, '')                   				   as comment
+               coalesce(pd.description, '')                   				   as comment,
+               c.is_identity = 'YES'                                           as is_auto_increment,
+               c.is_nullable = 'YES'                                           as is_nullable,
+               coalesce((select string_agg(pg_get_expr(con.conbin, con.conrelid), ' and ' order by con.conname)
+                         from pg_constraint con
+                                  inner join pg_class ccls on con.conrelid = ccls.oid
+                                  inner join pg_namespace cns on ccls.relnamespace = cns.oid
+                                  inner join pg_attribute att on att.attrelid = ccls.oid and att.attnum = any (con.conkey)
+                         where con.contype = 'c'
+                           and cns.nspname = c.table_schema
+                           and ccls.relname = c.table_name
+                           and att.attname = c.column_name), '')      as check_constraints,
+               coalesce(c.column_default, '')                                  as default_value,
+               c.ordinal_position
         from information_schema.columns c
                  left join pg_type typ on c.udt_name = typ.typname
                  left join pg_enum enu on typ.oid = enu.enumtypid
                  left join pg_class cls on c.table_name = cls.relname
 				 left join pg_namespace ns on cls.relnamespace = ns.oid
 				 left join pg_description pd on cls.oid = pd.objoid and c.ordinal_position = pd.objsubid
-        where c.table_name = $1  and c.table_schema = $2
+        where `+filter+`
         group by c.column_name,
+                 c.table_schema,
                  c.table_name,
                  c.data_type,
                  c.udt_name,
+                 c.character_maximum_length,
+                 c.numeric_precision,
+                 c.numeric_scale,
+                 c.is_identity,
+                 c.is_nullable,
+                 c.column_default,
                  c.ordinal_position,
          		 pd.description
-        order by c.ordinal_position;
+        order by c.table_schema, c.table_name, c.ordinal_position;
+		`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tableColumns := make(map[TableDetail][]ColumnResult)
+	for rows.Next() {
+		var table TableDetail
+		var column ColumnResult
+		if err = rows.Scan(&table.Schema, &table.Name, &column.Name, &column.DataType, &column.CharacterMaxLength, &column.NumericPrecision, &column.NumericScale, &column.IsPrimary, &column.IsForeign, &column.IsUnique, &column.EnumValues, &column.Comment, &column.IsAutoIncrement, &column.IsNullable, &column.CheckConstraints, &column.DefaultValue, &column.OrdinalPosition); err != nil {
+			return nil, err
+		}
+
+		table.Name = SanitizeValue(table.Name)
+		column.Name = SanitizeValue(column.Name)
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, column.DataType)
+		column.SequenceName = parsePostgresSequenceName(column.DefaultValue)
+
+		tableColumns[table] = append(tableColumns[table], column)
+	}
+
+	return tableColumns, nil
+}
+
+// getMaterializedViewColumns gets the columns of materialized views, as they are not part of the information_schema
+func (c *postgresConnector) getMaterializedViewColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+        select a.attname,
+               format_type(a.atttypid, a.atttypmod),
+               coalesce((select string_agg(enumlabel, ',' order by enumsortorder)
+                         from pg_enum
+                         where enumtypid = a.atttypid), ''),
+               coalesce(col_description(cls.oid, a.attnum), ''),
+               not a.attnotnull,
+               a.attnum
+        from pg_attribute a
+                 inner join pg_class cls on a.attrelid = cls.oid
+                 inner join pg_namespace ns on cls.relnamespace = ns.oid
+        where cls.relname = $1 and ns.nspname = $2
+          and cls.relkind = 'm'
+          and a.attnum > 0
+          and not a.attisdropped
+        order by a.attnum;
 		`, tableName.Name, tableName.Schema)
 	if err != nil {
 		return nil, err
@@ -125,12 +286,13 @@ func (c *postgresConnector) GetColumns(tableName TableDetail) ([]ColumnResult, e
 	var columns []ColumnResult
 	for rows.Next() {
 		var column ColumnResult
-		if err = rows.Scan(&column.Name, &column.DataType, &column.IsPrimary, &column.IsForeign, &column.EnumValues, &column.Comment); err != nil {
+		if err = rows.Scan(&column.Name, &column.DataType, &column.EnumValues, &column.Comment, &column.IsNullable, &column.OrdinalPosition); err != nil {
 			return nil, err
 		}
 
 		column.Name = SanitizeValue(column.Name)
-		column.DataType = SanitizeValue(column.DataType)
+		setDataTypeParameters(&column, column.DataType)
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, column.DataType)
 
 		columns = append(columns, column)
 	}
@@ -138,8 +300,72 @@ func (c *postgresConnector) GetColumns(tableName TableDetail) ([]ColumnResult, e
 	return columns, nil
 }
 
-func (c *postgresConnector) GetConstraints(tableName TableDetail) ([]ConstraintResult, error) {
-	rows, err := c.db.Query(`
+func (c *postgresConnector) GetConstraints(ctx context.Context, tableName TableDetail) ([]ConstraintResult, error) {
+	constraints, err := c.queryConstraints(ctx, "(fk.table_schema = $1 and fk.table_name = $2) or (pk.table_schema = $1 and pk.table_name = $2)", tableName.Schema, tableName.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	inheritances, err := c.queryInheritances(ctx, "(child_ns.nspname = $1 and child.relname = $2) or (parent_ns.nspname = $1 and parent.relname = $2)", tableName.Schema, tableName.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(constraints, inheritances...), nil
+}
+
+// GetAllConstraints reads the foreign keys of all tables of the schemas with one query
+func (c *postgresConnector) GetAllConstraints(ctx context.Context, schemaNames []string) ([]ConstraintResult, error) {
+	schemaSearch := "{" + strings.Join(schemaNames, ",") + "}"
+	constraints, err := c.queryConstraints(ctx, "fk.table_schema = any($1::varchar[]) or pk.table_schema = any($1::varchar[])", schemaSearch)
+	if err != nil {
+		return nil, err
+	}
+
+	inheritances, err := c.queryInheritances(ctx, "child_ns.nspname = any($1::varchar[]) or parent_ns.nspname = any($1::varchar[])", schemaSearch)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(constraints, inheritances...), nil
+}
+
+// queryInheritances returns the parents of the tables that are created with INHERITS, the partitions are not
+// included, as they are handled like tables of their own
+func (c *postgresConnector) queryInheritances(ctx context.Context, filter string, args ...any) ([]ConstraintResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+        select child.relname,
+               child_ns.nspname,
+               parent.relname,
+               parent_ns.nspname
+        from pg_inherits inh
+                 inner join pg_class child on inh.inhrelid = child.oid
+                 inner join pg_namespace child_ns on child.relnamespace = child_ns.oid
+                 inner join pg_class parent on inh.inhparent = parent.oid
+                 inner join pg_namespace parent_ns on parent.relnamespace = parent_ns.oid
+        where not child.relispartition
+          and (`+filter+`)
+        order by child_ns.nspname, child.relname, inh.inhseqno;
+		`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var constraints []ConstraintResult
+	for rows.Next() {
+		constraint := ConstraintResult{IsInheritance: true}
+		if err = rows.Scan(&constraint.FkTable, &constraint.FkSchema, &constraint.PkTable, &constraint.PkSchema); err != nil {
+			return nil, err
+		}
+
+		constraints = append(constraints, constraint)
+	}
+
+	return constraints, nil
+}
+
+func (c *postgresConnector) queryConstraints(ctx context.Context, filter string, args ...any) ([]ConstraintResult, error) {
+	rows, err := queryContext(ctx, c.db, `
 	select fk.table_name,
        fk.table_schema,
 		   pk.table_name,
@@ -150,11 +376,14 @@ func (c *postgresConnector) GetConstraints(tableName TableDetail) ([]ConstraintR
 				   (select tc.constraint_type is not null "isPrimary"
 					from information_schema.key_column_usage kc
 							 inner join information_schema.key_column_usage kc2
-										ON kc2.column_name = kc.column_name and kc2.table_name = kc.table_name
+										ON kc2.column_name = kc.column_name and kc2.table_name = kc.table_name and
+										   kc2.table_schema = kc.table_schema
 							 inner join information_schema.table_constraints tc
-										on kc2.constraint_name = tc.constraint_name and
+										on kc2.constraint_schema = tc.constraint_schema and
+										   kc2.constraint_name = tc.constraint_name and
 										   tc.constraint_type = 'PRIMARY KEY'
-					where kc.constraint_name = c.constraint_name
+					where kc.constraint_schema = c.constraint_schema
+					  and kc.constraint_name = c.constraint_name
 					  and kc.column_name = kcu.column_name
             and kc.table_name = fk.table_name)
 			   , false) "isPrimary",
@@ -162,15 +391,40 @@ func (c *postgresConnector) GetConstraints(tableName TableDetail) ([]ConstraintR
 			from information_schema.table_constraints tc
 					 -- one constraint can have multiple columns
 					 inner join information_schema.key_column_usage kc
-								on kc.constraint_name = tc.constraint_name
-			where tc.table_name = fk.table_name
-			  and tc.constraint_type = 'PRIMARY KEY')
+								on kc.constraint_schema = tc.constraint_schema and kc.constraint_name = tc.constraint_name
+			where tc.table_schema = fk.table_schema
+			  and tc.table_name = fk.table_name
+			  and tc.constraint_type = 'PRIMARY KEY'),
+		   -- the foreign key is unique if there is a unique index with exactly the same columns
+		   coalesce(
+				   (select bool_or(i.indisunique)
+					from pg_constraint con
+							 inner join pg_namespace ns on con.connamespace = ns.oid
+							 inner join pg_index i on i.indrelid = con.conrelid
+					where con.conname = c.constraint_name
+					  and ns.nspname = fk.table_schema
+					  and con.contype = 'f'
+					  and i.indisunique
+					  and i.indkey::int2[] @> con.conkey
+					  and i.indkey::int2[] <@ con.conkey)
+			   , false) "isUnique",
+		   coalesce(
+				   (select col.is_nullable = 'YES'
+					from information_schema.columns col
+					where col.table_schema = fk.table_schema
+					  and col.table_name = fk.table_name
+					  and col.column_name = kcu.column_name)
+			   , false) "isNullable"
 	from information_schema.referential_constraints c
-			 inner join information_schema.table_constraints fk on c.constraint_name = fk.constraint_name
-			 inner join information_schema.table_constraints pk on c.unique_constraint_name = pk.constraint_name
-			 inner join information_schema.key_column_usage kcu on c.constraint_name = kcu.constraint_name
-	where c.constraint_schema = $1 and (fk.table_name = $2 or pk.table_name = $2);
-		`, tableName.Schema, tableName.Name)
+			 -- the constraints are joined by schema and name, as the referenced table can be in another schema
+			 inner join information_schema.table_constraints fk
+						on c.constraint_schema = fk.constraint_schema and c.constraint_name = fk.constraint_name
+			 inner join information_schema.table_constraints pk
+						on c.unique_constraint_schema = pk.constraint_schema and c.unique_constraint_name = pk.constraint_name
+			 inner join information_schema.key_column_usage kcu
+						on c.constraint_schema = kcu.constraint_schema and c.constraint_name = kcu.constraint_name
+	where `+filter+`;
+		`, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -187,6 +441,8 @@ func (c *postgresConnector) GetConstraints(tableName TableDetail) ([]ConstraintR
 			&constraint.ColumnName,
 			&constraint.IsPrimary,
 			&constraint.HasMultiplePK,
+			&constraint.IsUnique,
+			&constraint.IsNullable,
 		)
 
 		if err != nil {
@@ -198,3 +454,43 @@ func (c *postgresConnector) GetConstraints(tableName TableDetail) ([]ConstraintR
 
 	return constraints, nil
 }
+
+func (c *postgresConnector) GetIndexes(ctx context.Context, tableName TableDetail) ([]IndexResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+        select i.relname,
+               a.attname,
+               ix.indisunique,
+               ix.indisprimary
+        from pg_index ix
+                 inner join pg_class t on t.oid = ix.indrelid
+                 inner join pg_namespace ns on t.relnamespace = ns.oid
+                 inner join pg_class i on i.oid = ix.indexrelid
+                 inner join lateral unnest(ix.indkey::int2[]) with ordinality as k(attnum, position) on true
+                 -- expression indexes have no column (attnum 0)
+                 inner join pg_attribute a on a.attrelid = t.oid and a.attnum = k.attnum
+        where t.relname = $1 and ns.nspname = $2
+        order by i.relname, k.position;
+		`, tableName.Name, tableName.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
+
+func (c *postgresConnector) GetSampleRows(ctx context.Context, tableName TableDetail, columnNames []string, limit int) ([][]string, error) {
+	return querySampleRows(ctx, c.db, c.dbType, tableName, columnNames, limit)
+}
+
+var postgresSequenceRegex = regexp.MustCompile(`(?i)^nextval\('(.+)'(::regclass)?\)$`)
+
+// parsePostgresSequenceName extracts the sequence name of a serial column, whose default is like
+// nextval('article_id_seq'::regclass)
+func parsePostgresSequenceName(columnDefault string) string {
+	match := postgresSequenceRegex.FindStringSubmatch(strings.TrimSpace(columnDefault))
+	if match == nil {
+		return ""
+	}
+
+	return strings.ReplaceAll(match[1], `"`, "")
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -17,11 +18,11 @@ func TestPostgresEnums(t *testing.T) {
 
 	// Act
 	connector, _ := NewConnectorFactory().NewConnector(testConnectionPostgres.connectionString)
-	if err := connector.Connect(); err != nil {
+	if err := connector.Connect(context.Background()); err != nil {
 		logrus.Error(err)
 		t.FailNow()
 	}
-	columns, err := connector.GetColumns(TableDetail{Schema: "public", Name: "test_2_enum"})
+	columns, err := connector.GetColumns(context.Background(), TableDetail{Schema: "public", Name: "test_2_enum"})
 
 	// Assert
 	for _, column := range columns {
@@ -42,15 +43,38 @@ func TestPostgresNotUniqueConstraintName(t *testing.T) {
 	// Arrange
 	tableName := TableDetail{Schema: "public", Name: "test_not_unique_constraint_name_b"}
 	connector, _ := NewConnectorFactory().NewConnector(testConnectionPostgres.connectionString)
-	if err := connector.Connect(); err != nil {
+	if err := connector.Connect(context.Background()); err != nil {
 		logrus.Error(err)
 		t.FailNow()
 	}
 
 	// Act
-	_, err := connector.GetConstraints(tableName)
+	_, err := connector.GetConstraints(context.Background(), tableName)
 
 	// Assert
 	// we only need to check if an error is thrown
 	assert.Nil(t, err)
 }
+
+func TestParsePostgresSequenceName(t *testing.T) {
+	testCases := []struct {
+		columnDefault        string
+		expectedSequenceName string
+	}{
+		{"nextval('article_id_seq'::regclass)", "article_id_seq"},
+		{`nextval('other_db."Article_id_seq"'::regclass)`, "other_db.Article_id_seq"},
+		{"nextval('public.article_id_seq'::REGCLASS)", "public.article_id_seq"},
+		{"now()", ""},
+		{"", ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.columnDefault, func(t *testing.T) {
+			// Act
+			result := parsePostgresSequenceName(testCase.columnDefault)
+
+			// Assert
+			assert.Equal(t, testCase.expectedSequenceName, result)
+		})
+	}
+}
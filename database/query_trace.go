@@ -0,0 +1,54 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	queryTraceMutex sync.Mutex
+	queryTrace      io.Writer
+)
+
+// SetQueryTrace records every executed query with its arguments and duration in the writer (--trace-sql), so it can
+// be audited what was executed against the database. The trace is turned off with nil.
+func SetQueryTrace(writer io.Writer) {
+	queryTraceMutex.Lock()
+	defer queryTraceMutex.Unlock()
+
+	queryTrace = writer
+}
+
+// traceQuery writes the query as sql statement after a comment with the time, the duration, the arguments and the
+// error (if any), e.g.
+//
+//	-- 2024-01-31T12:00:00.000Z | 12 ms | args: ["public"]
+//	select table_name from information_schema.tables where table_schema = $1;
+func traceQuery(query string, args []any, start time.Time, err error) {
+	queryTraceMutex.Lock()
+	defer queryTraceMutex.Unlock()
+
+	if queryTrace == nil {
+		return
+	}
+
+	comment := fmt.Sprintf("-- %s | %d ms", start.UTC().Format("2006-01-02T15:04:05.000Z07:00"), time.Since(start).Milliseconds())
+	if len(args) > 0 {
+		encodedArgs, _ := json.Marshal(args)
+		comment += " | args: " + string(encodedArgs)
+	}
+
+	if err != nil {
+		comment += " | error: " + strings.Join(strings.Fields(err.Error()), " ")
+	}
+
+	if _, err = fmt.Fprintf(queryTrace, "%s\n%s;\n\n", comment, strings.TrimRight(strings.TrimSpace(query), ";")); err != nil {
+		logrus.Warn("Could not trace query", " | ", err)
+	}
+}
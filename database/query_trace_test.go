@@ -0,0 +1,64 @@
+package database
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceQuery(t *testing.T) {
+	testCases := []struct {
+		query         string
+		args          []any
+		err           error
+		expectedTrace string
+	}{
+		{
+			query:         "select 1;",
+			expectedTrace: `^-- \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{3}Z \| \d+ ms\nselect 1;\n\n$`,
+		},
+		{
+			query:         "\n  select table_name from information_schema.tables where table_schema = $1\n",
+			args:          []any{"public", 1},
+			expectedTrace: `^-- \S+ \| \d+ ms \| args: \["public",1\]\nselect table_name from information_schema.tables where table_schema = \$1;\n\n$`,
+		},
+		{
+			query:         "select * from missing",
+			err:           errors.New("no such table:\n missing"),
+			expectedTrace: `^-- \S+ \| \d+ ms \| error: no such table: missing\nselect \* from missing;\n\n$`,
+		},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Arrange
+			var trace bytes.Buffer
+			SetQueryTrace(&trace)
+			defer SetQueryTrace(nil)
+
+			// Act
+			traceQuery(testCase.query, testCase.args, time.Now(), testCase.err)
+
+			// Assert
+			assert.Regexp(t, regexp.MustCompile(testCase.expectedTrace), trace.String())
+		})
+	}
+}
+
+func TestTraceQueryWithoutTrace(t *testing.T) {
+	// Arrange
+	var trace bytes.Buffer
+	SetQueryTrace(&trace)
+	SetQueryTrace(nil)
+
+	// Act
+	traceQuery("select 1", nil, time.Now(), nil)
+
+	// Assert
+	assert.Empty(t, trace.String())
+}
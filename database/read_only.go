@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ReadOnlyConnector is implemented by the connectors that can check whether the user of the connection is able to
+// change the database (--assert-readonly)
+type ReadOnlyConnector interface {
+	// GetWritePrivileges returns the privileges of the user that allow to change the data (e.g. INSERT on
+	// public.article), which are empty for a read-only user
+	GetWritePrivileges(ctx context.Context) ([]string, error)
+}
+
+// readOnlyStatements are the first keywords of the queries that the connectors are allowed to execute
+var readOnlyStatements = []string{"select", "with", "show", "pragma", "describe", "values"}
+
+// sqlWriteKeywordRegex matches the keywords that change the database anywhere in the statement, e.g. in a
+// data-modifying common table expression (with d as (delete ... returning *) select ...) or select ... into
+var sqlWriteKeywordRegex = regexp.MustCompile(`(?i)\b(insert|update|delete|merge|into|create|drop|alter|truncate|grant)\b`)
+
+// sqlFirstKeywordRegex matches the keyword of the statement, which can be in parentheses
+var sqlFirstKeywordRegex = regexp.MustCompile(`^[(\s]*([A-Za-z]+)`)
+
+// sqlQuotedOrCommentRegex matches the string literals and quoted identifiers, which can contain semicolons and
+// keywords (e.g. the table names of the sample rows), and the comments. They are matched together, as a comment can
+// contain a quote and a string literal can contain a comment marker.
+var sqlQuotedOrCommentRegex = regexp.MustCompile("'(?:[^']|'')*'|\"(?:[^\"]|\"\")*\"|`[^`]*`|\\[[^\\]]*\\]|--[^\n]*|/\\*[\\s\\S]*?\\*/")
+
+// assertReadOnlyQuery rejects the queries that are not a single reading statement, so that no connector changes the
+// database by mistake
+func assertReadOnlyQuery(query string) error {
+	statement := sqlQuotedOrCommentRegex.ReplaceAllStringFunc(query, func(match string) string {
+		if strings.HasPrefix(match, "--") || strings.HasPrefix(match, "/*") {
+			return " "
+		}
+
+		return "''"
+	})
+	statement = strings.Trim(statement, "; \t\r\n")
+	if strings.Contains(statement, ";") {
+		return fmt.Errorf("%w: multiple statements", ErrWriteQuery)
+	}
+
+	if match := sqlWriteKeywordRegex.FindString(statement); match != "" {
+		return fmt.Errorf("%w: %q in the statement", ErrWriteQuery, strings.ToLower(match))
+	}
+
+	keyword := ""
+	if match := sqlFirstKeywordRegex.FindStringSubmatch(statement); match != nil {
+		keyword = strings.ToLower(match[1])
+	}
+
+	for _, readOnlyStatement := range readOnlyStatements {
+		if keyword == readOnlyStatement {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q statement", ErrWriteQuery, keyword)
+}
+
+// applyReadOnlySession adds the parameters of the connection string that make the session read-only, which is only
+// supported by some databases (the privileges are checked for the others). The mysql driver sets the unknown
+// parameters as session variables, which is the same as SET SESSION TRANSACTION READ ONLY.
+func applyReadOnlySession(connectionString string) string {
+	scheme, _, _ := strings.Cut(connectionString, "://")
+	switch scheme {
+	case "postgresql", "postgres", "cockroachdb":
+		return addQueryParameters(connectionString, []queryParameter{{"default_transaction_read_only", "on"}})
+	case "mysql":
+		return addQueryParameters(connectionString, []queryParameter{{"transaction_read_only", "1"}})
+	case "mariadb":
+		// transaction_read_only is only an alias since MariaDB 11.1
+		return addQueryParameters(connectionString, []queryParameter{{"tx_read_only", "1"}})
+	case "sqlite":
+		return addQueryParameters(connectionString, []queryParameter{{"_pragma", "query_only(1)"}})
+	default:
+		return connectionString
+	}
+}
+
+// queryPrivileges reads the privileges (or missing grants) of the query, which returns one privilege per row
+func queryPrivileges(ctx context.Context, db *sql.DB, query string, args ...any) ([]string, error) {
+	rows, err := queryContext(ctx, db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var privileges []string
+	for rows.Next() {
+		var privilege string
+		if err = rows.Scan(&privilege); err != nil {
+			return nil, err
+		}
+
+		privileges = append(privileges, privilege)
+	}
+
+	return privileges, rows.Err()
+}
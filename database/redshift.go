@@ -0,0 +1,452 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jackc/pgx/v4/stdlib"
+)
+
+// redshiftConnector reuses the postgres connector, but only uses catalog queries that are supported by the leader
+// node of Redshift (no string_agg, no arrays as parameters, no pg_index and no inheritance). Redshift has no enums,
+// check constraints and indexes, the primary, foreign and unique keys are informational only.
+type redshiftConnector struct {
+	postgresConnector
+}
+
+// redshiftKeyColumn is a column of the distribution or sort key of a table
+type redshiftKeyColumn struct {
+	name      string
+	isDistKey bool
+	// sortKeyOrder is the position in the sort key, it is negative for interleaved sort keys
+	sortKeyOrder int
+}
+
+func init() {
+	// Redshift uses the pgx driver, it is registered under its own name so that the DbType can be used to connect
+	sql.Register(Redshift.String(), stdlib.GetDefaultDriver())
+	RegisterConnector("redshift", newRedshiftConnector)
+}
+
+func newRedshiftConnector(connectionString string, tunnel io.Closer, options ConnectorOptions) (Connector, error) {
+	return &redshiftConnector{postgresConnector{
+		dbType:           Redshift,
+		connectionString: strings.Replace(connectionString, "redshift://", "postgresql://", 1),
+		tunnel:           tunnel,
+		dataTypes:        options.DataTypes,
+	}}, nil
+}
+
+func (c *redshiftConnector) GetSchemas(ctx context.Context) ([]string, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select nspname
+		from pg_namespace
+		where substring(nspname, 1, 3) <> 'pg_'
+		  and nspname <> 'information_schema'
+		order by nspname`)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err = rows.Scan(&schema); err != nil {
+			return nil, err
+		}
+
+		schemas = append(schemas, schema)
+	}
+
+	return schemas, nil
+}
+
+// GetTables also reads the comment, the distribution style and the sort key of the tables. Late-binding views (WITH
+// NO SCHEMA BINDING) are part of the result like all other views.
+func (c *redshiftConnector) GetTables(ctx context.Context, schemaNames []string) ([]TableDetail, error) {
+	schemaFilter, args := getRedshiftSchemaFilter("t.table_schema", schemaNames)
+	rows, err := queryContext(ctx, c.db, `
+		select t.table_schema,
+		       t.table_name,
+		       t.table_type = 'VIEW',
+		       coalesce(obj_description(cls.oid, 'pg_class'), ''),
+		       coalesce(ci.releffectivediststyle, -1)
+		from information_schema.tables t
+		         inner join pg_namespace ns on ns.nspname = t.table_schema
+		         inner join pg_class cls on cls.relname = t.table_name and cls.relnamespace = ns.oid
+		         left join pg_class_info ci on ci.reloid = cls.oid
+		where t.table_type in ('BASE TABLE', 'VIEW')
+		  and `+schemaFilter+`
+		order by t.table_schema, t.table_name`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []TableDetail
+	distStyles := make(map[TableDetail]int)
+	for rows.Next() {
+		var table TableDetail
+		var distStyle int
+		if err = rows.Scan(&table.Schema, &table.Name, &table.IsView, &table.Comment, &distStyle); err != nil {
+			return nil, err
+		}
+
+		distStyles[TableDetail{Schema: table.Schema, Name: table.Name}] = distStyle
+		tables = append(tables, table)
+	}
+
+	keyColumns, err := c.queryKeyColumns(ctx, schemaNames)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, table := range tables {
+		key := TableDetail{Schema: table.Schema, Name: table.Name}
+		if !table.IsView {
+			tables[i].DistStyle = getRedshiftDistStyle(distStyles[key], keyColumns[key])
+			tables[i].SortKey = getRedshiftSortKey(keyColumns[key])
+		}
+
+		tables[i].Name = SanitizeValue(table.Name)
+	}
+
+	return tables, nil
+}
+
+// queryKeyColumns reads the columns of the distribution and sort keys of the tables of the schemas, grouped by table
+// and ordered by their position in the sort key
+func (c *redshiftConnector) queryKeyColumns(ctx context.Context, schemaNames []string) (map[TableDetail][]redshiftKeyColumn, error) {
+	schemaFilter, args := getRedshiftSchemaFilter("ns.nspname", schemaNames)
+	rows, err := queryContext(ctx, c.db, `
+		select ns.nspname, cls.relname, a.attname, a.attisdistkey, a.attsortkeyord
+		from pg_attribute a
+		         inner join pg_class cls on a.attrelid = cls.oid
+		         inner join pg_namespace ns on cls.relnamespace = ns.oid
+		where a.attnum > 0
+		  and not a.attisdropped
+		  and (a.attisdistkey or a.attsortkeyord <> 0)
+		  and `+schemaFilter+`
+		order by ns.nspname, cls.relname, abs(a.attsortkeyord)`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	keyColumns := make(map[TableDetail][]redshiftKeyColumn)
+	for rows.Next() {
+		var table TableDetail
+		var column redshiftKeyColumn
+		if err = rows.Scan(&table.Schema, &table.Name, &column.name, &column.isDistKey, &column.sortKeyOrder); err != nil {
+			return nil, err
+		}
+
+		keyColumns[table] = append(keyColumns[table], column)
+	}
+
+	return keyColumns, nil
+}
+
+// GetColumns also reads the columns of late-binding views, as they are not part of the information_schema
+func (c *redshiftConnector) GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	tableColumns, err := c.queryColumns(ctx, "c.table_schema = $1 and c.table_name = $2", tableName.Schema, tableName.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	key := TableDetail{Schema: tableName.Schema, Name: tableName.Name}
+	if columns := tableColumns[key]; len(columns) > 0 {
+		return columns, nil
+	}
+
+	viewColumns, err := c.queryLateBindingViewColumns(ctx, "view_schema = $1 and view_name = $2", tableName.Schema, tableName.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return viewColumns[key], nil
+}
+
+// GetAllColumns reads the columns of all tables and late-binding views of the schemas
+func (c *redshiftConnector) GetAllColumns(ctx context.Context, schemaNames []string) (map[TableDetail][]ColumnResult, error) {
+	schemaFilter, args := getRedshiftSchemaFilter("c.table_schema", schemaNames)
+	tableColumns, err := c.queryColumns(ctx, schemaFilter, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	viewSchemaFilter, viewArgs := getRedshiftSchemaFilter("view_schema", schemaNames)
+	viewColumns, err := c.queryLateBindingViewColumns(ctx, viewSchemaFilter, viewArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for table, columns := range viewColumns {
+		if _, ok := tableColumns[table]; !ok {
+			tableColumns[table] = columns
+		}
+	}
+
+	return tableColumns, nil
+}
+
+// queryColumns reads the columns of the tables that match the filter and groups them by table. Identity columns have
+// a default like "identity"(100205, 0, '1,1'::text) instead of is_identity, the default is not returned for them.
+func (c *redshiftConnector) queryColumns(ctx context.Context, filter string, args ...any) (map[TableDetail][]ColumnResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+        select c.table_schema,
+               c.table_name,
+               c.column_name,
+               c.data_type,
+               coalesce(c.character_maximum_length, 0),
+               coalesce(c.numeric_precision, 0),
+               coalesce(c.numeric_scale, 0),
+               exists(select 1
+                      from pg_constraint con
+                      where con.conrelid = cls.oid
+                        and con.contype = 'p'
+                        and a.attnum = any (con.conkey)),
+               exists(select 1
+                      from pg_constraint con
+                      where con.conrelid = cls.oid
+                        and con.contype = 'f'
+                        and a.attnum = any (con.conkey)),
+               -- only the unique keys of the single column
+               exists(select 1
+                      from pg_constraint con
+                      where con.conrelid = cls.oid
+                        and con.contype = 'u'
+                        and array_upper(con.conkey, 1) = 1
+                        and a.attnum = any (con.conkey)),
+               coalesce(pd.description, ''),
+               coalesce(c.column_default like '"identity"(%', false),
+               c.is_nullable = 'YES',
+               coalesce(c.column_default, ''),
+               c.ordinal_position
+        from information_schema.columns c
+                 inner join pg_namespace ns on ns.nspname = c.table_schema
+                 inner join pg_class cls on cls.relname = c.table_name and cls.relnamespace = ns.oid
+                 inner join pg_attribute a on a.attrelid = cls.oid and a.attname = c.column_name
+                 left join pg_description pd on pd.objoid = cls.oid and pd.objsubid = a.attnum
+        where `+filter+`
+        order by c.table_schema, c.table_name, c.ordinal_position`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tableColumns := make(map[TableDetail][]ColumnResult)
+	for rows.Next() {
+		var table TableDetail
+		var column ColumnResult
+		if err = rows.Scan(&table.Schema, &table.Name, &column.Name, &column.DataType, &column.CharacterMaxLength, &column.NumericPrecision, &column.NumericScale, &column.IsPrimary, &column.IsForeign, &column.IsUnique, &column.Comment, &column.IsAutoIncrement, &column.IsNullable, &column.DefaultValue, &column.OrdinalPosition); err != nil {
+			return nil, err
+		}
+
+		table.Name = SanitizeValue(table.Name)
+		column.Name = SanitizeValue(column.Name)
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, column.DataType)
+		if column.IsAutoIncrement {
+			column.DefaultValue = ""
+		}
+
+		tableColumns[table] = append(tableColumns[table], column)
+	}
+
+	return tableColumns, nil
+}
+
+// queryLateBindingViewColumns reads the columns of the late-binding views that match the filter, which are only
+// known to pg_get_late_binding_view_cols. Their nullability is unknown, so they are all nullable.
+func (c *redshiftConnector) queryLateBindingViewColumns(ctx context.Context, filter string, args ...any) (map[TableDetail][]ColumnResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select view_schema, view_name, col_name, col_type, col_num
+		from pg_get_late_binding_view_cols() cols(view_schema name, view_name name, col_name name, col_type varchar, col_num int)
+		where `+filter+`
+		order by view_schema, view_name, col_num`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tableColumns := make(map[TableDetail][]ColumnResult)
+	for rows.Next() {
+		var table TableDetail
+		column := ColumnResult{IsNullable: true}
+		if err = rows.Scan(&table.Schema, &table.Name, &column.Name, &column.DataType, &column.OrdinalPosition); err != nil {
+			return nil, err
+		}
+
+		table.Name = SanitizeValue(table.Name)
+		column.Name = SanitizeValue(column.Name)
+		setDataTypeParameters(&column, column.DataType)
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, column.DataType)
+
+		tableColumns[table] = append(tableColumns[table], column)
+	}
+
+	return tableColumns, nil
+}
+
+// GetConstraints returns the foreign keys only, as Redshift does not support inheritance
+func (c *redshiftConnector) GetConstraints(ctx context.Context, tableName TableDetail) ([]ConstraintResult, error) {
+	return c.queryConstraints(ctx, "(fk_ns.nspname = $1 and fk_cls.relname = $2) or (pk_ns.nspname = $1 and pk_cls.relname = $2)", tableName.Schema, tableName.Name)
+}
+
+// GetAllConstraints reads the foreign keys of all tables of the schemas with one query
+func (c *redshiftConnector) GetAllConstraints(ctx context.Context, schemaNames []string) ([]ConstraintResult, error) {
+	fkSchemaFilter, args := getRedshiftSchemaFilter("fk_ns.nspname", schemaNames)
+	// the placeholders of both filters refer to the same arguments
+	pkSchemaFilter, _ := getRedshiftSchemaFilter("pk_ns.nspname", schemaNames)
+	return c.queryConstraints(ctx, fkSchemaFilter+" or "+pkSchemaFilter, args...)
+}
+
+// queryConstraints reads the foreign keys from pg_constraint, as the information_schema of Redshift does not contain
+// the referential constraints. A foreign key is unique if a primary or unique key has exactly the same columns.
+func (c *redshiftConnector) queryConstraints(ctx context.Context, filter string, args ...any) ([]ConstraintResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+        select fk_cls.relname,
+               fk_ns.nspname,
+               pk_cls.relname,
+               pk_ns.nspname,
+               con.conname,
+               a.attname,
+               exists(select 1
+                      from pg_constraint pk
+                      where pk.conrelid = con.conrelid
+                        and pk.contype = 'p'
+                        and a.attnum = any (pk.conkey)),
+               coalesce((select array_upper(pk.conkey, 1) > 1
+                         from pg_constraint pk
+                         where pk.conrelid = con.conrelid
+                           and pk.contype = 'p'), false),
+               exists(select 1
+                      from pg_constraint uk
+                      where uk.conrelid = con.conrelid
+                        and uk.contype in ('p', 'u')
+                        and uk.conkey = con.conkey),
+               not a.attnotnull
+        from pg_constraint con
+                 inner join pg_class fk_cls on con.conrelid = fk_cls.oid
+                 inner join pg_namespace fk_ns on fk_cls.relnamespace = fk_ns.oid
+                 inner join pg_class pk_cls on con.confrelid = pk_cls.oid
+                 inner join pg_namespace pk_ns on pk_cls.relnamespace = pk_ns.oid
+                 inner join pg_attribute a on a.attrelid = con.conrelid and a.attnum = any (con.conkey)
+        where con.contype = 'f'
+          and (`+filter+`)
+        order by fk_ns.nspname, fk_cls.relname, con.conname, a.attnum`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var constraints []ConstraintResult
+	for rows.Next() {
+		var constraint ConstraintResult
+		err = rows.Scan(
+			&constraint.FkTable,
+			&constraint.FkSchema,
+			&constraint.PkTable,
+			&constraint.PkSchema,
+			&constraint.ConstraintName,
+			&constraint.ColumnName,
+			&constraint.IsPrimary,
+			&constraint.HasMultiplePK,
+			&constraint.IsUnique,
+			&constraint.IsNullable,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		constraints = append(constraints, constraint)
+	}
+
+	return constraints, nil
+}
+
+// GetIndexes returns the primary and unique keys, as Redshift has no indexes
+func (c *redshiftConnector) GetIndexes(ctx context.Context, tableName TableDetail) ([]IndexResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+        select con.conname,
+               a.attname,
+               true,
+               con.contype = 'p'
+        from pg_constraint con
+                 inner join pg_class cls on con.conrelid = cls.oid
+                 inner join pg_namespace ns on cls.relnamespace = ns.oid
+                 inner join pg_attribute a on a.attrelid = cls.oid and a.attnum = any (con.conkey)
+        where con.contype in ('p', 'u')
+          and cls.relname = $1 and ns.nspname = $2
+        order by con.conname, a.attnum`, tableName.Name, tableName.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
+
+// getRedshiftSchemaFilter returns the filter of the column for the schemas with numbered placeholders, as Redshift
+// does not support arrays as parameters
+func getRedshiftSchemaFilter(columnName string, schemaNames []string) (string, []any) {
+	placeholders := make([]string, len(schemaNames))
+	args := make([]any, len(schemaNames))
+	for i, schemaName := range schemaNames {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = schemaName
+	}
+
+	return columnName + " in (" + strings.Join(placeholders, ",") + ")", args
+}
+
+// getRedshiftDistStyle returns the effective distribution style of pg_class_info in the notation of CREATE TABLE,
+// e.g. KEY(customer_id) or AUTO(EVEN)
+func getRedshiftDistStyle(distStyle int, keyColumns []redshiftKeyColumn) string {
+	var distKey string
+	for _, column := range keyColumns {
+		if column.isDistKey {
+			distKey = "(" + column.name + ")"
+		}
+	}
+
+	switch distStyle {
+	case 0:
+		return "EVEN"
+	case 1:
+		return "KEY" + distKey
+	case 8:
+		return "ALL"
+	case 10:
+		return "AUTO(ALL)"
+	case 11:
+		return "AUTO(EVEN)"
+	case 12:
+		return "AUTO(KEY" + distKey + ")"
+	default:
+		return ""
+	}
+}
+
+// getRedshiftSortKey returns the sort key in the notation of CREATE TABLE, e.g. COMPOUND(created_at, id) or
+// INTERLEAVED(region, created_at). The key columns have to be ordered by their position in the sort key.
+func getRedshiftSortKey(keyColumns []redshiftKeyColumn) string {
+	var columnNames []string
+	isInterleaved := false
+	for _, column := range keyColumns {
+		if column.sortKeyOrder == 0 {
+			continue
+		}
+
+		isInterleaved = column.sortKeyOrder < 0
+		columnNames = append(columnNames, column.name)
+	}
+
+	if len(columnNames) == 0 {
+		return ""
+	}
+
+	if isInterleaved {
+		return "INTERLEAVED(" + strings.Join(columnNames, ", ") + ")"
+	}
+
+	return "COMPOUND(" + strings.Join(columnNames, ", ") + ")"
+}
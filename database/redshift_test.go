@@ -0,0 +1,66 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRedshiftDistStyle(t *testing.T) {
+	keyColumns := []redshiftKeyColumn{{name: "created_at", sortKeyOrder: 1}, {name: "customer_id", isDistKey: true}}
+	testCases := []struct {
+		distStyle      int
+		keyColumns     []redshiftKeyColumn
+		expectedResult string
+	}{
+		{0, nil, "EVEN"},
+		{1, keyColumns, "KEY(customer_id)"},
+		{8, nil, "ALL"},
+		{10, nil, "AUTO(ALL)"},
+		{11, nil, "AUTO(EVEN)"},
+		{12, keyColumns, "AUTO(KEY(customer_id))"},
+		{-1, nil, ""},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := getRedshiftDistStyle(testCase.distStyle, testCase.keyColumns)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestGetRedshiftSortKey(t *testing.T) {
+	testCases := []struct {
+		keyColumns     []redshiftKeyColumn
+		expectedResult string
+	}{
+		{nil, ""},
+		{[]redshiftKeyColumn{{name: "customer_id", isDistKey: true}}, ""},
+		{[]redshiftKeyColumn{{name: "customer_id", isDistKey: true}, {name: "created_at", sortKeyOrder: 1}, {name: "id", sortKeyOrder: 2}}, "COMPOUND(created_at, id)"},
+		{[]redshiftKeyColumn{{name: "region", sortKeyOrder: -1}, {name: "created_at", sortKeyOrder: -2}}, "INTERLEAVED(region, created_at)"},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := getRedshiftSortKey(testCase.keyColumns)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestGetRedshiftSchemaFilter(t *testing.T) {
+	// Act
+	filter, args := getRedshiftSchemaFilter("ns.nspname", []string{"public", "sales"})
+
+	// Assert
+	assert.Equal(t, "ns.nspname in ($1,$2)", filter)
+	assert.Equal(t, []any{"public", "sales"}, args)
+}
@@ -1,39 +1,149 @@
 package database
 
+import "reflect"
+
+// Result is the analyzed database model. The json and yaml tags are part of the public output format and must stay stable.
 type Result struct {
-	Tables []TableResult
+	Tables []TableResult `json:"tables" yaml:"tables"`
 }
 
 type TableResult struct {
-	Table       TableDetail
-	Columns     []ColumnResult
-	Constraints ConstraintResultList
+	Table       TableDetail          `json:"table" yaml:"table"`
+	Columns     []ColumnResult       `json:"columns" yaml:"columns"`
+	Constraints ConstraintResultList `json:"constraints" yaml:"constraints"`
+	Indexes     []IndexResult        `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+	// SampleRows are the first rows of the table (--sampleRows) with the values in the order of the columns
+	SampleRows [][]string `json:"sampleRows,omitempty" yaml:"sampleRows,omitempty"`
 }
 
 type TableDetail struct {
-	Schema string
-	Name   string
+	Schema   string `json:"schema" yaml:"schema"`
+	Name     string `json:"name" yaml:"name"`
+	Locality string `json:"locality,omitempty" yaml:"locality,omitempty"`
+	IsView   bool   `json:"isView,omitempty" yaml:"isView,omitempty"`
+	// Engine is the table engine, e.g. MergeTree (clickhouse)
+	Engine string `json:"engine,omitempty" yaml:"engine,omitempty"`
+	// Comment is the description of the table (bigquery and redshift)
+	Comment string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	// DistStyle and SortKey are the distribution style and the sort key of the table (redshift), e.g. KEY(customer_id)
+	// and COMPOUND(created_at, id)
+	DistStyle string `json:"distStyle,omitempty" yaml:"distStyle,omitempty"`
+	SortKey   string `json:"sortKey,omitempty" yaml:"sortKey,omitempty"`
+	// PartitionOf is the name of the partitioned table, if the table is one of its partitions (postgres)
+	PartitionOf string `json:"partitionOf,omitempty" yaml:"partitionOf,omitempty"`
 }
 
 type ColumnResult struct {
-	Name       string
-	DataType   string
-	IsPrimary  bool
-	IsForeign  bool
-	EnumValues string
-	Comment    string
+	Name      string `json:"name" yaml:"name"`
+	DataType  string `json:"dataType" yaml:"dataType"`
+	IsPrimary bool   `json:"isPrimary" yaml:"isPrimary"`
+	IsForeign bool   `json:"isForeign" yaml:"isForeign"`
+	// IsUnique is true if the column alone is a unique key besides the primary key (unique constraint or index)
+	IsUnique    bool   `json:"isUnique,omitempty" yaml:"isUnique,omitempty"`
+	EnumValues  string `json:"enumValues" yaml:"enumValues"`
+	Comment     string `json:"comment" yaml:"comment"`
+	IsGenerated bool   `json:"isGenerated" yaml:"isGenerated"`
+	// IsAutoIncrement is true for identity and auto_increment columns, SequenceName is the sequence of the column
+	// default (e.g. of serial columns)
+	IsAutoIncrement bool   `json:"isAutoIncrement" yaml:"isAutoIncrement"`
+	SequenceName    string `json:"sequenceName,omitempty" yaml:"sequenceName,omitempty"`
+	IsNullable      bool   `json:"isNullable" yaml:"isNullable"`
+	// CheckConstraints contains the check constraints that restrict the values of the column (joined with "and")
+	CheckConstraints string `json:"checkConstraints,omitempty" yaml:"checkConstraints,omitempty"`
+	// DefaultValue contains the default expression of the column as defined in the database (e.g. now())
+	DefaultValue string `json:"defaultValue,omitempty" yaml:"defaultValue,omitempty"`
+	// OrdinalPosition is the position of the column in the table definition (starting with 1)
+	OrdinalPosition int `json:"ordinalPosition,omitempty" yaml:"ordinalPosition,omitempty"`
+	// CharacterMaxLength is the declared length of character types (e.g. varchar(255)), NumericPrecision and
+	// NumericScale are declared for decimal types (e.g. numeric(10,2)). They are 0 if they are not declared.
+	CharacterMaxLength int `json:"characterMaxLength,omitempty" yaml:"characterMaxLength,omitempty"`
+	NumericPrecision   int `json:"numericPrecision,omitempty" yaml:"numericPrecision,omitempty"`
+	NumericScale       int `json:"numericScale,omitempty" yaml:"numericScale,omitempty"`
+	// Classifications are the names of the classifications (see config.Classification) that match the column, e.g. pii
+	Classifications []string `json:"classifications,omitempty" yaml:"classifications,omitempty"`
+}
+
+// IndexResult is an index or a unique constraint of the table (including the primary key)
+type IndexResult struct {
+	Name        string   `json:"name" yaml:"name"`
+	ColumnNames []string `json:"columnNames" yaml:"columnNames"`
+	IsUnique    bool     `json:"isUnique" yaml:"isUnique"`
+	IsPrimary   bool     `json:"isPrimary" yaml:"isPrimary"`
 }
 
 type ConstraintResultList []ConstraintResult
 type ConstraintResult struct {
-	FkTable        string
-	FkSchema       string
-	PkTable        string
-	PkSchema       string
-	ConstraintName string
-	ColumnName     string
-	IsPrimary      bool
-	HasMultiplePK  bool
+	FkTable        string `json:"fkTable" yaml:"fkTable"`
+	FkSchema       string `json:"fkSchema" yaml:"fkSchema"`
+	PkTable        string `json:"pkTable" yaml:"pkTable"`
+	PkSchema       string `json:"pkSchema" yaml:"pkSchema"`
+	ConstraintName string `json:"constraintName" yaml:"constraintName"`
+	ColumnName     string `json:"columnName" yaml:"columnName"`
+	IsPrimary      bool   `json:"isPrimary" yaml:"isPrimary"`
+	HasMultiplePK  bool   `json:"hasMultiplePk" yaml:"hasMultiplePk"`
+	// IsUnique is true if the columns of the foreign key have a unique constraint (or index), which makes the
+	// relation one-to-one
+	IsUnique bool `json:"isUnique" yaml:"isUnique"`
+	// IsNullable is true if the columns of the foreign key allow null values, which makes the relation optional
+	IsNullable bool `json:"isNullable" yaml:"isNullable"`
+	// ColumnNames contains all columns of composite foreign keys (ColumnName is the first one)
+	ColumnNames []string `json:"columnNames,omitempty" yaml:"columnNames,omitempty"`
+	// IsInferred is true if the foreign key is not declared in the database, but derived from the column name
+	IsInferred bool `json:"isInferred,omitempty" yaml:"isInferred,omitempty"`
+	// IsInheritance is true if the FK table inherits from the PK table (postgres INHERITS), which is no foreign key
+	// and has neither a constraint name nor columns
+	IsInheritance bool `json:"isInheritance,omitempty" yaml:"isInheritance,omitempty"`
+}
+
+// Equals compares all fields of the constraints, as the struct is not comparable with == (ColumnNames is a slice)
+func (c ConstraintResult) Equals(other ConstraintResult) bool {
+	return reflect.DeepEqual(c, other)
+}
+
+// MergeCompositeConstraints merges the results of the connectors, which return one result per column, so that
+// foreign keys with multiple columns are represented by one result with all ColumnNames
+func MergeCompositeConstraints(constraints []ConstraintResult) []ConstraintResult {
+	var result []ConstraintResult
+	for _, constraint := range constraints {
+		index := findCompositeConstraint(result, constraint)
+		if index < 0 {
+			if len(constraint.ColumnNames) == 0 && !constraint.IsInheritance {
+				constraint.ColumnNames = []string{constraint.ColumnName}
+			}
+			result = append(result, constraint)
+			continue
+		}
+
+		// self-referencing foreign keys can be returned twice (as foreign key of the table and as reference to it)
+		if containsString(result[index].ColumnNames, constraint.ColumnName) {
+			continue
+		}
+
+		// the relation is only one-to-one if all columns are part of the primary key
+		result[index].ColumnNames = append(result[index].ColumnNames, constraint.ColumnName)
+		result[index].IsPrimary = result[index].IsPrimary && constraint.IsPrimary
+		// the foreign key is not enforced as soon as one of the columns is null
+		result[index].IsNullable = result[index].IsNullable || constraint.IsNullable
+	}
+
+	return result
+}
+
+// IsSelfReference is true if the foreign key references the table itself (e.g. employee.manager_id -> employee.id)
+func (c ConstraintResult) IsSelfReference() bool {
+	return c.FkSchema == c.PkSchema && c.FkTable == c.PkTable
+}
+
+func findCompositeConstraint(constraints []ConstraintResult, constraint ConstraintResult) int {
+	for index, item := range constraints {
+		if item.ConstraintName == constraint.ConstraintName &&
+			item.FkSchema == constraint.FkSchema && item.FkTable == constraint.FkTable &&
+			item.PkSchema == constraint.PkSchema && item.PkTable == constraint.PkTable {
+			return index
+		}
+	}
+
+	return -1
 }
 
 // AppendIfNotExists ensures that only unique items are appended to the list of constraints
@@ -48,7 +158,7 @@ func (source ConstraintResultList) AppendIfNotExists(items ...ConstraintResult)
 	return result
 }
 
-func sliceContainsConstraint(slice []ConstraintResult, item ConstraintResult) bool {
+func containsString(slice []string, item string) bool {
 	for _, sliceItem := range slice {
 		if sliceItem == item {
 			return true
@@ -57,3 +167,13 @@ func sliceContainsConstraint(slice []ConstraintResult, item ConstraintResult) bo
 
 	return false
 }
+
+func sliceContainsConstraint(slice []ConstraintResult, item ConstraintResult) bool {
+	for _, sliceItem := range slice {
+		if sliceItem.Equals(item) {
+			return true
+		}
+	}
+
+	return false
+}
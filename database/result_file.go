@@ -0,0 +1,35 @@
+package database
+
+import (
+	"encoding/json"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadResult reads a database model that was exported with the yaml or json output format
+func ReadResult(fileName string) (*Result, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	// yaml is a superset of json, so both export formats can be read with the yaml decoder
+	var result Result
+	if err = yaml.Unmarshal(content, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// WriteResult writes the database model as json, which can be read again with ReadResult. The file is only readable
+// by the user, as the model may contain sample rows.
+func WriteResult(fileName string, result *Result) error {
+	content, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, content, 0o600)
+}
@@ -1,6 +1,10 @@
 package database
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -41,3 +45,102 @@ func TestConstraintResultList_AppendIfNotExists(t *testing.T) {
 		assert.Len(t, result, expectedCount)
 	})
 }
+
+func TestResult_Json(t *testing.T) {
+	// Arrange
+	result := Result{Tables: []TableResult{{
+		Table:       TableDetail{Schema: "public", Name: "article"},
+		Columns:     []ColumnResult{{Name: "id", DataType: "int", IsPrimary: true}},
+		Constraints: ConstraintResultList{{FkTable: "comment", PkTable: "article", ColumnName: "article_id"}},
+	}}}
+
+	// Act
+	data, err := json.Marshal(result)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"tables": [{
+		"table": {"schema": "public", "name": "article"},
+		"columns": [{"name": "id", "dataType": "int", "isPrimary": true, "isForeign": false, "enumValues": "", "comment": "", "isGenerated": false, "isAutoIncrement": false, "isNullable": false}],
+		"constraints": [{"fkTable": "comment", "fkSchema": "", "pkTable": "article", "pkSchema": "", "constraintName": "", "columnName": "article_id", "isPrimary": false, "hasMultiplePk": false, "isUnique": false, "isNullable": false}]
+	}]}`, string(data))
+}
+
+func TestReadResult(t *testing.T) {
+	testCases := []struct {
+		fileName string
+		content  string
+	}{
+		{"model.yaml", "tables:\n  - table:\n      schema: public\n      name: article\n    columns:\n      - name: id\n        dataType: int\n        isPrimary: true\n"},
+		{"model.json", `{"tables": [{"table": {"schema": "public", "name": "article"}, "columns": [{"name": "id", "dataType": "int", "isPrimary": true}]}]}`},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Arrange
+			fileName := filepath.Join(t.TempDir(), testCase.fileName)
+			_ = os.WriteFile(fileName, []byte(testCase.content), 0644)
+
+			// Act
+			result, err := ReadResult(fileName)
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, &Result{Tables: []TableResult{{
+				Table:   TableDetail{Schema: "public", Name: "article"},
+				Columns: []ColumnResult{{Name: "id", DataType: "int", IsPrimary: true}},
+			}}}, result)
+		})
+	}
+
+	t.Run("Missing file", func(t *testing.T) {
+		// Act
+		result, err := ReadResult("missing.yaml")
+
+		// Assert
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestMergeCompositeConstraints(t *testing.T) {
+	// Arrange
+	constraints := []ConstraintResult{
+		{FkTable: "test_1_b", PkTable: "test_1_a", ConstraintName: "fk_composite", ColumnName: "aid", IsPrimary: true, HasMultiplePK: true},
+		{FkTable: "test_1_b", PkTable: "test_1_a", ConstraintName: "fk_composite", ColumnName: "bid", IsPrimary: false, HasMultiplePK: true, IsNullable: true},
+		{FkTable: "test_1_b", PkTable: "label", ConstraintName: "fk_label", ColumnName: "label_id"},
+		{FkTable: "employee", PkTable: "employee", ConstraintName: "fk_manager", ColumnName: "manager_id"},
+		{FkTable: "employee", PkTable: "employee", ConstraintName: "fk_manager", ColumnName: "manager_id"},
+	}
+
+	// Act
+	result := MergeCompositeConstraints(constraints)
+
+	// Assert
+	assert.Equal(t, []ConstraintResult{
+		{FkTable: "test_1_b", PkTable: "test_1_a", ConstraintName: "fk_composite", ColumnName: "aid", IsPrimary: false, HasMultiplePK: true, IsNullable: true, ColumnNames: []string{"aid", "bid"}},
+		{FkTable: "test_1_b", PkTable: "label", ConstraintName: "fk_label", ColumnName: "label_id", ColumnNames: []string{"label_id"}},
+		{FkTable: "employee", PkTable: "employee", ConstraintName: "fk_manager", ColumnName: "manager_id", ColumnNames: []string{"manager_id"}},
+	}, result)
+}
+
+func TestConstraintResult_IsSelfReference(t *testing.T) {
+	testCases := []struct {
+		constraint     ConstraintResult
+		expectedResult bool
+	}{
+		{ConstraintResult{FkSchema: "public", FkTable: "employee", PkSchema: "public", PkTable: "employee"}, true},
+		{ConstraintResult{FkSchema: "public", FkTable: "employee", PkSchema: "public", PkTable: "department"}, false},
+		{ConstraintResult{FkSchema: "public", FkTable: "employee", PkSchema: "archive", PkTable: "employee"}, false},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := testCase.constraint.IsSelfReference()
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// sampleValueMaxLength is the maximum number of characters of the sample values, longer values are shortened
+const sampleValueMaxLength = 50
+
+// SampleConnector is implemented by the connectors that can read sample rows of the tables (--sampleRows). The values
+// of a row are in the order of the column names, null values are empty.
+type SampleConnector interface {
+	GetSampleRows(ctx context.Context, tableName TableDetail, columnNames []string, limit int) ([][]string, error)
+}
+
+// getSampleRowsQuery returns the query of the first rows of the table, which only reads the given columns
+func getSampleRowsQuery(dbType DbType, tableName TableDetail, columnNames []string, limit int) string {
+	columns := make([]string, len(columnNames))
+	for i, columnName := range columnNames {
+		columns[i] = quoteSampleIdentifier(dbType, columnName)
+	}
+
+	table := quoteSampleIdentifier(dbType, tableName.Name)
+	if tableName.Schema != "" {
+		table = quoteSampleIdentifier(dbType, tableName.Schema) + "." + table
+	}
+
+	if dbType == MsSql {
+		return fmt.Sprintf("select top %d %s from %s", limit, strings.Join(columns, ", "), table)
+	}
+
+	return fmt.Sprintf("select %s from %s limit %d", strings.Join(columns, ", "), table, limit)
+}
+
+func quoteSampleIdentifier(dbType DbType, name string) string {
+	switch dbType {
+	case MySql, MariaDb, ClickHouse:
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	case MsSql:
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	default:
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+}
+
+// querySampleRows reads the sample rows of the table, which is used by all connectors with a database/sql driver
+func querySampleRows(ctx context.Context, db *sql.DB, dbType DbType, tableName TableDetail, columnNames []string, limit int) ([][]string, error) {
+	rows, err := queryContext(ctx, db, getSampleRowsQuery(dbType, tableName, columnNames, limit))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	var sampleRows [][]string
+	for rows.Next() {
+		values := make([]any, len(columnNames))
+		pointers := make([]any, len(columnNames))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		sampleRow := make([]string, len(values))
+		for i, value := range values {
+			sampleRow[i] = formatSampleValue(value)
+		}
+
+		sampleRows = append(sampleRows, sampleRow)
+	}
+
+	return sampleRows, rows.Err()
+}
+
+// formatSampleValue converts the value of the driver to a string, binary values are not shown
+func formatSampleValue(value any) string {
+	var result string
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		if !utf8.Valid(typedValue) {
+			return fmt.Sprintf("(%d bytes)", len(typedValue))
+		}
+		result = string(typedValue)
+	case time.Time:
+		result = typedValue.Format(time.RFC3339Nano)
+	default:
+		result = fmt.Sprint(typedValue)
+	}
+
+	result = strings.Join(strings.Fields(result), " ")
+	if utf8.RuneCountInString(result) > sampleValueMaxLength {
+		result = string([]rune(result)[:sampleValueMaxLength-3]) + "..."
+	}
+
+	return result
+}
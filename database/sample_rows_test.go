@@ -0,0 +1,56 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSampleRowsQuery(t *testing.T) {
+	testCases := []struct {
+		dbType        DbType
+		expectedQuery string
+	}{
+		{Postgres, `select "id", "first ""name""" from "public"."user" limit 3`},
+		{MySql, "select `id`, `first \"name\"` from `public`.`user` limit 3"},
+		{MsSql, `select top 3 [id], [first "name"] from [public].[user]`},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			query := getSampleRowsQuery(testCase.dbType, TableDetail{Schema: "public", Name: "user"}, []string{"id", `first "name"`}, 3)
+
+			// Assert
+			assert.Equal(t, testCase.expectedQuery, query)
+		})
+	}
+}
+
+func TestFormatSampleValue(t *testing.T) {
+	testCases := []struct {
+		value          any
+		expectedResult string
+	}{
+		{nil, ""},
+		{int64(42), "42"},
+		{[]byte("text"), "text"},
+		{[]byte{0xff, 0xfe}, "(2 bytes)"},
+		{time.Date(2023, 5, 30, 12, 0, 0, 0, time.UTC), "2023-05-30T12:00:00Z"},
+		{"multi\nline", "multi line"},
+		{strings.Repeat("a", 60), strings.Repeat("a", 47) + "..."},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := formatSampleValue(testCase.value)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/snowflakedb/gosnowflake"
+)
+
+type snowflakeConnector baseConnector
+
+func init() {
+	RegisterConnector("snowflake", newSnowflakeConnector)
+}
+
+func newSnowflakeConnector(connectionString string, _ io.Closer, options ConnectorOptions) (Connector, error) {
+	return &snowflakeConnector{
+		dbType:           Snowflake,
+		connectionString: strings.ReplaceAll(connectionString, "snowflake://", ""),
+		dataTypes:        options.DataTypes,
+	}, nil
+}
+
+// snowflakeRow is a single row of a snowflake SHOW command, mapped by the lower case column name
+type snowflakeRow map[string]string
+
+func (c *snowflakeConnector) GetDbType() DbType {
+	return c.dbType
+}
+
+func (c *snowflakeConnector) Connect(ctx context.Context) error {
+	db, err := sql.Open(c.dbType.String(), c.connectionString)
+	if err != nil {
+		return err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	c.db = db
+	return nil
+}
+
+func (c *snowflakeConnector) Close() {
+	closeConnection(c.db, c.tunnel)
+}
+
+func (c *snowflakeConnector) GetSchemas(ctx context.Context) ([]string, error) {
+	rows, err := queryContext(ctx, c.db, "select schema_name from information_schema.schemata")
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err = rows.Scan(&schema); err != nil {
+			return nil, err
+		}
+
+		schemas = append(schemas, schema)
+	}
+
+	return schemas, nil
+}
+
+func (c *snowflakeConnector) GetTables(ctx context.Context, schemaNames []string) ([]TableDetail, error) {
+	schemaFilter, args := getMySqlSchemaFilter("table_schema", schemaNames)
+	rows, err := queryContext(ctx, c.db, `
+		select table_schema, table_name, table_type in ('VIEW', 'MATERIALIZED VIEW')
+		from information_schema.tables
+		where table_type in ('BASE TABLE', 'VIEW', 'MATERIALIZED VIEW')
+		  and `+schemaFilter+`
+		`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []TableDetail
+	for rows.Next() {
+		var table TableDetail
+		if err = rows.Scan(&table.Schema, &table.Name, &table.IsView); err != nil {
+			return nil, err
+		}
+
+		table.Name = SanitizeValue(table.Name)
+
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
+
+// GetModificationTimes returns the last_altered time of the tables, which also changes with DML statements, so
+// tables with new rows are analyzed again as well
+func (c *snowflakeConnector) GetModificationTimes(ctx context.Context, schemaNames []string) (map[TableDetail]time.Time, error) {
+	schemaFilter, args := getMySqlSchemaFilter("table_schema", schemaNames)
+	rows, err := queryContext(ctx, c.db, `
+		select table_schema, table_name, last_altered
+		from information_schema.tables
+		where table_type in ('BASE TABLE', 'VIEW', 'MATERIALIZED VIEW')
+		  and `+schemaFilter+`
+		`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanModificationTimes(rows)
+}
+
+// GetColumns reads the columns from the information_schema. Snowflake has no key_column_usage view, so the
+// key information is taken from the SHOW PRIMARY KEYS and SHOW IMPORTED KEYS commands. Snowflake does not support
+// check constraints, so they are always empty.
+func (c *snowflakeConnector) GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	primaryKeys, err := c.show(ctx, "show primary keys in table", tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	importedKeys, err := c.show(ctx, "show imported keys in table", tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	uniqueKeys, err := c.show(ctx, "show unique keys in table", tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queryContext(ctx, c.db, `
+		select c.column_name,
+			   c.data_type,
+			   coalesce(c.character_maximum_length, 0) as character_maximum_length,
+			   coalesce(c.numeric_precision, 0)        as numeric_precision,
+			   coalesce(c.numeric_scale, 0)            as numeric_scale,
+			   coalesce(c.comment, '') as comment,
+			   c.is_identity = 'YES' as is_auto_increment,
+			   c.is_nullable = 'YES' as is_nullable,
+			   coalesce(c.column_default, '') as default_value,
+			   c.ordinal_position
+		from information_schema.columns c
+		where c.table_name = ? and c.table_schema = ?
+		order by c.ordinal_position;
+		`, tableName.Name, tableName.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []ColumnResult
+	for rows.Next() {
+		var column ColumnResult
+		if err = rows.Scan(&column.Name, &column.DataType, &column.CharacterMaxLength, &column.NumericPrecision, &column.NumericScale, &column.Comment, &column.IsAutoIncrement, &column.IsNullable, &column.DefaultValue, &column.OrdinalPosition); err != nil {
+			return nil, err
+		}
+
+		column.IsPrimary = snowflakeRowsContainColumn(primaryKeys, "column_name", column.Name)
+		column.IsForeign = snowflakeRowsContainColumn(importedKeys, "fk_column_name", column.Name)
+		column.IsUnique = isSnowflakeKeyUnique(map[string]bool{column.Name: true}, uniqueKeys)
+		column.SequenceName = parseSnowflakeSequenceName(column.DefaultValue)
+		column.Name = SanitizeValue(column.Name)
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, column.DataType)
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+func (c *snowflakeConnector) GetConstraints(ctx context.Context, tableName TableDetail) ([]ConstraintResult, error) {
+	importedKeys, err := c.show(ctx, "show imported keys in table", tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	exportedKeys, err := c.show(ctx, "show exported keys in table", tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var constraints []ConstraintResult
+	keys := append(importedKeys, getSnowflakeExternalKeys(exportedKeys)...)
+	for _, key := range keys {
+		fkTable := TableDetail{Schema: key["fk_schema_name"], Name: key["fk_table_name"]}
+		primaryKeys, err := c.show(ctx, "show primary keys in table", fkTable)
+		if err != nil {
+			return nil, err
+		}
+
+		uniqueKeys, err := c.show(ctx, "show unique keys in table", fkTable)
+		if err != nil {
+			return nil, err
+		}
+
+		constraint := getSnowflakeConstraint(key, primaryKeys)
+		constraint.IsUnique = isSnowflakeKeyUnique(getSnowflakeForeignKeyColumns(keys, key), append(primaryKeys, uniqueKeys...))
+		constraint.IsNullable, err = c.isColumnNullable(ctx, fkTable, key["fk_column_name"])
+		if err != nil {
+			return nil, err
+		}
+
+		constraints = append(constraints, constraint)
+	}
+
+	return constraints, nil
+}
+
+// isColumnNullable checks the foreign key column, as the SHOW commands do not return the nullability
+func (c *snowflakeConnector) isColumnNullable(ctx context.Context, tableName TableDetail, columnName string) (bool, error) {
+	var isNullable bool
+	err := queryRowContext(ctx, c.db, `
+		select c.is_nullable = 'YES'
+		from information_schema.columns c
+		where c.table_schema = ? and c.table_name = ? and c.column_name = ?;
+		`, tableName.Schema, tableName.Name, columnName).Scan(&isNullable)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+
+	return isNullable, err
+}
+
+// show executes a snowflake SHOW command for the given table, e.g. "show primary keys in table"
+func (c *snowflakeConnector) show(ctx context.Context, command string, tableName TableDetail) ([]snowflakeRow, error) {
+	rows, err := queryContext(ctx, c.db, fmt.Sprintf("%s %s.%s", command, quoteSnowflakeIdentifier(tableName.Schema), quoteSnowflakeIdentifier(tableName.Name)))
+	if err != nil {
+		return nil, err
+	}
+
+	columnNames, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []snowflakeRow
+	for rows.Next() {
+		values := make([]sql.NullString, len(columnNames))
+		valuePointers := make([]any, len(columnNames))
+		for i := range values {
+			valuePointers[i] = &values[i]
+		}
+
+		if err = rows.Scan(valuePointers...); err != nil {
+			return nil, err
+		}
+
+		row := snowflakeRow{}
+		for i, columnName := range columnNames {
+			row[strings.ToLower(columnName)] = values[i].String
+		}
+
+		result = append(result, row)
+	}
+
+	return result, nil
+}
+
+func getSnowflakeConstraint(key snowflakeRow, fkTablePrimaryKeys []snowflakeRow) ConstraintResult {
+	return ConstraintResult{
+		FkTable:        key["fk_table_name"],
+		FkSchema:       key["fk_schema_name"],
+		PkTable:        key["pk_table_name"],
+		PkSchema:       key["pk_schema_name"],
+		ConstraintName: key["fk_name"],
+		ColumnName:     key["fk_column_name"],
+		IsPrimary:      snowflakeRowsContainColumn(fkTablePrimaryKeys, "column_name", key["fk_column_name"]),
+		HasMultiplePK:  len(fkTablePrimaryKeys) > 1,
+	}
+}
+
+// getSnowflakeExternalKeys removes the self-referencing keys (e.g. employee.manager_id -> employee.id), as they are
+// part of the imported and the exported keys
+func getSnowflakeExternalKeys(exportedKeys []snowflakeRow) []snowflakeRow {
+	var keys []snowflakeRow
+	for _, key := range exportedKeys {
+		if key["fk_schema_name"] != key["pk_schema_name"] || key["fk_table_name"] != key["pk_table_name"] {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// getSnowflakeForeignKeyColumns returns all columns of the foreign key, as the keys are returned per column
+func getSnowflakeForeignKeyColumns(keys []snowflakeRow, key snowflakeRow) map[string]bool {
+	columnNames := map[string]bool{}
+	for _, row := range keys {
+		if row["fk_name"] == key["fk_name"] && row["fk_schema_name"] == key["fk_schema_name"] &&
+			row["fk_table_name"] == key["fk_table_name"] {
+			columnNames[row["fk_column_name"]] = true
+		}
+	}
+
+	return columnNames
+}
+
+// isSnowflakeKeyUnique checks if the columns are exactly the columns of one of the primary or unique keys
+func isSnowflakeKeyUnique(columnNames map[string]bool, uniqueKeys []snowflakeRow) bool {
+	keyColumns := map[string]map[string]bool{}
+	for _, row := range uniqueKeys {
+		if keyColumns[row["constraint_name"]] == nil {
+			keyColumns[row["constraint_name"]] = map[string]bool{}
+		}
+		keyColumns[row["constraint_name"]][row["column_name"]] = true
+	}
+
+	for _, columns := range keyColumns {
+		matches := len(columns) == len(columnNames)
+		for columnName := range columnNames {
+			matches = matches && columns[columnName]
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+func snowflakeRowsContainColumn(rows []snowflakeRow, key string, columnName string) bool {
+	for _, row := range rows {
+		if row[key] == columnName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// quoteSnowflakeIdentifier quotes the identifier, so that it is used as it is stored in the information_schema.
+// Without quotes snowflake would resolve the identifier in upper case, which does not work for case-sensitive
+// identifiers that were created with quotes (e.g. "myTable").
+func quoteSnowflakeIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+// GetIndexes returns the primary and unique keys, as snowflake has no indexes on standard tables
+func (c *snowflakeConnector) GetIndexes(ctx context.Context, tableName TableDetail) ([]IndexResult, error) {
+	primaryKeys, err := c.show(ctx, "show primary keys in table", tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	uniqueKeys, err := c.show(ctx, "show unique keys in table", tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(getSnowflakeIndexes(primaryKeys, true), getSnowflakeIndexes(uniqueKeys, false)...), nil
+}
+
+func (c *snowflakeConnector) GetSampleRows(ctx context.Context, tableName TableDetail, columnNames []string, limit int) ([][]string, error) {
+	return querySampleRows(ctx, c.db, c.dbType, tableName, columnNames, limit)
+}
+
+// getSnowflakeIndexes groups the rows of the SHOW PRIMARY KEYS or SHOW UNIQUE KEYS command (one row per column)
+func getSnowflakeIndexes(keys []snowflakeRow, isPrimary bool) []IndexResult {
+	sort.SliceStable(keys, func(i, j int) bool {
+		sequenceI, _ := strconv.Atoi(keys[i]["key_sequence"])
+		sequenceJ, _ := strconv.Atoi(keys[j]["key_sequence"])
+		return sequenceI < sequenceJ
+	})
+
+	var indexes []IndexResult
+	for _, key := range keys {
+		found := false
+		for index := range indexes {
+			if indexes[index].Name == key["constraint_name"] {
+				indexes[index].ColumnNames = append(indexes[index].ColumnNames, key["column_name"])
+				found = true
+			}
+		}
+
+		if !found {
+			indexes = append(indexes, IndexResult{
+				Name:        key["constraint_name"],
+				ColumnNames: []string{key["column_name"]},
+				IsUnique:    true,
+				IsPrimary:   isPrimary,
+			})
+		}
+	}
+
+	return indexes
+}
+
+// parseSnowflakeSequenceName extracts the sequence name of a column default like "DB"."PUBLIC"."SEQUENCE".NEXTVAL
+func parseSnowflakeSequenceName(columnDefault string) string {
+	columnDefault = strings.TrimSpace(columnDefault)
+	if !strings.HasSuffix(strings.ToUpper(columnDefault), ".NEXTVAL") {
+		return ""
+	}
+
+	return strings.ReplaceAll(columnDefault[:len(columnDefault)-len(".NEXTVAL")], `"`, "")
+}
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteSnowflakeIdentifier(t *testing.T) {
+	testCases := []struct {
+		identifier     string
+		expectedResult string
+	}{
+		{"PUBLIC", `"PUBLIC"`},
+		{"myTable", `"myTable"`},
+		{`my"Table`, `"my""Table"`},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := quoteSnowflakeIdentifier(testCase.identifier)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestGetSnowflakeConstraint(t *testing.T) {
+	key := snowflakeRow{
+		"fk_schema_name": "PUBLIC",
+		"fk_table_name":  "article_label",
+		"fk_column_name": "article_id",
+		"fk_name":        "FK_ARTICLE_LABEL_ARTICLE_ID",
+		"pk_schema_name": "PUBLIC",
+		"pk_table_name":  "article",
+	}
+
+	t.Run("FK column is the only PK", func(t *testing.T) {
+		// Arrange
+		primaryKeys := []snowflakeRow{{"column_name": "article_id"}}
+
+		// Act
+		result := getSnowflakeConstraint(key, primaryKeys)
+
+		// Assert
+		assert.Equal(t, ConstraintResult{
+			FkTable:        "article_label",
+			FkSchema:       "PUBLIC",
+			PkTable:        "article",
+			PkSchema:       "PUBLIC",
+			ConstraintName: "FK_ARTICLE_LABEL_ARTICLE_ID",
+			ColumnName:     "article_id",
+			IsPrimary:      true,
+			HasMultiplePK:  false,
+		}, result)
+	})
+
+	t.Run("FK column is one of multiple PK", func(t *testing.T) {
+		// Arrange
+		primaryKeys := []snowflakeRow{{"column_name": "article_id"}, {"column_name": "label_id"}}
+
+		// Act
+		result := getSnowflakeConstraint(key, primaryKeys)
+
+		// Assert
+		assert.True(t, result.IsPrimary)
+		assert.True(t, result.HasMultiplePK)
+	})
+
+	t.Run("FK column is no PK", func(t *testing.T) {
+		// Arrange
+		primaryKeys := []snowflakeRow{{"column_name": "id"}}
+
+		// Act
+		result := getSnowflakeConstraint(key, primaryKeys)
+
+		// Assert
+		assert.False(t, result.IsPrimary)
+		assert.False(t, result.HasMultiplePK)
+	})
+}
+
+func TestIsSnowflakeKeyUnique(t *testing.T) {
+	uniqueKeys := []snowflakeRow{
+		{"constraint_name": "PK_ARTICLE_LABEL", "column_name": "article_id"},
+		{"constraint_name": "PK_ARTICLE_LABEL", "column_name": "label_id"},
+		{"constraint_name": "UQ_ARTICLE_LABEL_CODE", "column_name": "code"},
+	}
+	testCases := []struct {
+		keys           []snowflakeRow
+		expectedResult bool
+	}{
+		{[]snowflakeRow{{"fk_name": "FK_ARTICLE", "fk_column_name": "article_id"}}, false},
+		{[]snowflakeRow{{"fk_name": "FK_CODE", "fk_column_name": "code"}}, true},
+		{[]snowflakeRow{{"fk_name": "FK_BOTH", "fk_column_name": "label_id"}, {"fk_name": "FK_BOTH", "fk_column_name": "article_id"}}, true},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := isSnowflakeKeyUnique(getSnowflakeForeignKeyColumns(testCase.keys, testCase.keys[0]), uniqueKeys)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestGetSnowflakeExternalKeys(t *testing.T) {
+	// Arrange
+	exportedKeys := []snowflakeRow{
+		{"fk_schema_name": "PUBLIC", "fk_table_name": "employee", "pk_schema_name": "PUBLIC", "pk_table_name": "employee"},
+		{"fk_schema_name": "PUBLIC", "fk_table_name": "department", "pk_schema_name": "PUBLIC", "pk_table_name": "employee"},
+	}
+
+	// Act
+	result := getSnowflakeExternalKeys(exportedKeys)
+
+	// Assert
+	assert.Equal(t, []snowflakeRow{exportedKeys[1]}, result)
+}
+
+func TestGetSnowflakeIndexes(t *testing.T) {
+	// Arrange
+	keys := []snowflakeRow{
+		{"constraint_name": "UQ_TENANT_CODE", "column_name": "code", "key_sequence": "2"},
+		{"constraint_name": "UQ_EMAIL", "column_name": "email", "key_sequence": "1"},
+		{"constraint_name": "UQ_TENANT_CODE", "column_name": "tenant_id", "key_sequence": "1"},
+	}
+
+	// Act
+	result := getSnowflakeIndexes(keys, false)
+
+	// Assert
+	assert.Equal(t, []IndexResult{
+		{Name: "UQ_EMAIL", ColumnNames: []string{"email"}, IsUnique: true},
+		{Name: "UQ_TENANT_CODE", ColumnNames: []string{"tenant_id", "code"}, IsUnique: true},
+	}, result)
+}
+
+func TestParseSnowflakeSequenceName(t *testing.T) {
+	testCases := []struct {
+		columnDefault        string
+		expectedSequenceName string
+	}{
+		{`"MERMERD"."PUBLIC"."ORDER_SEQ".NEXTVAL`, "MERMERD.PUBLIC.ORDER_SEQ"},
+		{"order_seq.nextval", "order_seq"},
+		{"CURRENT_TIMESTAMP()", ""},
+		{"", ""},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := parseSnowflakeSequenceName(testCase.columnDefault)
+
+			// Assert
+			assert.Equal(t, testCase.expectedSequenceName, result)
+		})
+	}
+}
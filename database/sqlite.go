@@ -0,0 +1,264 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+type sqliteConnector baseConnector
+
+func init() {
+	RegisterConnector("sqlite", newSqliteConnector)
+}
+
+func newSqliteConnector(connectionString string, _ io.Closer, options ConnectorOptions) (Connector, error) {
+	return &sqliteConnector{
+		dbType:           Sqlite,
+		connectionString: strings.ReplaceAll(connectionString, "sqlite://", ""),
+		dataTypes:        options.DataTypes,
+	}, nil
+}
+
+func (c *sqliteConnector) GetDbType() DbType {
+	return c.dbType
+}
+
+func (c *sqliteConnector) Connect(ctx context.Context) error {
+	db, err := sql.Open(c.dbType.String(), c.connectionString)
+	if err != nil {
+		return err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	c.db = db
+	return nil
+}
+
+func (c *sqliteConnector) Close() {
+	closeConnection(c.db, c.tunnel)
+}
+
+// GetSchemas returns the main database and all attached databases
+func (c *sqliteConnector) GetSchemas(ctx context.Context) ([]string, error) {
+	rows, err := queryContext(ctx, c.db, "select name from pragma_database_list where name != 'temp'")
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err = rows.Scan(&schema); err != nil {
+			return nil, err
+		}
+
+		schemas = append(schemas, schema)
+	}
+
+	return schemas, nil
+}
+
+// GetWritePrivileges only depends on the query_only pragma, which is turned on for the read-only session, as sqlite has
+// no users
+func (c *sqliteConnector) GetWritePrivileges(ctx context.Context) ([]string, error) {
+	return queryPrivileges(ctx, c.db, "select 'query_only is off' from pragma_query_only where query_only = 0")
+}
+
+func (c *sqliteConnector) GetTables(ctx context.Context, schemaNames []string) ([]TableDetail, error) {
+	schemaFilter, args := getMySqlSchemaFilter("schema", schemaNames)
+	rows, err := queryContext(ctx, c.db, `
+		select schema, name, type = 'view'
+		from pragma_table_list
+		where type in ('table', 'view')
+		  and name not like 'sqlite_%'
+		  and `+schemaFilter+`
+		`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []TableDetail
+	for rows.Next() {
+		var table TableDetail
+		if err = rows.Scan(&table.Schema, &table.Name, &table.IsView); err != nil {
+			return nil, err
+		}
+
+		table.Name = SanitizeValue(table.Name)
+
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
+
+func (c *sqliteConnector) GetColumns(ctx context.Context, tableName TableDetail) ([]ColumnResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select ti.name,
+			   ti.type,
+			   ti.pk > 0 as is_primary,
+			   exists(select 1
+					  from pragma_foreign_key_list(?1, ?2) fk
+					  where fk."from" = ti.name) as is_foreign,
+			   -- only the unique keys of the single column, partial indexes are not unique for all rows
+			   exists(select 1
+					  from pragma_index_list(?1, ?2) il
+					  where il."unique" = 1
+						and il.origin <> 'pk'
+						and il.partial = 0
+						and (select count(*) from pragma_index_info(il.name, ?2)) = 1
+						and (select ii.name from pragma_index_info(il.name, ?2) ii) = ti.name) as is_unique,
+			   -- primary key columns are treated as not null, although sqlite allows null values in some of them
+			   ti."notnull" = 0 and ti.pk = 0 as is_nullable,
+			   coalesce(ti.dflt_value, '') as default_value,
+			   ti.cid + 1 as ordinal_position
+		from pragma_table_info(?1, ?2) ti
+		order by ti.cid;
+		`, tableName.Name, tableName.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := c.getDdlTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []ColumnResult
+	for rows.Next() {
+		var column ColumnResult
+		if err = rows.Scan(&column.Name, &column.DataType, &column.IsPrimary, &column.IsForeign, &column.IsUnique, &column.IsNullable, &column.DefaultValue, &column.OrdinalPosition); err != nil {
+			return nil, err
+		}
+
+		if table != nil {
+			column.CheckConstraints = table.getCheckConstraints(column.Name)
+			column.IsAutoIncrement = table.isAutoIncrement(column.Name)
+		}
+		column.Name = SanitizeValue(column.Name)
+		setDataTypeParameters(&column, column.DataType)
+		column.DataType = normalizeDataType(c.dbType, c.dataTypes, column.DataType)
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// getDdlTable parses the create statement of the table, as SQLite does not provide the check constraints in a
+// structured way. Views do not have a create table statement, in which case nil is returned.
+func (c *sqliteConnector) getDdlTable(ctx context.Context, tableName TableDetail) (*ddlTable, error) {
+	var statement sql.NullString
+	query := fmt.Sprintf(`select sql from "%s".sqlite_master where type = 'table' and name = ?`, strings.ReplaceAll(tableName.Schema, `"`, `""`))
+	err := queryRowContext(ctx, c.db, query, tableName.Name).Scan(&statement)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDdl(statement.String).findTable([]string{tableName.Name}, ddlDefaultSchema), nil
+}
+
+// GetConstraints returns the foreign keys of the table and the foreign keys of other tables referencing it.
+// SQLite does not support foreign keys across attached databases and does not keep the constraint names,
+// so the names are derived from the table name and the foreign key id.
+func (c *sqliteConnector) GetConstraints(ctx context.Context, tableName TableDetail) ([]ConstraintResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select tl.name,
+			   tl.schema,
+			   fk."table",
+			   tl.schema,
+			   tl.name || '_' || fk.id || '_fkey',
+			   fk."from",
+			   coalesce((select ti.pk > 0
+						 from pragma_table_info(tl.name, tl.schema) ti
+						 where ti.name = fk."from"), false) "isPrimary",
+			   (select count(*) > 1
+				from pragma_table_info(tl.name, tl.schema) ti
+				where ti.pk > 0) "hasMultiplePk",
+			   -- the foreign key is unique if there is a unique index with exactly the same columns
+			   exists(select il.name
+					  from pragma_index_list(tl.name, tl.schema) il
+					  where il."unique" = 1
+						and (select count(*) from pragma_index_info(il.name, tl.schema)) =
+							(select count(*) from pragma_foreign_key_list(tl.name, tl.schema) fk2 where fk2.id = fk.id)
+						and not exists(select ii.name
+									   from pragma_index_info(il.name, tl.schema) ii
+									   where ii.name not in (select fk2."from"
+															 from pragma_foreign_key_list(tl.name, tl.schema) fk2
+															 where fk2.id = fk.id))) "isUnique",
+			   coalesce((select ti."notnull" = 0 and ti.pk = 0
+						 from pragma_table_info(tl.name, tl.schema) ti
+						 where ti.name = fk."from"), false) "isNullable"
+		from pragma_table_list tl
+				 inner join pragma_foreign_key_list(tl.name, tl.schema) fk
+		where tl.type = 'table'
+		  and tl.schema = ?1
+		  and (tl.name = ?2 or fk."table" = ?2)
+		order by tl.name, fk.id, fk.seq;
+		`, tableName.Schema, tableName.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var constraints []ConstraintResult
+	for rows.Next() {
+		var constraint ConstraintResult
+		err = rows.Scan(
+			&constraint.FkTable,
+			&constraint.FkSchema,
+			&constraint.PkTable,
+			&constraint.PkSchema,
+			&constraint.ConstraintName,
+			&constraint.ColumnName,
+			&constraint.IsPrimary,
+			&constraint.HasMultiplePK,
+			&constraint.IsUnique,
+			&constraint.IsNullable,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		constraints = append(constraints, constraint)
+	}
+
+	return constraints, nil
+}
+
+// GetIndexes does not return the primary key of rowid tables (INTEGER PRIMARY KEY), as it is no separate index
+func (c *sqliteConnector) GetIndexes(ctx context.Context, tableName TableDetail) ([]IndexResult, error) {
+	rows, err := queryContext(ctx, c.db, `
+		select il.name,
+			   ii.name,
+			   il."unique" = 1,
+			   il.origin = 'pk'
+		from pragma_index_list(?1, ?2) il
+				 inner join pragma_index_info(il.name, ?2) ii
+		-- expression indexes have no column name
+		where ii.name is not null
+		order by il.name, ii.seqno;
+		`, tableName.Name, tableName.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
+
+func (c *sqliteConnector) GetSampleRows(ctx context.Context, tableName TableDetail, columnNames []string, limit int) ([][]string, error) {
+	return querySampleRows(ctx, c.db, c.dbType, tableName, columnNames, limit)
+}
@@ -0,0 +1,299 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// getSqliteConnector creates a fresh database file from the sqlite setup script.
+// In contrast to the other integration tests no running database server is needed.
+func getSqliteConnector(t *testing.T) Connector {
+	return getSqliteConnectorWithOptions(t, ConnectorOptions{})
+}
+
+func getSqliteConnectorWithOptions(t *testing.T, options ConnectorOptions) Connector {
+	dbFile := filepath.Join(t.TempDir(), "mermerd_test.sqlite")
+	setupScript, err := os.ReadFile("../test/sqlite/sqlite-setup.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open(Sqlite.String(), dbFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = db.Exec(string(setupScript)); err != nil {
+		t.Fatal(err)
+	}
+	_ = db.Close()
+
+	connector, err := NewConnectorFactoryWithOptions(options).NewConnector("sqlite://" + dbFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = connector.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(connector.Close)
+
+	return connector
+}
+
+func TestSqlite(t *testing.T) {
+	connector := getSqliteConnector(t)
+
+	t.Run("GetSchemas", func(t *testing.T) {
+		// Act
+		schemas, err := connector.GetSchemas(context.Background())
+
+		// Assert
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []string{"main"}, schemas)
+	})
+
+	t.Run("GetTables without schemas", func(t *testing.T) {
+		// Act
+		tables, err := connector.GetTables(context.Background(), []string{})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Empty(t, tables)
+	})
+
+	t.Run("GetTables", func(t *testing.T) {
+		// Act
+		tables, err := connector.GetTables(context.Background(), []string{"main"})
+
+		// Assert
+		expectedResult := []TableDetail{
+			{Schema: "main", Name: "article"},
+			{Schema: "main", Name: "article_detail"},
+			{Schema: "main", Name: "article_comment"},
+			{Schema: "main", Name: "label"},
+			{Schema: "main", Name: "article_label"},
+			{Schema: "main", Name: "test_1_a"},
+			{Schema: "main", Name: "test_1_b"},
+			{Schema: "main", Name: "article_overview", IsView: true},
+		}
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, expectedResult, tables)
+	})
+
+	t.Run("GetColumns of view", func(t *testing.T) {
+		// Act
+		columns, err := connector.GetColumns(context.Background(), TableDetail{Schema: "main", Name: "article_overview", IsView: true})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, columns, 3)
+		assert.Equal(t, "comment_count", columns[2].Name)
+	})
+
+	t.Run("GetColumns", func(t *testing.T) {
+		// Act
+		columns, err := connector.GetColumns(context.Background(), TableDetail{Schema: "main", Name: "article_comment"})
+
+		// Assert
+		var columnResult []columnTestResult
+		for index, column := range columns {
+			columnResult = append(columnResult, columnTestResult{
+				Name:      column.Name,
+				isPrimary: column.IsPrimary,
+				isForeign: column.IsForeign,
+			})
+			assert.Equal(t, index+1, column.OrdinalPosition)
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, []columnTestResult{
+			{Name: "id", isPrimary: true, isForeign: false},
+			{Name: "article_id", isPrimary: false, isForeign: true},
+			{Name: "comment", isPrimary: false, isForeign: false},
+		}, columnResult)
+	})
+
+	t.Run("One-to-one relation", func(t *testing.T) {
+		// Act
+		constraintResults, err := connector.GetConstraints(context.Background(), TableDetail{Schema: "main", Name: "article_detail"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, constraintResults, 1)
+		assert.Equal(t, "article", constraintResults[0].PkTable)
+		assert.True(t, constraintResults[0].IsPrimary)
+		assert.False(t, constraintResults[0].HasMultiplePK)
+	})
+
+	t.Run("Many-to-one relation", func(t *testing.T) {
+		// Act
+		constraintResults, err := connector.GetConstraints(context.Background(), TableDetail{Schema: "main", Name: "article_comment"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, constraintResults, 1)
+		assert.Equal(t, "article_id", constraintResults[0].ColumnName)
+		assert.False(t, constraintResults[0].IsPrimary)
+		assert.False(t, constraintResults[0].HasMultiplePK)
+	})
+
+	t.Run("Constraints referencing the table", func(t *testing.T) {
+		// Act
+		constraintResults, err := connector.GetConstraints(context.Background(), TableDetail{Schema: "main", Name: "article"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, constraintResults, 3)
+		for _, constraint := range constraintResults {
+			assert.Equal(t, "article", constraint.PkTable)
+			assert.Equal(t, "main", constraint.PkSchema)
+		}
+	})
+
+	t.Run("Multiple primary keys (Issue #8)", func(t *testing.T) {
+		// Act
+		constraintResults, err := connector.GetConstraints(context.Background(), TableDetail{Schema: "main", Name: "test_1_b"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, constraintResults, 2)
+		assert.True(t, constraintResults[0].IsPrimary)
+		assert.True(t, constraintResults[0].HasMultiplePK)
+		assert.Equal(t, "aid", constraintResults[0].ColumnName)
+		assert.True(t, constraintResults[1].IsPrimary)
+		assert.True(t, constraintResults[1].HasMultiplePK)
+		assert.Equal(t, "bid", constraintResults[1].ColumnName)
+	})
+
+	t.Run("Unique foreign keys", func(t *testing.T) {
+		// Act
+		compositeResults, compositeErr := connector.GetConstraints(context.Background(), TableDetail{Schema: "main", Name: "test_1_b"})
+		joinTableResults, joinTableErr := connector.GetConstraints(context.Background(), TableDetail{Schema: "main", Name: "article_label"})
+
+		// Assert
+		assert.Nil(t, compositeErr)
+		assert.Nil(t, joinTableErr)
+		assert.True(t, compositeResults[0].IsUnique)
+		assert.False(t, joinTableResults[0].IsUnique)
+	})
+
+	t.Run("GetIndexes", func(t *testing.T) {
+		// Act
+		indexes, err := connector.GetIndexes(context.Background(), TableDetail{Schema: "main", Name: "article_label"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Len(t, indexes, 1)
+		assert.Equal(t, []string{"article_id", "label_id"}, indexes[0].ColumnNames)
+		assert.True(t, indexes[0].IsUnique)
+		assert.True(t, indexes[0].IsPrimary)
+	})
+
+	t.Run("GetSampleRows", func(t *testing.T) {
+		// Arrange
+		sqliteConnector := connector.(*sqliteConnector)
+		_, err := sqliteConnector.db.Exec("insert into article (id, title) values (1, 'first'), (2, 'second'), (3, 'third')")
+		assert.Nil(t, err)
+		t.Cleanup(func() { _, _ = sqliteConnector.db.Exec("delete from article") })
+
+		// Act
+		rows, err := sqliteConnector.GetSampleRows(context.Background(), TableDetail{Schema: "main", Name: "article"}, []string{"id", "title"}, 2)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, [][]string{{"1", "first"}, {"2", "second"}}, rows)
+	})
+
+	t.Run("Unique columns", func(t *testing.T) {
+		// Arrange
+		sqliteConnector := connector.(*sqliteConnector)
+		_, err := sqliteConnector.db.Exec(`
+			create table member
+			(
+				id        int not null primary key,
+				email     varchar(255) unique,
+				tenant_id int,
+				code      varchar(10),
+				nickname  varchar(50),
+				unique (tenant_id, code)
+			);
+			create unique index member_nickname on member (nickname) where nickname is not null;`)
+		assert.Nil(t, err)
+		t.Cleanup(func() { _, _ = sqliteConnector.db.Exec("drop table member") })
+
+		// Act
+		columns, err := connector.GetColumns(context.Background(), TableDetail{Schema: "main", Name: "member"})
+
+		// Assert
+		var uniqueColumns []string
+		for _, column := range columns {
+			if column.IsUnique {
+				uniqueColumns = append(uniqueColumns, column.Name)
+			}
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"email"}, uniqueColumns)
+	})
+
+	t.Run("Not nullable foreign keys", func(t *testing.T) {
+		// Act
+		columns, columnsErr := connector.GetColumns(context.Background(), TableDetail{Schema: "main", Name: "article_comment"})
+		constraintResults, constraintsErr := connector.GetConstraints(context.Background(), TableDetail{Schema: "main", Name: "article_comment"})
+
+		// Assert
+		assert.Nil(t, columnsErr)
+		assert.Nil(t, constraintsErr)
+		for _, column := range columns {
+			assert.False(t, column.IsNullable)
+		}
+		assert.Len(t, constraintResults, 1)
+		assert.False(t, constraintResults[0].IsNullable)
+	})
+
+	t.Run("Check constraints", func(t *testing.T) {
+		// Act
+		columns, err := connector.GetColumns(context.Background(), TableDetail{Schema: "main", Name: "article_comment"})
+		viewColumns, viewErr := connector.GetColumns(context.Background(), TableDetail{Schema: "main", Name: "article_overview"})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Nil(t, viewErr)
+		assert.Len(t, columns, 3)
+		assert.Equal(t, "", columns[0].CheckConstraints)
+		assert.Equal(t, "length(comment) > 0", columns[2].CheckConstraints)
+		for _, column := range viewColumns {
+			assert.Equal(t, "", column.CheckConstraints)
+		}
+	})
+}
+
+func TestSqliteReadOnlySession(t *testing.T) {
+	t.Run("Write privileges without read-only session", func(t *testing.T) {
+		// Arrange
+		connector := getSqliteConnector(t)
+
+		// Act
+		privileges, err := connector.(ReadOnlyConnector).GetWritePrivileges(context.Background())
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"query_only is off"}, privileges)
+	})
+
+	t.Run("No write privileges with read-only session", func(t *testing.T) {
+		// Arrange
+		connector := getSqliteConnectorWithOptions(t, ConnectorOptions{ReadOnlySession: true})
+
+		// Act
+		privileges, err := connector.(ReadOnlyConnector).GetWritePrivileges(context.Background())
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Empty(t, privileges)
+	})
+}
@@ -0,0 +1,253 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SshTunnelConfig describes the ssh server (e.g. a bastion host) through which the database is reached
+type SshTunnelConfig struct {
+	// Destination of the ssh server in the format [user@]host[:port]
+	Destination string
+	// IdentityFile is the private key that is used to authenticate, the ssh agent is used if it is empty
+	IdentityFile string
+}
+
+// sshTunnel forwards the connections to a local port through the ssh server to the database
+type sshTunnel struct {
+	client        *ssh.Client
+	listener      net.Listener
+	targetAddress string
+}
+
+var (
+	// urlAddressRegex matches the host and port of the connection strings in url format (postgres, cockroachdb,
+	// redshift and sqlserver)
+	urlAddressRegex = regexp.MustCompile(`^(\w+://(?:[^/?#]*@)?)([^/?#@]*)`)
+	// mySqlAddressRegex matches the host and port of the mysql and mariadb DSNs (e.g. user:password@tcp(host:port)/db)
+	mySqlAddressRegex = regexp.MustCompile(`(@tcp\()([^)]*)(\))`)
+)
+
+var defaultDatabasePorts = map[string]string{
+	"postgresql":  "5432",
+	"postgres":    "5432",
+	"cockroachdb": "26257",
+	"sqlserver":   "1433",
+	"mysql":       "3306",
+	"mariadb":     "3306",
+	"clickhouse":  "9000",
+	"redshift":    "5439",
+}
+
+func openSshTunnel(config SshTunnelConfig, targetAddress string) (*sshTunnel, error) {
+	clientConfig, address, err := getSshClientConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ssh.Dial("tcp", address, clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to ssh server %s: %w", address, err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	tunnel := &sshTunnel{client: client, listener: listener, targetAddress: targetAddress}
+	go tunnel.forward()
+	return tunnel, nil
+}
+
+func (t *sshTunnel) localAddress() string {
+	return t.listener.Addr().String()
+}
+
+func (t *sshTunnel) Close() error {
+	listenerErr := t.listener.Close()
+	if err := t.client.Close(); err != nil {
+		return err
+	}
+
+	return listenerErr
+}
+
+// forward accepts the local connections until the tunnel is closed
+func (t *sshTunnel) forward() {
+	for {
+		localConn, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go t.forwardConnection(localConn)
+	}
+}
+
+func (t *sshTunnel) forwardConnection(localConn net.Conn) {
+	defer localConn.Close()
+	remoteConn, err := t.client.Dial("tcp", t.targetAddress)
+	if err != nil {
+		logrus.Error("Forwarding connection through ssh tunnel failed", " | ", err)
+		return
+	}
+	defer remoteConn.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remoteConn, localConn)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(localConn, remoteConn)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// getSshClientConfig returns the client config and the address of the ssh server. The host key of the server has to
+// be part of the known_hosts file of the user.
+func getSshClientConfig(config SshTunnelConfig) (*ssh.ClientConfig, string, error) {
+	userName, address, err := parseSshDestination(config.Destination)
+	if err != nil {
+		return nil, "", err
+	}
+
+	authMethod, err := getSshAuthMethod(config.IdentityFile)
+	if err != nil {
+		return nil, "", err
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, "", err
+	}
+
+	hostKeyCallback, err := knownhosts.New(filepath.Join(homeDir, ".ssh", "known_hosts"))
+	if err != nil {
+		return nil, "", fmt.Errorf("could not read known ssh hosts: %w", err)
+	}
+
+	return &ssh.ClientConfig{
+		User:            userName,
+		Auth:            []ssh.AuthMethod{authMethod},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         30 * time.Second,
+	}, address, nil
+}
+
+// parseSshDestination splits the destination [user@]host[:port] into the user (the current user by default) and the
+// address (port 22 by default)
+func parseSshDestination(destination string) (string, string, error) {
+	userName, host, hasUser := strings.Cut(destination, "@")
+	if !hasUser {
+		currentUser, err := user.Current()
+		if err != nil {
+			return "", "", err
+		}
+
+		userName, host = currentUser.Username, destination
+	}
+
+	if userName == "" || host == "" {
+		return "", "", fmt.Errorf("invalid ssh destination %q ([user@]host[:port])", destination)
+	}
+
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "22")
+	}
+
+	return userName, host, nil
+}
+
+func getSshAuthMethod(identityFile string) (ssh.AuthMethod, error) {
+	if identityFile != "" {
+		if strings.HasPrefix(identityFile, "~/") {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return nil, err
+			}
+
+			identityFile = filepath.Join(homeDir, identityFile[2:])
+		}
+
+		key, err := os.ReadFile(identityFile)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("could not read ssh identity file (keys with a passphrase have to be added to the ssh agent): %w", err)
+		}
+
+		return ssh.PublicKeys(signer), nil
+	}
+
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, errors.New("no ssh identity file given and no ssh agent running")
+	}
+
+	agentConn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to ssh agent: %w", err)
+	}
+
+	return ssh.PublicKeysCallback(agent.NewClient(agentConn).Signers), nil
+}
+
+// getConnectionAddress returns the host and port of the database, as they are seen from the ssh server
+func getConnectionAddress(connectionString string) (string, error) {
+	scheme, _, _ := strings.Cut(connectionString, "://")
+	defaultPort, ok := defaultDatabasePorts[scheme]
+	if !ok {
+		return "", fmt.Errorf("ssh tunnel is not supported for %s", scheme)
+	}
+
+	var address string
+	if scheme == "mysql" || scheme == "mariadb" {
+		match := mySqlAddressRegex.FindStringSubmatch(connectionString)
+		if match == nil {
+			return "", errors.New("ssh tunnel requires a tcp address in the connection string, e.g. tcp(host:port)")
+		}
+
+		address = match[2]
+	} else {
+		address = urlAddressRegex.FindStringSubmatch(connectionString)[2]
+	}
+
+	if address == "" {
+		return "", errors.New("ssh tunnel requires a host in the connection string")
+	}
+
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, defaultPort)
+	}
+
+	return address, nil
+}
+
+// replaceConnectionAddress replaces the host and port of the database in the connection string
+func replaceConnectionAddress(connectionString string, address string) string {
+	if mySqlAddressRegex.MatchString(connectionString) {
+		return mySqlAddressRegex.ReplaceAllString(connectionString, "${1}"+address+"${3}")
+	}
+
+	return urlAddressRegex.ReplaceAllString(connectionString, "${1}"+address)
+}
@@ -2,6 +2,9 @@ package database
 
 import (
 	"errors"
+	"fmt"
+	"path"
+	"regexp"
 	"strings"
 )
 
@@ -22,3 +25,135 @@ func ParseTableName(value string, selectedSchemas []string) (TableDetail, error)
 
 	return TableDetail{}, errors.New("Could not parse table name")
 }
+
+// MatchTableName checks if the table matches the given pattern. Patterns enclosed in slashes (e.g. /^flyway_/) are
+// regular expressions, all other patterns are globs (e.g. *_audit). Patterns that contain a dot are matched against
+// schema.table, all others only against the table name.
+func MatchTableName(pattern string, table TableDetail) (bool, error) {
+	return matchName(pattern, table.Name, table.Schema+"."+table.Name)
+}
+
+// MatchColumnName checks if the column matches the given pattern. The same rules as for MatchTableName apply, but
+// patterns with one dot are matched against table.column and patterns with two dots against schema.table.column.
+func MatchColumnName(pattern string, table TableDetail, column string) (bool, error) {
+	return matchName(pattern, column, table.Name+"."+column, table.Schema+"."+table.Name+"."+column)
+}
+
+// matchName matches the pattern against the qualified names, which are ordered from the least to the most qualified
+// name. Regular expressions are matched against all names, globs only against the name with the same number of dots.
+func matchName(pattern string, qualifiedNames ...string) (bool, error) {
+	if isRegexPattern(pattern) {
+		expression, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return false, err
+		}
+
+		for _, name := range qualifiedNames {
+			if expression.MatchString(name) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	dots := strings.Count(pattern, ".")
+	if dots >= len(qualifiedNames) {
+		return false, nil
+	}
+
+	return path.Match(pattern, qualifiedNames[dots])
+}
+
+// ReplaceTableName returns the alias of the table if it matches the pattern (see MatchTableName). Regular expressions
+// only replace the matched part of the table name, the alias can refer to the groups of the expression (e.g. /^tbl_/
+// with an empty alias strips the prefix).
+func ReplaceTableName(pattern string, alias string, table TableDetail) (string, bool, error) {
+	return replaceName(pattern, alias, table.Name, table.Schema+"."+table.Name)
+}
+
+// ReplaceColumnName returns the alias of the column if it matches the pattern (see MatchColumnName), regular
+// expressions replace the matched part of the column name like in ReplaceTableName
+func ReplaceColumnName(pattern string, alias string, table TableDetail, column string) (string, bool, error) {
+	return replaceName(pattern, alias, column, table.Name+"."+column, table.Schema+"."+table.Name+"."+column)
+}
+
+// replaceName matches regular expressions only against the unqualified name, as the replaced name must not contain
+// the schema or the table
+func replaceName(pattern string, alias string, qualifiedNames ...string) (string, bool, error) {
+	if isRegexPattern(pattern) {
+		expression, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return "", false, err
+		}
+
+		if !expression.MatchString(qualifiedNames[0]) {
+			return "", false, nil
+		}
+
+		return expression.ReplaceAllString(qualifiedNames[0], alias), true, nil
+	}
+
+	matches, err := matchName(pattern, qualifiedNames...)
+	if err != nil || !matches {
+		return "", false, err
+	}
+
+	return alias, true, nil
+}
+
+func isRegexPattern(value string) bool {
+	return len(value) > 1 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/")
+}
+
+// MatchAnyTableName checks if the table matches at least one of the given patterns (see MatchTableName)
+func MatchAnyTableName(patterns []string, table TableDetail) (bool, error) {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+
+		matches, err := MatchTableName(pattern, table)
+		if err != nil {
+			return false, fmt.Errorf("invalid table pattern %q: %w", pattern, err)
+		}
+
+		if matches {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// MatchAnyColumnName checks if the column matches at least one of the given patterns (see MatchColumnName)
+func MatchAnyColumnName(patterns []string, table TableDetail, column string) (bool, error) {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+
+		matches, err := MatchColumnName(pattern, table, column)
+		if err != nil {
+			return false, fmt.Errorf("invalid column pattern %q: %w", pattern, err)
+		}
+
+		if matches {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ContainsTableNamePattern checks if at least one of the values is a glob pattern or regular expression instead of
+// an exact table name
+func ContainsTableNamePattern(values []string) bool {
+	for _, value := range values {
+		if strings.ContainsAny(value, "*?[") || isRegexPattern(value) {
+			return true
+		}
+	}
+
+	return false
+}
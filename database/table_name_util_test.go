@@ -66,3 +66,147 @@ func TestParseTableName(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchTableName(t *testing.T) {
+	testCases := []struct {
+		pattern        string
+		table          TableDetail
+		expectedResult bool
+		shouldFail     bool
+	}{
+		{"flyway_*", TableDetail{Schema: "public", Name: "flyway_schema_history"}, true, false},
+		{"*_audit", TableDetail{Schema: "public", Name: "order_audit"}, true, false},
+		{"*_audit", TableDetail{Schema: "public", Name: "audit_log"}, false, false},
+		{"public.*", TableDetail{Schema: "public", Name: "article"}, true, false},
+		{"other_db.*", TableDetail{Schema: "public", Name: "article"}, false, false},
+		{"/^(flyway|liquibase)_/", TableDetail{Schema: "public", Name: "liquibase_lock"}, true, false},
+		{"/^public\\.art/", TableDetail{Schema: "public", Name: "article"}, true, false},
+		{"/[/", TableDetail{Schema: "public", Name: "article"}, false, true},
+		{"[", TableDetail{Schema: "public", Name: "article"}, false, true},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result, err := MatchTableName(testCase.pattern, testCase.table)
+
+			// Assert
+			if testCase.shouldFail {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestMatchAnyTableName(t *testing.T) {
+	// Arrange
+	table := TableDetail{Schema: "public", Name: "order_audit"}
+
+	// Act
+	result, err := MatchAnyTableName([]string{"", "flyway_*", "*_audit"}, table)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, result)
+}
+
+func TestContainsTableNamePattern(t *testing.T) {
+	testCases := []struct {
+		values         []string
+		expectedResult bool
+	}{
+		{[]string{"article", "public.article_label"}, false},
+		{[]string{"article", "public.order_*"}, true},
+		{[]string{"/^order/"}, true},
+		{[]string{"/"}, false},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := ContainsTableNamePattern(testCase.values)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestMatchColumnName(t *testing.T) {
+	table := TableDetail{Schema: "public", Name: "user"}
+	testCases := []struct {
+		pattern        string
+		expectedResult bool
+	}{
+		{"password_hash", true},
+		{"*_hash", true},
+		{"user.password_hash", true},
+		{"article.password_hash", false},
+		{"public.user.*", true},
+		{"/^pass/", true},
+		{"/^user\\.pass/", true},
+		{"a.b.c.d", false},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result, err := MatchColumnName(testCase.pattern, table, "password_hash")
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestReplaceTableName(t *testing.T) {
+	table := TableDetail{Schema: "sales", Name: "tbl_customer_order"}
+	testCases := []struct {
+		pattern         string
+		alias           string
+		expectedName    string
+		expectedMatches bool
+	}{
+		{"tbl_customer_order", "order", "order", true},
+		{"sales.tbl_*", "order", "order", true},
+		{"public.tbl_*", "order", "", false},
+		{"/^tbl_/", "", "customer_order", true},
+		{"/^tbl_(\\w+)_order$/", "${1}_orders", "customer_orders", true},
+		{"/^sales\\./", "order", "", false},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			name, matches, err := ReplaceTableName(testCase.pattern, testCase.alias, table)
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedName, name)
+			assert.Equal(t, testCase.expectedMatches, matches)
+		})
+	}
+}
+
+func TestReplaceColumnName(t *testing.T) {
+	// Arrange
+	table := TableDetail{Schema: "public", Name: "user"}
+
+	// Act
+	name, matches, err := ReplaceColumnName("user.very_long_column_name", "short", table, "very_long_column_name")
+	otherName, otherMatches, otherErr := ReplaceColumnName("article.very_long_column_name", "short", table, "very_long_column_name")
+	_, _, invalidErr := ReplaceColumnName("/(/", "short", table, "very_long_column_name")
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, otherErr)
+	assert.NotNil(t, invalidErr)
+	assert.Equal(t, "short", name)
+	assert.True(t, matches)
+	assert.Equal(t, "", otherName)
+	assert.False(t, otherMatches)
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mySqlTlsConfigName is the name under which the tls config is registered for the mysql driver
+const mySqlTlsConfigName = "mermerd"
+
+// TlsConfig contains the certificates that are used for the connection, which are translated into the parameters of
+// the connection string of the database
+type TlsConfig struct {
+	// CaCertFile is the certificate of the CA that signed the certificate of the server
+	CaCertFile string
+	// ClientCertFile and ClientKeyFile are used to authenticate with a client certificate
+	ClientCertFile string
+	ClientKeyFile  string
+	// SkipVerify encrypts the connection without verifying the certificate of the server
+	SkipVerify bool
+}
+
+func (c TlsConfig) isEnabled() bool {
+	return c.CaCertFile != "" || c.ClientCertFile != "" || c.ClientKeyFile != "" || c.SkipVerify
+}
+
+// queryParameter is a query parameter of the connection string
+type queryParameter struct {
+	key   string
+	value string
+}
+
+// applyTlsConfig adds the driver specific tls parameters to the connection string. Parameters that are already part
+// of the connection string are kept.
+func applyTlsConfig(connectionString string, config TlsConfig) (string, error) {
+	if !config.isEnabled() {
+		return connectionString, nil
+	}
+
+	if (config.ClientCertFile == "") != (config.ClientKeyFile == "") {
+		return "", errors.New("the client certificate and the client key have to be given together")
+	}
+
+	scheme, _, _ := strings.Cut(connectionString, "://")
+	switch scheme {
+	case "postgresql", "postgres", "cockroachdb", "redshift":
+		return addQueryParameters(connectionString, getPostgresTlsParameters(config)), nil
+	case "mysql", "mariadb":
+		if err := registerMySqlTlsConfig(connectionString, config); err != nil {
+			return "", err
+		}
+
+		return addQueryParameters(connectionString, []queryParameter{{"tls", mySqlTlsConfigName}}), nil
+	case "sqlserver":
+		if config.ClientCertFile != "" {
+			return "", errors.New("client certificates are not supported for sqlserver")
+		}
+
+		return addQueryParameters(connectionString, getMssqlTlsParameters(config)), nil
+	default:
+		return "", fmt.Errorf("tls options are not supported for %s", scheme)
+	}
+}
+
+func getPostgresTlsParameters(config TlsConfig) []queryParameter {
+	var parameters []queryParameter
+	switch {
+	case config.SkipVerify:
+		parameters = append(parameters, queryParameter{"sslmode", "require"})
+	case config.CaCertFile != "":
+		parameters = append(parameters, queryParameter{"sslmode", "verify-full"}, queryParameter{"sslrootcert", config.CaCertFile})
+	default:
+		parameters = append(parameters, queryParameter{"sslmode", "require"})
+	}
+
+	if config.ClientCertFile != "" {
+		parameters = append(parameters, queryParameter{"sslcert", config.ClientCertFile}, queryParameter{"sslkey", config.ClientKeyFile})
+	}
+
+	return parameters
+}
+
+func getMssqlTlsParameters(config TlsConfig) []queryParameter {
+	parameters := []queryParameter{{"encrypt", "true"}}
+	if config.SkipVerify {
+		return append(parameters, queryParameter{"TrustServerCertificate", "true"})
+	}
+
+	if config.CaCertFile != "" {
+		parameters = append(parameters, queryParameter{"certificate", config.CaCertFile})
+	}
+
+	return parameters
+}
+
+// registerMySqlTlsConfig registers the tls config for the mysql driver, as the certificates cannot be passed as
+// parameters of the connection string
+func registerMySqlTlsConfig(connectionString string, config TlsConfig) error {
+	tlsConfig := &tls.Config{InsecureSkipVerify: config.SkipVerify}
+
+	// the server name is taken from the connection string, so the certificate is still verified against the database
+	// host if the connection string is changed (e.g. by the ssh tunnel)
+	if address, err := getConnectionAddress(connectionString); err == nil {
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			tlsConfig.ServerName = host
+		}
+	}
+
+	if config.CaCertFile != "" {
+		caCert, err := os.ReadFile(config.CaCertFile)
+		if err != nil {
+			return err
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return fmt.Errorf("no certificates found in %s", config.CaCertFile)
+		}
+	}
+
+	if config.ClientCertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(config.ClientCertFile, config.ClientKeyFile)
+		if err != nil {
+			return err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return mysql.RegisterTLSConfig(mySqlTlsConfigName, tlsConfig)
+}
+
+// addQueryParameters appends the parameters to the query of the connection string, unless the query already
+// contains them
+func addQueryParameters(connectionString string, parameters []queryParameter) string {
+	_, query, hasQuery := strings.Cut(connectionString, "?")
+	existingParameters, _ := url.ParseQuery(query)
+
+	for _, parameter := range parameters {
+		if hasQueryParameter(existingParameters, parameter.key) {
+			continue
+		}
+
+		separator := "&"
+		if !hasQuery {
+			separator = "?"
+			hasQuery = true
+		}
+
+		connectionString += separator + url.QueryEscape(parameter.key) + "=" + url.QueryEscape(parameter.value)
+	}
+
+	return connectionString
+}
+
+// hasQueryParameter ignores the case of the key, as e.g. sqlserver does not distinguish it
+func hasQueryParameter(parameters url.Values, key string) bool {
+	for existingKey := range parameters {
+		if strings.EqualFold(existingKey, key) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransientError(t *testing.T) {
+	testCases := []struct {
+		err            error
+		expectedResult bool
+	}{
+		{err: nil, expectedResult: false},
+		{err: errors.New("password authentication failed"), expectedResult: false},
+		{err: errors.New("syntax error at or near \"select\""), expectedResult: false},
+		{err: driver.ErrBadConn, expectedResult: true},
+		{err: mysql.ErrInvalidConn, expectedResult: true},
+		{err: io.EOF, expectedResult: true},
+		{err: fmt.Errorf("reading rows: %w", io.ErrUnexpectedEOF), expectedResult: true},
+		{err: fmt.Errorf("query: %w", context.DeadlineExceeded), expectedResult: true},
+		{err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, expectedResult: true},
+		{err: fmt.Errorf("connect: %w", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}), expectedResult: true},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Arrange
+			err := testCase.err
+
+			// Act
+			result := IsTransientError(err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
@@ -3,9 +3,18 @@ package database
 type DbType string
 
 const (
-	Postgres DbType = "pgx"
-	MySql    DbType = "mysql"
-	MsSql    DbType = "sqlserver"
+	Postgres    DbType = "pgx"
+	MySql       DbType = "mysql"
+	MsSql       DbType = "sqlserver"
+	Sqlite      DbType = "sqlite"
+	MariaDb     DbType = "mariadb"
+	Snowflake   DbType = "snowflake"
+	CockroachDb DbType = "cockroachdb"
+	File        DbType = "file"
+	ClickHouse  DbType = "clickhouse"
+	DuckDb      DbType = "duckdb"
+	BigQuery    DbType = "bigquery"
+	Redshift    DbType = "redshift"
 )
 
 func (c DbType) String() string {
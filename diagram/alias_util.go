@@ -0,0 +1,180 @@
+package diagram
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+// aliases contains the names of the tables and columns in the diagram (see tableAliases, columnAliases and nameCase),
+// which replace the names once the diagram data is complete. The table styles, groups and focused tables are
+// configured with the original names, so the model itself is not renamed.
+type aliases struct {
+	nameCase nameCase
+	// tableNames maps the table name in the diagram (see getTableName) to the name of the alias
+	tableNames map[string]string
+	// columnNames maps the table name in the diagram and the column name to the alias of the column
+	columnNames map[string]map[string]string
+}
+
+func getAliases(config config.MermerdConfig, result *database.Result) (aliases, error) {
+	aliases := aliases{tableNames: make(map[string]string), columnNames: make(map[string]map[string]string)}
+	nameCase, err := getNameCase(config.NameCase())
+	if err != nil {
+		return aliases, err
+	}
+
+	aliases.nameCase = nameCase
+	tableAliases, err := config.TableAliases()
+	if err != nil {
+		return aliases, err
+	}
+
+	columnAliases, err := config.ColumnAliases()
+	if err != nil {
+		return aliases, err
+	}
+
+	if len(tableAliases) == 0 && len(columnAliases) == 0 && nameCase == nil {
+		return aliases, nil
+	}
+
+	for _, table := range result.Tables {
+		tables := []database.TableDetail{table.Table}
+		for _, constraint := range table.Constraints {
+			tables = append(tables,
+				database.TableDetail{Schema: constraint.FkSchema, Name: constraint.FkTable},
+				database.TableDetail{Schema: constraint.PkSchema, Name: constraint.PkTable})
+		}
+
+		for _, tableDetail := range tables {
+			if err = aliases.addTable(config, tableAliases, tableDetail); err != nil {
+				return aliases, err
+			}
+		}
+
+		for _, column := range table.Columns {
+			if err = aliases.addColumn(config, columnAliases, table.Table, column.Name); err != nil {
+				return aliases, err
+			}
+		}
+	}
+
+	return aliases, nil
+}
+
+// addTable uses the first matching alias of the table, whose case is transformed afterwards
+func (a aliases) addTable(config config.MermerdConfig, tableAliases []config.Alias, table database.TableDetail) error {
+	name := table.Name
+	for _, tableAlias := range tableAliases {
+		alias, matches, err := database.ReplaceTableName(tableAlias.Pattern, tableAlias.Alias, table)
+		if err != nil {
+			return fmt.Errorf("invalid table alias pattern %q: %w", tableAlias.Pattern, err)
+		}
+
+		if matches && alias != "" {
+			name = alias
+			break
+		}
+	}
+
+	name = a.nameCase.apply(name)
+	if name != table.Name {
+		a.tableNames[getTableName(config, table)] = getTableName(config, database.TableDetail{Schema: table.Schema, Name: name})
+	}
+
+	return nil
+}
+
+// addColumn uses the first matching alias of the column, whose case is transformed afterwards
+func (a aliases) addColumn(config config.MermerdConfig, columnAliases []config.Alias, table database.TableDetail, column string) error {
+	name := column
+	for _, columnAlias := range columnAliases {
+		alias, matches, err := database.ReplaceColumnName(columnAlias.Pattern, columnAlias.Alias, table, column)
+		if err != nil {
+			return fmt.Errorf("invalid column alias pattern %q: %w", columnAlias.Pattern, err)
+		}
+
+		if matches && alias != "" {
+			name = alias
+			break
+		}
+	}
+
+	name = a.nameCase.apply(name)
+	if name != column {
+		tableName := getTableName(config, table)
+		if a.columnNames[tableName] == nil {
+			a.columnNames[tableName] = make(map[string]string)
+		}
+
+		a.columnNames[tableName][column] = name
+	}
+
+	return nil
+}
+
+// apply renames the tables and columns of the diagram data. The original name of a column with an alias is added to
+// its description, as it is needed to find the column in the database (a different case is still recognizable).
+func (a aliases) apply(diagramData ErdDiagramData) ErdDiagramData {
+	if len(a.tableNames) == 0 && len(a.columnNames) == 0 {
+		return diagramData
+	}
+
+	tables := make([]ErdTableData, len(diagramData.Tables))
+	for tableIndex, table := range diagramData.Tables {
+		columns := make([]ErdColumnData, len(table.Columns))
+		for columnIndex, column := range table.Columns {
+			if alias, ok := a.columnNames[table.Name][column.Name]; ok {
+				if alias != a.nameCase.apply(column.Name) {
+					column.Description = strings.TrimSpace(fmt.Sprintf("(%s) %s", column.Name, column.Description))
+				}
+
+				column.Name = alias
+			}
+
+			columns[columnIndex] = column
+		}
+
+		table.Name = a.getTableName(table.Name)
+		table.Columns = columns
+		tables[tableIndex] = table
+	}
+
+	constraints := make([]ErdConstraintData, len(diagramData.Constraints))
+	for index, constraint := range diagramData.Constraints {
+		constraint.FkTableName = a.getTableName(constraint.FkTableName)
+		constraint.PkTableName = a.getTableName(constraint.PkTableName)
+		constraints[index] = constraint
+	}
+
+	diagramData.Tables = tables
+	diagramData.Constraints = constraints
+	diagramData.Classes = a.applyToClasses(diagramData.Classes)
+	return diagramData
+}
+
+func (a aliases) applyToClasses(classes []ErdClassData) []ErdClassData {
+	result := make([]ErdClassData, len(classes))
+	for index, class := range classes {
+		tableNames := strings.Split(class.TableNames, ",")
+		for tableIndex, tableName := range tableNames {
+			tableNames[tableIndex] = a.getTableName(tableName)
+		}
+
+		class.TableNames = strings.Join(tableNames, ",")
+		result[index] = class
+	}
+
+	return result
+}
+
+func (a aliases) getTableName(tableName string) string {
+	if alias, ok := a.tableNames[tableName]; ok {
+		return alias
+	}
+
+	return tableName
+}
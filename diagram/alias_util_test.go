@@ -0,0 +1,111 @@
+package diagram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+func TestAliases(t *testing.T) {
+	result := &database.Result{Tables: []database.TableResult{
+		{
+			Table:   database.TableDetail{Schema: "public", Name: "tbl_order"},
+			Columns: []database.ColumnResult{{Name: "id"}, {Name: "very_long_customer_reference"}},
+			Constraints: database.ConstraintResultList{
+				{FkSchema: "public", FkTable: "tbl_order", PkSchema: "public", PkTable: "tbl_customer"},
+			},
+		},
+	}}
+	diagramData := ErdDiagramData{
+		Tables: []ErdTableData{{
+			Name:    "tbl_order",
+			Columns: []ErdColumnData{{Name: "id"}, {Name: "very_long_customer_reference", Description: "customer"}},
+		}},
+		Constraints: []ErdConstraintData{{FkTableName: "tbl_order", PkTableName: "tbl_customer"}},
+		Classes:     []ErdClassData{{Name: "ghost", TableNames: "tbl_customer,enum"}},
+	}
+
+	t.Run("Tables and columns are renamed", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("NameCase").Return("").Once()
+		configMock.On("TableAliases").Return([]config.Alias{{Pattern: "/^tbl_/", Alias: ""}}, nil).Once()
+		configMock.On("ColumnAliases").Return([]config.Alias{{Pattern: "tbl_order.very_long_*", Alias: "customer_ref"}}, nil).Once()
+		configMock.On("ShowSchemaPrefix").Return(false)
+
+		// Act
+		aliases, err := getAliases(&configMock, result)
+		aliasedData := aliases.apply(diagramData)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Equal(t, "order", aliasedData.Tables[0].Name)
+		assert.Equal(t, []ErdColumnData{
+			{Name: "id"},
+			{Name: "customer_ref", Description: "(very_long_customer_reference) customer"},
+		}, aliasedData.Tables[0].Columns)
+		assert.Equal(t, []ErdConstraintData{{FkTableName: "order", PkTableName: "customer"}}, aliasedData.Constraints)
+		assert.Equal(t, "customer,enum", aliasedData.Classes[0].TableNames)
+		assert.Equal(t, "tbl_order", diagramData.Tables[0].Name)
+	})
+
+	t.Run("Names are transformed to the case after the aliases", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("NameCase").Return("pascal").Once()
+		configMock.On("TableAliases").Return(nil, nil).Once()
+		configMock.On("ColumnAliases").Return([]config.Alias{{Pattern: "very_long_*", Alias: "customer_ref"}}, nil).Once()
+		configMock.On("ShowSchemaPrefix").Return(false)
+
+		// Act
+		aliases, err := getAliases(&configMock, result)
+		aliasedData := aliases.apply(diagramData)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Equal(t, "TblOrder", aliasedData.Tables[0].Name)
+		assert.Equal(t, []ErdColumnData{
+			{Name: "Id"},
+			{Name: "CustomerRef", Description: "(very_long_customer_reference) customer"},
+		}, aliasedData.Tables[0].Columns)
+		assert.Equal(t, []ErdConstraintData{{FkTableName: "TblOrder", PkTableName: "TblCustomer"}}, aliasedData.Constraints)
+	})
+
+	t.Run("Diagram data is unchanged without aliases", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("NameCase").Return("").Once()
+		configMock.On("TableAliases").Return(nil, nil).Once()
+		configMock.On("ColumnAliases").Return(nil, nil).Once()
+
+		// Act
+		aliases, err := getAliases(&configMock, result)
+		aliasedData := aliases.apply(diagramData)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Equal(t, diagramData, aliasedData)
+	})
+
+	t.Run("Invalid pattern", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("NameCase").Return("").Once()
+		configMock.On("TableAliases").Return([]config.Alias{{Pattern: "/(/", Alias: "order"}}, nil).Once()
+		configMock.On("ColumnAliases").Return(nil, nil).Once()
+
+		// Act
+		_, err := getAliases(&configMock, result)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.ErrorContains(t, err, "invalid table alias pattern")
+	})
+}
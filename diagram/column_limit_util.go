@@ -0,0 +1,50 @@
+package diagram
+
+import "github.com/aslakhellesoy/mermerd/database"
+
+// limitColumns keeps the first maxColumns columns of wide tables, in which the primary key is preferred to the other
+// keys and the keys to the other columns. The columns stay in the order of the table, the number of omitted columns
+// is shown in a placeholder row.
+func limitColumns(columns []database.ColumnResult, columnData []ErdColumnData, maxColumns int) ([]ErdColumnData, int) {
+	if maxColumns <= 0 || len(columnData) <= maxColumns {
+		return columnData, 0
+	}
+
+	keep := make([]bool, len(columnData))
+	kept := 0
+	for _, priority := range []int{primaryKeyPriority, keyPriority, columnPriority} {
+		for index, column := range columns {
+			if kept < maxColumns && !keep[index] && getColumnPriority(column, columnData[index]) == priority {
+				keep[index] = true
+				kept++
+			}
+		}
+	}
+
+	var limitedColumnData []ErdColumnData
+	for index, data := range columnData {
+		if keep[index] {
+			limitedColumnData = append(limitedColumnData, data)
+		}
+	}
+
+	return limitedColumnData, len(columnData) - maxColumns
+}
+
+const (
+	primaryKeyPriority = iota
+	keyPriority
+	columnPriority
+)
+
+// getColumnPriority uses the attribute key for unique keys, which are not part of the column result
+func getColumnPriority(column database.ColumnResult, data ErdColumnData) int {
+	switch {
+	case column.IsPrimary:
+		return primaryKeyPriority
+	case column.IsForeign || len(data.AttributeKeys) > 0:
+		return keyPriority
+	default:
+		return columnPriority
+	}
+}
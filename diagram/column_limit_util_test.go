@@ -0,0 +1,55 @@
+package diagram
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+func TestLimitColumns(t *testing.T) {
+	columns := []database.ColumnResult{
+		{Name: "title"},
+		{Name: "id", IsPrimary: true},
+		{Name: "body"},
+		{Name: "slug"},
+		{Name: "author_id", IsForeign: true},
+	}
+	columnData := []ErdColumnData{
+		{Name: "title"},
+		{Name: "id", AttributeKeys: ErdAttributeKeys{primaryKey}},
+		{Name: "body"},
+		{Name: "slug", AttributeKeys: ErdAttributeKeys{uniqueKey}},
+		{Name: "author_id", AttributeKeys: ErdAttributeKeys{foreignKey}},
+	}
+
+	testCases := []struct {
+		maxColumns             int
+		expectedColumnNames    []string
+		expectedOmittedColumns int
+	}{
+		{0, []string{"title", "id", "body", "slug", "author_id"}, 0},
+		{5, []string{"title", "id", "body", "slug", "author_id"}, 0},
+		{4, []string{"title", "id", "slug", "author_id"}, 1},
+		{2, []string{"id", "slug"}, 3},
+		{1, []string{"id"}, 4},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result, omittedColumns := limitColumns(columns, columnData, testCase.maxColumns)
+
+			// Assert
+			var columnNames []string
+			for _, column := range result {
+				columnNames = append(columnNames, column.Name)
+			}
+
+			assert.Equal(t, testCase.expectedColumnNames, columnNames)
+			assert.Equal(t, testCase.expectedOmittedColumns, omittedColumns)
+		})
+	}
+}
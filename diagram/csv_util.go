@@ -0,0 +1,32 @@
+package diagram
+
+import (
+	"strconv"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+var dataDictionaryHeader = []string{"schema", "table", "column", "type", "nullable", "default", "primary key", "foreign key", "comment"}
+
+// getDataDictionaryRows returns the header and a row for every column of the tables (csv and tsv output). The data
+// type always contains the declared length or precision, as the spreadsheet documents the columns.
+func getDataDictionaryRows(result *database.Result) [][]string {
+	rows := [][]string{dataDictionaryHeader}
+	for _, table := range result.Tables {
+		for _, column := range table.Columns {
+			rows = append(rows, []string{
+				table.Table.Schema,
+				table.Table.Name,
+				column.Name,
+				column.DataTypeWithPrecision(),
+				strconv.FormatBool(column.IsNullable),
+				column.DefaultValue,
+				strconv.FormatBool(column.IsPrimary),
+				strconv.FormatBool(column.IsForeign),
+				column.Comment,
+			})
+		}
+	}
+
+	return rows
+}
@@ -0,0 +1,50 @@
+package diagram
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+func TestGetDataDictionaryRows(t *testing.T) {
+	// Arrange
+	result := &database.Result{Tables: []database.TableResult{{
+		Table: database.TableDetail{Schema: "public", Name: "article"},
+		Columns: []database.ColumnResult{
+			{Name: "id", DataType: "int", IsPrimary: true},
+			{Name: "title", DataType: "varchar", CharacterMaxLength: 255, DefaultValue: "'untitled'", Comment: "shown as heading"},
+			{Name: "author_id", DataType: "int", IsForeign: true, IsNullable: true},
+		},
+	}}}
+
+	// Act
+	rows := getDataDictionaryRows(result)
+
+	// Assert
+	assert.Equal(t, [][]string{
+		{"schema", "table", "column", "type", "nullable", "default", "primary key", "foreign key", "comment"},
+		{"public", "article", "id", "int", "false", "", "true", "false", ""},
+		{"public", "article", "title", "varchar(255)", "false", "'untitled'", "false", "false", "shown as heading"},
+		{"public", "article", "author_id", "int", "true", "", "false", "true", ""},
+	}, rows)
+}
+
+func TestWriteDataDictionary(t *testing.T) {
+	// Arrange
+	result := &database.Result{Tables: []database.TableResult{{
+		Table:   database.TableDetail{Schema: "public", Name: "article"},
+		Columns: []database.ColumnResult{{Name: "price", DataType: "numeric", NumericPrecision: 10, NumericScale: 2, Comment: "net\tprice"}},
+	}}}
+	var output bytes.Buffer
+
+	// Act
+	err := writeDataDictionary(&output, result, '\t')
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "schema\ttable\tcolumn\ttype\tnullable\tdefault\tprimary key\tforeign key\tcomment\n"+
+		"public\tarticle\tprice\tnumeric(10,2)\tfalse\t\tfalse\tfalse\t\"net\tprice\"\n", output.String())
+}
@@ -1,25 +1,71 @@
 package diagram
 
 import (
+	"bytes"
 	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
 	"os"
+	"strings"
 	"text/template"
 
 	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
 
 	"github.com/aslakhellesoy/mermerd/config"
 	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/diff"
 )
 
 //go:embed erd_template.gommd
 var erdTemplate string
 
+//go:embed dot_template.godot
+var dotTemplate string
+
+//go:embed markdown_template.gomd
+var markdownTemplate string
+
+//go:embed prisma_template.goprisma
+var prismaTemplate string
+
+//go:embed gorm_template.gogo
+var gormTemplate string
+
+//go:embed sql_template.gosql
+var sqlTemplate string
+
+const (
+	outputFormatMermaid  = "mermaid"
+	outputFormatDot      = "dot"
+	outputFormatJson     = "json"
+	outputFormatYaml     = "yaml"
+	outputFormatMarkdown = "markdown"
+	outputFormatHtml     = "html"
+	outputFormatPrisma   = "prisma"
+	outputFormatGorm     = "gorm"
+	outputFormatSql      = "sql"
+	outputFormatCsv      = "csv"
+	outputFormatTsv      = "tsv"
+	outputFormatOpenApi  = "openapi"
+)
+
+// StdoutFileName as output file name writes the diagram to stdout (e.g. to pipe it into another tool)
+const StdoutFileName = "-"
+
 type diagram struct {
 	config config.MermerdConfig
 }
 
 type Diagram interface {
 	Create(result *database.Result) error
+	Write(w io.Writer, result *database.Result) error
+	Render(w io.Writer, result *database.Result, imageFormat string) error
+	CreateDiff(result *diff.Result) error
 }
 
 func NewDiagram(config config.MermerdConfig) Diagram {
@@ -27,7 +73,134 @@ func NewDiagram(config config.MermerdConfig) Diagram {
 }
 
 func (d diagram) Create(result *database.Result) error {
-	f, err := os.Create(d.config.OutputFileName())
+	imageFormat := d.config.Render()
+	if imageFormat != "" && d.config.OutputFileName() == StdoutFileName {
+		return errors.New("the diagram cannot be rendered if it is written to stdout")
+	}
+
+	if d.config.Inject() {
+		if err := d.injectDiagram(result); err != nil {
+			return err
+		}
+	} else if err := d.writeOutputFile(result); err != nil {
+		return err
+	}
+
+	if imageFormat != "" {
+		return d.createImage(result, imageFormat)
+	}
+
+	return nil
+}
+
+func (d diagram) writeOutputFile(result *database.Result) error {
+	f, err := createOutputFile(d.config.OutputFileName())
+	if err != nil {
+		logrus.Error("Could not create output file", " | ", err)
+		return err
+	}
+
+	defer f.Close()
+	return d.Write(f, result)
+}
+
+// injectDiagram replaces the content between the markers of the existing output file (see injectOutput)
+func (d diagram) injectDiagram(result *database.Result) error {
+	fileName := d.config.OutputFileName()
+	if fileName == StdoutFileName {
+		return errors.New("the diagram cannot be injected if it is written to stdout")
+	}
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	var output bytes.Buffer
+	if err = d.Write(&output, result); err != nil {
+		return err
+	}
+
+	injected, err := injectOutput(string(content), output.String())
+	if err != nil {
+		return fmt.Errorf("could not inject the diagram into %s: %w", fileName, err)
+	}
+
+	return os.WriteFile(fileName, []byte(injected), 0o644)
+}
+
+// createOutputFile creates the output file or returns stdout (see StdoutFileName), which is not closed
+func createOutputFile(fileName string) (io.WriteCloser, error) {
+	if fileName == StdoutFileName {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+
+	return os.Create(fileName)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// createImage renders the diagram next to the output file (e.g. result.svg for result.mmd)
+func (d diagram) createImage(result *database.Result, imageFormat string) error {
+	f, err := os.Create(getImageFileName(d.config.OutputFileName(), imageFormat))
+	if err != nil {
+		logrus.Error("Could not create image file", " | ", err)
+		return err
+	}
+
+	defer f.Close()
+	return d.Render(f, result, imageFormat)
+}
+
+// Write writes the diagram (or the exported model) in the configured output format to w
+func (d diagram) Write(w io.Writer, result *database.Result) error {
+	return d.writeStableOutput(w, func(w io.Writer) error {
+		return d.write(w, result)
+	})
+}
+
+func (d diagram) write(w io.Writer, result *database.Result) error {
+	switch d.config.OutputFormat() {
+	case outputFormatJson:
+		return writeJson(w, result)
+	case outputFormatYaml:
+		return writeYaml(w, result)
+	case outputFormatMarkdown:
+		return d.writeMarkdown(w, result)
+	case outputFormatHtml:
+		return d.writeHtml(w, result)
+	case outputFormatPrisma:
+		return d.writePrisma(w, result)
+	case outputFormatGorm:
+		return writeGorm(w, result)
+	case outputFormatSql:
+		return d.writeSql(w, result)
+	case outputFormatCsv:
+		return writeDataDictionary(w, result, ',')
+	case outputFormatTsv:
+		return writeDataDictionary(w, result, '\t')
+	case outputFormatOpenApi:
+		return writeOpenApi(w, result)
+	}
+
+	diagramData, err := d.getDiagramData(result)
+	if err != nil {
+		return err
+	}
+
+	return d.execute(w, diagramData)
+}
+
+// CreateDiff creates a diagram with the tables of both sources, in which the added, removed and changed tables
+// are highlighted
+func (d diagram) CreateDiff(result *diff.Result) error {
+	f, err := createOutputFile(d.config.OutputFileName())
 	if err != nil {
 		logrus.Error("Could not create output file", " | ", err)
 		return err
@@ -35,47 +208,384 @@ func (d diagram) Create(result *database.Result) error {
 
 	defer f.Close()
 
-	tmpl, err := template.New("erd_template").Parse(erdTemplate)
+	model := result.Model()
+	diagramData, err := d.getDiagramData(model)
+	if err != nil {
+		return err
+	}
+
+	aliases, err := getAliases(d.config, model)
+	if err != nil {
+		return err
+	}
+
+	diagramData.Classes = append(diagramData.Classes, aliases.applyToClasses(getDiffClassData(d.config, result))...)
+	return d.writeStableOutput(f, func(w io.Writer) error {
+		return d.execute(w, diagramData)
+	})
+}
+
+// writeStableOutput normalizes the whitespace of the output in the stable output mode (see normalizeWhitespace)
+func (d diagram) writeStableOutput(w io.Writer, write func(w io.Writer) error) error {
+	if isDataOutputFormat(d.config.OutputFormat()) || !d.config.StableOutput() {
+		return write(w)
+	}
+
+	var output bytes.Buffer
+	if err := write(&output); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, normalizeWhitespace(output.String()))
+	return err
+}
+
+func (d diagram) execute(w io.Writer, diagramData ErdDiagramData) error {
+	tmpl, err := getTemplate(d.config.OutputFormat())
 	if err != nil {
 		logrus.Error("Could not load template file", " | ", err)
 		return err
 	}
 
-	tableData := make([]ErdTableData, len(result.Tables))
+	if err = tmpl.Execute(w, diagramData); err != nil {
+		logrus.Error("Could not create diagram", " | ", err)
+		return err
+	}
+	return nil
+}
+
+func (d diagram) getDiagramData(result *database.Result) (ErdDiagramData, error) {
+	tables := result.Tables
+	if d.config.OmitOrphanTables() {
+		var orphanTables []database.TableResult
+		tables, orphanTables = splitOrphanTables(d.config, tables)
+		if len(orphanTables) > 0 {
+			logrus.WithField("tables", getTableNames(d.config, orphanTables)).Info("Omitting the tables without relationships")
+		}
+	}
+
+	if _, err := getCommentEscaper(d.config.MermaidCommentStyle()); err != nil {
+		logrus.Error("Could not escape the comments", " | ", err)
+		return ErdDiagramData{}, err
+	}
+
+	var joinTables []database.TableResult
+	if d.config.CollapseJoinTables() {
+		tables, joinTables = splitJoinTables(tables)
+	}
+
+	groupNames, err := getTableGroupNames(d.config, tables)
+	if err != nil {
+		logrus.Error("Could not group the tables", " | ", err)
+		return ErdDiagramData{}, err
+	}
+
+	tableData := make([]ErdTableData, len(tables))
 	var allConstraints database.ConstraintResultList
 
-	for tableIndex, table := range result.Tables {
+	for tableIndex, table := range tables {
 		allConstraints = allConstraints.AppendIfNotExists(table.Constraints...)
 
-		columnData := make([]ErdColumnData, len(table.Columns))
-		for columnIndex, column := range table.Columns {
-			columnData[columnIndex] = getColumnData(d.config, column)
+		var columnData []ErdColumnData
+		var omittedColumns int
+		if !d.config.OmitColumns() {
+			columnData = make([]ErdColumnData, len(table.Columns))
+			keyType := getKeyType(table)
+			for columnIndex, column := range table.Columns {
+				if d.config.ShowEnumsAsEntities() {
+					// the values are shown in the enum entity instead of the description
+					column.EnumValues = ""
+				}
+
+				columnData[columnIndex] = getColumnData(d.config, column, table.Indexes)
+				columnData[columnIndex].Description = addKeyTypeDescription(d.config.ShowDescriptions(), column, keyType, columnData[columnIndex].Description)
+			}
+
+			columnData, omittedColumns = limitColumns(table.Columns, columnData, d.config.MaxColumnsPerTable())
 		}
 
 		tableData[tableIndex] = ErdTableData{
-			Name:    getTableName(d.config, table.Table),
-			Columns: columnData,
+			Name:           getTableName(d.config, table.Table),
+			IsView:         table.Table.IsView,
+			Group:          groupNames[tableIndex],
+			Columns:        columnData,
+			OmittedColumns: omittedColumns,
 		}
 	}
 
 	var constraints []ErdConstraintData
 	for _, constraint := range allConstraints {
-		if shouldSkipConstraint(d.config, tableData, constraint) {
+		if shouldSkipConstraint(d.config, tables, constraint) || isJoinTableConstraint(joinTables, constraint) {
 			continue
 		}
 
-		constraints = append(constraints, getConstraintData(d.config, constraint))
+		constraintData, err := getConstraintData(d.config, constraint)
+		if err != nil {
+			logrus.Error("Could not create the relationship label", " | ", err)
+			return ErdDiagramData{}, err
+		}
+
+		constraints = append(constraints, constraintData)
+	}
+
+	for _, joinTable := range joinTables {
+		constraint, err := getJoinTableConstraintData(d.config, joinTable)
+		if err != nil {
+			logrus.Error("Could not create the relationship label", " | ", err)
+			return ErdDiagramData{}, err
+		}
+
+		if d.config.ShowAllConstraints() || d.config.ShowGhostTables() || (tableNameInSlice(tableData, constraint.FkTableName) && tableNameInSlice(tableData, constraint.PkTableName)) {
+			constraints = append(constraints, constraint)
+		}
+	}
+
+	if d.config.ShowGhostTables() {
+		tableData = append(tableData, getGhostTableData(tableData, constraints)...)
+	}
+
+	if d.config.ShowEnumsAsEntities() {
+		enumTables, enumConstraints := getEnumData(d.config, tables, tableData)
+		tableData = append(tableData, enumTables...)
+		constraints = append(constraints, enumConstraints...)
+	}
+
+	styleClasses, err := getTableStyleClassData(d.config, tables)
+	if err != nil {
+		logrus.Error("Could not apply the table styles", " | ", err)
+		return ErdDiagramData{}, err
+	}
+
+	classes := append(getViewClassData(tableData), getEnumClassData(tableData)...)
+	classes = append(classes, getGhostClassData(tableData)...)
+
+	if d.config.MergeRelationships() {
+		constraints = mergeConstraints(constraints)
+	}
+
+	parentFirst, err := isParentFirst(d.config)
+	if err != nil {
+		logrus.Error("Could not order the relationships", " | ", err)
+		return ErdDiagramData{}, err
 	}
 
-	diagramData := ErdDiagramData{
+	if err = sortConstraints(d.config, constraints, parentFirst); err != nil {
+		logrus.Error("Could not order the relationships", " | ", err)
+		return ErdDiagramData{}, err
+	}
+
+	aliases, err := getAliases(d.config, result)
+	if err != nil {
+		logrus.Error("Could not apply the aliases", " | ", err)
+		return ErdDiagramData{}, err
+	}
+
+	return aliases.apply(ErdDiagramData{
 		EncloseWithMermaidBackticks: d.config.EncloseWithMermaidBackticks(),
+		Frontmatter:                 getMermaidConfig(d.config),
+		CommentStyle:                d.config.MermaidCommentStyle(),
+		ParentFirst:                 parentFirst,
 		Tables:                      tableData,
 		Constraints:                 constraints,
+		Classes:                     append(classes, styleClasses...),
+	}), nil
+}
+
+func getTemplate(outputFormat string) (*template.Template, error) {
+	switch outputFormat {
+	case "", outputFormatMermaid:
+		return template.New("erd_template").Funcs(erdTemplateFuncs).Parse(erdTemplate)
+	case outputFormatDot:
+		return template.New("dot_template").Funcs(dotTemplateFuncs).Parse(dotTemplate)
+	case outputFormatMarkdown:
+		return template.New("markdown_template").Funcs(markdownTemplateFuncs).Parse(markdownTemplate)
+	case outputFormatPrisma:
+		return template.New("prisma_template").Funcs(prismaTemplateFuncs).Parse(prismaTemplate)
+	case outputFormatGorm:
+		return template.New("gorm_template").Funcs(gormTemplateFuncs).Parse(gormTemplate)
+	case outputFormatSql:
+		return template.New("sql_template").Parse(sqlTemplate)
+	default:
+		return nil, fmt.Errorf("unsupported output format %q", outputFormat)
+	}
+}
+
+// writeMarkdown writes a data dictionary with a section per table, in which the mermaid diagram is embedded
+func (d diagram) writeMarkdown(w io.Writer, result *database.Result) error {
+	erd, err := d.getErd(result, true)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := getTemplate(outputFormatMarkdown)
+	if err != nil {
+		logrus.Error("Could not load template file", " | ", err)
+		return err
 	}
 
-	if err = tmpl.Execute(f, diagramData); err != nil {
+	if err = tmpl.Execute(w, getReportData(d.config, result, erd)); err != nil {
+		logrus.Error("Could not create markdown output", " | ", err)
+		return err
+	}
+
+	return nil
+}
+
+// writeHtml writes a standalone page with the diagram (rendered by mermaid.js in the browser) and a searchable
+// index of all tables and columns
+func (d diagram) writeHtml(w io.Writer, result *database.Result) error {
+	erd, err := d.getErd(result, false)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := getHtmlTemplate()
+	if err != nil {
+		logrus.Error("Could not load template file", " | ", err)
+		return err
+	}
+
+	if err = tmpl.Execute(w, getReportData(d.config, result, erd)); err != nil {
+		logrus.Error("Could not create html output", " | ", err)
+		return err
+	}
+
+	return nil
+}
+
+// getErd returns the mermaid diagram, which is embedded in the markdown and html output
+func (d diagram) getErd(result *database.Result, encloseWithMermaidBackticks bool) (string, error) {
+	tmpl, err := getTemplate(outputFormatMermaid)
+	if err != nil {
+		logrus.Error("Could not load template file", " | ", err)
+		return "", err
+	}
+
+	diagramData, err := d.getDiagramData(result)
+	if err != nil {
+		return "", err
+	}
+
+	diagramData.EncloseWithMermaidBackticks = encloseWithMermaidBackticks
+	var erd strings.Builder
+	if err = tmpl.Execute(&erd, diagramData); err != nil {
 		logrus.Error("Could not create diagram", " | ", err)
+		return "", err
+	}
+
+	return erd.String(), nil
+}
+
+// writePrisma writes a prisma schema (schema.prisma) with a model per table, so that prisma can be adopted for an
+// existing database
+func (d diagram) writePrisma(w io.Writer, result *database.Result) error {
+	tmpl, err := getTemplate(outputFormatPrisma)
+	if err != nil {
+		logrus.Error("Could not load template file", " | ", err)
+		return err
+	}
+
+	if err = tmpl.Execute(w, getPrismaData(d.config, result)); err != nil {
+		logrus.Error("Could not create prisma schema", " | ", err)
 		return err
 	}
+
 	return nil
 }
+
+// writeGorm writes a go file with a struct for every table, which is formatted like gofmt would do
+func writeGorm(w io.Writer, result *database.Result) error {
+	tmpl, err := getTemplate(outputFormatGorm)
+	if err != nil {
+		logrus.Error("Could not load template file", " | ", err)
+		return err
+	}
+
+	var source bytes.Buffer
+	if err = tmpl.Execute(&source, getGormData(result)); err != nil {
+		logrus.Error("Could not create go structs", " | ", err)
+		return err
+	}
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		logrus.Error("Could not format go structs", " | ", err)
+		return err
+	}
+
+	_, err = w.Write(formatted)
+	return err
+}
+
+// writeSql writes the CREATE TABLE statements of the tables in the configured dialect, so that the schema can be
+// archived and compared (or analyzed again with the file connector)
+func (d diagram) writeSql(w io.Writer, result *database.Result) error {
+	dialect, err := getSqlDialect(d.config.SqlDialect())
+	if err != nil {
+		logrus.Error("Could not create sql output", " | ", err)
+		return err
+	}
+
+	tmpl, err := getTemplate(outputFormatSql)
+	if err != nil {
+		logrus.Error("Could not load template file", " | ", err)
+		return err
+	}
+
+	if err = tmpl.Execute(w, getSqlScriptData(dialect, result)); err != nil {
+		logrus.Error("Could not create sql output", " | ", err)
+		return err
+	}
+
+	return nil
+}
+
+// writeDataDictionary writes a row for every column separated by the delimiter (csv or tsv), so that the columns can
+// be documented in a spreadsheet
+func writeDataDictionary(w io.Writer, result *database.Result, delimiter rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = delimiter
+	if err := writer.WriteAll(getDataDictionaryRows(result)); err != nil {
+		logrus.Error("Could not create data dictionary", " | ", err)
+		return err
+	}
+
+	return nil
+}
+
+// writeOpenApi writes an OpenAPI document with the schemas of the tables as components, so that the request and
+// response schemas of an api can be derived from them
+func writeOpenApi(w io.Writer, result *database.Result) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(getOpenApiDocument(result)); err != nil {
+		logrus.Error("Could not create openapi output", " | ", err)
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// writeJson writes the analyzed database model instead of a diagram, so that it can be used by other tools
+func writeJson(w io.Writer, result *database.Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		logrus.Error("Could not create json output", " | ", err)
+		return err
+	}
+
+	return nil
+}
+
+// writeYaml writes the analyzed database model, so that the diagram can be rendered later without a database connection
+func writeYaml(w io.Writer, result *database.Result) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(result); err != nil {
+		logrus.Error("Could not create yaml output", " | ", err)
+		return err
+	}
+
+	return encoder.Close()
+}
@@ -1,10 +1,16 @@
 package diagram
 
+import "strings"
+
 type ErdRelationType string
 
 const (
-	relationOneToOne  ErdRelationType = "|o--||"
-	relationManyToOne ErdRelationType = "}o--||"
+	relationOneToOne          ErdRelationType = "|o--||"
+	relationManyToOne         ErdRelationType = "}o--||"
+	relationOptionalOneToOne  ErdRelationType = "|o--o|"
+	relationOptionalManyToOne ErdRelationType = "}o--o|"
+	relationManyToMany        ErdRelationType = "}o--o{"
+	relationInheritance       ErdRelationType = "|o..||" // dashed, as there is no foreign key to the parent
 )
 
 type ErdAttributeKey string
@@ -12,25 +18,56 @@ type ErdAttributeKey string
 const (
 	primaryKey ErdAttributeKey = "PK"
 	foreignKey ErdAttributeKey = "FK"
-	none       ErdAttributeKey = ""
+	uniqueKey  ErdAttributeKey = "UK"
 )
 
+// ErdAttributeKeys are the keys of a column in the order PK, FK and UK
+type ErdAttributeKeys []ErdAttributeKey
+
+// String joins the keys with commas like mermaid expects them (e.g. PK,FK), so the templates can print them directly
+func (k ErdAttributeKeys) String() string {
+	keys := make([]string, len(k))
+	for index, key := range k {
+		keys[index] = string(key)
+	}
+
+	return strings.Join(keys, ",")
+}
+
 type ErdDiagramData struct {
 	EncloseWithMermaidBackticks bool
-	Tables                      []ErdTableData
-	Constraints                 []ErdConstraintData
+	// Frontmatter contains the mermaid configuration (or the init directive), which is printed before erDiagram
+	Frontmatter string
+	// CommentStyle is the style of the attribute comments (see --mermaidCommentStyle)
+	CommentStyle string
+	// ParentFirst writes the referenced table first in the relationships (see --relationshipDirection)
+	ParentFirst bool
+	Tables      []ErdTableData
+	Constraints []ErdConstraintData
+	Classes     []ErdClassData
 }
 
 type ErdTableData struct {
 	Name    string
+	IsView  bool
+	IsEnum  bool
+	IsGhost bool
+	Group   string
 	Columns []ErdColumnData
+	// OmittedColumns is the number of columns that are not shown (see --maxColumnsPerTable)
+	OmittedColumns int
+}
+
+type ErdGroupData struct {
+	Name   string
+	Tables []ErdTableData
 }
 
 type ErdColumnData struct {
-	Name         string
-	DataType     string
-	Description  string
-	AttributeKey ErdAttributeKey
+	Name          string
+	DataType      string
+	Description   string
+	AttributeKeys ErdAttributeKeys
 }
 
 type ErdConstraintData struct {
@@ -38,4 +75,122 @@ type ErdConstraintData struct {
 	FkTableName     string
 	Relation        ErdRelationType
 	ConstraintLabel string
+	IsInferred      bool
+	IsInheritance   bool
+}
+
+type ErdClassData struct {
+	Name       string
+	Style      string
+	TableNames string
+}
+
+type ReportData struct {
+	Diagram           string
+	Tables            []ReportTableData
+	ClassifiedColumns []ReportClassifiedColumnData
+	// OrphanTables are the names of the tables that are omitted from the diagram (see --omitOrphanTables)
+	OrphanTables []string
+}
+
+type ReportTableData struct {
+	Name       string
+	IsView     bool
+	Columns    []ReportColumnData
+	SampleRows [][]string
+}
+
+type ReportColumnData struct {
+	Name            string
+	DataType        string
+	AttributeKeys   ErdAttributeKeys
+	IsNullable      bool
+	DefaultValue    string
+	Comment         string
+	Classifications string
+	// SampleValues are the distinct values of the column in the sample rows, which are shown as tooltip
+	SampleValues string
+}
+
+type ReportClassifiedColumnData struct {
+	Table           string
+	Column          string
+	Classifications string
+}
+
+type PrismaSchemaData struct {
+	Provider        string
+	PreviewFeatures []string
+	Schemas         []string
+	Models          []PrismaModelData
+	Enums           []PrismaEnumData
+}
+
+type PrismaModelData struct {
+	// Keyword is model or view
+	Keyword string
+	Name    string
+	Comment string
+	Fields  []PrismaFieldData
+	// Attributes are the block attributes, e.g. @@id([order_id, product_id])
+	Attributes []string
+}
+
+type PrismaFieldData struct {
+	Name       string
+	Type       string
+	Attributes []string
+	Comment    string
+}
+
+type PrismaEnumData struct {
+	Name       string
+	Values     []PrismaFieldData
+	Attributes []string
+}
+
+type GormFileData struct {
+	Package string
+	Imports []string
+	Structs []GormStructData
+}
+
+type GormStructData struct {
+	Name      string
+	Comment   string
+	TableName string
+	Fields    []GormFieldData
+}
+
+type GormFieldData struct {
+	Name    string
+	Type    string
+	Tag     string
+	Comment string
+}
+
+type SqlScriptData struct {
+	Dialect string
+	// Notes are the objects that are not exported, which are listed as comments
+	Notes       []string
+	Types       []string
+	Tables      []SqlTableData
+	Indexes     []string
+	ForeignKeys []string
+	Comments    []string
+}
+
+type SqlTableData struct {
+	Name string
+	// Comment is only set for the dialects without comments in the DDL, it is added as line comment
+	Comment string
+	// Lines are the column definitions and constraints with the separating commas
+	Lines []string
+	// Options are added after the column definitions, e.g. the table comment of mysql
+	Options string
+}
+
+type SqlElementData struct {
+	Definition string
+	Comment    string
 }
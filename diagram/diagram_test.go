@@ -0,0 +1,33 @@
+package diagram
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErdTemplate(t *testing.T) {
+	// Arrange
+	tmpl, err := getTemplate(outputFormatMermaid)
+	data := ErdDiagramData{
+		Tables: []ErdTableData{
+			{Name: "article", Columns: []ErdColumnData{{Name: "id", DataType: "int", AttributeKeys: ErdAttributeKeys{primaryKey}}}},
+			{Name: "author"},
+		},
+		Constraints: []ErdConstraintData{
+			{FkTableName: "article", PkTableName: "author", Relation: relationManyToOne, ConstraintLabel: "author_id"},
+		},
+	}
+	var result bytes.Buffer
+
+	// Act
+	_ = tmpl.Execute(&result, data)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Contains(t, result.String(), "    article {\n        int id PK\n    }\n")
+	assert.Contains(t, result.String(), "    author\n")
+	assert.NotContains(t, result.String(), "author {")
+	assert.Contains(t, result.String(), `    article }o--|| author : "author_id"`)
+}
@@ -2,7 +2,9 @@ package diagram
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"text/template"
 
 	"github.com/sirupsen/logrus"
 
@@ -10,10 +12,29 @@ import (
 	"github.com/aslakhellesoy/mermerd/database"
 )
 
+var erdTemplateFuncs = template.FuncMap{
+	"groupTables":        groupTables,
+	"mermaidAttribute":   mermaidAttribute,
+	"mermaidDataType":    mermaidDataType,
+	"mermaidDescription": mermaidDescription,
+	"mermaidEntities":    mermaidEntities,
+	"mermaidEntity":      mermaidEntity,
+	"mermaidLabel":       mermaidLabel,
+	"reverseRelation":    reverseRelation,
+}
+
+// getRelation returns the cardinality of the relation. A nullable foreign key makes the referenced side optional
+// (zero or one instead of exactly one).
 func getRelation(constraint database.ConstraintResult) ErdRelationType {
-	if constraint.IsPrimary && !constraint.HasMultiplePK {
+	isOneToOne := (constraint.IsPrimary && !constraint.HasMultiplePK) || constraint.IsUnique
+	switch {
+	case isOneToOne && constraint.IsNullable:
+		return relationOptionalOneToOne
+	case isOneToOne:
 		return relationOneToOne
-	} else {
+	case constraint.IsNullable:
+		return relationOptionalManyToOne
+	default:
 		return relationManyToOne
 	}
 }
@@ -28,30 +49,115 @@ func tableNameInSlice(slice []ErdTableData, tableName string) bool {
 	return false
 }
 
-func getAttributeKey(column database.ColumnResult) ErdAttributeKey {
+func getAttributeKeys(column database.ColumnResult) ErdAttributeKeys {
+	var keys ErdAttributeKeys
 	if column.IsPrimary {
-		return primaryKey
+		keys = append(keys, primaryKey)
 	}
 
 	if column.IsForeign {
-		return foreignKey
+		keys = append(keys, foreignKey)
+	}
+
+	return keys
+}
+
+// getColumnKeys returns the attribute keys of the column, unique columns are reported by the connector or have a
+// unique index (indexes are only read if they should be shown). Only the first key is returned, unless all keys
+// should be shown.
+func getColumnKeys(config config.MermerdConfig, column database.ColumnResult, indexes []database.IndexResult) ErdAttributeKeys {
+	keys := getAttributeKeys(column)
+	multipleKeys := config.MultipleAttributeKeys()
+	if (len(keys) == 0 || multipleKeys) && (column.IsUnique || config.ShowIndexes() && isUniqueColumn(indexes, column.Name)) {
+		keys = append(keys, uniqueKey)
+	}
+
+	if !multipleKeys && len(keys) > 1 {
+		return keys[:1]
 	}
 
-	return none
+	return keys
 }
 
-func getColumnData(config config.MermerdConfig, column database.ColumnResult) ErdColumnData {
-	attributeKey := getAttributeKey(column)
+func getColumnData(config config.MermerdConfig, column database.ColumnResult, indexes []database.IndexResult) ErdColumnData {
+	attributeKeys := getColumnKeys(config, column, indexes)
 	if config.OmitAttributeKeys() {
-		attributeKey = none
+		attributeKeys = nil
+	}
+
+	description := getDescription(config.ShowDescriptions(), column)
+	if config.ShowIdentity() {
+		description = strings.TrimSpace(description + " " + getIdentityDescription(column))
+	}
+	if config.ShowNullable() {
+		description = strings.TrimSpace(description + " " + getNullableDescription(column))
+	}
+	if len(column.Classifications) > 0 {
+		description = strings.TrimSpace(getClassificationDescription(column) + " " + description)
 	}
 
 	return ErdColumnData{
-		Name:         column.Name,
-		DataType:     column.DataType,
-		Description:  getDescription(config.ShowDescriptions(), column),
-		AttributeKey: attributeKey,
+		Name:          column.Name,
+		DataType:      getDataType(config, column),
+		Description:   escapeComments(config.MermaidCommentStyle(), description),
+		AttributeKeys: attributeKeys,
+	}
+}
+
+// getDataType returns the data type of the column, which includes the length or precision with
+// --showDataTypePrecision
+func getDataType(config config.MermerdConfig, column database.ColumnResult) string {
+	if config.ShowDataTypePrecision() {
+		return column.DataTypeWithPrecision()
+	}
+
+	return column.DataType
+}
+
+// mermaidDataType replaces the comma between the precision and the scale (e.g. numeric(10,2)), as mermaid does not
+// allow commas in the data types. Spaces (e.g. character varying) and other special characters are replaced with an
+// underscore.
+func mermaidDataType(dataType string) string {
+	return mermaidWord(strings.ReplaceAll(dataType, ",", "-"))
+}
+
+// getIdentityDescription marks the columns whose values are generated by the database, sequences are shown with
+// their name
+func getIdentityDescription(column database.ColumnResult) string {
+	switch {
+	case column.IsAutoIncrement:
+		return "identity"
+	case column.SequenceName != "":
+		return "sequence: " + column.SequenceName
+	default:
+		return ""
+	}
+}
+
+// getClassificationDescription marks the classified columns (e.g. [pii]), the marker is the first part of the
+// description so that it is visible even if the description is truncated
+func getClassificationDescription(column database.ColumnResult) string {
+	return "[" + strings.Join(column.Classifications, ", ") + "]"
+}
+
+func getNullableDescription(column database.ColumnResult) string {
+	if column.IsNullable {
+		return "NULL"
+	}
+
+	return "NOT NULL"
+}
+
+// isUniqueColumn checks if the column has its own unique constraint or index besides the primary key (composite ones
+// are not taken into account, as the single column is not unique)
+func isUniqueColumn(indexes []database.IndexResult, columnName string) bool {
+	for _, index := range indexes {
+		if index.IsUnique && !index.IsPrimary && len(index.ColumnNames) == 1 && index.ColumnNames[0] == columnName {
+			return true
+		}
 	}
+
+	return false
 }
 
 func getDescription(options []string, column database.ColumnResult) string {
@@ -63,7 +169,17 @@ func getDescription(options []string, column database.ColumnResult) string {
 				description = append(description, "<"+column.EnumValues+">")
 			}
 		case "columnComments":
-			description = append(description, escapeComments(column.Comment))
+			description = append(description, column.Comment)
+		case "defaultValues":
+			if column.DefaultValue != "" {
+				description = append(description, "default: "+column.DefaultValue)
+			}
+		case "checkConstraints":
+			if column.CheckConstraints != "" {
+				description = append(description, column.CheckConstraints)
+			}
+		case "keyTypes":
+			// the key type depends on the other columns of the table (see addKeyTypeDescription)
 		default:
 			logrus.Errorf("Could not parse option %q", option)
 		}
@@ -73,32 +189,78 @@ func getDescription(options []string, column database.ColumnResult) string {
 
 // escapeComments escapes invalid characters in comments.
 // mermaid does not support quote marks ("), as it uses this to mark the comment, as such these need to be replaced
-// with `#quot;`.
-func escapeComments(str string) string {
-	return strings.ReplaceAll(str, `"`, "#quot;")
+// with `#quot;` or with a single quote mark in the native comment style (see commentEscapers).
+func escapeComments(commentStyle string, str string) string {
+	escaper, err := getCommentEscaper(commentStyle)
+	if err != nil {
+		escaper = commentEscapers[commentStyleEntity]
+	}
+
+	return escaper.Replace(str)
 }
 
-func shouldSkipConstraint(config config.MermerdConfig, tables []ErdTableData, constraint database.ConstraintResult) bool {
-	if config.ShowAllConstraints() {
+func shouldSkipConstraint(config config.MermerdConfig, tables []database.TableResult, constraint database.ConstraintResult) bool {
+	if config.ShowAllConstraints() || config.ShowGhostTables() {
 		return false
 	}
 
-	// if config for all constraints is not set, only show constraints of selected tables
-	return !(tableNameInSlice(tables, constraint.PkTable) && tableNameInSlice(tables, constraint.FkTable))
+	// if config for all constraints is not set, only show constraints of selected tables (in the same schema, as
+	// tables of different schemas can have the same name)
+	pkTable := database.TableDetail{Schema: constraint.PkSchema, Name: constraint.PkTable}
+	fkTable := database.TableDetail{Schema: constraint.FkSchema, Name: constraint.FkTable}
+	return !(tableInSlice(tables, pkTable) && tableInSlice(tables, fkTable))
+}
+
+// tableInSlice compares the schema and the name of the tables, the names of the constraints may not be sanitized
+func tableInSlice(slice []database.TableResult, table database.TableDetail) bool {
+	for _, sliceItem := range slice {
+		if sliceItem.Table.Schema == table.Schema && sliceItem.Table.Name == database.SanitizeValue(table.Name) {
+			return true
+		}
+	}
+
+	return false
 }
 
-func getConstraintData(config config.MermerdConfig, constraint database.ConstraintResult) ErdConstraintData {
-	constraintLabel := constraint.ColumnName
-	if config.OmitConstraintLabels() {
-		constraintLabel = ""
+func getConstraintData(config config.MermerdConfig, constraint database.ConstraintResult) (ErdConstraintData, error) {
+	constraintName := constraint.ConstraintName
+	columns := constraint.ColumnName
+	if len(constraint.ColumnNames) > 0 {
+		columns = strings.Join(constraint.ColumnNames, ", ")
+	}
+	if constraint.IsInheritance {
+		constraintName, columns = relationshipLabelInherits, relationshipLabelInherits
+	}
+
+	constraintLabel, err := getRelationshipLabel(config, relationshipLabelData{
+		FkSchema:       constraint.FkSchema,
+		FkTable:        constraint.FkTable,
+		PkSchema:       constraint.PkSchema,
+		PkTable:        constraint.PkTable,
+		ConstraintName: constraintName,
+		Columns:        columns,
+	})
+	if err != nil {
+		return ErdConstraintData{}, err
+	}
+
+	relation := getRelation(constraint)
+	switch {
+	case constraint.IsInheritance:
+		relation = relationInheritance
+	case constraint.IsInferred:
+		// mermaid shows non-identifying relations with a dashed line
+		relation = ErdRelationType(strings.Replace(string(relation), "--", "..", 1))
 	}
 
 	return ErdConstraintData{
 		PkTableName:     getTableName(config, database.TableDetail{Schema: constraint.PkSchema, Name: constraint.PkTable}),
 		FkTableName:     getTableName(config, database.TableDetail{Schema: constraint.FkSchema, Name: constraint.FkTable}),
-		Relation:        getRelation(constraint),
+		Relation:        relation,
 		ConstraintLabel: constraintLabel,
-	}
+		IsInferred:      constraint.IsInferred,
+		IsInheritance:   constraint.IsInheritance,
+	}, nil
 }
 
 func getTableName(config config.MermerdConfig, table database.TableDetail) string {
@@ -116,3 +278,172 @@ func getTableName(config config.MermerdConfig, table database.TableDetail) strin
 
 	return name
 }
+
+// getViewClassData assigns a mermaid class to the views, so that they can be distinguished from tables
+func getViewClassData(tables []ErdTableData) []ErdClassData {
+	var viewNames []string
+	for _, table := range tables {
+		if table.IsView {
+			viewNames = append(viewNames, table.Name)
+		}
+	}
+
+	if len(viewNames) == 0 {
+		return nil
+	}
+
+	return []ErdClassData{{Name: "view", Style: "stroke-dasharray:5 5", TableNames: strings.Join(viewNames, ",")}}
+}
+
+// splitJoinTables separates the join tables of many-to-many relations from the other tables
+func splitJoinTables(tables []database.TableResult) (otherTables []database.TableResult, joinTables []database.TableResult) {
+	for _, table := range tables {
+		if isJoinTable(table) {
+			joinTables = append(joinTables, table)
+		} else {
+			otherTables = append(otherTables, table)
+		}
+	}
+
+	return otherTables, joinTables
+}
+
+// isJoinTable checks if the table only consists of the foreign keys to exactly two tables (e.g. article_label).
+// Tables that reference themselves are never join tables, as the table itself would be removed from the diagram.
+func isJoinTable(table database.TableResult) bool {
+	constraints := getOwnConstraints(table)
+	if len(table.Columns) == 0 || len(constraints) != 2 {
+		return false
+	}
+
+	for _, constraint := range constraints {
+		if constraint.IsSelfReference() {
+			return false
+		}
+	}
+
+	for _, column := range table.Columns {
+		if !column.IsForeign {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isJoinTableConstraint(joinTables []database.TableResult, constraint database.ConstraintResult) bool {
+	for _, joinTable := range joinTables {
+		if joinTable.Table.Schema == constraint.FkSchema && joinTable.Table.Name == constraint.FkTable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getJoinTableConstraintData creates the many-to-many relation between the two tables of the join table
+func getJoinTableConstraintData(config config.MermerdConfig, joinTable database.TableResult) (ErdConstraintData, error) {
+	constraints := getOwnConstraints(joinTable)
+	constraintLabel, err := getRelationshipLabel(config, relationshipLabelData{
+		FkSchema:       constraints[0].PkSchema,
+		FkTable:        constraints[0].PkTable,
+		PkSchema:       constraints[1].PkSchema,
+		PkTable:        constraints[1].PkTable,
+		ConstraintName: joinTable.Table.Name,
+		Columns:        joinTable.Table.Name,
+	})
+	if err != nil {
+		return ErdConstraintData{}, err
+	}
+
+	return ErdConstraintData{
+		FkTableName:     getTableName(config, database.TableDetail{Schema: constraints[0].PkSchema, Name: constraints[0].PkTable}),
+		PkTableName:     getTableName(config, database.TableDetail{Schema: constraints[1].PkSchema, Name: constraints[1].PkTable}),
+		Relation:        relationManyToMany,
+		ConstraintLabel: constraintLabel,
+	}, nil
+}
+
+// getOwnConstraints returns the foreign keys of the table (merged per constraint), without the constraints of
+// other tables that reference it and without the inherited tables
+func getOwnConstraints(table database.TableResult) []database.ConstraintResult {
+	var constraints []database.ConstraintResult
+	for _, constraint := range database.MergeCompositeConstraints(table.Constraints) {
+		if constraint.FkSchema == table.Table.Schema && constraint.FkTable == table.Table.Name && !constraint.IsInheritance {
+			constraints = append(constraints, constraint)
+		}
+	}
+
+	return constraints
+}
+
+// getPrimaryKeyColumnNames returns the columns of the primary key in the order of the primary key index. Without
+// indexes (--showIndexes) the primary key columns are ordered by their position in the table.
+func getPrimaryKeyColumnNames(table database.TableResult) []string {
+	for _, index := range table.Indexes {
+		if index.IsPrimary {
+			return index.ColumnNames
+		}
+	}
+
+	var primaryColumns []database.ColumnResult
+	for _, column := range table.Columns {
+		if column.IsPrimary {
+			primaryColumns = append(primaryColumns, column)
+		}
+	}
+
+	sort.SliceStable(primaryColumns, func(i, j int) bool {
+		return primaryColumns[i].OrdinalPosition < primaryColumns[j].OrdinalPosition
+	})
+
+	columnNames := make([]string, len(primaryColumns))
+	for i, column := range primaryColumns {
+		columnNames[i] = column.Name
+	}
+
+	return columnNames
+}
+
+// getSchemaNames returns the sorted schemas of the tables
+func getSchemaNames(tables []database.TableResult) []string {
+	var schemas []string
+	for _, table := range tables {
+		if !containsString(schemas, table.Table.Schema) {
+			schemas = append(schemas, table.Table.Schema)
+		}
+	}
+
+	sort.Strings(schemas)
+	return schemas
+}
+
+// getUniqueName returns the first candidate that is not used yet, or the last candidate with a number, and marks it
+// as used
+func getUniqueName(usedNames map[string]bool, candidates ...string) string {
+	for _, candidate := range candidates {
+		if !usedNames[candidate] {
+			usedNames[candidate] = true
+			return candidate
+		}
+	}
+
+	last := candidates[len(candidates)-1]
+	for number := 2; ; number++ {
+		candidate := fmt.Sprintf("%s%d", last, number)
+		if !usedNames[candidate] {
+			usedNames[candidate] = true
+			return candidate
+		}
+	}
+}
+
+func containsString(slice []string, item string) bool {
+	for _, sliceItem := range slice {
+		if sliceItem == item {
+			return true
+		}
+	}
+
+	return false
+}
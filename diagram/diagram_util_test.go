@@ -14,12 +14,18 @@ func TestGetRelation(t *testing.T) {
 	testCases := []struct {
 		isPrimary        bool
 		hasMultiplePK    bool
+		isUnique         bool
+		isNullable       bool
 		expectedRelation ErdRelationType
 	}{
-		{true, true, relationManyToOne},
-		{false, true, relationManyToOne},
-		{false, false, relationManyToOne},
-		{true, false, relationOneToOne},
+		{true, true, false, false, relationManyToOne},
+		{false, true, false, false, relationManyToOne},
+		{false, false, false, false, relationManyToOne},
+		{true, false, false, false, relationOneToOne},
+		{false, false, true, false, relationOneToOne},
+		{true, true, true, false, relationOneToOne},
+		{false, false, false, true, relationOptionalManyToOne},
+		{false, false, true, true, relationOptionalOneToOne},
 	}
 
 	for index, testCase := range testCases {
@@ -31,6 +37,8 @@ func TestGetRelation(t *testing.T) {
 				ConstraintName: "constraintXY",
 				IsPrimary:      testCase.isPrimary,
 				HasMultiplePK:  testCase.hasMultiplePK,
+				IsUnique:       testCase.isUnique,
+				IsNullable:     testCase.isNullable,
 			}
 
 			// Act
@@ -42,10 +50,10 @@ func TestGetRelation(t *testing.T) {
 	}
 }
 
-func TestGetAttributeKey(t *testing.T) {
+func TestGetAttributeKeys(t *testing.T) {
 	testCases := []struct {
 		column                  database.ColumnResult
-		expectedAttributeResult ErdAttributeKey
+		expectedAttributeResult ErdAttributeKeys
 	}{
 		{
 			column: database.ColumnResult{
@@ -54,7 +62,7 @@ func TestGetAttributeKey(t *testing.T) {
 				IsPrimary: true,
 				IsForeign: false,
 			},
-			expectedAttributeResult: primaryKey,
+			expectedAttributeResult: ErdAttributeKeys{primaryKey},
 		},
 		{
 			column: database.ColumnResult{
@@ -63,7 +71,7 @@ func TestGetAttributeKey(t *testing.T) {
 				IsPrimary: false,
 				IsForeign: true,
 			},
-			expectedAttributeResult: foreignKey,
+			expectedAttributeResult: ErdAttributeKeys{foreignKey},
 		},
 		{
 			column: database.ColumnResult{
@@ -72,7 +80,7 @@ func TestGetAttributeKey(t *testing.T) {
 				IsPrimary: true,
 				IsForeign: true,
 			},
-			expectedAttributeResult: primaryKey,
+			expectedAttributeResult: ErdAttributeKeys{primaryKey, foreignKey},
 		},
 		{
 			column: database.ColumnResult{
@@ -81,7 +89,7 @@ func TestGetAttributeKey(t *testing.T) {
 				IsPrimary: false,
 				IsForeign: false,
 			},
-			expectedAttributeResult: none,
+			expectedAttributeResult: nil,
 		},
 	}
 
@@ -91,7 +99,7 @@ func TestGetAttributeKey(t *testing.T) {
 			column := testCase.column
 
 			// Act
-			result := getAttributeKey(column)
+			result := getAttributeKeys(column)
 
 			// Assert
 			assert.Equal(t, testCase.expectedAttributeResult, result)
@@ -142,103 +150,412 @@ func TestGetColumnData(t *testing.T) {
 		// Arrange
 		configMock := mocks.MermerdConfig{}
 		configMock.On("OmitAttributeKeys").Return(false).Once()
+		configMock.On("MultipleAttributeKeys").Return(false).Once()
 		configMock.On("ShowDescriptions").Return([]string{"enumValues", "columnComments"}).Once()
+		configMock.On("MermaidCommentStyle").Return("").Once()
+		configMock.On("ShowNullable").Return(false).Once()
+		configMock.On("ShowIdentity").Return(false).Once()
+		configMock.On("ShowDataTypePrecision").Return(false).Once()
 
 		// Act
-		result := getColumnData(&configMock, column)
+		result := getColumnData(&configMock, column, nil)
 
 		// Assert
 		configMock.AssertExpectations(t)
 		assert.Equal(t, columnName, result.Name)
 		assert.Equal(t, "<"+enumValues+"> "+expectedComment, result.Description)
-		assert.Equal(t, primaryKey, result.AttributeKey)
+		assert.Equal(t, ErdAttributeKeys{primaryKey}, result.AttributeKeys)
 	})
 
 	t.Run("Get all fields with enum values", func(t *testing.T) {
 		// Arrange
 		configMock := mocks.MermerdConfig{}
 		configMock.On("OmitAttributeKeys").Return(false).Once()
+		configMock.On("MultipleAttributeKeys").Return(false).Once()
 		configMock.On("ShowDescriptions").Return([]string{"enumValues"}).Once()
+		configMock.On("MermaidCommentStyle").Return("").Once()
+		configMock.On("ShowNullable").Return(false).Once()
+		configMock.On("ShowIdentity").Return(false).Once()
+		configMock.On("ShowDataTypePrecision").Return(false).Once()
 
 		// Act
-		result := getColumnData(&configMock, column)
+		result := getColumnData(&configMock, column, nil)
 
 		// Assert
 		configMock.AssertExpectations(t)
 		assert.Equal(t, columnName, result.Name)
 		assert.Equal(t, "<"+enumValues+">", result.Description)
-		assert.Equal(t, primaryKey, result.AttributeKey)
+		assert.Equal(t, ErdAttributeKeys{primaryKey}, result.AttributeKeys)
+	})
+
+	t.Run("Get column comments in the native comment style", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("OmitAttributeKeys").Return(false).Once()
+		configMock.On("MultipleAttributeKeys").Return(false).Once()
+		configMock.On("ShowDescriptions").Return([]string{"columnComments"}).Once()
+		configMock.On("MermaidCommentStyle").Return("native").Once()
+		configMock.On("ShowNullable").Return(false).Once()
+		configMock.On("ShowIdentity").Return(false).Once()
+		configMock.On("ShowDataTypePrecision").Return(false).Once()
+		multilineColumn := database.ColumnResult{Name: columnName, IsPrimary: true, Comment: "{\"comment\":\n\"detail\"}"}
+
+		// Act
+		result := getColumnData(&configMock, multilineColumn, nil)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Equal(t, "{'comment': 'detail'}", result.Description)
 	})
 
 	t.Run("Get all fields with column comments", func(t *testing.T) {
 		// Arrange
 		configMock := mocks.MermerdConfig{}
 		configMock.On("OmitAttributeKeys").Return(false).Once()
+		configMock.On("MultipleAttributeKeys").Return(false).Once()
 		configMock.On("ShowDescriptions").Return([]string{"columnComments"}).Once()
+		configMock.On("MermaidCommentStyle").Return("").Once()
+		configMock.On("ShowNullable").Return(false).Once()
+		configMock.On("ShowIdentity").Return(false).Once()
+		configMock.On("ShowDataTypePrecision").Return(false).Once()
 
 		// Act
-		result := getColumnData(&configMock, column)
+		result := getColumnData(&configMock, column, nil)
 
 		// Assert
 		configMock.AssertExpectations(t)
 		assert.Equal(t, columnName, result.Name)
 		assert.Equal(t, expectedComment, result.Description)
-		assert.Equal(t, primaryKey, result.AttributeKey)
+		assert.Equal(t, ErdAttributeKeys{primaryKey}, result.AttributeKeys)
+	})
+
+	t.Run("Get all fields with check constraints", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("OmitAttributeKeys").Return(false).Once()
+		configMock.On("MultipleAttributeKeys").Return(false).Once()
+		configMock.On("ShowDescriptions").Return([]string{"enumValues", "checkConstraints"}).Once()
+		configMock.On("MermaidCommentStyle").Return("").Once()
+		configMock.On("ShowNullable").Return(false).Once()
+		configMock.On("ShowIdentity").Return(false).Once()
+		configMock.On("ShowDataTypePrecision").Return(false).Once()
+		configMock.On("ShowIndexes").Return(false).Once()
+		checkColumn := database.ColumnResult{
+			Name:             columnName,
+			CheckConstraints: `status IN ('a', 'b') and "status" <> ''`,
+		}
+
+		// Act
+		result := getColumnData(&configMock, checkColumn, nil)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Equal(t, columnName, result.Name)
+		assert.Equal(t, "status IN ('a', 'b') and #quot;status#quot; <> ''", result.Description)
+		assert.Empty(t, result.AttributeKeys)
+	})
+
+	t.Run("Get all fields with default values", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("OmitAttributeKeys").Return(false).Once()
+		configMock.On("MultipleAttributeKeys").Return(false).Once()
+		configMock.On("ShowDescriptions").Return([]string{"defaultValues", "columnComments"}).Once()
+		configMock.On("MermaidCommentStyle").Return("").Once()
+		configMock.On("ShowNullable").Return(false).Once()
+		configMock.On("ShowIdentity").Return(false).Once()
+		configMock.On("ShowDataTypePrecision").Return(false).Once()
+		defaultColumn := database.ColumnResult{
+			Name:         columnName,
+			IsPrimary:    true,
+			Comment:      "created",
+			DefaultValue: `"now"()`,
+		}
+
+		// Act
+		result := getColumnData(&configMock, defaultColumn, nil)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Equal(t, columnName, result.Name)
+		assert.Equal(t, "default: #quot;now#quot;() created", result.Description)
+		assert.Equal(t, ErdAttributeKeys{primaryKey}, result.AttributeKeys)
+	})
+
+	t.Run("Get all fields with nullable indicator", func(t *testing.T) {
+		testCases := []struct {
+			isPrimary           bool
+			isNullable          bool
+			expectedDescription string
+		}{
+			{false, true, "<" + enumValues + "> NULL"},
+			{false, false, "<" + enumValues + "> NOT NULL"},
+			{true, false, "<" + enumValues + "> NOT NULL"},
+		}
+
+		for index, testCase := range testCases {
+			t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+				// Arrange
+				configMock := mocks.MermerdConfig{}
+				configMock.On("OmitAttributeKeys").Return(true).Once()
+				configMock.On("MultipleAttributeKeys").Return(false).Once()
+				configMock.On("ShowDescriptions").Return([]string{"enumValues"}).Once()
+				configMock.On("MermaidCommentStyle").Return("").Once()
+				configMock.On("ShowNullable").Return(true).Once()
+				configMock.On("ShowIdentity").Return(false).Once()
+				configMock.On("ShowDataTypePrecision").Return(false).Once()
+				if !testCase.isPrimary {
+					configMock.On("ShowIndexes").Return(false).Once()
+				}
+				nullableColumn := database.ColumnResult{
+					Name:       columnName,
+					IsPrimary:  testCase.isPrimary,
+					IsNullable: testCase.isNullable,
+					EnumValues: enumValues,
+				}
+
+				// Act
+				result := getColumnData(&configMock, nullableColumn, nil)
+
+				// Assert
+				configMock.AssertExpectations(t)
+				assert.Equal(t, testCase.expectedDescription, result.Description)
+			})
+		}
+	})
+
+	t.Run("Get all fields with identity indicator", func(t *testing.T) {
+		testCases := []struct {
+			isAutoIncrement     bool
+			sequenceName        string
+			expectedDescription string
+		}{
+			{true, "", "identity NOT NULL"},
+			{false, "public.article_id_seq", "sequence: public.article_id_seq NOT NULL"},
+			{false, "", "NOT NULL"},
+		}
+
+		for index, testCase := range testCases {
+			t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+				// Arrange
+				configMock := mocks.MermerdConfig{}
+				configMock.On("OmitAttributeKeys").Return(true).Once()
+				configMock.On("MultipleAttributeKeys").Return(false).Once()
+				configMock.On("ShowDescriptions").Return([]string{}).Once()
+				configMock.On("MermaidCommentStyle").Return("").Once()
+				configMock.On("ShowNullable").Return(true).Once()
+				configMock.On("ShowIdentity").Return(true).Once()
+				configMock.On("ShowDataTypePrecision").Return(false).Once()
+				identityColumn := database.ColumnResult{
+					Name:            columnName,
+					IsPrimary:       true,
+					IsAutoIncrement: testCase.isAutoIncrement,
+					SequenceName:    testCase.sequenceName,
+				}
+
+				// Act
+				result := getColumnData(&configMock, identityColumn, nil)
+
+				// Assert
+				configMock.AssertExpectations(t)
+				assert.Equal(t, testCase.expectedDescription, result.Description)
+			})
+		}
 	})
 
 	t.Run("Get all fields except description", func(t *testing.T) {
 		// Arrange
 		configMock := mocks.MermerdConfig{}
 		configMock.On("OmitAttributeKeys").Return(false).Once()
+		configMock.On("MultipleAttributeKeys").Return(false).Once()
 		configMock.On("ShowDescriptions").Return([]string{""}).Once()
+		configMock.On("MermaidCommentStyle").Return("").Once()
+		configMock.On("ShowNullable").Return(false).Once()
+		configMock.On("ShowIdentity").Return(false).Once()
+		configMock.On("ShowDataTypePrecision").Return(false).Once()
 
 		// Act
-		result := getColumnData(&configMock, column)
+		result := getColumnData(&configMock, column, nil)
 
 		// Assert
 		configMock.AssertExpectations(t)
 		assert.Equal(t, columnName, result.Name)
 		assert.Equal(t, "", result.Description)
-		assert.Equal(t, primaryKey, result.AttributeKey)
+		assert.Equal(t, ErdAttributeKeys{primaryKey}, result.AttributeKeys)
 	})
 
 	t.Run("Get all fields except attribute key", func(t *testing.T) {
 		// Arrange
 		configMock := mocks.MermerdConfig{}
 		configMock.On("OmitAttributeKeys").Return(true).Once()
+		configMock.On("MultipleAttributeKeys").Return(false).Once()
 		configMock.On("ShowDescriptions").Return([]string{"enumValues", "columnComments"}).Once()
+		configMock.On("MermaidCommentStyle").Return("").Once()
+		configMock.On("ShowNullable").Return(false).Once()
+		configMock.On("ShowIdentity").Return(false).Once()
+		configMock.On("ShowDataTypePrecision").Return(false).Once()
 
 		// Act
-		result := getColumnData(&configMock, column)
+		result := getColumnData(&configMock, column, nil)
 
 		// Assert
 		configMock.AssertExpectations(t)
 		assert.Equal(t, columnName, result.Name)
 		assert.Equal(t, "<"+enumValues+"> "+expectedComment, result.Description)
-		assert.Equal(t, none, result.AttributeKey)
+		assert.Empty(t, result.AttributeKeys)
 	})
 
 	t.Run("Get only minimal fields", func(t *testing.T) {
 		// Arrange
 		configMock := mocks.MermerdConfig{}
 		configMock.On("OmitAttributeKeys").Return(true).Once()
+		configMock.On("MultipleAttributeKeys").Return(false).Once()
 		configMock.On("ShowDescriptions").Return([]string{""}).Once()
+		configMock.On("MermaidCommentStyle").Return("").Once()
+		configMock.On("ShowNullable").Return(false).Once()
+		configMock.On("ShowIdentity").Return(false).Once()
+		configMock.On("ShowDataTypePrecision").Return(false).Once()
 
 		// Act
-		result := getColumnData(&configMock, column)
+		result := getColumnData(&configMock, column, nil)
 
 		// Assert
 		configMock.AssertExpectations(t)
 		assert.Equal(t, columnName, result.Name)
 		assert.Equal(t, "", result.Description)
-		assert.Equal(t, none, result.AttributeKey)
+		assert.Empty(t, result.AttributeKeys)
+	})
+
+	t.Run("Classified columns are marked", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("ShowIndexes").Return(false).Once()
+		configMock.On("OmitAttributeKeys").Return(false).Once()
+		configMock.On("MultipleAttributeKeys").Return(false).Once()
+		configMock.On("ShowDescriptions").Return([]string{"columnComments"}).Once()
+		configMock.On("MermaidCommentStyle").Return("").Once()
+		configMock.On("ShowNullable").Return(true).Once()
+		configMock.On("ShowIdentity").Return(false).Once()
+		configMock.On("ShowDataTypePrecision").Return(false).Once()
+		classifiedColumn := database.ColumnResult{Name: "ssn", Comment: "social security number", Classifications: []string{"pii", "secret"}}
+
+		// Act
+		result := getColumnData(&configMock, classifiedColumn, nil)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Equal(t, "[pii, secret] social security number NOT NULL", result.Description)
+	})
+
+	t.Run("Columns with a unique index are unique keys", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("ShowIndexes").Return(true).Twice()
+		configMock.On("OmitAttributeKeys").Return(false).Twice()
+		configMock.On("MultipleAttributeKeys").Return(false).Twice()
+		configMock.On("ShowDescriptions").Return([]string{""}).Twice()
+		configMock.On("MermaidCommentStyle").Return("").Twice()
+		configMock.On("ShowNullable").Return(false).Twice()
+		configMock.On("ShowIdentity").Return(false).Twice()
+		configMock.On("ShowDataTypePrecision").Return(false).Twice()
+		indexes := []database.IndexResult{
+			{Name: "uq_email", ColumnNames: []string{"email"}, IsUnique: true},
+			{Name: "uq_tenant_code", ColumnNames: []string{"tenant_id", "code"}, IsUnique: true},
+		}
+
+		// Act
+		emailResult := getColumnData(&configMock, database.ColumnResult{Name: "email"}, indexes)
+		codeResult := getColumnData(&configMock, database.ColumnResult{Name: "code"}, indexes)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Equal(t, ErdAttributeKeys{uniqueKey}, emailResult.AttributeKeys)
+		assert.Empty(t, codeResult.AttributeKeys)
+	})
+
+	t.Run("Unique columns are unique keys without the indexes", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("ShowIndexes").Return(false).Once()
+		configMock.On("OmitAttributeKeys").Return(false).Times(3)
+		configMock.On("MultipleAttributeKeys").Return(false).Times(3)
+		configMock.On("ShowDescriptions").Return([]string{""}).Times(3)
+		configMock.On("MermaidCommentStyle").Return("").Times(3)
+		configMock.On("ShowNullable").Return(false).Times(3)
+		configMock.On("ShowIdentity").Return(false).Times(3)
+		configMock.On("ShowDataTypePrecision").Return(false).Times(3)
+
+		// Act
+		emailResult := getColumnData(&configMock, database.ColumnResult{Name: "email", IsUnique: true}, nil)
+		userResult := getColumnData(&configMock, database.ColumnResult{Name: "user_id", IsForeign: true, IsUnique: true}, nil)
+		nameResult := getColumnData(&configMock, database.ColumnResult{Name: "name"}, nil)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Equal(t, ErdAttributeKeys{uniqueKey}, emailResult.AttributeKeys)
+		assert.Equal(t, ErdAttributeKeys{foreignKey}, userResult.AttributeKeys)
+		assert.Empty(t, nameResult.AttributeKeys)
+	})
+
+	t.Run("MultipleAttributeKeys shows all keys of the column", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("ShowIndexes").Return(true).Twice()
+		configMock.On("OmitAttributeKeys").Return(false).Twice()
+		configMock.On("MultipleAttributeKeys").Return(true).Twice()
+		configMock.On("ShowDescriptions").Return([]string{""}).Twice()
+		configMock.On("MermaidCommentStyle").Return("").Twice()
+		configMock.On("ShowNullable").Return(false).Twice()
+		configMock.On("ShowIdentity").Return(false).Twice()
+		configMock.On("ShowDataTypePrecision").Return(false).Twice()
+		indexes := []database.IndexResult{
+			{Name: "profile_pkey", ColumnNames: []string{"user_id"}, IsUnique: true, IsPrimary: true},
+			{Name: "profile_handle_key", ColumnNames: []string{"handle_id"}, IsUnique: true},
+		}
+
+		// Act
+		userResult := getColumnData(&configMock, database.ColumnResult{Name: "user_id", IsPrimary: true, IsForeign: true}, indexes)
+		handleResult := getColumnData(&configMock, database.ColumnResult{Name: "handle_id", IsForeign: true}, indexes)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Equal(t, "PK,FK", userResult.AttributeKeys.String())
+		assert.Equal(t, "FK,UK", handleResult.AttributeKeys.String())
+	})
+
+	t.Run("ShowDataTypePrecision adds the length of the data type", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("ShowIndexes").Return(false).Once()
+		configMock.On("OmitAttributeKeys").Return(false).Once()
+		configMock.On("MultipleAttributeKeys").Return(false).Once()
+		configMock.On("ShowDescriptions").Return([]string{}).Once()
+		configMock.On("MermaidCommentStyle").Return("").Once()
+		configMock.On("ShowNullable").Return(false).Once()
+		configMock.On("ShowIdentity").Return(false).Once()
+		configMock.On("ShowDataTypePrecision").Return(true).Once()
+
+		// Act
+		result := getColumnData(&configMock, database.ColumnResult{Name: "title", DataType: "varchar", CharacterMaxLength: 255}, nil)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Equal(t, "varchar(255)", result.DataType)
 	})
 }
 
+func TestMermaidDataType(t *testing.T) {
+	assert.Equal(t, "varchar(255)", mermaidDataType("varchar(255)"))
+	assert.Equal(t, "numeric(10-2)", mermaidDataType("numeric(10,2)"))
+}
+
 func TestShouldSkipConstraint(t *testing.T) {
 	tableName1 := "Table1"
 	tableName2 := "Table2"
-	tables := []ErdTableData{{Name: tableName1}, {Name: tableName2}}
+	tables := []database.TableResult{
+		{Table: database.TableDetail{Schema: "public", Name: tableName1}},
+		{Table: database.TableDetail{Schema: "other", Name: tableName2}},
+	}
 
 	t.Run("ShowAllConstraints config should never skip", func(t *testing.T) {
 		// Arrange
@@ -254,11 +571,27 @@ func TestShouldSkipConstraint(t *testing.T) {
 		assert.False(t, result)
 	})
 
+	t.Run("ShowGhostTables config should never skip", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("ShowAllConstraints").Return(false).Once()
+		configMock.On("ShowGhostTables").Return(true).Once()
+		constraint := database.ConstraintResult{PkSchema: "public", PkTable: "UnknownTable", FkSchema: "public", FkTable: tableName1}
+
+		// Act
+		result := shouldSkipConstraint(&configMock, tables, constraint)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.False(t, result)
+	})
+
 	t.Run("Skip constraint if both tables are not present", func(t *testing.T) {
 		// Arrange
 		configMock := mocks.MermerdConfig{}
 		configMock.On("ShowAllConstraints").Return(false).Once()
-		constraint := database.ConstraintResult{PkTable: tableName1, FkTable: "UnknownTable"}
+		configMock.On("ShowGhostTables").Return(false).Once()
+		constraint := database.ConstraintResult{PkSchema: "public", PkTable: tableName1, FkSchema: "public", FkTable: "UnknownTable"}
 
 		// Act
 		result := shouldSkipConstraint(&configMock, tables, constraint)
@@ -272,7 +605,8 @@ func TestShouldSkipConstraint(t *testing.T) {
 		// Arrange
 		configMock := mocks.MermerdConfig{}
 		configMock.On("ShowAllConstraints").Return(false).Once()
-		constraint := database.ConstraintResult{PkTable: tableName1, FkTable: tableName2}
+		configMock.On("ShowGhostTables").Return(false).Once()
+		constraint := database.ConstraintResult{PkSchema: "public", PkTable: tableName1, FkSchema: "other", FkTable: tableName2}
 
 		// Act
 		result := shouldSkipConstraint(&configMock, tables, constraint)
@@ -281,6 +615,21 @@ func TestShouldSkipConstraint(t *testing.T) {
 		configMock.AssertExpectations(t)
 		assert.False(t, result)
 	})
+
+	t.Run("Skip constraint if a table with the same name is in another schema", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("ShowAllConstraints").Return(false).Once()
+		configMock.On("ShowGhostTables").Return(false).Once()
+		constraint := database.ConstraintResult{PkSchema: "public", PkTable: tableName1, FkSchema: "public", FkTable: tableName2}
+
+		// Act
+		result := shouldSkipConstraint(&configMock, tables, constraint)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.True(t, result)
+	})
 }
 
 func TestGetConstraintData(t *testing.T) {
@@ -292,12 +641,77 @@ func TestGetConstraintData(t *testing.T) {
 		constraint := database.ConstraintResult{ColumnName: "Column1"}
 
 		// Act
-		result := getConstraintData(&configMock, constraint)
+		result, err := getConstraintData(&configMock, constraint)
 
 		// Assert
 		configMock.AssertExpectations(t)
+		assert.Nil(t, err)
 		assert.Equal(t, result.ConstraintLabel, "")
 	})
+	t.Run("Composite foreign keys have a combined constraint label", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("OmitConstraintLabels").Return(false).Once()
+		configMock.On("RelationshipLabel").Return("columnName").Once()
+		configMock.On("ShowSchemaPrefix").Return(false).Twice()
+		constraint := database.ConstraintResult{ColumnName: "Column1", ColumnNames: []string{"Column1", "Column2"}}
+
+		// Act
+		result, err := getConstraintData(&configMock, constraint)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Equal(t, "Column1, Column2", result.ConstraintLabel)
+	})
+	t.Run("Inherited tables are labeled as inherits", func(t *testing.T) {
+		testCases := []struct {
+			relationshipLabel string
+			expectedLabel     string
+		}{
+			{"columnName", "inherits"},
+			{"constraintName", "inherits"},
+			{"{{.FkTable}} {{.Columns}} {{.PkTable}}", "capital inherits city"},
+		}
+
+		for index, testCase := range testCases {
+			t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+				// Arrange
+				configMock := mocks.MermerdConfig{}
+				configMock.On("OmitConstraintLabels").Return(false).Once()
+				configMock.On("RelationshipLabel").Return(testCase.relationshipLabel).Once()
+				configMock.On("ShowSchemaPrefix").Return(false).Twice()
+				constraint := database.ConstraintResult{FkTable: "capital", PkTable: "city", IsInheritance: true}
+
+				// Act
+				result, err := getConstraintData(&configMock, constraint)
+
+				// Assert
+				configMock.AssertExpectations(t)
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expectedLabel, result.ConstraintLabel)
+				assert.Equal(t, relationInheritance, result.Relation)
+				assert.True(t, result.IsInheritance)
+			})
+		}
+	})
+	t.Run("Inferred constraints are shown with a dashed line", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("OmitConstraintLabels").Return(false).Once()
+		configMock.On("RelationshipLabel").Return("columnName").Once()
+		configMock.On("ShowSchemaPrefix").Return(false).Twice()
+		constraint := database.ConstraintResult{ColumnName: "customer_id", IsNullable: true, IsInferred: true}
+
+		// Act
+		result, err := getConstraintData(&configMock, constraint)
+
+		// Assert
+		configMock.AssertExpectations(t)
+		assert.Nil(t, err)
+		assert.Equal(t, ErdRelationType("}o..o|"), result.Relation)
+		assert.True(t, result.IsInferred)
+	})
 }
 
 func TestGetTableName(t *testing.T) {
@@ -346,3 +760,100 @@ func TestGetTableName(t *testing.T) {
 	})
 
 }
+
+func TestGetViewClassData(t *testing.T) {
+	t.Run("No views", func(t *testing.T) {
+		// Act
+		result := getViewClassData([]ErdTableData{{Name: "article"}})
+
+		// Assert
+		assert.Nil(t, result)
+	})
+
+	t.Run("Views are assigned to the view class", func(t *testing.T) {
+		// Act
+		result := getViewClassData([]ErdTableData{{Name: "article"}, {Name: "article_overview", IsView: true}, {Name: "label_overview", IsView: true}})
+
+		// Assert
+		assert.Equal(t, []ErdClassData{{Name: "view", Style: "stroke-dasharray:5 5", TableNames: "article_overview,label_overview"}}, result)
+	})
+}
+
+func TestIsJoinTable(t *testing.T) {
+	articleConstraint := database.ConstraintResult{FkTable: "article_label", PkTable: "article", ConstraintName: "fk_article", ColumnName: "article_id"}
+	labelConstraint := database.ConstraintResult{FkTable: "article_label", PkTable: "label", ConstraintName: "fk_label", ColumnName: "label_id"}
+	testCases := []struct {
+		columns        []database.ColumnResult
+		constraints    []database.ConstraintResult
+		expectedResult bool
+	}{
+		{
+			columns:        []database.ColumnResult{{Name: "article_id", IsForeign: true}, {Name: "label_id", IsForeign: true}},
+			constraints:    []database.ConstraintResult{articleConstraint, labelConstraint},
+			expectedResult: true,
+		},
+		{
+			columns:        []database.ColumnResult{{Name: "article_id", IsForeign: true}, {Name: "label_id", IsForeign: true}, {Name: "created_at"}},
+			constraints:    []database.ConstraintResult{articleConstraint, labelConstraint},
+			expectedResult: false,
+		},
+		{
+			columns:        []database.ColumnResult{{Name: "article_id", IsForeign: true}},
+			constraints:    []database.ConstraintResult{articleConstraint},
+			expectedResult: false,
+		},
+		{
+			columns: []database.ColumnResult{{Name: "article_id", IsForeign: true}, {Name: "parent_id", IsForeign: true}},
+			constraints: []database.ConstraintResult{
+				articleConstraint,
+				{FkTable: "article_label", PkTable: "article_label", ConstraintName: "fk_parent", ColumnName: "parent_id"},
+			},
+			expectedResult: false,
+		},
+		{
+			columns:        []database.ColumnResult{{Name: "article_id", IsForeign: true}},
+			constraints:    []database.ConstraintResult{articleConstraint, {FkTable: "article_label", PkTable: "label", IsInheritance: true}},
+			expectedResult: false,
+		},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Arrange
+			table := database.TableResult{
+				Table:       database.TableDetail{Name: "article_label"},
+				Columns:     testCase.columns,
+				Constraints: testCase.constraints,
+			}
+
+			// Act
+			result := isJoinTable(table)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestGetJoinTableConstraintData(t *testing.T) {
+	// Arrange
+	configMock := mocks.MermerdConfig{}
+	configMock.On("OmitConstraintLabels").Return(false).Once()
+	configMock.On("RelationshipLabel").Return("columnName").Once()
+	configMock.On("ShowSchemaPrefix").Return(false).Twice()
+	table := database.TableResult{
+		Table: database.TableDetail{Name: "article_label"},
+		Constraints: []database.ConstraintResult{
+			{FkTable: "article_label", PkTable: "article", ConstraintName: "fk_article", ColumnName: "article_id"},
+			{FkTable: "article_label", PkTable: "label", ConstraintName: "fk_label", ColumnName: "label_id"},
+		},
+	}
+
+	// Act
+	result, err := getJoinTableConstraintData(&configMock, table)
+
+	// Assert
+	configMock.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, ErdConstraintData{FkTableName: "article", PkTableName: "label", Relation: relationManyToMany, ConstraintLabel: "article_label"}, result)
+}
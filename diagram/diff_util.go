@@ -0,0 +1,40 @@
+package diagram
+
+import (
+	"strings"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/diff"
+)
+
+var diffClassStyles = []struct {
+	change diff.Change
+	style  string
+}{
+	{diff.Added, "fill:#d4edda,stroke:#28a745"},
+	{diff.Removed, "fill:#f8d7da,stroke:#dc3545"},
+	{diff.Changed, "fill:#fff3cd,stroke:#ffc107"},
+}
+
+// getDiffClassData assigns a mermaid class to every table that was added, removed or changed
+func getDiffClassData(config config.MermerdConfig, result *diff.Result) []ErdClassData {
+	var classes []ErdClassData
+	for _, classStyle := range diffClassStyles {
+		var tableNames []string
+		for _, table := range result.Tables {
+			if table.Change == classStyle.change {
+				tableNames = append(tableNames, getTableName(config, table.Table))
+			}
+		}
+
+		if len(tableNames) > 0 {
+			classes = append(classes, ErdClassData{
+				Name:       string(classStyle.change),
+				Style:      classStyle.style,
+				TableNames: strings.Join(tableNames, ","),
+			})
+		}
+	}
+
+	return classes
+}
@@ -0,0 +1,32 @@
+package diagram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/diff"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+func TestGetDiffClassData(t *testing.T) {
+	// Arrange
+	configMock := mocks.MermerdConfig{}
+	configMock.On("ShowSchemaPrefix").Return(false)
+	result := &diff.Result{Tables: []diff.TableDiff{
+		{Table: database.TableDetail{Name: "article"}, Change: diff.Changed},
+		{Table: database.TableDetail{Name: "label"}, Change: diff.Added},
+		{Table: database.TableDetail{Name: "comment"}, Change: diff.Added},
+		{Table: database.TableDetail{Name: "author"}, Change: diff.Unchanged},
+	}}
+
+	// Act
+	classes := getDiffClassData(&configMock, result)
+
+	// Assert
+	assert.Equal(t, []ErdClassData{
+		{Name: "added", Style: "fill:#d4edda,stroke:#28a745", TableNames: "label,comment"},
+		{Name: "changed", Style: "fill:#fff3cd,stroke:#ffc107", TableNames: "article"},
+	}, classes)
+}
@@ -0,0 +1,54 @@
+package diagram
+
+import (
+	"strings"
+	"text/template"
+)
+
+var dotTemplateFuncs = template.FuncMap{
+	"dotId":       dotId,
+	"dotLabel":    dotLabel,
+	"dotArrow":    dotArrow,
+	"unquote":     unquote,
+	"groupTables": groupTables,
+}
+
+// dotId returns the name as quoted graphviz id. Names that are already quoted for mermaid (e.g. "schema.table")
+// are unquoted first.
+func dotId(name string) string {
+	name = unquote(name)
+	return `"` + strings.ReplaceAll(name, `"`, `\"`) + `"`
+}
+
+// dotLabel escapes the characters that have a special meaning in graphviz record labels
+func dotLabel(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "{", `\{`, "}", `\}`, "|", `\|`, "<", `\<`, ">", `\>`)
+	return replacer.Replace(value)
+}
+
+// dotArrow converts one side of the mermaid relation (e.g. }o--||) to the graphviz arrow shape.
+// The tail is the left side (FK table) and the head the right side (PK table).
+func dotArrow(relation ErdRelationType, tail bool) string {
+	cardinality := string(relation)[len(relation)-2:]
+	if tail {
+		cardinality = string(relation)[:2]
+	}
+
+	switch cardinality {
+	case "|o", "o|":
+		return "teeodot"
+	case "||":
+		return "teetee"
+	case "}o", "o{":
+		return "crowodot"
+	case "}|", "|{":
+		return "crowtee"
+	default:
+		return "none"
+	}
+}
+
+// unquote removes the quote marks that are needed for some names in mermaid (e.g. "schema.table")
+func unquote(name string) string {
+	return strings.Trim(name, `"`)
+}
@@ -0,0 +1,100 @@
+package diagram
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDotId(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedResult string
+	}{
+		{"article", `"article"`},
+		{`"public.article"`, `"public.article"`},
+		{`some"name`, `"some\"name"`},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := dotId(testCase.name)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestDotLabel(t *testing.T) {
+	// Act
+	result := dotLabel(`a{b}|<c> "d"`)
+
+	// Assert
+	assert.Equal(t, `a\{b\}\|\<c\> \"d\"`, result)
+}
+
+func TestDotArrow(t *testing.T) {
+	testCases := []struct {
+		relation     ErdRelationType
+		expectedTail string
+		expectedHead string
+	}{
+		{relationOneToOne, "teeodot", "teetee"},
+		{relationManyToOne, "crowodot", "teetee"},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			tail := dotArrow(testCase.relation, true)
+			head := dotArrow(testCase.relation, false)
+
+			// Assert
+			assert.Equal(t, testCase.expectedTail, tail)
+			assert.Equal(t, testCase.expectedHead, head)
+		})
+	}
+}
+
+func TestDotTemplate(t *testing.T) {
+	// Arrange
+	tmpl, err := getTemplate(outputFormatDot)
+	data := ErdDiagramData{
+		Tables: []ErdTableData{
+			{Name: "article", Columns: []ErdColumnData{{Name: "id", DataType: "int", AttributeKeys: ErdAttributeKeys{primaryKey}}}},
+			{Name: "comment", Columns: []ErdColumnData{{Name: "article_id", DataType: "int", AttributeKeys: ErdAttributeKeys{foreignKey}}}},
+			{Name: "author"},
+		},
+		Constraints: []ErdConstraintData{
+			{FkTableName: "comment", PkTableName: "article", Relation: relationManyToOne, ConstraintLabel: "article_id"},
+			{FkTableName: "comment", PkTableName: "author", Relation: "}o..||", IsInferred: true},
+		},
+	}
+	var result bytes.Buffer
+
+	// Act
+	_ = tmpl.Execute(&result, data)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Contains(t, result.String(), `"article" [label="{article|int id PK\l}"];`)
+	assert.Contains(t, result.String(), `"comment" [label="{comment|int article_id FK\l}"];`)
+	assert.Contains(t, result.String(), `"author" [label="{author}"];`)
+	assert.Contains(t, result.String(), `"comment" -> "article" [arrowtail=crowodot, arrowhead=teetee, label="article_id"];`)
+	assert.Contains(t, result.String(), `"comment" -> "author" [arrowtail=crowodot, arrowhead=teetee, style=dashed];`)
+}
+
+func TestGetTemplate(t *testing.T) {
+	t.Run("Unsupported output format returns error", func(t *testing.T) {
+		// Act
+		tmpl, err := getTemplate("unknown")
+
+		// Assert
+		assert.NotNil(t, err)
+		assert.Nil(t, tmpl)
+	})
+}
@@ -0,0 +1,101 @@
+package diagram
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+var invalidAttributeNameRegex = regexp.MustCompile("[^a-zA-Z0-9_-]+")
+
+// getEnumData creates an entity for every enum type with the values as attributes and relates it to every column
+// that uses the enum type (--showEnumsAsEntities). An enum type that is named like an entity of the diagram (e.g. a
+// status enum and a status table) gets the suffix _enum, as the entity names of mermaid are unique.
+func getEnumData(config config.MermerdConfig, tables []database.TableResult, tableData []ErdTableData) ([]ErdTableData, []ErdConstraintData) {
+	usedNames := make(map[string]bool)
+	for _, table := range tableData {
+		usedNames[table.Name] = true
+	}
+
+	var enumTables []ErdTableData
+	var enumConstraints []ErdConstraintData
+	entityNames := make(map[string]string)
+	for _, table := range tables {
+		for _, column := range table.Columns {
+			if column.EnumValues == "" {
+				continue
+			}
+
+			enumName := getEnumName(config, table.Table, column)
+			entityName, ok := entityNames[enumName]
+			if !ok {
+				entityName = getUniqueName(usedNames, enumName, enumName+"_enum")
+				entityNames[enumName] = entityName
+				enumTables = append(enumTables, ErdTableData{Name: entityName, IsEnum: true, Columns: getEnumColumnData(column.EnumValues)})
+			}
+
+			relation := relationManyToOne
+			if column.IsNullable {
+				relation = relationOptionalManyToOne
+			}
+
+			constraintLabel := column.Name
+			if config.OmitConstraintLabels() {
+				constraintLabel = ""
+			}
+
+			enumConstraints = append(enumConstraints, ErdConstraintData{
+				FkTableName:     getTableName(config, table.Table),
+				PkTableName:     entityName,
+				Relation:        relation,
+				ConstraintLabel: constraintLabel,
+			})
+		}
+	}
+
+	return enumTables, enumConstraints
+}
+
+// getEnumName returns the name of the enum type (e.g. mood in postgres). The enums of mysql and mariadb have no name,
+// as they are declared per column, so they are named after the table and the column (e.g. article_status).
+func getEnumName(config config.MermerdConfig, table database.TableDetail, column database.ColumnResult) string {
+	if strings.EqualFold(column.DataType, "enum") {
+		return getTableName(config, database.TableDetail{Schema: table.Schema, Name: table.Name + "_" + column.Name})
+	}
+
+	return getTableName(config, database.TableDetail{Schema: table.Schema, Name: column.DataType})
+}
+
+// getEnumColumnData returns the values of the enum as attributes, whose names may only contain letters, digits,
+// hyphens and underscores and must not start with a digit or hyphen
+func getEnumColumnData(enumValues string) []ErdColumnData {
+	var columns []ErdColumnData
+	for _, value := range strings.Split(enumValues, ",") {
+		name := invalidAttributeNameRegex.ReplaceAllString(strings.TrimSpace(value), "_")
+		if name == "" || strings.ContainsAny(name[:1], "0123456789-") {
+			name = "_" + name
+		}
+
+		columns = append(columns, ErdColumnData{Name: name, DataType: "enum"})
+	}
+
+	return columns
+}
+
+// getEnumClassData assigns a mermaid class to the enum entities, so that they can be distinguished from tables
+func getEnumClassData(tables []ErdTableData) []ErdClassData {
+	var enumNames []string
+	for _, table := range tables {
+		if table.IsEnum {
+			enumNames = append(enumNames, table.Name)
+		}
+	}
+
+	if len(enumNames) == 0 {
+		return nil
+	}
+
+	return []ErdClassData{{Name: "enum", Style: "fill:#f5f5f5,stroke-dasharray:2 2", TableNames: strings.Join(enumNames, ",")}}
+}
@@ -0,0 +1,93 @@
+package diagram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+func TestGetEnumData(t *testing.T) {
+	// Arrange
+	configMock := mocks.MermerdConfig{}
+	configMock.On("ShowSchemaPrefix").Return(false)
+	configMock.On("OmitConstraintLabels").Return(false)
+	tables := []database.TableResult{
+		{
+			Table: database.TableDetail{Schema: "public", Name: "person"},
+			Columns: []database.ColumnResult{
+				{Name: "id", DataType: "int"},
+				{Name: "mood", DataType: "mood", EnumValues: "sad,ok,happy"},
+				{Name: "previous_mood", DataType: "mood", EnumValues: "sad,ok,happy", IsNullable: true},
+			},
+		},
+		{
+			Table:   database.TableDetail{Schema: "shop", Name: "article"},
+			Columns: []database.ColumnResult{{Name: "status", DataType: "enum", EnumValues: "in stock,sold out,2nd hand"}},
+		},
+	}
+
+	// Act
+	enumTables, enumConstraints := getEnumData(&configMock, tables, nil)
+
+	// Assert
+	assert.Equal(t, []ErdTableData{
+		{Name: "mood", IsEnum: true, Columns: []ErdColumnData{
+			{Name: "sad", DataType: "enum"},
+			{Name: "ok", DataType: "enum"},
+			{Name: "happy", DataType: "enum"},
+		}},
+		{Name: "article_status", IsEnum: true, Columns: []ErdColumnData{
+			{Name: "in_stock", DataType: "enum"},
+			{Name: "sold_out", DataType: "enum"},
+			{Name: "_2nd_hand", DataType: "enum"},
+		}},
+	}, enumTables)
+	assert.Equal(t, []ErdConstraintData{
+		{FkTableName: "person", PkTableName: "mood", Relation: relationManyToOne, ConstraintLabel: "mood"},
+		{FkTableName: "person", PkTableName: "mood", Relation: relationOptionalManyToOne, ConstraintLabel: "previous_mood"},
+		{FkTableName: "article", PkTableName: "article_status", Relation: relationManyToOne, ConstraintLabel: "status"},
+	}, enumConstraints)
+}
+
+func TestGetEnumDataWithTableName(t *testing.T) {
+	// Arrange
+	configMock := mocks.MermerdConfig{}
+	configMock.On("ShowSchemaPrefix").Return(false)
+	configMock.On("OmitConstraintLabels").Return(false)
+	tables := []database.TableResult{
+		{
+			Table: database.TableDetail{Schema: "public", Name: "article"},
+			Columns: []database.ColumnResult{
+				{Name: "status", DataType: "status", EnumValues: "draft,published"},
+				{Name: "previous_status", DataType: "status", EnumValues: "draft,published"},
+			},
+		},
+		{Table: database.TableDetail{Schema: "public", Name: "status"}},
+	}
+	tableData := []ErdTableData{{Name: "article"}, {Name: "status"}}
+
+	// Act
+	enumTables, enumConstraints := getEnumData(&configMock, tables, tableData)
+
+	// Assert
+	assert.Len(t, enumTables, 1)
+	assert.Equal(t, "status_enum", enumTables[0].Name)
+	assert.Len(t, enumConstraints, 2)
+	assert.Equal(t, "status_enum", enumConstraints[0].PkTableName)
+	assert.Equal(t, "status_enum", enumConstraints[1].PkTableName)
+}
+
+func TestGetEnumClassData(t *testing.T) {
+	// Arrange
+	tables := []ErdTableData{{Name: "person"}, {Name: "mood", IsEnum: true}, {Name: "status", IsEnum: true}}
+
+	// Act
+	classes := getEnumClassData(tables)
+
+	// Assert
+	assert.Equal(t, []ErdClassData{{Name: "enum", Style: "fill:#f5f5f5,stroke-dasharray:2 2", TableNames: "mood,status"}}, classes)
+	assert.Nil(t, getEnumClassData([]ErdTableData{{Name: "person"}}))
+}
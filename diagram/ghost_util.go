@@ -0,0 +1,35 @@
+package diagram
+
+import "strings"
+
+// getGhostTableData returns the tables of the relations that are not part of the diagram (e.g. a referenced table
+// that was not selected). They are shown without columns to keep the context of a partial diagram.
+func getGhostTableData(tables []ErdTableData, constraints []ErdConstraintData) []ErdTableData {
+	var ghostTables []ErdTableData
+	for _, constraint := range constraints {
+		for _, tableName := range []string{constraint.FkTableName, constraint.PkTableName} {
+			if !tableNameInSlice(tables, tableName) && !tableNameInSlice(ghostTables, tableName) {
+				ghostTables = append(ghostTables, ErdTableData{Name: tableName, IsGhost: true})
+			}
+		}
+	}
+
+	return ghostTables
+}
+
+// getGhostClassData assigns a mermaid class to the ghost tables, so that they can be distinguished from the
+// selected tables
+func getGhostClassData(tables []ErdTableData) []ErdClassData {
+	var ghostNames []string
+	for _, table := range tables {
+		if table.IsGhost {
+			ghostNames = append(ghostNames, table.Name)
+		}
+	}
+
+	if len(ghostNames) == 0 {
+		return nil
+	}
+
+	return []ErdClassData{{Name: "ghost", Style: "fill:#fafafa,stroke:#bbb,stroke-dasharray:4 4,color:#999", TableNames: strings.Join(ghostNames, ",")}}
+}
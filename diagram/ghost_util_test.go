@@ -0,0 +1,40 @@
+package diagram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetGhostTableData(t *testing.T) {
+	// Arrange
+	tables := []ErdTableData{{Name: "order"}, {Name: "order_item"}}
+	constraints := []ErdConstraintData{
+		{FkTableName: "order_item", PkTableName: "order"},
+		{FkTableName: "order", PkTableName: "customer"},
+		{FkTableName: "order_item", PkTableName: "product"},
+		{FkTableName: "invoice", PkTableName: "customer"},
+	}
+
+	// Act
+	result := getGhostTableData(tables, constraints)
+
+	// Assert
+	assert.Equal(t, []ErdTableData{
+		{Name: "customer", IsGhost: true},
+		{Name: "product", IsGhost: true},
+		{Name: "invoice", IsGhost: true},
+	}, result)
+}
+
+func TestGetGhostClassData(t *testing.T) {
+	// Arrange
+	tables := []ErdTableData{{Name: "order"}, {Name: "customer", IsGhost: true}, {Name: "product", IsGhost: true}}
+
+	// Act
+	result := getGhostClassData(tables)
+
+	// Assert
+	assert.Equal(t, []ErdClassData{{Name: "ghost", Style: "fill:#fafafa,stroke:#bbb,stroke-dasharray:4 4,color:#999", TableNames: "customer,product"}}, result)
+	assert.Nil(t, getGhostClassData([]ErdTableData{{Name: "order"}}))
+}
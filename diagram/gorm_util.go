@@ -0,0 +1,382 @@
+package diagram
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+const gormPackageName = "models"
+
+var goNameSeparatorRegex = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+var gormTemplateFuncs = template.FuncMap{
+	"goComment": goComment,
+}
+
+// goInitialisms are written in upper case in go names (e.g. AuthorID instead of AuthorId)
+var goInitialisms = []string{"ID", "UUID", "URL", "URI", "API", "HTTP", "HTTPS", "JSON", "XML", "SQL", "IP", "SKU", "UID", "UTC"}
+
+// gormGoTypes maps the data types of all dialects to go types, the other data types (e.g. numeric, which would lose
+// precision as float64) are strings
+var gormGoTypes = map[string]string{
+	"int": "int32", "integer": "int32", "int4": "int32", "mediumint": "int32", "serial": "int32", "int32": "int32",
+	"smallint": "int16", "int2": "int16", "smallserial": "int16", "int16": "int16", "tinyint": "int8",
+	"bigint": "int64", "int8": "int64", "bigserial": "int64", "int64": "int64",
+	"boolean": "bool", "bool": "bool",
+	"real": "float32", "float4": "float32", "float": "float64", "float8": "float64", "double": "float64",
+	"float64":   "float64",
+	"timestamp": "time.Time", "timestamptz": "time.Time", "date": "time.Time", "datetime": "time.Time",
+	"datetime2": "time.Time", "smalldatetime": "time.Time", "datetimeoffset": "time.Time",
+	"json": "json.RawMessage", "jsonb": "json.RawMessage",
+	"bytea": "[]byte", "blob": "[]byte", "binary": "[]byte", "varbinary": "[]byte", "tinyblob": "[]byte",
+	"mediumblob": "[]byte", "longblob": "[]byte", "image": "[]byte", "bytes": "[]byte",
+}
+
+// gormStruct is a struct while the file is created. The field names of the columns are needed to add the relations.
+type gormStruct struct {
+	table      database.TableResult
+	data       GormStructData
+	fieldNames map[string]string
+	usedNames  map[string]bool
+}
+
+// getGormData creates a struct with gorm and db tags for every table. The foreign keys are added as belongs to
+// relation to the struct of the table and as has many (or has one, if the foreign key is unique) relation to the
+// referenced struct, which is only possible if the referenced table has a primary key.
+func getGormData(result *database.Result) GormFileData {
+	structNames := getGormStructNames(result.Tables)
+	isMultiSchema := len(getSchemaNames(result.Tables)) > 1
+
+	var imports []string
+	structs := make([]*gormStruct, len(result.Tables))
+	structsByTable := make(map[database.TableDetail]*gormStruct)
+	for tableIndex, table := range result.Tables {
+		tableName := table.Table.Name
+		if isMultiSchema {
+			tableName = table.Table.Schema + "." + table.Table.Name
+		}
+
+		item := &gormStruct{
+			table: table,
+			data: GormStructData{
+				Name:      structNames[tableIndex],
+				Comment:   strings.TrimSpace(structNames[tableIndex] + " maps the table " + table.Table.Schema + "." + table.Table.Name + "\n" + table.Table.Comment),
+				TableName: tableName,
+			},
+			fieldNames: make(map[string]string),
+			// the field would conflict with the method of the struct
+			usedNames: map[string]bool{"TableName": true},
+		}
+
+		for _, column := range table.Columns {
+			item.fieldNames[column.Name] = getUniqueName(item.usedNames, getGoName(column.Name))
+		}
+
+		indexTags := getGormIndexTags(table)
+		primaryKey := getPrimaryKeyColumnNames(table)
+		for _, column := range table.Columns {
+			fieldType := getGormType(column)
+			if packageName, _, ok := strings.Cut(strings.TrimLeft(fieldType, "*[]"), "."); ok && !containsString(imports, getGoImport(packageName)) {
+				imports = append(imports, getGoImport(packageName))
+			}
+
+			tagSettings := append(getGormTagSettings(column, containsString(primaryKey, column.Name)), indexTags[column.Name]...)
+			item.data.Fields = append(item.data.Fields, GormFieldData{
+				Name:    item.fieldNames[column.Name],
+				Type:    fieldType,
+				Tag:     getGormTag(tagSettings, column.Name),
+				Comment: column.Comment,
+			})
+		}
+
+		structs[tableIndex] = item
+		structsByTable[database.TableDetail{Schema: table.Table.Schema, Name: table.Table.Name}] = item
+	}
+
+	addGormRelations(structs, structsByTable)
+
+	sort.Strings(imports)
+	data := GormFileData{Package: gormPackageName, Imports: imports}
+	for _, item := range structs {
+		data.Structs = append(data.Structs, item.data)
+	}
+
+	return data
+}
+
+type gormRelation struct {
+	fkStruct *gormStruct
+	pkStruct *gormStruct
+	fkFields []string
+	pkFields []string
+	isUnique bool
+}
+
+// addGormRelations adds the relation fields of the foreign keys to both structs. The relation fields are named after
+// the foreign key (e.g. Author for AuthorID), the fields of the referenced struct are named after the struct (e.g.
+// Articles) and also after the foreign key if the structs have more than one relation (e.g. ArticlesByReviewer).
+func addGormRelations(structs []*gormStruct, structsByTable map[database.TableDetail]*gormStruct) {
+	var relations []gormRelation
+	relationCounts := make(map[[2]*gormStruct]int)
+	for _, fkStruct := range structs {
+		for _, constraint := range getOwnConstraints(fkStruct.table) {
+			pkStruct := structsByTable[database.TableDetail{Schema: constraint.PkSchema, Name: constraint.PkTable}]
+			if pkStruct == nil {
+				continue
+			}
+
+			fkFields, fkOk := getGormFields(fkStruct, constraint.ColumnNames)
+			pkFields, pkOk := getGormFields(pkStruct, getPrimaryKeyColumnNames(pkStruct.table))
+			if !fkOk || !pkOk || len(pkFields) != len(fkFields) {
+				continue
+			}
+
+			relations = append(relations, gormRelation{
+				fkStruct: fkStruct,
+				pkStruct: pkStruct,
+				fkFields: fkFields,
+				pkFields: pkFields,
+				isUnique: isUniqueColumnSet(fkStruct.table, constraint.ColumnNames),
+			})
+			relationCounts[[2]*gormStruct{fkStruct, pkStruct}]++
+		}
+	}
+
+	for _, relation := range relations {
+		fkStruct, pkStruct := relation.fkStruct, relation.pkStruct
+		tag := getGormTag([]string{"foreignKey:" + strings.Join(relation.fkFields, ","), "references:" + strings.Join(relation.pkFields, ",")}, "-")
+
+		fieldName := pkStruct.data.Name
+		if len(relation.fkFields) == 1 && len(relation.fkFields[0]) > 2 && strings.HasSuffix(relation.fkFields[0], "ID") {
+			fieldName = strings.TrimSuffix(relation.fkFields[0], "ID")
+		}
+
+		fieldName = getUniqueName(fkStruct.usedNames, fieldName, fieldName+"Ref")
+		fkStruct.data.Fields = append(fkStruct.data.Fields, GormFieldData{Name: fieldName, Type: "*" + pkStruct.data.Name, Tag: tag})
+
+		referenceName := getGoPlural(fkStruct.data.Name)
+		referenceType := "[]" + fkStruct.data.Name
+		if relation.isUnique {
+			referenceName = fkStruct.data.Name
+			referenceType = "*" + fkStruct.data.Name
+		}
+
+		if fkStruct == pkStruct || relationCounts[[2]*gormStruct{fkStruct, pkStruct}] > 1 {
+			referenceName += "By" + fieldName
+		}
+
+		pkStruct.data.Fields = append(pkStruct.data.Fields, GormFieldData{Name: getUniqueName(pkStruct.usedNames, referenceName), Type: referenceType, Tag: tag})
+	}
+}
+
+func getGormFields(item *gormStruct, columnNames []string) ([]string, bool) {
+	if len(columnNames) == 0 {
+		return nil, false
+	}
+
+	fields := make([]string, len(columnNames))
+	for i, columnName := range columnNames {
+		field, ok := item.fieldNames[columnName]
+		if !ok {
+			return nil, false
+		}
+
+		fields[i] = field
+	}
+
+	return fields, true
+}
+
+// isUniqueColumnSet is true if the columns are the primary key or the columns of a unique index (in any order)
+func isUniqueColumnSet(table database.TableResult, columnNames []string) bool {
+	uniqueColumnSets := [][]string{getPrimaryKeyColumnNames(table)}
+	for _, index := range table.Indexes {
+		if index.IsUnique {
+			uniqueColumnSets = append(uniqueColumnSets, index.ColumnNames)
+		}
+	}
+
+	for _, uniqueColumns := range uniqueColumnSets {
+		isSame := len(uniqueColumns) == len(columnNames)
+		for _, columnName := range columnNames {
+			isSame = isSame && containsString(uniqueColumns, columnName)
+		}
+
+		if isSame {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getGormStructNames returns the names of the structs, which are prefixed with the schema if a table name is used in
+// more than one schema
+func getGormStructNames(tables []database.TableResult) []string {
+	tableNameCounts := make(map[string]int)
+	for _, table := range tables {
+		tableNameCounts[getGoName(table.Table.Name)]++
+	}
+
+	usedNames := make(map[string]bool)
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		name := getGoName(table.Table.Name)
+		if tableNameCounts[name] > 1 {
+			name = getGoName(table.Table.Schema + "_" + table.Table.Name)
+		}
+
+		names[i] = getUniqueName(usedNames, name)
+	}
+
+	return names
+}
+
+// getGormType returns the go type of the column, nullable columns are pointers (except slices)
+func getGormType(column database.ColumnResult) string {
+	goType, ok := gormGoTypes[strings.ToLower(column.DataType)]
+	if !ok {
+		goType = "string"
+	}
+
+	if column.IsNullable && !strings.HasPrefix(goType, "[]") && goType != "json.RawMessage" {
+		return "*" + goType
+	}
+
+	return goType
+}
+
+func getGoImport(packageName string) string {
+	if packageName == "json" {
+		return "encoding/json"
+	}
+
+	return packageName
+}
+
+// getGormTagSettings returns the settings of the gorm tag that describe the column, so that gorm.AutoMigrate would
+// create the same column. Generated columns are read-only (->).
+func getGormTagSettings(column database.ColumnResult, isPrimary bool) []string {
+	settings := []string{"column:" + column.Name}
+	if isPrimary {
+		settings = append(settings, "primaryKey")
+	}
+
+	isAutoIncrement := column.IsAutoIncrement || column.SequenceName != ""
+	if isAutoIncrement {
+		settings = append(settings, "autoIncrement")
+	}
+
+	dataType := column.DataTypeWithPrecision()
+	if strings.EqualFold(column.DataType, "enum") && column.EnumValues != "" {
+		dataType = "enum('" + strings.Join(strings.Split(strings.ReplaceAll(column.EnumValues, "'", "''"), ","), "','") + "')"
+	}
+
+	settings = append(settings, "type:"+dataType)
+	if !column.IsNullable && !isPrimary {
+		settings = append(settings, "not null")
+	}
+
+	if column.IsGenerated {
+		settings = append(settings, "->")
+	} else if column.DefaultValue != "" && !isAutoIncrement && !strings.Contains(column.DefaultValue, "`") {
+		settings = append(settings, "default:"+column.DefaultValue)
+	}
+
+	return settings
+}
+
+// getGormIndexTags returns the index settings of the gorm tags by column. The position of the columns of composite
+// indexes is set via priority, as gorm would use the order of the fields otherwise.
+func getGormIndexTags(table database.TableResult) map[string][]string {
+	indexTags := make(map[string][]string)
+	for _, index := range table.Indexes {
+		if index.IsPrimary {
+			continue
+		}
+
+		setting := "index:" + index.Name
+		if index.IsUnique {
+			setting = "uniqueIndex:" + index.Name
+		}
+
+		for position, columnName := range index.ColumnNames {
+			if len(index.ColumnNames) > 1 {
+				indexTags[columnName] = append(indexTags[columnName], setting+",priority:"+strconv.Itoa(position+1))
+			} else {
+				indexTags[columnName] = append(indexTags[columnName], setting)
+			}
+		}
+	}
+
+	return indexTags
+}
+
+// getGormTag returns the struct tag with the gorm settings (; is escaped) and the db tag. The db tag of relation
+// fields is "-", as they are no columns.
+func getGormTag(gormSettings []string, dbName string) string {
+	escapedSettings := make([]string, len(gormSettings))
+	for i, setting := range gormSettings {
+		escapedSettings[i] = strings.ReplaceAll(setting, ";", `\;`)
+	}
+
+	tag := "gorm:" + strconv.Quote(strings.Join(escapedSettings, ";")) + " db:" + strconv.Quote(dbName)
+	return "`" + strings.ReplaceAll(tag, "`", "") + "`"
+}
+
+// getGoName converts the name to an exported go name, e.g. author_id to AuthorID
+func getGoName(name string) string {
+	var result strings.Builder
+	for _, part := range goNameSeparatorRegex.Split(name, -1) {
+		if part == "" {
+			continue
+		}
+
+		if containsString(goInitialisms, strings.ToUpper(part)) {
+			result.WriteString(strings.ToUpper(part))
+		} else {
+			result.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+
+	goName := result.String()
+	if goName == "" || !isAsciiLetter(goName[0]) {
+		goName = "X" + goName
+	}
+
+	return goName
+}
+
+// getGoPlural returns the english plural of the name (e.g. Categories for Category), which is used for the has many
+// relations
+func getGoPlural(name string) string {
+	lowerName := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lowerName, "y") && len(name) > 1 && !strings.ContainsAny(lowerName[len(lowerName)-2:len(lowerName)-1], "aeiou"):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(lowerName, "s") || strings.HasSuffix(lowerName, "x") || strings.HasSuffix(lowerName, "z") ||
+		strings.HasSuffix(lowerName, "ch") || strings.HasSuffix(lowerName, "sh"):
+		return name + "es"
+	default:
+		return name + "s"
+	}
+}
+
+// goComment returns the comment as go comment lines with the indentation
+func goComment(comment string, indent string) string {
+	if comment == "" {
+		return ""
+	}
+
+	var result strings.Builder
+	for _, line := range strings.Split(comment, "\n") {
+		result.WriteString(strings.TrimRight(indent+"// "+strings.TrimSpace(line), " ") + "\n")
+	}
+
+	return result.String()
+}
@@ -0,0 +1,144 @@
+package diagram
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+func TestWriteGorm(t *testing.T) {
+	// Arrange
+	result := &database.Result{Tables: []database.TableResult{
+		{
+			Table: database.TableDetail{Schema: "public", Name: "article", Comment: "The articles"},
+			Columns: []database.ColumnResult{
+				{Name: "id", DataType: "int", IsPrimary: true, IsAutoIncrement: true},
+				{Name: "author_id", DataType: "int", IsForeign: true, IsNullable: true},
+				{Name: "created_at", DataType: "timestamptz", DefaultValue: "now()", Comment: "creation time"},
+			},
+			Constraints: []database.ConstraintResult{
+				{FkSchema: "public", FkTable: "article", PkSchema: "public", PkTable: "author", ConstraintName: "article_author_fk", ColumnName: "author_id", IsNullable: true},
+			},
+		},
+		{
+			Table:   database.TableDetail{Schema: "public", Name: "author"},
+			Columns: []database.ColumnResult{{Name: "id", DataType: "bigint", IsPrimary: true}},
+		},
+	}}
+	var output bytes.Buffer
+
+	// Act
+	err := writeGorm(&output, result)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Contains(t, output.String(), "package models\n\nimport (\n\t\"time\"\n)\n")
+	assert.Contains(t, output.String(), "// Article maps the table public.article\n// The articles\ntype Article struct {\n")
+	assert.Contains(t, output.String(), "\tID       int32  `gorm:\"column:id;primaryKey;autoIncrement;type:int\" db:\"id\"`\n")
+	assert.Contains(t, output.String(), "\tAuthorID *int32 `gorm:\"column:author_id;type:int\" db:\"author_id\"`\n")
+	assert.Contains(t, output.String(), "\t// creation time\n\tCreatedAt time.Time `gorm:\"column:created_at;type:timestamptz;not null;default:now()\" db:\"created_at\"`\n")
+	assert.Contains(t, output.String(), "\tAuthor    *Author   `gorm:\"foreignKey:AuthorID;references:ID\" db:\"-\"`\n")
+	assert.Contains(t, output.String(), "\tArticles []Article `gorm:\"foreignKey:AuthorID;references:ID\" db:\"-\"`\n")
+	assert.Contains(t, output.String(), "func (Article) TableName() string {\n\treturn \"article\"\n}\n")
+}
+
+func TestGetGormTagSettings(t *testing.T) {
+	testCases := []struct {
+		column         database.ColumnResult
+		isPrimary      bool
+		expectedResult []string
+	}{
+		{database.ColumnResult{Name: "id", DataType: "int", SequenceName: "article_id_seq", DefaultValue: "nextval('article_id_seq'::regclass)"}, true, []string{"column:id", "primaryKey", "autoIncrement", "type:int"}},
+		{database.ColumnResult{Name: "title", DataType: "varchar", CharacterMaxLength: 255, IsNullable: true}, false, []string{"column:title", "type:varchar(255)"}},
+		{database.ColumnResult{Name: "status", DataType: "enum", EnumValues: "draft,it's"}, false, []string{"column:status", "type:enum('draft','it''s')", "not null"}},
+		{database.ColumnResult{Name: "total", DataType: "numeric", IsGenerated: true, DefaultValue: "price * amount"}, false, []string{"column:total", "type:numeric", "not null", "->"}},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := getGormTagSettings(testCase.column, testCase.isPrimary)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestGetGormIndexTags(t *testing.T) {
+	// Arrange
+	table := database.TableResult{Indexes: []database.IndexResult{
+		{Name: "article_pkey", ColumnNames: []string{"id"}, IsUnique: true, IsPrimary: true},
+		{Name: "article_slug_key", ColumnNames: []string{"slug"}, IsUnique: true},
+		{Name: "article_author_idx", ColumnNames: []string{"author_id", "created_at"}},
+	}}
+
+	// Act
+	result := getGormIndexTags(table)
+
+	// Assert
+	assert.Equal(t, map[string][]string{
+		"slug":       {"uniqueIndex:article_slug_key"},
+		"author_id":  {"index:article_author_idx,priority:1"},
+		"created_at": {"index:article_author_idx,priority:2"},
+	}, result)
+}
+
+func TestGetGormTag(t *testing.T) {
+	// Act
+	result := getGormTag([]string{"column:note", `default:'a;b'`}, "note")
+
+	// Assert
+	assert.Equal(t, "`"+`gorm:"column:note;default:'a\\;b'" db:"note"`+"`", result)
+}
+
+func TestGetGoName(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedResult string
+	}{
+		{"article", "Article"},
+		{"author_id", "AuthorID"},
+		{"created-at", "CreatedAt"},
+		{"avatarUrl", "AvatarUrl"},
+		{"api_key", "APIKey"},
+		{"2fa", "X2fa"},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := getGoName(testCase.name)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestGetGoPlural(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expectedResult string
+	}{
+		{"Article", "Articles"},
+		{"Category", "Categories"},
+		{"Survey", "Surveys"},
+		{"Address", "Addresses"},
+		{"Branch", "Branches"},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := getGoPlural(testCase.name)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
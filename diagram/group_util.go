@@ -0,0 +1,73 @@
+package diagram
+
+import (
+	"fmt"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+const groupBySchema = "schema"
+
+// getTableGroupNames returns the group of every table, which is the first matching table group of the configuration
+// file or the schema with --groupBy schema (empty if the table has no group)
+func getTableGroupNames(config config.MermerdConfig, tables []database.TableResult) ([]string, error) {
+	groupBy := config.GroupBy()
+	if groupBy != "" && groupBy != groupBySchema {
+		return nil, fmt.Errorf("unsupported groupBy %q (schema)", groupBy)
+	}
+
+	tableGroups, err := config.TableGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	groupNames := make([]string, len(tables))
+	for index, table := range tables {
+		for _, tableGroup := range tableGroups {
+			matches, err := database.MatchAnyTableName(tableGroup.Tables, table.Table)
+			if err != nil {
+				return nil, err
+			}
+
+			if matches {
+				groupNames[index] = tableGroup.Name
+				break
+			}
+		}
+
+		if groupNames[index] == "" && groupBy == groupBySchema {
+			groupNames[index] = table.Table.Schema
+		}
+	}
+
+	return groupNames, nil
+}
+
+// groupTables groups the tables in the order of the first table of each group. The tables without a group come last.
+func groupTables(tables []ErdTableData) []ErdGroupData {
+	var groups []ErdGroupData
+	var ungroupedTables []ErdTableData
+	groupIndexes := make(map[string]int)
+	for _, table := range tables {
+		if table.Group == "" {
+			ungroupedTables = append(ungroupedTables, table)
+			continue
+		}
+
+		index, ok := groupIndexes[table.Group]
+		if !ok {
+			index = len(groups)
+			groupIndexes[table.Group] = index
+			groups = append(groups, ErdGroupData{Name: table.Group})
+		}
+
+		groups[index].Tables = append(groups[index].Tables, table)
+	}
+
+	if len(ungroupedTables) > 0 {
+		groups = append(groups, ErdGroupData{Tables: ungroupedTables})
+	}
+
+	return groups
+}
@@ -0,0 +1,142 @@
+package diagram
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+func TestGetTableGroupNames(t *testing.T) {
+	tables := []database.TableResult{
+		{Table: database.TableDetail{Schema: "public", Name: "article"}},
+		{Table: database.TableDetail{Schema: "public", Name: "article_audit"}},
+		{Table: database.TableDetail{Schema: "dwh", Name: "fact_sales"}},
+	}
+
+	testCases := []struct {
+		name               string
+		groupBy            string
+		tableGroups        []config.TableGroup
+		expectedGroupNames []string
+	}{
+		{name: "No groups", expectedGroupNames: []string{"", "", ""}},
+		{name: "Group by schema", groupBy: "schema", expectedGroupNames: []string{"public", "public", "dwh"}},
+		{
+			name:               "Table groups",
+			tableGroups:        []config.TableGroup{{Name: "audit", Tables: []string{"*_audit"}}, {Name: "all", Tables: []string{"*"}}},
+			expectedGroupNames: []string{"all", "audit", "all"},
+		},
+		{
+			name:               "Table groups before schema",
+			groupBy:            "schema",
+			tableGroups:        []config.TableGroup{{Name: "audit", Tables: []string{"*_audit"}}},
+			expectedGroupNames: []string{"public", "audit", "dwh"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrange
+			configMock := mocks.MermerdConfig{}
+			configMock.On("GroupBy").Return(testCase.groupBy)
+			configMock.On("TableGroups").Return(testCase.tableGroups, nil)
+
+			// Act
+			groupNames, err := getTableGroupNames(&configMock, tables)
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedGroupNames, groupNames)
+		})
+	}
+
+	t.Run("Unsupported groupBy", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("GroupBy").Return("owner")
+
+		// Act
+		groupNames, err := getTableGroupNames(&configMock, tables)
+
+		// Assert
+		assert.NotNil(t, err)
+		assert.Nil(t, groupNames)
+	})
+
+	t.Run("Invalid table groups", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("GroupBy").Return("")
+		configMock.On("TableGroups").Return(nil, errors.New("invalid tableGroups"))
+
+		// Act
+		groupNames, err := getTableGroupNames(&configMock, tables)
+
+		// Assert
+		assert.NotNil(t, err)
+		assert.Nil(t, groupNames)
+	})
+}
+
+func TestGroupTables(t *testing.T) {
+	// Arrange
+	tables := []ErdTableData{
+		{Name: "article", Group: "blog"},
+		{Name: "flyway_schema_history"},
+		{Name: "customer", Group: "shop"},
+		{Name: "comment", Group: "blog"},
+	}
+
+	// Act
+	groups := groupTables(tables)
+
+	// Assert
+	assert.Equal(t, []ErdGroupData{
+		{Name: "blog", Tables: []ErdTableData{tables[0], tables[3]}},
+		{Name: "shop", Tables: []ErdTableData{tables[2]}},
+		{Tables: []ErdTableData{tables[1]}},
+	}, groups)
+}
+
+func TestGroupedTemplates(t *testing.T) {
+	data := ErdDiagramData{
+		Tables: []ErdTableData{
+			{Name: "article", Group: "blog"},
+			{Name: "customer", Group: "shop"},
+			{Name: "flyway_schema_history"},
+		},
+	}
+
+	t.Run("Mermaid groups are comments", func(t *testing.T) {
+		// Arrange
+		tmpl, err := getTemplate(outputFormatMermaid)
+		var result bytes.Buffer
+
+		// Act
+		_ = tmpl.Execute(&result, data)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Contains(t, result.String(), "erDiagram\n    %% blog\n    article\n\n    %% shop\n    customer\n\n    flyway_schema_history\n")
+	})
+
+	t.Run("Dot groups are clusters", func(t *testing.T) {
+		// Arrange
+		tmpl, err := getTemplate(outputFormatDot)
+		var result bytes.Buffer
+
+		// Act
+		_ = tmpl.Execute(&result, data)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Contains(t, result.String(), "    subgraph \"cluster_blog\" {\n    label=\"blog\";\n    \"article\" [label=\"{article}\"];\n    }")
+		assert.Contains(t, result.String(), "    }\n    \"flyway_schema_history\" [label=\"{flyway_schema_history}\"];")
+	})
+}
@@ -0,0 +1,14 @@
+package diagram
+
+import (
+	_ "embed"
+	"html/template"
+)
+
+//go:embed html_template.gohtml
+var htmlTemplate string
+
+// getHtmlTemplate uses html/template instead of text/template, so that names and comments are escaped
+func getHtmlTemplate() (*template.Template, error) {
+	return template.New("html_template").Parse(htmlTemplate)
+}
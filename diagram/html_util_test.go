@@ -0,0 +1,88 @@
+package diagram
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHtmlTemplate(t *testing.T) {
+	// Arrange
+	tmpl, err := getHtmlTemplate()
+	data := ReportData{
+		Diagram: "erDiagram\n    comment }o--|| article : \"article_id\"",
+		Tables: []ReportTableData{
+			{Name: "article", Columns: []ReportColumnData{
+				{Name: "id", DataType: "int", AttributeKeys: ErdAttributeKeys{primaryKey}, DefaultValue: "0"},
+				{Name: "title", DataType: "varchar", IsNullable: true, Comment: "<b>title</b>"},
+			}},
+			{Name: "article_overview", IsView: true},
+		},
+	}
+	var result bytes.Buffer
+
+	// Act
+	executeErr := tmpl.Execute(&result, data)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, executeErr)
+	assert.Contains(t, result.String(), "<pre class=\"mermaid\">\nerDiagram\n    comment }o--|| article : &#34;article_id&#34;\n</pre>")
+	assert.Contains(t, result.String(), `<li data-table="article"><a href="#table-article">article</a></li>`)
+	assert.Contains(t, result.String(), `<section id="table-article" data-table="article">`)
+	assert.Contains(t, result.String(), `<tr data-column="id"><td>id</td><td>int</td><td>PK</td><td>no</td><td>0</td><td></td></tr>`)
+	assert.Contains(t, result.String(), `<td>&lt;b&gt;title&lt;/b&gt;</td>`)
+	assert.Contains(t, result.String(), `<h3>article_overview <span class="view">(view)</span></h3>`)
+}
+
+func TestHtmlTemplateWithSampleRows(t *testing.T) {
+	// Arrange
+	tmpl, err := getHtmlTemplate()
+	data := ReportData{
+		Tables: []ReportTableData{
+			{
+				Name: "article",
+				Columns: []ReportColumnData{
+					{Name: "id", DataType: "int", SampleValues: "1, 2"},
+					{Name: "title", DataType: "varchar"},
+				},
+				SampleRows: [][]string{{"1", "<i>first</i>"}, {"2", ""}},
+			},
+		},
+	}
+	var result bytes.Buffer
+
+	// Act
+	executeErr := tmpl.Execute(&result, data)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, executeErr)
+	assert.Contains(t, result.String(), `<tr data-column="id"><td title="Sample values: 1, 2">id</td>`)
+	assert.Contains(t, result.String(), `<tr data-column="title"><td>title</td>`)
+	assert.Contains(t, result.String(), "<summary>Sample rows</summary>")
+	assert.Contains(t, result.String(), `<tr><th>id</th><th>title</th></tr>`)
+	assert.Contains(t, result.String(), `<tr><td>1</td><td>&lt;i&gt;first&lt;/i&gt;</td></tr>`)
+}
+
+func TestHtmlTemplateWithClassifications(t *testing.T) {
+	// Arrange
+	tmpl, err := getHtmlTemplate()
+	data := ReportData{
+		Tables: []ReportTableData{
+			{Name: "user", Columns: []ReportColumnData{{Name: "ssn", DataType: "varchar", Classifications: "pii"}}},
+		},
+		ClassifiedColumns: []ReportClassifiedColumnData{{Table: "user", Column: "ssn", Classifications: "pii"}},
+	}
+	var result bytes.Buffer
+
+	// Act
+	executeErr := tmpl.Execute(&result, data)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, executeErr)
+	assert.Contains(t, result.String(), `<td>ssn <span class="classification">[pii]</span></td>`)
+	assert.Contains(t, result.String(), `<tr><td><a href="#table-user">user</a></td><td>ssn</td><td>pii</td></tr>`)
+}
@@ -0,0 +1,28 @@
+package diagram
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	injectBeginMarker = "<!-- mermerd:begin -->"
+	injectEndMarker   = "<!-- mermerd:end -->"
+)
+
+// injectOutput replaces the content between the markers of the existing file with the output, the markers are kept so
+// that the output can be replaced again
+func injectOutput(content string, output string) (string, error) {
+	begin := strings.Index(content, injectBeginMarker)
+	if begin < 0 {
+		return "", fmt.Errorf("missing marker %s", injectBeginMarker)
+	}
+
+	contentStart := begin + len(injectBeginMarker)
+	end := strings.Index(content[contentStart:], injectEndMarker)
+	if end < 0 {
+		return "", fmt.Errorf("missing marker %s after %s", injectEndMarker, injectBeginMarker)
+	}
+
+	return content[:contentStart] + "\n" + strings.TrimRight(output, "\n") + "\n" + content[contentStart+end:], nil
+}
@@ -0,0 +1,34 @@
+package diagram
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectOutput(t *testing.T) {
+	testCases := []struct {
+		content         string
+		output          string
+		expectedContent string
+		expectedErr     bool
+	}{
+		{"# Schema\n<!-- mermerd:begin -->\n<!-- mermerd:end -->\nend\n", "erDiagram\n", "# Schema\n<!-- mermerd:begin -->\nerDiagram\n<!-- mermerd:end -->\nend\n", false},
+		{"# Schema\n<!-- mermerd:begin -->\nerDiagram\n    old\n<!-- mermerd:end -->\n", "erDiagram\n    new\n\n", "# Schema\n<!-- mermerd:begin -->\nerDiagram\n    new\n<!-- mermerd:end -->\n", false},
+		{"<!-- mermerd:begin --><!-- mermerd:end -->", "erDiagram", "<!-- mermerd:begin -->\nerDiagram\n<!-- mermerd:end -->", false},
+		{"<!-- mermerd:end -->\n<!-- mermerd:begin -->\n", "erDiagram", "", true},
+		{"# Schema\n", "erDiagram", "", true},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result, err := injectOutput(testCase.content, testCase.output)
+
+			// Assert
+			assert.Equal(t, testCase.expectedErr, err != nil)
+			assert.Equal(t, testCase.expectedContent, result)
+		})
+	}
+}
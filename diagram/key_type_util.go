@@ -0,0 +1,51 @@
+package diagram
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+const (
+	surrogateKey = "surrogate key"
+	naturalKey   = "natural key"
+)
+
+// generatedUuidRegex matches the defaults that generate a uuid, e.g. gen_random_uuid() of PostgreSQL or newid() of
+// MSSQL
+var generatedUuidRegex = regexp.MustCompile(`(?i)\b(gen_random_uuid|uuid_generate_v[14]|newid|newsequentialid|uuid|uuid_to_bin)\s*\(`)
+
+// getKeyType classifies the primary key of the table (showDescriptions keyTypes). A surrogate key is a single column
+// whose values are generated by the database (identity, sequence or uuid), any other primary key consists of values
+// of the rows themselves (e.g. an email or a composite key). Tables without primary key have no key type.
+func getKeyType(table database.TableResult) string {
+	var primaryColumns []database.ColumnResult
+	for _, column := range table.Columns {
+		if column.IsPrimary {
+			primaryColumns = append(primaryColumns, column)
+		}
+	}
+
+	switch {
+	case len(primaryColumns) == 0:
+		return ""
+	case len(primaryColumns) == 1 && isGeneratedKey(primaryColumns[0]):
+		return surrogateKey
+	default:
+		return naturalKey
+	}
+}
+
+func isGeneratedKey(column database.ColumnResult) bool {
+	return column.IsAutoIncrement || column.SequenceName != "" || generatedUuidRegex.MatchString(column.DefaultValue)
+}
+
+// addKeyTypeDescription adds the key type to the description of the primary key columns
+func addKeyTypeDescription(options []string, column database.ColumnResult, keyType string, description string) string {
+	if !column.IsPrimary || keyType == "" || !containsString(options, "keyTypes") {
+		return description
+	}
+
+	return strings.TrimSpace(description + " " + keyType)
+}
@@ -0,0 +1,60 @@
+package diagram
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+func TestGetKeyType(t *testing.T) {
+	testCases := []struct {
+		columns         []database.ColumnResult
+		expectedKeyType string
+	}{
+		{[]database.ColumnResult{{Name: "id", IsPrimary: true, IsAutoIncrement: true}, {Name: "title"}}, surrogateKey},
+		{[]database.ColumnResult{{Name: "id", IsPrimary: true, SequenceName: "article_id_seq"}}, surrogateKey},
+		{[]database.ColumnResult{{Name: "id", IsPrimary: true, DefaultValue: "gen_random_uuid()"}}, surrogateKey},
+		{[]database.ColumnResult{{Name: "id", IsPrimary: true, DefaultValue: "(newsequentialid())"}}, surrogateKey},
+		{[]database.ColumnResult{{Name: "email", IsPrimary: true}, {Name: "name"}}, naturalKey},
+		{[]database.ColumnResult{{Name: "code", IsPrimary: true, DefaultValue: "'uuid'"}}, naturalKey},
+		{[]database.ColumnResult{{Name: "article_id", IsPrimary: true, IsAutoIncrement: true}, {Name: "label_id", IsPrimary: true}}, naturalKey},
+		{[]database.ColumnResult{{Name: "name"}}, ""},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := getKeyType(database.TableResult{Columns: testCase.columns})
+
+			// Assert
+			assert.Equal(t, testCase.expectedKeyType, result)
+		})
+	}
+}
+
+func TestAddKeyTypeDescription(t *testing.T) {
+	testCases := []struct {
+		options             []string
+		column              database.ColumnResult
+		keyType             string
+		expectedDescription string
+	}{
+		{[]string{"columnComments", "keyTypes"}, database.ColumnResult{Name: "id", IsPrimary: true}, surrogateKey, "the id surrogate key"},
+		{[]string{"keyTypes"}, database.ColumnResult{Name: "email", IsPrimary: true}, naturalKey, "the id natural key"},
+		{[]string{"keyTypes"}, database.ColumnResult{Name: "title"}, surrogateKey, "the id"},
+		{[]string{"columnComments"}, database.ColumnResult{Name: "id", IsPrimary: true}, surrogateKey, "the id"},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := addKeyTypeDescription(testCase.options, testCase.column, testCase.keyType, "the id")
+
+			// Assert
+			assert.Equal(t, testCase.expectedDescription, result)
+		})
+	}
+}
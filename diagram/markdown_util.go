@@ -0,0 +1,16 @@
+package diagram
+
+import (
+	"strings"
+	"text/template"
+)
+
+var markdownTemplateFuncs = template.FuncMap{
+	"markdownCell": markdownCell,
+}
+
+// markdownCell escapes the characters that would break a markdown table (pipes and line breaks)
+func markdownCell(value string) string {
+	replacer := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+	return replacer.Replace(value)
+}
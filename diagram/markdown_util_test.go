@@ -0,0 +1,89 @@
+package diagram
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownCell(t *testing.T) {
+	// Act
+	result := markdownCell("a|b\nc")
+
+	// Assert
+	assert.Equal(t, `a\|b<br>c`, result)
+}
+
+func TestMarkdownTemplate(t *testing.T) {
+	// Arrange
+	tmpl, err := getTemplate(outputFormatMarkdown)
+	data := ReportData{
+		Diagram: "```mermaid\nerDiagram\n```",
+		Tables: []ReportTableData{
+			{Name: "article", Columns: []ReportColumnData{
+				{Name: "id", DataType: "int", AttributeKeys: ErdAttributeKeys{primaryKey}, DefaultValue: "0"},
+				{Name: "title", DataType: "varchar", IsNullable: true, Comment: "a | b"},
+			}},
+			{Name: "article_overview", IsView: true},
+		},
+	}
+	var result bytes.Buffer
+
+	// Act
+	_ = tmpl.Execute(&result, data)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Contains(t, result.String(), "# Data dictionary\n\n```mermaid\nerDiagram\n```\n")
+	assert.Contains(t, result.String(), "## article\n\n| Column | Type | Key | Nullable | Default | Comment |\n")
+	assert.Contains(t, result.String(), "| id | int | PK | no | 0 |  |\n")
+	assert.Contains(t, result.String(), `| title | varchar |  | yes |  | a \| b |`)
+	assert.Contains(t, result.String(), "## article_overview (view)\n")
+}
+
+func TestMarkdownTemplateWithSampleRows(t *testing.T) {
+	// Arrange
+	tmpl, err := getTemplate(outputFormatMarkdown)
+	data := ReportData{
+		Tables: []ReportTableData{
+			{
+				Name: "article",
+				Columns: []ReportColumnData{
+					{Name: "id", DataType: "int"},
+					{Name: "title", DataType: "varchar"},
+				},
+				SampleRows: [][]string{{"1", "first | second"}, {"2", ""}},
+			},
+		},
+	}
+	var result bytes.Buffer
+
+	// Act
+	_ = tmpl.Execute(&result, data)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Contains(t, result.String(), "\n\nSample rows:\n\n| id | title |\n| --- | --- |\n| 1 | first \\| second |\n| 2 |  |\n")
+}
+
+func TestMarkdownTemplateWithClassifications(t *testing.T) {
+	// Arrange
+	tmpl, err := getTemplate(outputFormatMarkdown)
+	data := ReportData{
+		Tables: []ReportTableData{
+			{Name: "user", Columns: []ReportColumnData{{Name: "ssn", DataType: "varchar", Classifications: "pii"}}},
+		},
+		ClassifiedColumns: []ReportClassifiedColumnData{{Table: "user", Column: "ssn", Classifications: "pii"}},
+	}
+	var result bytes.Buffer
+
+	// Act
+	_ = tmpl.Execute(&result, data)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Contains(t, result.String(), "| ssn [pii] | varchar |")
+	assert.True(t, strings.HasSuffix(result.String(), "\n\n## Classified columns\n\n| Table | Column | Classification |\n| ----- | ------ | -------------- |\n| user | ssn | pii |\n"), result.String())
+}
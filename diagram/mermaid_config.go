@@ -0,0 +1,42 @@
+package diagram
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aslakhellesoy/mermerd/config"
+)
+
+// mermaidConfig is the configuration of the mermaid renderer, which is added at the top of the diagram
+type mermaidConfig struct {
+	Theme       string `yaml:"theme,omitempty" json:"theme,omitempty"`
+	Layout      string `yaml:"layout,omitempty" json:"layout,omitempty"`
+	MaxTextSize int    `yaml:"maxTextSize,omitempty" json:"maxTextSize,omitempty"`
+}
+
+// getMermaidConfig returns the frontmatter (or the init directive) with the configured mermaid options, or an empty
+// string if none are configured
+func getMermaidConfig(config config.MermerdConfig) string {
+	options := mermaidConfig{
+		Theme:       config.MermaidTheme(),
+		Layout:      config.MermaidLayout(),
+		MaxTextSize: config.MermaidMaxTextSize(),
+	}
+	if options == (mermaidConfig{}) {
+		return ""
+	}
+
+	if config.MermaidInitDirective() {
+		// encoding the struct of strings and ints cannot fail
+		directive, _ := json.Marshal(options)
+		return "%%{init: " + string(directive) + "}%%\n"
+	}
+
+	var frontmatter bytes.Buffer
+	encoder := yaml.NewEncoder(&frontmatter)
+	encoder.SetIndent(2)
+	_ = encoder.Encode(map[string]mermaidConfig{"config": options})
+	return "---\n" + frontmatter.String() + "---\n"
+}
@@ -0,0 +1,71 @@
+package diagram
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+func TestGetMermaidConfig(t *testing.T) {
+	testCases := []struct {
+		name           string
+		theme          string
+		layout         string
+		maxTextSize    int
+		initDirective  bool
+		expectedConfig string
+	}{
+		{name: "No options", expectedConfig: ""},
+		{name: "No options with init directive", initDirective: true, expectedConfig: ""},
+		{
+			name:           "Frontmatter",
+			theme:          "dark",
+			layout:         "elk",
+			maxTextSize:    90000,
+			expectedConfig: "---\nconfig:\n  theme: dark\n  layout: elk\n  maxTextSize: 90000\n---\n",
+		},
+		{name: "Frontmatter with theme only", theme: "forest", expectedConfig: "---\nconfig:\n  theme: forest\n---\n"},
+		{
+			name:           "Init directive",
+			theme:          "neutral",
+			maxTextSize:    90000,
+			initDirective:  true,
+			expectedConfig: "%%{init: {\"theme\":\"neutral\",\"maxTextSize\":90000}}%%\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Arrange
+			configMock := mocks.MermerdConfig{}
+			configMock.On("MermaidTheme").Return(testCase.theme)
+			configMock.On("MermaidLayout").Return(testCase.layout)
+			configMock.On("MermaidMaxTextSize").Return(testCase.maxTextSize)
+			configMock.On("MermaidInitDirective").Return(testCase.initDirective).Maybe()
+
+			// Act
+			result := getMermaidConfig(&configMock)
+
+			// Assert
+			assert.Equal(t, testCase.expectedConfig, result)
+		})
+	}
+}
+
+func TestErdTemplateFrontmatter(t *testing.T) {
+	// Arrange
+	tmpl, err := getTemplate(outputFormatMermaid)
+	assert.Nil(t, err)
+	var erd strings.Builder
+	data := ErdDiagramData{EncloseWithMermaidBackticks: true, Frontmatter: "---\nconfig:\n  theme: dark\n---\n"}
+
+	// Act
+	err = tmpl.Execute(&erd, data)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(erd.String(), "```mermaid\n---\nconfig:\n  theme: dark\n---\nerDiagram\n"), erd.String())
+}
@@ -0,0 +1,108 @@
+package diagram
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// mermaidIdentifierPattern matches the entity names that mermaid parses without quote marks
+var mermaidIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// mermaidInvalidWordCharacters are the characters that cannot be part of attribute names or data types, which mermaid
+// cannot quote
+var mermaidInvalidWordCharacters = regexp.MustCompile(`[^A-Za-z0-9_\-\[\]()]+`)
+
+// mermaidReservedWords are the keywords of erDiagram (case-insensitive), which are no valid entity names without quote
+// marks
+var mermaidReservedWords = map[string]bool{
+	"erdiagram": true, "title": true, "acctitle": true, "accdescr": true, "direction": true, "classdef": true,
+	"class": true, "style": true, "u": true, "to": true, "optionally": true, "one": true, "only": true, "zero": true,
+	"exactly": true, "many": true, "only_one": true, "zero_or_one": true, "one_or_more": true, "zero_or_more": true,
+}
+
+// mermaidAttributeKeys are the only keywords within the attributes of an entity
+var mermaidAttributeKeys = map[string]bool{"pk": true, "fk": true, "uk": true}
+
+const (
+	commentStyleEntity = "entity"
+	commentStyleNative = "native"
+)
+
+// commentEscapers replace the quote marks in the attribute comments (see --mermaidCommentStyle). Mermaid renders the
+// entity code as quote mark, whereas the native style keeps the comments readable in the diagram source, which is why
+// it also puts multiline comments on one line.
+var commentEscapers = map[string]*strings.Replacer{
+	commentStyleEntity: strings.NewReplacer(`"`, "#quot;"),
+	commentStyleNative: strings.NewReplacer(`"`, "'", "\r\n", " ", "\r", " ", "\n", " "),
+}
+
+// getCommentEscaper returns the entity style by default
+func getCommentEscaper(commentStyle string) (*strings.Replacer, error) {
+	if commentStyle == "" {
+		return commentEscapers[commentStyleEntity], nil
+	}
+
+	escaper, ok := commentEscapers[strings.ToLower(commentStyle)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported mermaid comment style %q (entity or native)", commentStyle)
+	}
+
+	return escaper, nil
+}
+
+// mermaidEntity quotes the entity names that are no identifiers (e.g. with spaces, dots or emoji) or reserved words.
+// Names are already enclosed with quote marks if the schema prefix is separated with a dot (see getTableName). Mermaid
+// cannot escape quote marks in names, so they are replaced.
+func mermaidEntity(name string) string {
+	if len(name) > 1 && strings.HasPrefix(name, `"`) && strings.HasSuffix(name, `"`) {
+		name = name[1 : len(name)-1]
+	}
+
+	lowerName := strings.ToLower(name)
+	if mermaidIdentifierPattern.MatchString(name) && !mermaidReservedWords[lowerName] && !mermaidAttributeKeys[lowerName] {
+		return name
+	}
+
+	return `"` + strings.ReplaceAll(name, `"`, "'") + `"`
+}
+
+// mermaidEntities quotes the comma separated entity names of a class statement (see mermaidEntity)
+func mermaidEntities(names string) string {
+	entities := strings.Split(names, ",")
+	for index, name := range entities {
+		entities[index] = mermaidEntity(name)
+	}
+
+	return strings.Join(entities, ",")
+}
+
+// mermaidAttribute replaces the characters that mermaid does not support in attribute names with an underscore, the
+// original name is shown in the description (see mermaidDescription)
+func mermaidAttribute(name string) string {
+	return mermaidWord(name)
+}
+
+// mermaidDescription adds the original name to the description of the attributes whose name was replaced
+func mermaidDescription(column ErdColumnData, commentStyle string) string {
+	if mermaidAttribute(column.Name) == column.Name {
+		return column.Description
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("(%s) %s", escapeComments(commentStyle, column.Name), column.Description))
+}
+
+// mermaidLabel returns the relationship label, which is enclosed with quote marks and must be on one line
+func mermaidLabel(label string) string {
+	return strings.NewReplacer(`"`, "'", "\r", " ", "\n", " ").Replace(label)
+}
+
+// mermaidWord replaces the invalid characters and prefixes words that would be parsed as number or attribute key
+func mermaidWord(value string) string {
+	word := mermaidInvalidWordCharacters.ReplaceAllString(value, "_")
+	if word == "" || !mermaidIdentifierPattern.MatchString(word[:1]) || mermaidAttributeKeys[strings.ToLower(word)] {
+		return "_" + word
+	}
+
+	return word
+}
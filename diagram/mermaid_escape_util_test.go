@@ -0,0 +1,168 @@
+package diagram
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMermaidEntity(t *testing.T) {
+	testCases := []struct {
+		name         string
+		expectedName string
+	}{
+		{"article", "article"},
+		{"article-comment", "article-comment"},
+		{"_article", "_article"},
+		{`"public.article"`, `"public.article"`},
+		{"order details", `"order details"`},
+		{"1st_article", `"1st_article"`},
+		{"article 🚀", `"article 🚀"`},
+		{"Übersicht", `"Übersicht"`},
+		{`say "hi"`, `"say 'hi'"`},
+		{"class", `"class"`},
+		{"ONE", `"ONE"`},
+		{"u", `"u"`},
+		{"pk", `"pk"`},
+		{"", `""`},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := mermaidEntity(testCase.name)
+
+			// Assert
+			assert.Equal(t, testCase.expectedName, result)
+		})
+	}
+}
+
+func TestMermaidEntities(t *testing.T) {
+	// Act
+	result := mermaidEntities(`article,order details,"public.label"`)
+
+	// Assert
+	assert.Equal(t, `article,"order details","public.label"`, result)
+}
+
+func TestMermaidAttribute(t *testing.T) {
+	testCases := []struct {
+		name         string
+		expectedName string
+	}{
+		{"id", "id"},
+		{"created-at", "created-at"},
+		{"title", "title"},
+		{"first name", "first_name"},
+		{"price (€)", "price_(_)"},
+		{"2fa_enabled", "_2fa_enabled"},
+		{"-1", "_-1"},
+		{"PK", "_PK"},
+		{"🚀", "_"},
+		{"", "_"},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := mermaidAttribute(testCase.name)
+
+			// Assert
+			assert.Equal(t, testCase.expectedName, result)
+		})
+	}
+}
+
+func TestMermaidDescription(t *testing.T) {
+	testCases := []struct {
+		column              ErdColumnData
+		commentStyle        string
+		expectedDescription string
+	}{
+		{ErdColumnData{Name: "id", Description: "identifier"}, "", "identifier"},
+		{ErdColumnData{Name: "first name"}, "", "(first name)"},
+		{ErdColumnData{Name: `"quoted"`, Description: "note"}, "", "(#quot;quoted#quot;) note"},
+		{ErdColumnData{Name: `"quoted"`, Description: "note"}, "native", "('quoted') note"},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := mermaidDescription(testCase.column, testCase.commentStyle)
+
+			// Assert
+			assert.Equal(t, testCase.expectedDescription, result)
+		})
+	}
+}
+
+func TestMermaidLabel(t *testing.T) {
+	// Act
+	result := mermaidLabel("fk_\"order\"\nitems")
+
+	// Assert
+	assert.Equal(t, "fk_'order' items", result)
+}
+
+func TestErdTemplateEscapesIdentifiers(t *testing.T) {
+	// Arrange
+	tmpl, err := getTemplate(outputFormatMermaid)
+	data := ErdDiagramData{
+		Tables: []ErdTableData{
+			{Name: "order details", Columns: []ErdColumnData{
+				{Name: "id", DataType: "int", AttributeKeys: ErdAttributeKeys{primaryKey}},
+				{Name: "unit price", DataType: "double precision", Description: "net"},
+			}},
+			{Name: "class", Columns: []ErdColumnData{{Name: "order-id", DataType: "int", AttributeKeys: ErdAttributeKeys{foreignKey}}}},
+		},
+		Constraints: []ErdConstraintData{
+			{FkTableName: "class", PkTableName: "order details", Relation: relationManyToOne, ConstraintLabel: `fk "order"`},
+		},
+		Classes: []ErdClassData{{Name: "highlight", Style: "fill:#f00", TableNames: "order details,class"}},
+	}
+	var result bytes.Buffer
+
+	// Act
+	_ = tmpl.Execute(&result, data)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Contains(t, result.String(), `"order details" {`)
+	assert.Contains(t, result.String(), `double_precision unit_price "(unit price) net"`)
+	assert.Contains(t, result.String(), `"class" {`)
+	assert.Contains(t, result.String(), `int order-id FK`)
+	assert.Contains(t, result.String(), `"class" }o--|| "order details" : "fk 'order'"`)
+	assert.Contains(t, result.String(), `class "order details","class" highlight`)
+}
+
+func TestGetCommentEscaper(t *testing.T) {
+	testCases := []struct {
+		commentStyle    string
+		expectedComment string
+		expectedError   bool
+	}{
+		{"", `say #quot;hi#quot;`, false},
+		{"entity", `say #quot;hi#quot;`, false},
+		{"Native", `say 'hi'`, false},
+		{"html", "", true},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			escaper, err := getCommentEscaper(testCase.commentStyle)
+
+			// Assert
+			if testCase.expectedError {
+				assert.ErrorContains(t, err, "unsupported mermaid comment style")
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedComment, escaper.Replace(`say "hi"`))
+		})
+	}
+}
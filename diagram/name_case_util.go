@@ -0,0 +1,101 @@
+package diagram
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type nameCase func(words []string) string
+
+// nameCases transform the names of the tables and columns in the diagram (see --nameCase), further cases only need
+// to join the words of the name
+var nameCases = map[string]nameCase{
+	"snake": func(words []string) string {
+		return strings.ToLower(strings.Join(words, "_"))
+	},
+	"camel": func(words []string) string {
+		return strings.ToLower(words[0]) + toTitleWords(words[1:])
+	},
+	"pascal": func(words []string) string {
+		return toTitleWords(words)
+	},
+	"upper": func(words []string) string {
+		return strings.ToUpper(strings.Join(words, "_"))
+	},
+}
+
+// getNameCase returns nil if the names are not transformed
+func getNameCase(name string) (nameCase, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	caseFunc, ok := nameCases[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported name case %q (snake, camel, pascal or upper)", name)
+	}
+
+	return caseFunc, nil
+}
+
+// apply keeps names without letters or digits, as they have no words
+func (c nameCase) apply(name string) string {
+	if c == nil {
+		return name
+	}
+
+	words := splitWords(name)
+	if len(words) == 0 {
+		return name
+	}
+
+	return c(words)
+}
+
+// splitWords splits the name at separators (e.g. _ or a space) and at case changes. A sequence of upper case letters
+// is one word (e.g. HTTPServer is split into HTTP and Server), digits belong to the word before them.
+func splitWords(name string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	for index, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			words = appendWord(words, word)
+			word = nil
+			continue
+		}
+
+		if index > 0 && unicode.IsUpper(r) && len(word) > 0 {
+			previous := runes[index-1]
+			nextIsLower := index+1 < len(runes) && unicode.IsLower(runes[index+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				words = appendWord(words, word)
+				word = nil
+			}
+		}
+
+		word = append(word, r)
+	}
+
+	return appendWord(words, word)
+}
+
+func appendWord(words []string, word []rune) []string {
+	if len(word) == 0 {
+		return words
+	}
+
+	return append(words, string(word))
+}
+
+func toTitleWords(words []string) string {
+	var builder strings.Builder
+	for _, word := range words {
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		builder.WriteString(string(runes))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,72 @@
+package diagram
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitWords(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expectedWords []string
+	}{
+		{"customer_order", []string{"customer", "order"}},
+		{"customerOrder", []string{"customer", "Order"}},
+		{"CustomerOrder", []string{"Customer", "Order"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"CUSTOMER_ORDER", []string{"CUSTOMER", "ORDER"}},
+		{"address2Line", []string{"address2", "Line"}},
+		{"order item", []string{"order", "item"}},
+		{"__", nil},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := splitWords(testCase.name)
+
+			// Assert
+			assert.Equal(t, testCase.expectedWords, result)
+		})
+	}
+}
+
+func TestNameCase(t *testing.T) {
+	testCases := []struct {
+		nameCase     string
+		name         string
+		expectedName string
+	}{
+		{"", "customer_order", "customer_order"},
+		{"snake", "CustomerOrder", "customer_order"},
+		{"camel", "customer_order", "customerOrder"},
+		{"Pascal", "customer_order", "CustomerOrder"},
+		{"pascal", "HTTPServer", "HttpServer"},
+		{"upper", "customerOrder", "CUSTOMER_ORDER"},
+		{"snake", "__", "__"},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Arrange
+			nameCase, err := getNameCase(testCase.nameCase)
+
+			// Act
+			result := nameCase.apply(testCase.name)
+
+			// Assert
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedName, result)
+		})
+	}
+
+	t.Run("Unsupported case", func(t *testing.T) {
+		// Act
+		_, err := getNameCase("kebab")
+
+		// Assert
+		assert.ErrorContains(t, err, "unsupported name case")
+	})
+}
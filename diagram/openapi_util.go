@@ -0,0 +1,225 @@
+package diagram
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+const openApiVersion = "3.1.0"
+
+var invalidOpenApiNameRegex = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// openApiTypes maps the data types of all dialects to the type and format of json schema, the other data types are
+// strings
+var openApiTypes = map[string][2]string{
+	"int": {"integer", "int32"}, "integer": {"integer", "int32"}, "int4": {"integer", "int32"},
+	"int2": {"integer", "int32"}, "smallint": {"integer", "int32"}, "tinyint": {"integer", "int32"},
+	"mediumint": {"integer", "int32"}, "serial": {"integer", "int32"}, "smallserial": {"integer", "int32"},
+	"int32": {"integer", "int32"}, "int16": {"integer", "int32"}, "int8": {"integer", "int64"},
+	"bigint": {"integer", "int64"}, "bigserial": {"integer", "int64"}, "int64": {"integer", "int64"},
+	"boolean": {"boolean", ""}, "bool": {"boolean", ""}, "bit": {"boolean", ""},
+	"real": {"number", "float"}, "float4": {"number", "float"}, "float": {"number", "double"},
+	"float8": {"number", "double"}, "double": {"number", "double"}, "float64": {"number", "double"},
+	"numeric": {"number", ""}, "decimal": {"number", ""}, "number": {"number", ""}, "money": {"number", ""},
+	"bignumeric": {"number", ""}, "uuid": {"string", "uuid"}, "uniqueidentifier": {"string", "uuid"},
+	"date": {"string", "date"}, "time": {"string", "time"}, "timetz": {"string", "time"},
+	"timestamp": {"string", "date-time"}, "timestamptz": {"string", "date-time"}, "datetime": {"string", "date-time"},
+	"datetime2": {"string", "date-time"}, "smalldatetime": {"string", "date-time"},
+	"datetimeoffset": {"string", "date-time"}, "bytea": {"string", "byte"}, "blob": {"string", "byte"},
+	"binary": {"string", "byte"}, "varbinary": {"string", "byte"}, "tinyblob": {"string", "byte"},
+	"mediumblob": {"string", "byte"}, "longblob": {"string", "byte"}, "image": {"string", "byte"},
+	"bytes": {"string", "byte"},
+	// json columns can contain any value, so they have no type
+	"json": {"", ""}, "jsonb": {"", ""},
+}
+
+type OpenApiDocument struct {
+	OpenApi    string            `yaml:"openapi"`
+	Info       OpenApiInfo       `yaml:"info"`
+	Components OpenApiComponents `yaml:"components"`
+}
+
+type OpenApiInfo struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+type OpenApiComponents struct {
+	Schemas OpenApiSchemaMap `yaml:"schemas"`
+}
+
+type OpenApiSchema struct {
+	// Type is the name of the type, or the type and "null" for nullable columns
+	Type        interface{}      `yaml:"type,omitempty"`
+	Format      string           `yaml:"format,omitempty"`
+	Description string           `yaml:"description,omitempty"`
+	MaxLength   int              `yaml:"maxLength,omitempty"`
+	Enum        []interface{}    `yaml:"enum,omitempty"`
+	Default     interface{}      `yaml:"default,omitempty"`
+	ReadOnly    bool             `yaml:"readOnly,omitempty"`
+	Required    []string         `yaml:"required,omitempty"`
+	Properties  OpenApiSchemaMap `yaml:"properties,omitempty"`
+}
+
+// OpenApiSchemaMap contains the schemas in the order of the tables (or columns), which a map would not keep
+type OpenApiSchemaMap []OpenApiNamedSchema
+
+type OpenApiNamedSchema struct {
+	Name   string
+	Schema OpenApiSchema
+}
+
+func (m OpenApiSchemaMap) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, item := range m {
+		value := &yaml.Node{}
+		if err := value.Encode(item.Schema); err != nil {
+			return nil, err
+		}
+
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item.Name}, value)
+	}
+
+	return node, nil
+}
+
+// getOpenApiDocument creates an OpenAPI document with a component schema for every table (and view), in which the
+// columns are the properties. The columns that are not nullable are required, the values of identity and generated
+// columns are created by the database and therefore read only. As OpenAPI 3.1 uses json schema, the schemas can also
+// be used without OpenAPI.
+func getOpenApiDocument(result *database.Result) OpenApiDocument {
+	names := getOpenApiSchemaNames(result.Tables)
+	schemas := make(OpenApiSchemaMap, len(result.Tables))
+	for tableIndex, table := range result.Tables {
+		schema := OpenApiSchema{Type: "object", Description: table.Table.Comment}
+		for _, column := range table.Columns {
+			if !column.IsNullable {
+				schema.Required = append(schema.Required, column.Name)
+			}
+
+			schema.Properties = append(schema.Properties, OpenApiNamedSchema{Name: column.Name, Schema: getOpenApiPropertySchema(column)})
+		}
+
+		schemas[tableIndex] = OpenApiNamedSchema{Name: names[tableIndex], Schema: schema}
+	}
+
+	return OpenApiDocument{
+		OpenApi:    openApiVersion,
+		Info:       OpenApiInfo{Title: "Database model", Version: "1.0.0"},
+		Components: OpenApiComponents{Schemas: schemas},
+	}
+}
+
+func getOpenApiPropertySchema(column database.ColumnResult) OpenApiSchema {
+	openApiType, ok := openApiTypes[strings.ToLower(column.DataType)]
+	if !ok {
+		openApiType = [2]string{"string", ""}
+	}
+
+	schema := OpenApiSchema{
+		Format:      openApiType[1],
+		Description: column.Comment,
+		ReadOnly:    column.IsAutoIncrement || column.SequenceName != "" || column.IsGenerated,
+	}
+
+	if column.EnumValues != "" {
+		openApiType = [2]string{"string", ""}
+		schema.Format = ""
+		for _, value := range strings.Split(column.EnumValues, ",") {
+			schema.Enum = append(schema.Enum, value)
+		}
+
+		if column.IsNullable {
+			schema.Enum = append(schema.Enum, nil)
+		}
+	}
+
+	if openApiType[0] == "string" && column.CharacterMaxLength > 0 && schema.Format == "" {
+		schema.MaxLength = column.CharacterMaxLength
+	}
+
+	switch {
+	case openApiType[0] == "":
+	case column.IsNullable:
+		schema.Type = []string{openApiType[0], "null"}
+	default:
+		schema.Type = openApiType[0]
+	}
+
+	if !schema.ReadOnly {
+		schema.Default = getOpenApiDefault(column.DefaultValue, openApiType[0])
+	}
+
+	return schema
+}
+
+// getOpenApiDefault returns the default of the column if it is a literal of the type, expressions like now() are
+// evaluated by the database and are no default of the schema
+func getOpenApiDefault(defaultValue string, openApiType string) interface{} {
+	value := strings.TrimSpace(defaultValue)
+	for isEnclosedInParentheses(value) {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+
+	if match := sqlLiteralCastRegex.FindStringSubmatch(value); match != nil {
+		value = match[1]
+	}
+
+	isString := strings.HasSuffix(value, "'") && (strings.HasPrefix(value, "'") || strings.HasPrefix(strings.ToLower(value), "n'"))
+	if isString {
+		value = strings.ReplaceAll(value[strings.Index(value, "'")+1:len(value)-1], "''", "'")
+	}
+
+	switch openApiType {
+	case "string":
+		if isString {
+			return value
+		}
+	case "integer":
+		if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return number
+		}
+	case "number":
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case "boolean":
+		if boolean, err := strconv.ParseBool(strings.ToLower(value)); err == nil {
+			return boolean
+		}
+	}
+
+	return nil
+}
+
+// getOpenApiSchemaNames returns the names of the component schemas, which are prefixed with the schema if a table
+// name is used in more than one schema
+func getOpenApiSchemaNames(tables []database.TableResult) []string {
+	tableNameCounts := make(map[string]int)
+	for _, table := range tables {
+		tableNameCounts[getOpenApiName(table.Table.Name)]++
+	}
+
+	usedNames := make(map[string]bool)
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		name := getOpenApiName(table.Table.Name)
+		if tableNameCounts[name] > 1 {
+			name = getOpenApiName(table.Table.Schema + "." + table.Table.Name)
+		}
+
+		names[i] = getUniqueName(usedNames, name)
+	}
+
+	return names
+}
+
+// getOpenApiName replaces the characters that are not allowed in the names of components
+func getOpenApiName(name string) string {
+	return invalidOpenApiNameRegex.ReplaceAllString(name, "_")
+}
@@ -0,0 +1,109 @@
+package diagram
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+func TestWriteOpenApi(t *testing.T) {
+	// Arrange
+	result := &database.Result{Tables: []database.TableResult{{
+		Table: database.TableDetail{Schema: "public", Name: "article", Comment: "The articles"},
+		Columns: []database.ColumnResult{
+			{Name: "id", DataType: "bigint", IsPrimary: true, IsAutoIncrement: true},
+			{Name: "title", DataType: "varchar", CharacterMaxLength: 255, Comment: "shown as heading"},
+			{Name: "status", DataType: "article_status", EnumValues: "draft,published", IsNullable: true, DefaultValue: "'draft'::article_status"},
+			{Name: "created_at", DataType: "timestamptz", DefaultValue: "now()"},
+			{Name: "metadata", DataType: "jsonb", IsNullable: true},
+		},
+	}}}
+	var output bytes.Buffer
+
+	// Act
+	err := writeOpenApi(&output, result)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+  title: Database model
+  version: 1.0.0
+components:
+  schemas:
+    article:
+      type: object
+      description: The articles
+      required:
+        - id
+        - title
+        - created_at
+      properties:
+        id:
+          type: integer
+          format: int64
+          readOnly: true
+        title:
+          type: string
+          description: shown as heading
+          maxLength: 255
+        status:
+          type:
+            - string
+            - "null"
+          enum:
+            - draft
+            - published
+            - null
+          default: draft
+        created_at:
+          type: string
+          format: date-time
+        metadata: {}
+`, output.String())
+}
+
+func TestGetOpenApiDefault(t *testing.T) {
+	testCases := []struct {
+		defaultValue   string
+		openApiType    string
+		expectedResult interface{}
+	}{
+		{"'it''s'::character varying", "string", "it's"},
+		{"N'abc'", "string", "abc"},
+		{"((0))", "integer", int64(0)},
+		{"1.5", "number", 1.5},
+		{"false", "boolean", false},
+		{"now()", "string", nil},
+		{"'1'", "integer", int64(1)},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := getOpenApiDefault(testCase.defaultValue, testCase.openApiType)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestGetOpenApiSchemaNames(t *testing.T) {
+	// Arrange
+	tables := []database.TableResult{
+		{Table: database.TableDetail{Schema: "sales", Name: "order"}},
+		{Table: database.TableDetail{Schema: "shop", Name: "order"}},
+		{Table: database.TableDetail{Schema: "shop", Name: "order item"}},
+	}
+
+	// Act
+	names := getOpenApiSchemaNames(tables)
+
+	// Assert
+	assert.Equal(t, []string{"sales.order", "shop.order", "order_item"}, names)
+}
@@ -0,0 +1,51 @@
+package diagram
+
+import (
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+// splitOrphanTables separates the tables without relationship in the diagram (see shouldSkipConstraint) from the
+// other tables. A self reference does not relate the table to another one, so the table is an orphan anyway.
+func splitOrphanTables(config config.MermerdConfig, tables []database.TableResult) (otherTables []database.TableResult, orphanTables []database.TableResult) {
+	related := make(map[database.TableDetail]bool)
+	for _, table := range tables {
+		for _, constraint := range table.Constraints {
+			if constraint.IsSelfReference() || shouldSkipConstraint(config, tables, constraint) {
+				continue
+			}
+
+			related[database.TableDetail{Schema: constraint.FkSchema, Name: database.SanitizeValue(constraint.FkTable)}] = true
+			related[database.TableDetail{Schema: constraint.PkSchema, Name: database.SanitizeValue(constraint.PkTable)}] = true
+		}
+	}
+
+	for _, table := range tables {
+		if related[database.TableDetail{Schema: table.Table.Schema, Name: table.Table.Name}] {
+			otherTables = append(otherTables, table)
+		} else {
+			orphanTables = append(orphanTables, table)
+		}
+	}
+
+	return otherTables, orphanTables
+}
+
+// getOrphanTableNames returns the names of the tables that are omitted from the diagram, which are listed in the reports
+func getOrphanTableNames(config config.MermerdConfig, tables []database.TableResult) []string {
+	if !config.OmitOrphanTables() {
+		return nil
+	}
+
+	_, orphanTables := splitOrphanTables(config, tables)
+	return getTableNames(config, orphanTables)
+}
+
+func getTableNames(config config.MermerdConfig, tables []database.TableResult) []string {
+	names := make([]string, len(tables))
+	for index, table := range tables {
+		names[index] = unquote(getTableName(config, table.Table))
+	}
+
+	return names
+}
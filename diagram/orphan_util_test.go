@@ -0,0 +1,78 @@
+package diagram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+func TestSplitOrphanTables(t *testing.T) {
+	order := database.TableResult{
+		Table: database.TableDetail{Schema: "public", Name: "order"},
+		Constraints: database.ConstraintResultList{
+			{FkSchema: "public", FkTable: "order", PkSchema: "public", PkTable: "customer"},
+			{FkSchema: "public", FkTable: "order", PkSchema: "public", PkTable: "product"},
+		},
+	}
+	customer := database.TableResult{Table: database.TableDetail{Schema: "public", Name: "customer"}}
+	employee := database.TableResult{
+		Table:       database.TableDetail{Schema: "public", Name: "employee"},
+		Constraints: database.ConstraintResultList{{FkSchema: "public", FkTable: "employee", PkSchema: "public", PkTable: "employee"}},
+	}
+	setting := database.TableResult{Table: database.TableDetail{Schema: "public", Name: "setting"}}
+	invoice := database.TableResult{
+		Table:       database.TableDetail{Schema: "public", Name: "invoice"},
+		Constraints: database.ConstraintResultList{{FkSchema: "public", FkTable: "invoice", PkSchema: "public", PkTable: "payment"}},
+	}
+	tables := []database.TableResult{order, customer, employee, setting, invoice}
+
+	t.Run("Tables without relationships to the selected tables are orphans", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("ShowAllConstraints").Return(false)
+		configMock.On("ShowGhostTables").Return(false)
+
+		// Act
+		otherTables, orphanTables := splitOrphanTables(&configMock, tables)
+
+		// Assert
+		assert.Equal(t, []database.TableResult{order, customer}, otherTables)
+		assert.Equal(t, []database.TableResult{employee, setting, invoice}, orphanTables)
+	})
+
+	t.Run("Relationships to tables that are not selected count if all constraints are shown", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("ShowAllConstraints").Return(true)
+
+		// Act
+		otherTables, orphanTables := splitOrphanTables(&configMock, tables)
+
+		// Assert
+		assert.Equal(t, []database.TableResult{order, customer, invoice}, otherTables)
+		assert.Equal(t, []database.TableResult{employee, setting}, orphanTables)
+	})
+}
+
+func TestGetOrphanTableNames(t *testing.T) {
+	// Arrange
+	tables := []database.TableResult{{Table: database.TableDetail{Schema: "public", Name: "setting"}}}
+	configMock := mocks.MermerdConfig{}
+	configMock.On("OmitOrphanTables").Return(true).Once()
+	configMock.On("ShowSchemaPrefix").Return(false).Once()
+	disabledConfigMock := mocks.MermerdConfig{}
+	disabledConfigMock.On("OmitOrphanTables").Return(false).Once()
+
+	// Act
+	names := getOrphanTableNames(&configMock, tables)
+	disabledNames := getOrphanTableNames(&disabledConfigMock, tables)
+
+	// Assert
+	configMock.AssertExpectations(t)
+	disabledConfigMock.AssertExpectations(t)
+	assert.Equal(t, []string{"setting"}, names)
+	assert.Nil(t, disabledNames)
+}
@@ -0,0 +1,563 @@
+package diagram
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+var (
+	invalidPrismaIdentifierRegex = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+	// prismaStringLiteralRegex matches quoted defaults with an optional cast, e.g. 'draft'::article_status or N'abc'
+	prismaStringLiteralRegex = regexp.MustCompile(`^[Nn]?'((?:[^']|'')*)'(?:::[\w\s".]+)?$`)
+	prismaNumberLiteralRegex = regexp.MustCompile(`^(-?\d+(?:\.\d+)?)(?:::[\w\s".]+)?$`)
+)
+
+var prismaTemplateFuncs = template.FuncMap{
+	"prismaComment": prismaComment,
+	"prismaField":   prismaField,
+	"prismaList":    prismaList,
+}
+
+// prismaScalarTypes maps the data types of all dialects to the scalar types of prisma, the other data types are
+// declared as Unsupported("type")
+var prismaScalarTypes = map[string]string{
+	"int": "Int", "integer": "Int", "int2": "Int", "int4": "Int", "smallint": "Int", "tinyint": "Int",
+	"mediumint": "Int", "serial": "Int", "smallserial": "Int", "int32": "Int", "int16": "Int", "int8": "BigInt",
+	"bigint": "BigInt", "bigserial": "BigInt", "int64": "BigInt",
+	"boolean": "Boolean", "bool": "Boolean",
+	"real": "Float", "float": "Float", "float4": "Float", "float8": "Float", "double": "Float", "float64": "Float",
+	"numeric": "Decimal", "decimal": "Decimal", "money": "Decimal", "bignumeric": "Decimal",
+	"varchar": "String", "char": "String", "text": "String", "nvarchar": "String", "nchar": "String",
+	"ntext": "String", "bpchar": "String", "citext": "String", "uuid": "String", "uniqueidentifier": "String",
+	"tinytext": "String", "mediumtext": "String", "longtext": "String", "string": "String", "xml": "String",
+	"timestamp": "DateTime", "timestamptz": "DateTime", "date": "DateTime", "time": "DateTime",
+	"timetz": "DateTime", "datetime": "DateTime", "datetime2": "DateTime", "smalldatetime": "DateTime",
+	"datetimeoffset": "DateTime", "json": "Json", "jsonb": "Json",
+	"bytea": "Bytes", "blob": "Bytes", "binary": "Bytes", "varbinary": "Bytes", "tinyblob": "Bytes",
+	"mediumblob": "Bytes", "longblob": "Bytes", "image": "Bytes", "bytes": "Bytes",
+}
+
+var prismaCurrentTimestamps = []string{"now()", "current_timestamp", "current_timestamp()", "getdate()", "sysdatetime()", "localtimestamp"}
+
+// prismaModel is a model while the schema is created. The field names of the columns are needed to add the relations.
+type prismaModel struct {
+	table      database.TableResult
+	data       PrismaModelData
+	fieldNames map[string]string
+	usedNames  map[string]bool
+	// uniqueFields contains the fields of the primary key (first) and of the unique indexes
+	uniqueFields [][]string
+	idFields     []string
+}
+
+// getPrismaData creates the prisma schema of the tables, in which every table is a model (or a view) and every
+// foreign key a relation. As the foreign keys do not contain the referenced columns, they reference the primary key
+// of the model. Tables without primary key or unique index are ignored (@@ignore), as prisma requires a unique
+// identifier, and relations to them are not added.
+func getPrismaData(config config.MermerdConfig, result *database.Result) PrismaSchemaData {
+	// the type of the connection is the scheme of the connection string that is built from it
+	connectionString := config.ConnectionString()
+	if connectionString == "" {
+		connectionString = config.Connection().Type + "://"
+	}
+
+	schemaData := PrismaSchemaData{Provider: getPrismaProvider(connectionString)}
+	schemas := getSchemaNames(result.Tables)
+	isMultiSchema := len(schemas) > 1
+	if isMultiSchema {
+		schemaData.PreviewFeatures = append(schemaData.PreviewFeatures, "multiSchema")
+		schemaData.Schemas = schemas
+	}
+
+	modelNames := getPrismaModelNames(result.Tables)
+	usedTypeNames := make(map[string]bool)
+	for _, name := range modelNames {
+		usedTypeNames[name] = true
+	}
+
+	var enums []PrismaEnumData
+	var enumValueNames []map[string]string
+	enumIndexes := make(map[string]int)
+	models := make([]*prismaModel, len(result.Tables))
+	modelsByTable := make(map[database.TableDetail]*prismaModel)
+	for tableIndex, table := range result.Tables {
+		model := &prismaModel{
+			table:      table,
+			data:       PrismaModelData{Keyword: "model", Name: modelNames[tableIndex], Comment: table.Table.Comment},
+			fieldNames: make(map[string]string),
+			usedNames:  make(map[string]bool),
+		}
+
+		if table.Table.IsView {
+			model.data.Keyword = "view"
+			if !containsString(schemaData.PreviewFeatures, "views") {
+				schemaData.PreviewFeatures = append(schemaData.PreviewFeatures, "views")
+			}
+		}
+
+		for _, column := range table.Columns {
+			model.fieldNames[column.Name] = getUniqueName(model.usedNames, getPrismaIdentifier(column.Name))
+		}
+
+		for _, column := range table.Columns {
+			var enumValues map[string]string
+			fieldType := getPrismaType(column)
+			if column.EnumValues != "" {
+				enumName := getPrismaEnumName(table.Table, column)
+				index, ok := enumIndexes[enumName]
+				if !ok {
+					index = len(enums)
+					enumIndexes[enumName] = index
+					enum, valueNames := getPrismaEnumData(enumName, getUniqueName(usedTypeNames, getPrismaIdentifier(enumName)), column.EnumValues, table.Table.Schema, isMultiSchema)
+					enums = append(enums, enum)
+					enumValueNames = append(enumValueNames, valueNames)
+				}
+
+				fieldType = enums[index].Name
+				enumValues = enumValueNames[index]
+			}
+
+			model.data.Fields = append(model.data.Fields, getPrismaFieldData(model.fieldNames[column.Name], fieldType, column, enumValues))
+		}
+
+		model.addKeys()
+		models[tableIndex] = model
+		modelsByTable[database.TableDetail{Schema: table.Table.Schema, Name: table.Table.Name}] = model
+	}
+
+	addPrismaRelations(models, modelsByTable)
+
+	for _, model := range models {
+		if model.data.Name != model.table.Table.Name {
+			model.data.Attributes = append(model.data.Attributes, "@@map("+prismaString(model.table.Table.Name)+")")
+		}
+
+		if isMultiSchema {
+			model.data.Attributes = append(model.data.Attributes, "@@schema("+prismaString(model.table.Table.Schema)+")")
+		}
+
+		if len(model.uniqueFields) == 0 {
+			model.data.Attributes = append(model.data.Attributes, "@@ignore")
+			model.data.Comment = strings.TrimSpace(model.data.Comment + "\nThe table has no primary key or unique index, so it cannot be used by the prisma client.")
+		}
+
+		schemaData.Models = append(schemaData.Models, model.data)
+	}
+
+	schemaData.Enums = enums
+	return schemaData
+}
+
+// addKeys adds the primary key (@id or @@id), the unique indexes (@unique or @@unique) and the other indexes
+// (@@index) to the model. Indexes on expressions are skipped, as they have no fields.
+func (m *prismaModel) addKeys() {
+	if fields, ok := m.getFields(getPrimaryKeyColumnNames(m.table)); ok && len(fields) > 0 {
+		m.idFields = fields
+		m.uniqueFields = append(m.uniqueFields, fields)
+		if len(fields) == 1 {
+			m.addFieldAttribute(fields[0], "@id")
+		} else {
+			m.data.Attributes = append(m.data.Attributes, "@@id(["+strings.Join(fields, ", ")+"])")
+		}
+	}
+
+	for _, index := range m.table.Indexes {
+		fields, ok := m.getFields(index.ColumnNames)
+		if index.IsPrimary || !ok || len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case !index.IsUnique:
+			m.data.Attributes = append(m.data.Attributes, "@@index(["+strings.Join(fields, ", ")+"])")
+		case m.isUnique(fields):
+			// e.g. a unique index with the same columns as the primary key
+		case len(fields) == 1:
+			m.uniqueFields = append(m.uniqueFields, fields)
+			m.addFieldAttribute(fields[0], "@unique")
+		default:
+			m.uniqueFields = append(m.uniqueFields, fields)
+			m.data.Attributes = append(m.data.Attributes, "@@unique(["+strings.Join(fields, ", ")+"])")
+		}
+	}
+}
+
+// getFields returns the field names of the columns, ok is false if one of the columns is not a field of the model
+func (m *prismaModel) getFields(columnNames []string) ([]string, bool) {
+	fields := make([]string, len(columnNames))
+	for i, columnName := range columnNames {
+		field, ok := m.fieldNames[columnName]
+		if !ok {
+			return nil, false
+		}
+
+		fields[i] = field
+	}
+
+	return fields, true
+}
+
+// isUnique is true if the fields are the primary key or the fields of a unique index (in any order)
+func (m *prismaModel) isUnique(fields []string) bool {
+	for _, uniqueFields := range m.uniqueFields {
+		if len(uniqueFields) != len(fields) {
+			continue
+		}
+
+		isSame := true
+		for _, field := range fields {
+			isSame = isSame && containsString(uniqueFields, field)
+		}
+
+		if isSame {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *prismaModel) isOptional(fields []string) bool {
+	for _, field := range m.data.Fields {
+		if containsString(fields, field.Name) && strings.HasSuffix(field.Type, "?") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *prismaModel) addFieldAttribute(fieldName string, attribute string) {
+	for i, field := range m.data.Fields {
+		if field.Name == fieldName {
+			// @id and @unique are added in front of @default and @map
+			m.data.Fields[i].Attributes = append([]string{attribute}, field.Attributes...)
+		}
+	}
+}
+
+type prismaRelation struct {
+	fkModel    *prismaModel
+	pkModel    *prismaModel
+	constraint database.ConstraintResult
+	fkFields   []string
+}
+
+// addPrismaRelations adds the relation fields to both models of every foreign key. The relations are named after
+// the constraint if the models have more than one relation (or if the model references itself), as prisma could not
+// match the fields of both sides otherwise.
+func addPrismaRelations(models []*prismaModel, modelsByTable map[database.TableDetail]*prismaModel) {
+	var relations []prismaRelation
+	relationCounts := make(map[string]int)
+	for _, fkModel := range models {
+		for _, constraint := range getOwnConstraints(fkModel.table) {
+			pkModel := modelsByTable[database.TableDetail{Schema: constraint.PkSchema, Name: constraint.PkTable}]
+			if pkModel == nil || len(fkModel.uniqueFields) == 0 || len(pkModel.idFields) != len(constraint.ColumnNames) {
+				continue
+			}
+
+			fkFields, ok := fkModel.getFields(constraint.ColumnNames)
+			if !ok {
+				continue
+			}
+
+			relations = append(relations, prismaRelation{fkModel, pkModel, constraint, fkFields})
+			relationCounts[getPrismaRelationKey(fkModel, pkModel)]++
+		}
+	}
+
+	for _, relation := range relations {
+		fkModel, pkModel := relation.fkModel, relation.pkModel
+		var relationName string
+		if fkModel == pkModel || relationCounts[getPrismaRelationKey(fkModel, pkModel)] > 1 {
+			relationName = relation.constraint.ConstraintName
+			if relationName == "" {
+				relationName = fkModel.data.Name + "_" + strings.Join(relation.fkFields, "_")
+			}
+		}
+
+		fkArguments := []string{"fields: [" + strings.Join(relation.fkFields, ", ") + "]", "references: [" + strings.Join(pkModel.idFields, ", ") + "]"}
+		if relationName != "" {
+			fkArguments = append([]string{prismaString(relationName)}, fkArguments...)
+		}
+
+		fkType := pkModel.data.Name
+		if fkModel.isOptional(relation.fkFields) {
+			fkType += "?"
+		}
+
+		fkModel.data.Fields = append(fkModel.data.Fields, PrismaFieldData{
+			Name:       getUniqueName(fkModel.usedNames, pkModel.data.Name, pkModel.data.Name+"_"+strings.Join(relation.fkFields, "_")),
+			Type:       fkType,
+			Attributes: []string{"@relation(" + strings.Join(fkArguments, ", ") + ")"},
+		})
+
+		// the relation is one-to-one if the foreign key is unique
+		pkType := fkModel.data.Name + "[]"
+		if fkModel.isUnique(relation.fkFields) {
+			pkType = fkModel.data.Name + "?"
+		}
+
+		var pkAttributes []string
+		if relationName != "" {
+			pkAttributes = []string{"@relation(" + prismaString(relationName) + ")"}
+		}
+
+		pkModel.data.Fields = append(pkModel.data.Fields, PrismaFieldData{
+			Name:       getUniqueName(pkModel.usedNames, fkModel.data.Name, fkModel.data.Name+"_"+strings.Join(relation.fkFields, "_")),
+			Type:       pkType,
+			Attributes: pkAttributes,
+		})
+	}
+}
+
+func getPrismaRelationKey(fkModel *prismaModel, pkModel *prismaModel) string {
+	names := []string{fkModel.data.Name, pkModel.data.Name}
+	sort.Strings(names)
+	return strings.Join(names, "\x00")
+}
+
+func getPrismaFieldData(fieldName string, fieldType string, column database.ColumnResult, enumValues map[string]string) PrismaFieldData {
+	field := PrismaFieldData{Name: fieldName, Type: fieldType, Comment: column.Comment}
+	if column.IsNullable {
+		field.Type += "?"
+	}
+
+	if defaultValue := getPrismaDefault(column, fieldType, enumValues); defaultValue != "" {
+		field.Attributes = append(field.Attributes, "@default("+defaultValue+")")
+	}
+
+	if fieldName != column.Name {
+		field.Attributes = append(field.Attributes, "@map("+prismaString(column.Name)+")")
+	}
+
+	return field
+}
+
+// getPrismaProvider returns the prisma provider of the connection string. Prisma does not support the other databases,
+// they use the postgresql provider (e.g. redshift, which is compatible) so that the schema can still be edited.
+func getPrismaProvider(connectionString string) string {
+	scheme, _, _ := strings.Cut(connectionString, "://")
+	switch strings.ToLower(scheme) {
+	case "mysql", "mariadb":
+		return "mysql"
+	case "sqlserver":
+		return "sqlserver"
+	case "sqlite":
+		return "sqlite"
+	case "cockroachdb":
+		return "cockroachdb"
+	default:
+		return "postgresql"
+	}
+}
+
+// getPrismaModelNames returns the names of the models, which are prefixed with the schema if a table name is used in
+// more than one schema
+func getPrismaModelNames(tables []database.TableResult) []string {
+	tableNameCounts := make(map[string]int)
+	for _, table := range tables {
+		tableNameCounts[getPrismaIdentifier(table.Table.Name)]++
+	}
+
+	usedNames := make(map[string]bool)
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		name := getPrismaIdentifier(table.Table.Name)
+		if tableNameCounts[name] > 1 {
+			name = getPrismaIdentifier(table.Table.Schema + "_" + table.Table.Name)
+		}
+
+		names[i] = getUniqueName(usedNames, name)
+	}
+
+	return names
+}
+
+// getPrismaEnumName returns the name of the enum type like getEnumName, but without the schema prefix
+func getPrismaEnumName(table database.TableDetail, column database.ColumnResult) string {
+	if strings.EqualFold(column.DataType, "enum") {
+		return table.Name + "_" + column.Name
+	}
+
+	return column.DataType
+}
+
+// getPrismaEnumData returns the enum and the names of its values in the prisma schema
+func getPrismaEnumData(enumName string, name string, enumValues string, schema string, isMultiSchema bool) (PrismaEnumData, map[string]string) {
+	enum := PrismaEnumData{Name: name}
+	valueNames := make(map[string]string)
+	usedNames := make(map[string]bool)
+	for _, value := range strings.Split(enumValues, ",") {
+		if value == "" {
+			continue
+		}
+
+		valueData := PrismaFieldData{Name: getUniqueName(usedNames, getPrismaIdentifier(value))}
+		if valueData.Name != value {
+			valueData.Attributes = []string{"@map(" + prismaString(value) + ")"}
+		}
+
+		valueNames[value] = valueData.Name
+		enum.Values = append(enum.Values, valueData)
+	}
+
+	if name != enumName {
+		enum.Attributes = append(enum.Attributes, "@@map("+prismaString(enumName)+")")
+	}
+
+	if isMultiSchema {
+		enum.Attributes = append(enum.Attributes, "@@schema("+prismaString(schema)+")")
+	}
+
+	return enum, valueNames
+}
+
+func getPrismaType(column database.ColumnResult) string {
+	if scalarType, ok := prismaScalarTypes[strings.ToLower(column.DataType)]; ok {
+		return scalarType
+	}
+
+	return "Unsupported(" + prismaString(column.DataType) + ")"
+}
+
+// getPrismaDefault converts the default of the column to the argument of @default. Literals of the field type are
+// used as they are, the current timestamp is now() and all other expressions are dbgenerated.
+func getPrismaDefault(column database.ColumnResult, fieldType string, enumValues map[string]string) string {
+	if column.IsAutoIncrement || column.SequenceName != "" {
+		return "autoincrement()"
+	}
+
+	value := strings.TrimSpace(column.DefaultValue)
+	for isEnclosedInParentheses(value) {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+
+	if value == "" || column.IsGenerated || strings.EqualFold(value, "null") || strings.HasPrefix(strings.ToLower(value), "null::") {
+		return ""
+	}
+
+	if fieldType == "DateTime" && containsString(prismaCurrentTimestamps, strings.ToLower(value)) {
+		return "now()"
+	}
+
+	if match := prismaStringLiteralRegex.FindStringSubmatch(value); match != nil {
+		literal := strings.ReplaceAll(match[1], "''", "'")
+		if enumValue, ok := enumValues[literal]; ok {
+			return enumValue
+		}
+
+		if fieldType == "String" {
+			return prismaString(literal)
+		}
+
+		value = literal
+	}
+
+	if match := prismaNumberLiteralRegex.FindStringSubmatch(value); match != nil {
+		switch fieldType {
+		case "Int", "BigInt", "Float", "Decimal":
+			return match[1]
+		case "Boolean":
+			if match[1] == "0" || match[1] == "1" {
+				return fmt.Sprint(match[1] == "1")
+			}
+		}
+	}
+
+	if fieldType == "Boolean" && (strings.EqualFold(value, "true") || strings.EqualFold(value, "false")) {
+		return strings.ToLower(value)
+	}
+
+	return "dbgenerated(" + prismaString(strings.TrimSpace(column.DefaultValue)) + ")"
+}
+
+// isEnclosedInParentheses is true for defaults like ((0)) of mssql, but not for (a) + (b)
+func isEnclosedInParentheses(value string) bool {
+	if !strings.HasPrefix(value, "(") || !strings.HasSuffix(value, ")") {
+		return false
+	}
+
+	depth := 0
+	for i, character := range value {
+		switch character {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i < len(value)-1 {
+				return false
+			}
+		}
+	}
+
+	return depth == 0
+}
+
+// getPrismaIdentifier replaces the characters that are not allowed in prisma names, which have to start with a letter
+func getPrismaIdentifier(name string) string {
+	identifier := strings.TrimLeft(invalidPrismaIdentifierRegex.ReplaceAllString(name, "_"), "_")
+	if identifier == "" || !isAsciiLetter(identifier[0]) {
+		identifier = "x" + identifier
+	}
+
+	return identifier
+}
+
+func isAsciiLetter(character byte) bool {
+	return (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z')
+}
+
+func prismaString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+// prismaComment returns the comment as documentation comments (///) with the indentation
+func prismaComment(comment string, indent string) string {
+	if comment == "" {
+		return ""
+	}
+
+	var result strings.Builder
+	for _, line := range strings.Split(comment, "\n") {
+		result.WriteString(indent + "/// " + strings.TrimSpace(line) + "\n")
+	}
+
+	return result.String()
+}
+
+// prismaField returns the field with the name, type and attributes aligned to the other fields of the model, like
+// prisma format does
+func prismaField(model PrismaModelData, field PrismaFieldData) string {
+	nameWidth, typeWidth := 0, 0
+	for _, item := range model.Fields {
+		nameWidth = maxInt(nameWidth, len(item.Name))
+		typeWidth = maxInt(typeWidth, len(item.Type))
+	}
+
+	line := fmt.Sprintf("%-*s %-*s %s", nameWidth, field.Name, typeWidth, field.Type, strings.Join(field.Attributes, " "))
+	return strings.TrimRight(line, " ")
+}
+
+func prismaList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = prismaString(value)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
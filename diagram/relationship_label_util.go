@@ -0,0 +1,63 @@
+package diagram
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/aslakhellesoy/mermerd/config"
+)
+
+const (
+	relationshipLabelColumnName     = "columnName"
+	relationshipLabelConstraintName = "constraintName"
+	relationshipLabelNone           = "none"
+	relationshipLabelInherits       = "inherits"
+)
+
+// relationshipLabelData contains the values that can be used in a relationshipLabel template (e.g. {{.FkTable}}).
+// The constraint name and the columns of collapsed join tables are the name of the join table, the ones of inherited
+// tables are "inherits".
+type relationshipLabelData struct {
+	FkSchema       string
+	FkTable        string
+	PkSchema       string
+	PkTable        string
+	ConstraintName string
+	// Columns are the comma separated columns of the foreign key
+	Columns string
+}
+
+// getRelationshipLabel returns the label of the relationship, which are the columns of the foreign key by default
+func getRelationshipLabel(config config.MermerdConfig, data relationshipLabelData) (string, error) {
+	if config.OmitConstraintLabels() {
+		return "", nil
+	}
+
+	relationshipLabel := config.RelationshipLabel()
+	switch relationshipLabel {
+	case "", relationshipLabelColumnName:
+		return data.Columns, nil
+	case relationshipLabelConstraintName:
+		return data.ConstraintName, nil
+	case relationshipLabelNone:
+		return "", nil
+	}
+
+	if !strings.Contains(relationshipLabel, "{{") {
+		return "", fmt.Errorf("unsupported relationshipLabel %q (columnName, constraintName, none or a template)", relationshipLabel)
+	}
+
+	tmpl, err := template.New("relationship_label").Parse(relationshipLabel)
+	if err != nil {
+		return "", fmt.Errorf("invalid relationshipLabel template: %w", err)
+	}
+
+	var label strings.Builder
+	if err := tmpl.Execute(&label, data); err != nil {
+		return "", fmt.Errorf("invalid relationshipLabel template: %w", err)
+	}
+
+	// the label is enclosed with quote marks in the mermaid diagram
+	return strings.ReplaceAll(label.String(), `"`, "'"), nil
+}
@@ -0,0 +1,59 @@
+package diagram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+func TestGetRelationshipLabel(t *testing.T) {
+	data := relationshipLabelData{
+		FkSchema:       "public",
+		FkTable:        "comment",
+		PkSchema:       "public",
+		PkTable:        "article",
+		ConstraintName: "fk_comment_article",
+		Columns:        "article_id",
+	}
+
+	testCases := []struct {
+		relationshipLabel string
+		omitLabels        bool
+		expectedLabel     string
+		expectError       bool
+	}{
+		{relationshipLabel: "", expectedLabel: "article_id"},
+		{relationshipLabel: "columnName", expectedLabel: "article_id"},
+		{relationshipLabel: "constraintName", expectedLabel: "fk_comment_article"},
+		{relationshipLabel: "none", expectedLabel: ""},
+		{relationshipLabel: "constraintName", omitLabels: true, expectedLabel: ""},
+		{relationshipLabel: "{{.FkTable}}.{{.Columns}} -> {{.PkTable}}", expectedLabel: "comment.article_id -> article"},
+		{relationshipLabel: `{{printf "%q" .ConstraintName}}`, expectedLabel: "'fk_comment_article'"},
+		{relationshipLabel: "tableName", expectError: true},
+		{relationshipLabel: "{{.FkTable", expectError: true},
+		{relationshipLabel: "{{.Unknown}}", expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.relationshipLabel, func(t *testing.T) {
+			// Arrange
+			configMock := mocks.MermerdConfig{}
+			configMock.On("OmitConstraintLabels").Return(testCase.omitLabels)
+			configMock.On("RelationshipLabel").Return(testCase.relationshipLabel).Maybe()
+
+			// Act
+			label, err := getRelationshipLabel(&configMock, data)
+
+			// Assert
+			if testCase.expectError {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedLabel, label)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package diagram
+
+import "strings"
+
+// mergedLabelSeparator separates the labels of merged relationships, a comma would be ambiguous with the columns of
+// composite foreign keys
+const mergedLabelSeparator = " / "
+
+// mergeConstraints replaces the relationships from the same table to the same referenced table by a single
+// relationship at the position of the first one. It keeps the relation of the first relationship and lists the
+// distinct labels of all of them. Inheritance is drawn differently, so it is never merged with foreign keys.
+func mergeConstraints(constraints []ErdConstraintData) []ErdConstraintData {
+	type constraintKey struct {
+		fkTableName   string
+		pkTableName   string
+		isInheritance bool
+	}
+
+	var mergedConstraints []ErdConstraintData
+	var labels [][]string
+	positions := make(map[constraintKey]int)
+	for _, constraint := range constraints {
+		key := constraintKey{constraint.FkTableName, constraint.PkTableName, constraint.IsInheritance}
+		position, ok := positions[key]
+		if !ok {
+			position = len(mergedConstraints)
+			positions[key] = position
+			mergedConstraints = append(mergedConstraints, constraint)
+			labels = append(labels, nil)
+		}
+
+		if constraint.ConstraintLabel != "" && !containsString(labels[position], constraint.ConstraintLabel) {
+			labels[position] = append(labels[position], constraint.ConstraintLabel)
+		}
+	}
+
+	for index := range mergedConstraints {
+		mergedConstraints[index].ConstraintLabel = strings.Join(labels[index], mergedLabelSeparator)
+	}
+
+	return mergedConstraints
+}
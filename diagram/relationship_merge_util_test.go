@@ -0,0 +1,70 @@
+package diagram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeConstraints(t *testing.T) {
+	t.Run("Relationships between the same tables are merged", func(t *testing.T) {
+		// Arrange
+		constraints := []ErdConstraintData{
+			{FkTableName: "article", PkTableName: "person", Relation: relationManyToOne, ConstraintLabel: "author_id"},
+			{FkTableName: "comment", PkTableName: "article", Relation: relationManyToOne, ConstraintLabel: "article_id"},
+			{FkTableName: "article", PkTableName: "person", Relation: relationOptionalManyToOne, ConstraintLabel: "editor_id"},
+			{FkTableName: "article", PkTableName: "person", Relation: relationManyToOne, ConstraintLabel: "author_id"},
+		}
+
+		// Act
+		result := mergeConstraints(constraints)
+
+		// Assert
+		assert.Equal(t, []ErdConstraintData{
+			{FkTableName: "article", PkTableName: "person", Relation: relationManyToOne, ConstraintLabel: "author_id / editor_id"},
+			{FkTableName: "comment", PkTableName: "article", Relation: relationManyToOne, ConstraintLabel: "article_id"},
+		}, result)
+	})
+
+	t.Run("Relationships in the other direction are kept", func(t *testing.T) {
+		// Arrange
+		constraints := []ErdConstraintData{
+			{FkTableName: "article", PkTableName: "person", Relation: relationManyToOne, ConstraintLabel: "author_id"},
+			{FkTableName: "person", PkTableName: "article", Relation: relationOptionalManyToOne, ConstraintLabel: "favorite_article_id"},
+		}
+
+		// Act
+		result := mergeConstraints(constraints)
+
+		// Assert
+		assert.Equal(t, constraints, result)
+	})
+
+	t.Run("Inheritance is not merged with foreign keys", func(t *testing.T) {
+		// Arrange
+		constraints := []ErdConstraintData{
+			{FkTableName: "employee", PkTableName: "person", Relation: relationInheritance, ConstraintLabel: "inherits", IsInheritance: true},
+			{FkTableName: "employee", PkTableName: "person", Relation: relationOptionalManyToOne, ConstraintLabel: "manager_id"},
+		}
+
+		// Act
+		result := mergeConstraints(constraints)
+
+		// Assert
+		assert.Equal(t, constraints, result)
+	})
+
+	t.Run("Empty labels are omitted", func(t *testing.T) {
+		// Arrange
+		constraints := []ErdConstraintData{
+			{FkTableName: "article", PkTableName: "person", Relation: relationManyToOne},
+			{FkTableName: "article", PkTableName: "person", Relation: relationManyToOne},
+		}
+
+		// Act
+		result := mergeConstraints(constraints)
+
+		// Assert
+		assert.Equal(t, constraints[:1], result)
+	})
+}
@@ -0,0 +1,87 @@
+package diagram
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aslakhellesoy/mermerd/config"
+)
+
+const (
+	relationshipDirectionChild  = "child"
+	relationshipDirectionParent = "parent"
+
+	relationshipOrderTable        = "table"
+	relationshipOrderParent       = "parent"
+	relationshipOrderAlphabetical = "alphabetical"
+)
+
+// isParentFirst checks if the referenced table is written before the table with the foreign key, the child (the table
+// with the foreign key) is written first by default
+func isParentFirst(config config.MermerdConfig) (bool, error) {
+	switch config.RelationshipDirection() {
+	case "", relationshipDirectionChild:
+		return false, nil
+	case relationshipDirectionParent:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported relationshipDirection %q (child or parent)", config.RelationshipDirection())
+	}
+}
+
+// sortConstraints orders the relationships, as mermaid places the entities in the order of the statements. The
+// relationships are in the order of the tables by default, the parent order groups them by the referenced table and the
+// alphabetical order sorts them by the table that is written first.
+func sortConstraints(config config.MermerdConfig, constraints []ErdConstraintData, parentFirst bool) error {
+	switch config.RelationshipOrder() {
+	case "", relationshipOrderTable:
+	case relationshipOrderParent:
+		sort.SliceStable(constraints, func(i, j int) bool {
+			return constraints[i].PkTableName < constraints[j].PkTableName
+		})
+	case relationshipOrderAlphabetical:
+		sort.SliceStable(constraints, func(i, j int) bool {
+			return getSortKey(constraints[i], parentFirst) < getSortKey(constraints[j], parentFirst)
+		})
+	default:
+		return fmt.Errorf("unsupported relationshipOrder %q (table, parent or alphabetical)", config.RelationshipOrder())
+	}
+
+	return nil
+}
+
+// getSortKey contains the tables in the order in which they are written, followed by the label
+func getSortKey(constraint ErdConstraintData, parentFirst bool) string {
+	tableNames := []string{constraint.FkTableName, constraint.PkTableName}
+	if parentFirst {
+		tableNames[0], tableNames[1] = tableNames[1], tableNames[0]
+	}
+
+	return strings.Join(append(tableNames, constraint.ConstraintLabel), "\x00")
+}
+
+// reverseRelation describes the relation from the other side, e.g. }o--|| (many to one) becomes ||--o{ (one to many)
+func reverseRelation(relation ErdRelationType) ErdRelationType {
+	value := string(relation)
+	if len(value) != 6 {
+		return relation
+	}
+
+	return ErdRelationType(mirrorCardinality(value[4:]) + value[2:4] + mirrorCardinality(value[:2]))
+}
+
+// mirrorCardinality turns the marker of the right side into the one of the left side and vice versa (e.g. o{ and }o)
+func mirrorCardinality(cardinality string) string {
+	mirrored := []byte{cardinality[1], cardinality[0]}
+	for index, character := range mirrored {
+		switch character {
+		case '{':
+			mirrored[index] = '}'
+		case '}':
+			mirrored[index] = '{'
+		}
+	}
+
+	return string(mirrored)
+}
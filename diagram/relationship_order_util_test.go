@@ -0,0 +1,134 @@
+package diagram
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+func TestIsParentFirst(t *testing.T) {
+	testCases := []struct {
+		relationshipDirection string
+		expectedParentFirst   bool
+		expectedError         bool
+	}{
+		{"", false, false},
+		{"child", false, false},
+		{"parent", true, false},
+		{"up", false, true},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Arrange
+			configMock := mocks.MermerdConfig{}
+			configMock.On("RelationshipDirection").Return(testCase.relationshipDirection)
+
+			// Act
+			result, err := isParentFirst(&configMock)
+
+			// Assert
+			if testCase.expectedError {
+				assert.ErrorContains(t, err, "unsupported relationshipDirection")
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedParentFirst, result)
+		})
+	}
+}
+
+func TestSortConstraints(t *testing.T) {
+	testCases := []struct {
+		relationshipOrder string
+		parentFirst       bool
+		expectedOrder     []string
+		expectedError     bool
+	}{
+		{"", false, []string{"payment-invoice", "invoice-customer", "address-customer", "payment-customer"}, false},
+		{"table", true, []string{"payment-invoice", "invoice-customer", "address-customer", "payment-customer"}, false},
+		{"parent", false, []string{"invoice-customer", "address-customer", "payment-customer", "payment-invoice"}, false},
+		{"alphabetical", false, []string{"address-customer", "invoice-customer", "payment-customer", "payment-invoice"}, false},
+		{"alphabetical", true, []string{"address-customer", "invoice-customer", "payment-customer", "payment-invoice"}, false},
+		{"random", false, nil, true},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Arrange
+			configMock := mocks.MermerdConfig{}
+			configMock.On("RelationshipOrder").Return(testCase.relationshipOrder)
+			constraints := []ErdConstraintData{
+				{FkTableName: "payment", PkTableName: "invoice"},
+				{FkTableName: "invoice", PkTableName: "customer"},
+				{FkTableName: "address", PkTableName: "customer"},
+				{FkTableName: "payment", PkTableName: "customer"},
+			}
+
+			// Act
+			err := sortConstraints(&configMock, constraints, testCase.parentFirst)
+
+			// Assert
+			if testCase.expectedError {
+				assert.ErrorContains(t, err, "unsupported relationshipOrder")
+				return
+			}
+
+			var order []string
+			for _, constraint := range constraints {
+				order = append(order, constraint.FkTableName+"-"+constraint.PkTableName)
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedOrder, order)
+		})
+	}
+}
+
+func TestReverseRelation(t *testing.T) {
+	testCases := []struct {
+		relation         ErdRelationType
+		expectedRelation ErdRelationType
+	}{
+		{relationOneToOne, "||--o|"},
+		{relationManyToOne, "||--o{"},
+		{relationOptionalOneToOne, "|o--o|"},
+		{relationOptionalManyToOne, "|o--o{"},
+		{relationManyToMany, "}o--o{"},
+		{relationInheritance, "||..o|"},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := reverseRelation(testCase.relation)
+
+			// Assert
+			assert.Equal(t, testCase.expectedRelation, result)
+		})
+	}
+}
+
+func TestErdTemplateWritesParentFirst(t *testing.T) {
+	// Arrange
+	tmpl, err := getTemplate(outputFormatMermaid)
+	data := ErdDiagramData{
+		Constraints: []ErdConstraintData{
+			{FkTableName: "invoice", PkTableName: "customer", Relation: relationManyToOne, ConstraintLabel: "customer_id"},
+		},
+		ParentFirst: true,
+	}
+	var result bytes.Buffer
+
+	// Act
+	_ = tmpl.Execute(&result, data)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Contains(t, result.String(), `customer ||--o{ invoice : "customer_id"`)
+}
@@ -0,0 +1,95 @@
+package diagram
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+const (
+	imageFormatSvg = "svg"
+	imageFormatPng = "png"
+)
+
+// Render renders the diagram as svg or png image. The mermaid diagram is rendered with the mermaid cli (mmdc), the
+// GraphViz DOT diagram (--outputFormat dot) with the dot command of GraphViz. Both have to be installed.
+func (d diagram) Render(w io.Writer, result *database.Result, imageFormat string) error {
+	if imageFormat != imageFormatSvg && imageFormat != imageFormatPng {
+		return fmt.Errorf("unsupported image format %q (svg or png)", imageFormat)
+	}
+
+	tempDir, err := os.MkdirTemp("", "mermerd")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	diagramFormat := outputFormatMermaid
+	if d.config.OutputFormat() == outputFormatDot {
+		diagramFormat = outputFormatDot
+	}
+
+	diagramFileName := filepath.Join(tempDir, "diagram."+diagramFormat)
+	imageFileName := filepath.Join(tempDir, "diagram."+imageFormat)
+	if err := d.writeDiagramFile(diagramFileName, diagramFormat, result); err != nil {
+		return err
+	}
+
+	command := exec.Command("mmdc", "--input", diagramFileName, "--output", imageFileName)
+	if diagramFormat == outputFormatDot {
+		command = exec.Command("dot", "-T"+imageFormat, "-o", imageFileName, diagramFileName)
+	}
+
+	if output, err := command.CombinedOutput(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return fmt.Errorf("rendering requires the mermaid cli (npm install -g @mermaid-js/mermaid-cli) or GraphViz for dot diagrams: %w", err)
+		}
+
+		logrus.Error("Could not render diagram", " | ", strings.TrimSpace(string(output)))
+		return fmt.Errorf("could not render diagram with %s: %w", filepath.Base(command.Path), err)
+	}
+
+	image, err := os.Open(imageFileName)
+	if err != nil {
+		return err
+	}
+	defer image.Close()
+
+	_, err = io.Copy(w, image)
+	return err
+}
+
+// writeDiagramFile writes the diagram that is rendered, which is never enclosed with the mermaid backticks
+func (d diagram) writeDiagramFile(fileName string, diagramFormat string, result *database.Result) error {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tmpl, err := getTemplate(diagramFormat)
+	if err != nil {
+		return err
+	}
+
+	diagramData, err := d.getDiagramData(result)
+	if err != nil {
+		return err
+	}
+
+	diagramData.EncloseWithMermaidBackticks = false
+	return tmpl.Execute(f, diagramData)
+}
+
+// getImageFileName replaces the extension of the output file with the image format (e.g. result.mmd -> result.svg)
+func getImageFileName(outputFileName string, imageFormat string) string {
+	return strings.TrimSuffix(outputFileName, filepath.Ext(outputFileName)) + "." + imageFormat
+}
@@ -0,0 +1,232 @@
+package diagram
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+// useFakeRenderers replaces mmdc and dot with scripts that copy the diagram to the image file
+func useFakeRenderers(t *testing.T) {
+	binDir := t.TempDir()
+	scripts := map[string]string{
+		"mmdc": "#!/bin/sh\ncp \"$2\" \"$4\"\n",
+		"dot":  "#!/bin/sh\ncp \"$4\" \"$3\"\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(binDir, name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestRender(t *testing.T) {
+	result := &database.Result{Tables: []database.TableResult{{Table: database.TableDetail{Schema: "public", Name: "article"}}}}
+
+	t.Run("Render mermaid diagram without backticks", func(t *testing.T) {
+		// Arrange
+		useFakeRenderers(t)
+		configMock := mocks.MermerdConfig{}
+		configMock.On("OutputFormat").Return("mermaid")
+		configMock.On("EncloseWithMermaidBackticks").Return(true)
+		configMock.On("ShowAllConstraints").Return(false)
+		configMock.On("ShowGhostTables").Return(false)
+		configMock.On("OmitConstraintLabels").Return(false)
+		configMock.On("OmitAttributeKeys").Return(false)
+		configMock.On("MultipleAttributeKeys").Return(false)
+		configMock.On("OmitColumns").Return(false)
+		configMock.On("MaxColumnsPerTable").Return(0)
+		configMock.On("ShowSchemaPrefix").Return(false)
+		configMock.On("ShowDescriptions").Return([]string{})
+		configMock.On("MermaidCommentStyle").Return("")
+		configMock.On("RelationshipDirection").Return("")
+		configMock.On("RelationshipOrder").Return("")
+		configMock.On("MergeRelationships").Return(false)
+		configMock.On("ShowNullable").Return(false)
+		configMock.On("ShowIdentity").Return(false)
+		configMock.On("ShowDataTypePrecision").Return(false)
+		configMock.On("ShowEnumsAsEntities").Return(false)
+		configMock.On("CollapseJoinTables").Return(false)
+		configMock.On("OmitOrphanTables").Return(false)
+		configMock.On("NameCase").Return("")
+		configMock.On("TableAliases").Return(nil, nil)
+		configMock.On("ColumnAliases").Return(nil, nil)
+		configMock.On("MermaidTheme").Return("")
+		configMock.On("MermaidLayout").Return("")
+		configMock.On("MermaidMaxTextSize").Return(0)
+		configMock.On("TableStyles").Return(nil, nil)
+		configMock.On("GroupBy").Return("")
+		configMock.On("TableGroups").Return(nil, nil)
+		var image bytes.Buffer
+
+		// Act
+		err := NewDiagram(&configMock).Render(&image, result, "svg")
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Contains(t, image.String(), "erDiagram")
+		assert.NotContains(t, image.String(), "```")
+	})
+
+	t.Run("Unsupported image format", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		var image bytes.Buffer
+
+		// Act
+		err := NewDiagram(&configMock).Render(&image, result, "gif")
+
+		// Assert
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Missing renderer", func(t *testing.T) {
+		// Arrange
+		t.Setenv("PATH", t.TempDir())
+		configMock := mocks.MermerdConfig{}
+		configMock.On("OutputFormat").Return("dot")
+		configMock.On("EncloseWithMermaidBackticks").Return(false)
+		configMock.On("ShowAllConstraints").Return(false)
+		configMock.On("ShowGhostTables").Return(false)
+		configMock.On("OmitConstraintLabels").Return(false)
+		configMock.On("OmitAttributeKeys").Return(false)
+		configMock.On("MultipleAttributeKeys").Return(false)
+		configMock.On("OmitColumns").Return(false)
+		configMock.On("MaxColumnsPerTable").Return(0)
+		configMock.On("ShowSchemaPrefix").Return(false)
+		configMock.On("ShowDescriptions").Return([]string{})
+		configMock.On("MermaidCommentStyle").Return("")
+		configMock.On("RelationshipDirection").Return("")
+		configMock.On("RelationshipOrder").Return("")
+		configMock.On("MergeRelationships").Return(false)
+		configMock.On("ShowNullable").Return(false)
+		configMock.On("ShowIdentity").Return(false)
+		configMock.On("ShowDataTypePrecision").Return(false)
+		configMock.On("ShowEnumsAsEntities").Return(false)
+		configMock.On("CollapseJoinTables").Return(false)
+		configMock.On("OmitOrphanTables").Return(false)
+		configMock.On("NameCase").Return("")
+		configMock.On("TableAliases").Return(nil, nil)
+		configMock.On("ColumnAliases").Return(nil, nil)
+		configMock.On("MermaidTheme").Return("")
+		configMock.On("MermaidLayout").Return("")
+		configMock.On("MermaidMaxTextSize").Return(0)
+		configMock.On("TableStyles").Return(nil, nil)
+		configMock.On("GroupBy").Return("")
+		configMock.On("TableGroups").Return(nil, nil)
+		var image bytes.Buffer
+
+		// Act
+		err := NewDiagram(&configMock).Render(&image, result, "png")
+
+		// Assert
+		assert.NotNil(t, err)
+	})
+}
+
+func TestCreateRenderedDiagramOnStdout(t *testing.T) {
+	// Arrange
+	configMock := mocks.MermerdConfig{}
+	configMock.On("Render").Return("svg").Once()
+	configMock.On("OutputFileName").Return(StdoutFileName).Once()
+	result := &database.Result{}
+
+	// Act
+	err := NewDiagram(&configMock).Create(result)
+
+	// Assert
+	configMock.AssertExpectations(t)
+	assert.NotNil(t, err)
+}
+
+func TestCreateInjectedDiagram(t *testing.T) {
+	// Arrange
+	fileName := filepath.Join(t.TempDir(), "readme.md")
+	_ = os.WriteFile(fileName, []byte("# Schema\n<!-- mermerd:begin -->\nold\n<!-- mermerd:end -->\n"), 0o600)
+	configMock := mocks.MermerdConfig{}
+	configMock.On("Render").Return("")
+	configMock.On("Inject").Return(true)
+	configMock.On("StableOutput").Return(true)
+	configMock.On("OutputFileName").Return(fileName)
+	configMock.On("OutputFormat").Return("mermaid")
+	configMock.On("EncloseWithMermaidBackticks").Return(true)
+	configMock.On("ShowAllConstraints").Return(false)
+	configMock.On("ShowGhostTables").Return(false)
+	configMock.On("OmitConstraintLabels").Return(false)
+	configMock.On("OmitAttributeKeys").Return(false)
+	configMock.On("MultipleAttributeKeys").Return(false)
+	configMock.On("OmitColumns").Return(true)
+	configMock.On("ShowSchemaPrefix").Return(false)
+	configMock.On("ShowDescriptions").Return([]string{})
+	configMock.On("MermaidCommentStyle").Return("")
+	configMock.On("RelationshipDirection").Return("")
+	configMock.On("RelationshipOrder").Return("")
+	configMock.On("MergeRelationships").Return(false)
+	configMock.On("ShowNullable").Return(false)
+	configMock.On("ShowIdentity").Return(false)
+	configMock.On("ShowDataTypePrecision").Return(false)
+	configMock.On("ShowEnumsAsEntities").Return(false)
+	configMock.On("CollapseJoinTables").Return(false)
+	configMock.On("OmitOrphanTables").Return(false)
+	configMock.On("NameCase").Return("")
+	configMock.On("TableAliases").Return(nil, nil)
+	configMock.On("ColumnAliases").Return(nil, nil)
+	configMock.On("MermaidTheme").Return("")
+	configMock.On("MermaidLayout").Return("")
+	configMock.On("MermaidMaxTextSize").Return(0)
+	configMock.On("TableStyles").Return(nil, nil)
+	configMock.On("GroupBy").Return("")
+	configMock.On("TableGroups").Return(nil, nil)
+	result := &database.Result{Tables: []database.TableResult{{Table: database.TableDetail{Schema: "public", Name: "article"}}}}
+
+	// Act
+	err := NewDiagram(&configMock).Create(result)
+
+	// Assert
+	assert.Nil(t, err)
+	content, _ := os.ReadFile(fileName)
+	assert.Contains(t, string(content), "# Schema\n<!-- mermerd:begin -->\n```mermaid\nerDiagram\n")
+	assert.Contains(t, string(content), "    article\n")
+	assert.NotContains(t, string(content), "old")
+	assert.True(t, strings.HasSuffix(string(content), "```\n<!-- mermerd:end -->\n"))
+}
+
+func TestCreateOutputFile(t *testing.T) {
+	t.Run("Stdout", func(t *testing.T) {
+		// Act
+		file, err := createOutputFile(StdoutFileName)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, nopWriteCloser{os.Stdout}, file)
+		assert.Nil(t, file.Close())
+	})
+
+	t.Run("File", func(t *testing.T) {
+		// Arrange
+		fileName := filepath.Join(t.TempDir(), "result.mmd")
+
+		// Act
+		file, err := createOutputFile(fileName)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Nil(t, file.Close())
+		assert.FileExists(t, fileName)
+	})
+}
+
+func TestGetImageFileName(t *testing.T) {
+	assert.Equal(t, "result.svg", getImageFileName("result.mmd", "svg"))
+	assert.Equal(t, "out/my-db.png", getImageFileName("out/my-db.md", "png"))
+	assert.Equal(t, "result.svg", getImageFileName("result", "svg"))
+}
@@ -0,0 +1,71 @@
+package diagram
+
+import (
+	"strings"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+// getReportData is used by the markdown and html reports. It contains all tables (including the collapsed join
+// tables), as the reports document every table and not only the ones shown in the diagram. The classified columns
+// of all tables are also listed in a separate section, which can be reviewed without reading the whole report.
+func getReportData(config config.MermerdConfig, result *database.Result, diagram string) ReportData {
+	tables := make([]ReportTableData, len(result.Tables))
+	var classifiedColumns []ReportClassifiedColumnData
+	for tableIndex, table := range result.Tables {
+		tableName := unquote(getTableName(config, table.Table))
+		columns := make([]ReportColumnData, len(table.Columns))
+		for columnIndex, column := range table.Columns {
+			classifications := strings.Join(column.Classifications, ", ")
+			if classifications != "" {
+				classifiedColumns = append(classifiedColumns, ReportClassifiedColumnData{
+					Table:           tableName,
+					Column:          column.Name,
+					Classifications: classifications,
+				})
+			}
+
+			columns[columnIndex] = ReportColumnData{
+				Name:            column.Name,
+				DataType:        getDataType(config, column),
+				AttributeKeys:   getColumnKeys(config, column, table.Indexes),
+				IsNullable:      column.IsNullable,
+				DefaultValue:    column.DefaultValue,
+				Comment:         column.Comment,
+				Classifications: classifications,
+				SampleValues:    getSampleValues(table.SampleRows, columnIndex),
+			}
+		}
+
+		tables[tableIndex] = ReportTableData{
+			Name:       tableName,
+			IsView:     table.Table.IsView,
+			Columns:    columns,
+			SampleRows: table.SampleRows,
+		}
+	}
+
+	return ReportData{
+		Diagram:           diagram,
+		Tables:            tables,
+		ClassifiedColumns: classifiedColumns,
+		OrphanTables:      getOrphanTableNames(config, result.Tables),
+	}
+}
+
+// getSampleValues joins the distinct values of the column in the sample rows, empty values (null) are skipped
+func getSampleValues(sampleRows [][]string, columnIndex int) string {
+	var values []string
+	seen := make(map[string]bool)
+	for _, row := range sampleRows {
+		if columnIndex >= len(row) || row[columnIndex] == "" || seen[row[columnIndex]] {
+			continue
+		}
+
+		seen[row[columnIndex]] = true
+		values = append(values, row[columnIndex])
+	}
+
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,108 @@
+package diagram
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+func TestGetReportData(t *testing.T) {
+	// Arrange
+	configMock := mocks.MermerdConfig{}
+	configMock.On("OmitOrphanTables").Return(false).Once()
+	configMock.On("ShowSchemaPrefix").Return(true).Twice()
+	configMock.On("SchemaPrefixSeparator").Return(".").Twice()
+	configMock.On("ShowIndexes").Return(true).Twice()
+	configMock.On("ShowDataTypePrecision").Return(true).Times(3)
+	configMock.On("MultipleAttributeKeys").Return(false).Times(3)
+	result := &database.Result{
+		Tables: []database.TableResult{
+			{
+				Table: database.TableDetail{Schema: "public", Name: "article"},
+				Columns: []database.ColumnResult{
+					{Name: "id", DataType: "int", IsPrimary: true, DefaultValue: "nextval('article_id_seq'::regclass)"},
+					{Name: "slug", DataType: "varchar", CharacterMaxLength: 100, IsNullable: true, Comment: "unique name"},
+				},
+				Indexes:    []database.IndexResult{{Name: "article_slug_key", ColumnNames: []string{"slug"}, IsUnique: true}},
+				SampleRows: [][]string{{"1", "first"}, {"2", ""}},
+			},
+			{
+				Table:   database.TableDetail{Schema: "public", Name: "article_overview", IsView: true},
+				Columns: []database.ColumnResult{{Name: "title", DataType: "varchar"}},
+			},
+		},
+	}
+
+	// Act
+	markdownData := getReportData(&configMock, result, "diagram")
+
+	// Assert
+	configMock.AssertExpectations(t)
+	assert.Equal(t, "diagram", markdownData.Diagram)
+	assert.Len(t, markdownData.Tables, 2)
+	assert.Equal(t, "public.article", markdownData.Tables[0].Name)
+	assert.False(t, markdownData.Tables[0].IsView)
+	assert.Equal(t, []ReportColumnData{
+		{Name: "id", DataType: "int", AttributeKeys: ErdAttributeKeys{primaryKey}, DefaultValue: "nextval('article_id_seq'::regclass)", SampleValues: "1, 2"},
+		{Name: "slug", DataType: "varchar(100)", AttributeKeys: ErdAttributeKeys{uniqueKey}, IsNullable: true, Comment: "unique name", SampleValues: "first"},
+	}, markdownData.Tables[0].Columns)
+	assert.Equal(t, [][]string{{"1", "first"}, {"2", ""}}, markdownData.Tables[0].SampleRows)
+	assert.Equal(t, "public.article_overview", markdownData.Tables[1].Name)
+	assert.True(t, markdownData.Tables[1].IsView)
+}
+
+func TestGetReportDataWithClassifications(t *testing.T) {
+	// Arrange
+	configMock := mocks.MermerdConfig{}
+	configMock.On("OmitOrphanTables").Return(false).Once()
+	configMock.On("ShowSchemaPrefix").Return(false).Once()
+	configMock.On("ShowIndexes").Return(false).Twice()
+	configMock.On("ShowDataTypePrecision").Return(false).Twice()
+	configMock.On("MultipleAttributeKeys").Return(false).Twice()
+	result := &database.Result{
+		Tables: []database.TableResult{
+			{
+				Table: database.TableDetail{Schema: "public", Name: "user"},
+				Columns: []database.ColumnResult{
+					{Name: "id", DataType: "int"},
+					{Name: "ssn", DataType: "varchar", Classifications: []string{"pii", "secret"}},
+				},
+			},
+		},
+	}
+
+	// Act
+	reportData := getReportData(&configMock, result, "diagram")
+
+	// Assert
+	configMock.AssertExpectations(t)
+	assert.Equal(t, "", reportData.Tables[0].Columns[0].Classifications)
+	assert.Equal(t, "pii, secret", reportData.Tables[0].Columns[1].Classifications)
+	assert.Equal(t, []ReportClassifiedColumnData{{Table: "user", Column: "ssn", Classifications: "pii, secret"}}, reportData.ClassifiedColumns)
+}
+
+func TestGetSampleValues(t *testing.T) {
+	testCases := []struct {
+		sampleRows     [][]string
+		columnIndex    int
+		expectedResult string
+	}{
+		{nil, 0, ""},
+		{[][]string{{"1", "a"}, {"2", "a"}, {"3", ""}, {"4", "b"}}, 1, "a, b"},
+		{[][]string{{"1"}}, 1, ""},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := getSampleValues(testCase.sampleRows, testCase.columnIndex)
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
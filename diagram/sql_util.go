@@ -0,0 +1,471 @@
+package diagram
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+const (
+	sqlDialectPortable  = "portable"
+	sqlDialectPostgres  = "postgres"
+	sqlDialectMysql     = "mysql"
+	sqlDialectSqlServer = "sqlserver"
+	sqlDialectSqlite    = "sqlite"
+)
+
+// sqlLiteralCastRegex matches literals with a cast of postgres, e.g. 'draft'::article_status or 0::smallint, which
+// are only valid in postgres
+var sqlLiteralCastRegex = regexp.MustCompile(`^([Nn]?'(?:[^']|'')*'|-?\d+(?:\.\d+)?)::[\w\s".]+$`)
+
+// sqlPortableTypes maps the data types of all dialects to the types that are understood by most databases, the other
+// data types are exported as they are declared
+var sqlPortableTypes = map[string]string{
+	"int": "INTEGER", "integer": "INTEGER", "int4": "INTEGER", "int32": "INTEGER", "mediumint": "INTEGER",
+	"serial": "INTEGER", "smallint": "SMALLINT", "int2": "SMALLINT", "tinyint": "SMALLINT", "int16": "SMALLINT",
+	"smallserial": "SMALLINT", "bigint": "BIGINT", "int8": "BIGINT", "int64": "BIGINT", "bigserial": "BIGINT",
+	"boolean": "BOOLEAN", "bool": "BOOLEAN",
+	"real": "REAL", "float4": "REAL", "float": "DOUBLE PRECISION", "float8": "DOUBLE PRECISION",
+	"double": "DOUBLE PRECISION", "float64": "DOUBLE PRECISION",
+	"numeric": "DECIMAL", "decimal": "DECIMAL", "number": "DECIMAL", "money": "DECIMAL", "bignumeric": "DECIMAL",
+	"varchar": "VARCHAR", "nvarchar": "VARCHAR", "varchar2": "VARCHAR", "string": "VARCHAR",
+	"char": "CHAR", "nchar": "CHAR", "bpchar": "CHAR",
+	"text": "TEXT", "ntext": "TEXT", "tinytext": "TEXT", "mediumtext": "TEXT", "longtext": "TEXT", "citext": "TEXT",
+	"clob": "TEXT", "xml": "TEXT",
+	"date": "DATE", "time": "TIME", "timetz": "TIME WITH TIME ZONE",
+	"timestamp": "TIMESTAMP", "datetime": "TIMESTAMP", "datetime2": "TIMESTAMP", "smalldatetime": "TIMESTAMP",
+	"timestamptz": "TIMESTAMP WITH TIME ZONE", "datetimeoffset": "TIMESTAMP WITH TIME ZONE",
+	"json": "JSON", "jsonb": "JSON", "uuid": "UUID", "uniqueidentifier": "UUID",
+	"bytea": "BLOB", "blob": "BLOB", "binary": "BLOB", "varbinary": "BLOB", "tinyblob": "BLOB",
+	"mediumblob": "BLOB", "longblob": "BLOB", "image": "BLOB", "bytes": "BLOB",
+}
+
+// sqlDialect contains the differences of the dialects, which are needed to create the DDL
+type sqlDialect struct {
+	name       string
+	openQuote  string
+	closeQuote string
+	// identity is added to auto increment columns, sqlite only supports them as INTEGER PRIMARY KEY AUTOINCREMENT
+	identity string
+	// enumType is the type of enum columns (with the length of the longest value), whose values are checked. Enum
+	// types are created in postgres and mysql declares them inline.
+	enumType         string
+	hasSchemas       bool
+	hasDeclaredTypes bool
+	// foreign keys are added with ALTER TABLE, so that the tables can reference each other, except in sqlite
+	hasAlterTable     bool
+	hasCommentOn      bool
+	hasInlineComments bool
+}
+
+var sqlDialects = map[string]sqlDialect{
+	sqlDialectPortable: {name: sqlDialectPortable, openQuote: `"`, closeQuote: `"`, identity: "GENERATED BY DEFAULT AS IDENTITY",
+		enumType: "VARCHAR(%d)", hasSchemas: true, hasAlterTable: true, hasCommentOn: true},
+	sqlDialectPostgres: {name: sqlDialectPostgres, openQuote: `"`, closeQuote: `"`, identity: "GENERATED BY DEFAULT AS IDENTITY",
+		hasSchemas: true, hasDeclaredTypes: true, hasAlterTable: true, hasCommentOn: true},
+	sqlDialectMysql: {name: sqlDialectMysql, openQuote: "`", closeQuote: "`", identity: "AUTO_INCREMENT",
+		hasSchemas: true, hasDeclaredTypes: true, hasAlterTable: true, hasInlineComments: true},
+	sqlDialectSqlServer: {name: sqlDialectSqlServer, openQuote: "[", closeQuote: "]", identity: "IDENTITY(1,1)",
+		enumType: "nvarchar(%d)", hasSchemas: true, hasDeclaredTypes: true, hasAlterTable: true},
+	sqlDialectSqlite: {name: sqlDialectSqlite, openQuote: `"`, closeQuote: `"`, enumType: "TEXT", hasDeclaredTypes: true},
+}
+
+// getSqlDialect returns the configured dialect, the portable dialect is used if none is configured
+func getSqlDialect(name string) (sqlDialect, error) {
+	if name == "" {
+		name = sqlDialectPortable
+	}
+
+	dialect, ok := sqlDialects[strings.ToLower(name)]
+	if !ok {
+		return sqlDialect{}, fmt.Errorf("unsupported sql dialect %q (portable, postgres, mysql, sqlserver or sqlite)", name)
+	}
+
+	return dialect, nil
+}
+
+// getSqlScriptData creates the normalized DDL of the tables, which are ordered by schema and name with the columns in
+// the order of the table definition. As the foreign keys do not contain the referenced columns, they reference the
+// primary key of the referenced table. Views are not exported, as the model does not contain their definition, and
+// inferred relations are no foreign keys of the database.
+func getSqlScriptData(dialect sqlDialect, result *database.Result) SqlScriptData {
+	scriptData := SqlScriptData{Dialect: dialect.name}
+	tables := make([]database.TableResult, len(result.Tables))
+	copy(tables, result.Tables)
+	sort.SliceStable(tables, func(i, j int) bool {
+		if tables[i].Table.Schema != tables[j].Table.Schema {
+			return tables[i].Table.Schema < tables[j].Table.Schema
+		}
+
+		return tables[i].Table.Name < tables[j].Table.Name
+	})
+
+	tablesByName := make(map[database.TableDetail]database.TableResult)
+	for _, table := range tables {
+		tablesByName[database.TableDetail{Schema: table.Table.Schema, Name: table.Table.Name}] = table
+	}
+
+	createdTypes := make(map[string]bool)
+	for _, table := range tables {
+		tableName := dialect.tableName(table.Table.Schema, table.Table.Name)
+		if table.Table.IsView {
+			scriptData.Notes = append(scriptData.Notes, fmt.Sprintf("view %s is not exported, as its definition is not part of the model", tableName))
+			continue
+		}
+
+		tableData := SqlTableData{Name: tableName}
+		columns := getSqlColumns(table)
+		primaryKey := getPrimaryKeyColumnNames(table)
+		inlinePrimaryKey := dialect.name == sqlDialectSqlite && len(primaryKey) == 1 && isSqlAutoIncrement(table, primaryKey[0])
+
+		var elements []SqlElementData
+		var comments []string
+		for _, column := range columns {
+			isInlinePrimaryKey := inlinePrimaryKey && column.Name == primaryKey[0]
+			element := SqlElementData{Definition: dialect.quote(column.Name) + " " + dialect.columnType(column)}
+			if isInlinePrimaryKey {
+				// sqlite only allows AUTOINCREMENT for INTEGER PRIMARY KEY
+				element.Definition = dialect.quote(column.Name) + " INTEGER"
+			}
+			if column.EnumValues != "" && dialect.name == sqlDialectPostgres {
+				typeName := getSqlEnumTypeName(table.Table, column)
+				element.Definition = dialect.quote(column.Name) + " " + dialect.tableName(table.Table.Schema, typeName)
+				if !createdTypes[table.Table.Schema+"."+typeName] {
+					createdTypes[table.Table.Schema+"."+typeName] = true
+					scriptData.Types = append(scriptData.Types, fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", dialect.tableName(table.Table.Schema, typeName), sqlList(strings.Split(column.EnumValues, ","))))
+				}
+			}
+
+			element.Definition += dialect.columnConstraints(column, isInlinePrimaryKey)
+			if column.Comment != "" {
+				switch {
+				case dialect.hasInlineComments:
+					element.Definition += " COMMENT " + sqlString(column.Comment)
+				case dialect.hasCommentOn:
+					comments = append(comments, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", tableName, dialect.quote(column.Name), sqlString(column.Comment)))
+				default:
+					element.Comment = sqlLineComment(column.Comment)
+				}
+			}
+
+			elements = append(elements, element)
+		}
+
+		if len(primaryKey) > 0 && !inlinePrimaryKey {
+			elements = append(elements, SqlElementData{Definition: dialect.constraintName(getSqlPrimaryKeyName(table)) + "PRIMARY KEY (" + dialect.quoteList(primaryKey) + ")"})
+		}
+
+		for _, constraint := range getSqlForeignKeys(table) {
+			foreignKey, ok := dialect.foreignKey(constraint, tablesByName)
+			switch {
+			case !ok:
+				scriptData.Notes = append(scriptData.Notes, fmt.Sprintf("foreign key %s of %s is not exported, as the referenced columns are unknown", dialect.quote(constraint.ConstraintName), tableName))
+			case dialect.hasAlterTable:
+				scriptData.ForeignKeys = append(scriptData.ForeignKeys, "ALTER TABLE "+tableName+" ADD "+foreignKey)
+			default:
+				elements = append(elements, SqlElementData{Definition: foreignKey})
+			}
+		}
+
+		for index, element := range elements {
+			line := element.Definition
+			if index < len(elements)-1 {
+				line += ","
+			}
+
+			if element.Comment != "" {
+				line += " -- " + element.Comment
+			}
+
+			tableData.Lines = append(tableData.Lines, line)
+		}
+
+		switch {
+		case table.Table.Comment == "":
+		case dialect.hasInlineComments:
+			tableData.Options = " COMMENT=" + sqlString(table.Table.Comment)
+		case dialect.hasCommentOn:
+			comments = append([]string{fmt.Sprintf("COMMENT ON TABLE %s IS %s", tableName, sqlString(table.Table.Comment))}, comments...)
+		default:
+			tableData.Comment = sqlLineComment(table.Table.Comment)
+		}
+
+		scriptData.Comments = append(scriptData.Comments, comments...)
+		scriptData.Tables = append(scriptData.Tables, tableData)
+		scriptData.Indexes = append(scriptData.Indexes, getSqlIndexes(dialect, table, tableName)...)
+	}
+
+	return scriptData
+}
+
+// getSqlColumns returns the columns in the order of the table definition, if the connector returns their position
+func getSqlColumns(table database.TableResult) []database.ColumnResult {
+	columns := make([]database.ColumnResult, len(table.Columns))
+	copy(columns, table.Columns)
+	for _, column := range columns {
+		if column.OrdinalPosition <= 0 {
+			return columns
+		}
+	}
+
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].OrdinalPosition < columns[j].OrdinalPosition
+	})
+
+	return columns
+}
+
+// getSqlForeignKeys returns the declared foreign keys of the table ordered by name
+func getSqlForeignKeys(table database.TableResult) []database.ConstraintResult {
+	var foreignKeys []database.ConstraintResult
+	for _, constraint := range getOwnConstraints(table) {
+		if !constraint.IsInferred && len(constraint.ColumnNames) > 0 {
+			foreignKeys = append(foreignKeys, constraint)
+		}
+	}
+
+	sort.SliceStable(foreignKeys, func(i, j int) bool {
+		return foreignKeys[i].ConstraintName < foreignKeys[j].ConstraintName
+	})
+
+	return foreignKeys
+}
+
+// getSqlIndexes returns the CREATE INDEX statements of the unique constraints and indexes ordered by name, the
+// primary key is part of the table
+func getSqlIndexes(dialect sqlDialect, table database.TableResult, tableName string) []string {
+	indexes := make([]database.IndexResult, 0, len(table.Indexes))
+	for _, index := range table.Indexes {
+		if !index.IsPrimary && len(index.ColumnNames) > 0 {
+			indexes = append(indexes, index)
+		}
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return indexes[i].Name < indexes[j].Name
+	})
+
+	statements := make([]string, len(indexes))
+	for i, index := range indexes {
+		name := index.Name
+		if name == "" {
+			suffix := "_idx"
+			if index.IsUnique {
+				suffix = "_key"
+			}
+			name = table.Table.Name + "_" + strings.Join(index.ColumnNames, "_") + suffix
+		}
+
+		keyword := "CREATE INDEX "
+		if index.IsUnique {
+			keyword = "CREATE UNIQUE INDEX "
+		}
+
+		// the columns of indexes on expressions are the expressions, which are not quoted
+		columns := make([]string, len(index.ColumnNames))
+		for j, columnName := range index.ColumnNames {
+			columns[j] = columnName
+			if hasSqlColumn(table, columnName) {
+				columns[j] = dialect.quote(columnName)
+			}
+		}
+
+		statements[i] = keyword + dialect.quote(name) + " ON " + tableName + " (" + strings.Join(columns, ", ") + ")"
+	}
+
+	return statements
+}
+
+func hasSqlColumn(table database.TableResult, columnName string) bool {
+	for _, column := range table.Columns {
+		if column.Name == columnName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getSqlPrimaryKeyName(table database.TableResult) string {
+	for _, index := range table.Indexes {
+		if index.IsPrimary {
+			return index.Name
+		}
+	}
+
+	return ""
+}
+
+// isSqlAutoIncrement is true for identity and auto_increment columns and for columns with a sequence (serial)
+func isSqlAutoIncrement(table database.TableResult, columnName string) bool {
+	for _, column := range table.Columns {
+		if column.Name == columnName {
+			return column.IsAutoIncrement || column.SequenceName != ""
+		}
+	}
+
+	return false
+}
+
+// getSqlEnumTypeName returns the name of the enum type, the enums of mysql get the name of the table and the column
+func getSqlEnumTypeName(table database.TableDetail, column database.ColumnResult) string {
+	if strings.EqualFold(column.DataType, "enum") {
+		return table.Name + "_" + column.Name
+	}
+
+	return column.DataType
+}
+
+// getSqlDefault returns the default of the column, the casts of literals are only kept in postgres
+func getSqlDefault(dialect sqlDialect, column database.ColumnResult) string {
+	value := strings.TrimSpace(column.DefaultValue)
+	if dialect.name == sqlDialectPostgres {
+		return value
+	}
+
+	unwrapped := value
+	for isEnclosedInParentheses(unwrapped) {
+		unwrapped = strings.TrimSpace(unwrapped[1 : len(unwrapped)-1])
+	}
+
+	if match := sqlLiteralCastRegex.FindStringSubmatch(unwrapped); match != nil {
+		return match[1]
+	}
+
+	return value
+}
+
+func (d sqlDialect) quote(name string) string {
+	return d.openQuote + strings.ReplaceAll(name, d.closeQuote, d.closeQuote+d.closeQuote) + d.closeQuote
+}
+
+func (d sqlDialect) quoteList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = d.quote(name)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// tableName returns the quoted name qualified with the schema, sqlite has no schemas
+func (d sqlDialect) tableName(schema string, name string) string {
+	if !d.hasSchemas || schema == "" {
+		return d.quote(name)
+	}
+
+	return d.quote(schema) + "." + d.quote(name)
+}
+
+// constraintName returns the CONSTRAINT clause of the name, mysql always names the primary key PRIMARY
+func (d sqlDialect) constraintName(name string) string {
+	if name == "" || (d.name == sqlDialectMysql && strings.EqualFold(name, "primary")) {
+		return ""
+	}
+
+	return "CONSTRAINT " + d.quote(name) + " "
+}
+
+// columnType returns the declared data type of the column, or the portable data type in the portable dialect
+func (d sqlDialect) columnType(column database.ColumnResult) string {
+	if column.EnumValues != "" {
+		switch {
+		case d.name == sqlDialectMysql:
+			return "ENUM(" + sqlList(strings.Split(column.EnumValues, ",")) + ")"
+		case strings.Contains(d.enumType, "%d"):
+			return fmt.Sprintf(d.enumType, getSqlMaxLength(strings.Split(column.EnumValues, ",")))
+		case d.enumType != "":
+			return d.enumType
+		}
+	}
+
+	if d.hasDeclaredTypes {
+		return column.DataTypeWithPrecision()
+	}
+
+	portableType, ok := sqlPortableTypes[strings.ToLower(column.DataType)]
+	if !ok {
+		return column.DataTypeWithPrecision()
+	}
+
+	column.DataType = portableType
+	return column.DataTypeWithPrecision()
+}
+
+// columnConstraints returns the identity, default, not null and check clauses of the column definition
+func (d sqlDialect) columnConstraints(column database.ColumnResult, isInlinePrimaryKey bool) string {
+	var clauses []string
+	isAutoIncrement := column.IsAutoIncrement || column.SequenceName != ""
+	switch {
+	case isInlinePrimaryKey:
+		clauses = append(clauses, "PRIMARY KEY AUTOINCREMENT")
+	case isAutoIncrement && d.identity != "":
+		clauses = append(clauses, d.identity)
+	}
+
+	if column.DefaultValue != "" && !column.IsGenerated && !(isAutoIncrement && (d.identity != "" || isInlinePrimaryKey)) {
+		clauses = append(clauses, "DEFAULT "+getSqlDefault(d, column))
+	}
+
+	if !column.IsNullable && !isInlinePrimaryKey {
+		clauses = append(clauses, "NOT NULL")
+	}
+
+	if column.EnumValues != "" && d.enumType != "" {
+		clauses = append(clauses, "CHECK ("+d.quote(column.Name)+" IN ("+sqlList(strings.Split(column.EnumValues, ","))+"))")
+	}
+
+	if column.CheckConstraints != "" {
+		clauses = append(clauses, "CHECK ("+column.CheckConstraints+")")
+	}
+
+	if len(clauses) == 0 {
+		return ""
+	}
+
+	return " " + strings.Join(clauses, " ")
+}
+
+// foreignKey returns the foreign key constraint, which references the primary key of the referenced table. Mysql
+// requires the referenced columns, so the foreign keys to tables that are not part of the model are not exported.
+func (d sqlDialect) foreignKey(constraint database.ConstraintResult, tablesByName map[database.TableDetail]database.TableResult) (string, bool) {
+	references := d.tableName(constraint.PkSchema, constraint.PkTable)
+	pkTable, ok := tablesByName[database.TableDetail{Schema: constraint.PkSchema, Name: constraint.PkTable}]
+	if primaryKey := getPrimaryKeyColumnNames(pkTable); ok && len(primaryKey) == len(constraint.ColumnNames) {
+		references += " (" + d.quoteList(primaryKey) + ")"
+	} else if d.name == sqlDialectMysql {
+		return "", false
+	}
+
+	return d.constraintName(constraint.ConstraintName) + "FOREIGN KEY (" + d.quoteList(constraint.ColumnNames) + ") REFERENCES " + references, true
+}
+
+func getSqlMaxLength(values []string) int {
+	length := 1
+	for _, value := range values {
+		length = maxInt(length, len(value))
+	}
+
+	return length
+}
+
+func sqlString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func sqlList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = sqlString(value)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// sqlLineComment returns the comment in one line, so that it can be used as line comment (--)
+func sqlLineComment(comment string) string {
+	return strings.Join(strings.Fields(comment), " ")
+}
@@ -0,0 +1,200 @@
+package diagram
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+func getSqlTestResult() *database.Result {
+	return &database.Result{Tables: []database.TableResult{
+		{
+			Table: database.TableDetail{Schema: "public", Name: "author", Comment: "The authors"},
+			Columns: []database.ColumnResult{
+				{Name: "name", DataType: "varchar", CharacterMaxLength: 100, Comment: "full name", OrdinalPosition: 2},
+				{Name: "id", DataType: "int", IsPrimary: true, SequenceName: "author_id_seq", DefaultValue: "nextval('author_id_seq'::regclass)", OrdinalPosition: 1},
+			},
+			Indexes: []database.IndexResult{
+				{Name: "author_pkey", ColumnNames: []string{"id"}, IsUnique: true, IsPrimary: true},
+				{Name: "author_name_key", ColumnNames: []string{"name"}, IsUnique: true},
+			},
+		},
+		{
+			Table: database.TableDetail{Schema: "public", Name: "article"},
+			Columns: []database.ColumnResult{
+				{Name: "id", DataType: "bigint", IsPrimary: true, IsAutoIncrement: true},
+				{Name: "author_id", DataType: "int", IsForeign: true, IsNullable: true},
+				{Name: "status", DataType: "article_status", EnumValues: "draft,published", DefaultValue: "'draft'::article_status"},
+				{Name: "price", DataType: "numeric", NumericPrecision: 10, NumericScale: 2, CheckConstraints: "price > 0"},
+			},
+			Constraints: []database.ConstraintResult{
+				{FkSchema: "public", FkTable: "article", PkSchema: "public", PkTable: "author", ConstraintName: "article_author_fk", ColumnName: "author_id", IsNullable: true},
+				{FkSchema: "public", FkTable: "article", PkSchema: "public", PkTable: "author", ConstraintName: "article_editor_fk", ColumnName: "author_id", IsInferred: true},
+			},
+		},
+		{
+			Table:   database.TableDetail{Schema: "public", Name: "article_view", IsView: true},
+			Columns: []database.ColumnResult{{Name: "id", DataType: "bigint"}},
+		},
+	}}
+}
+
+func TestWriteSql(t *testing.T) {
+	// Arrange
+	configMock := mocks.MermerdConfig{}
+	configMock.On("OutputFormat").Return("sql").Twice()
+	configMock.On("StableOutput").Return(false).Once()
+	configMock.On("SqlDialect").Return("portable").Once()
+	var output bytes.Buffer
+
+	// Act
+	err := NewDiagram(&configMock).Write(&output, getSqlTestResult())
+
+	// Assert
+	configMock.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, `-- Schema exported by mermerd (sql dialect: portable)
+-- The view "public"."article_view" is not exported, as its definition is not part of the model.
+
+CREATE TABLE "public"."article" (
+  "id" BIGINT GENERATED BY DEFAULT AS IDENTITY NOT NULL,
+  "author_id" INTEGER,
+  "status" VARCHAR(9) DEFAULT 'draft' NOT NULL CHECK ("status" IN ('draft', 'published')),
+  "price" DECIMAL(10,2) NOT NULL CHECK (price > 0),
+  PRIMARY KEY ("id")
+);
+
+CREATE TABLE "public"."author" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY NOT NULL,
+  "name" VARCHAR(100) NOT NULL,
+  CONSTRAINT "author_pkey" PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX "author_name_key" ON "public"."author" ("name");
+
+ALTER TABLE "public"."article" ADD CONSTRAINT "article_author_fk" FOREIGN KEY ("author_id") REFERENCES "public"."author" ("id");
+
+COMMENT ON TABLE "public"."author" IS 'The authors';
+COMMENT ON COLUMN "public"."author"."name" IS 'full name';
+`, output.String())
+}
+
+func TestGetSqlScriptData(t *testing.T) {
+	t.Run("Postgres", func(t *testing.T) {
+		// Arrange
+		dialect, _ := getSqlDialect("postgres")
+
+		// Act
+		data := getSqlScriptData(dialect, getSqlTestResult())
+
+		// Assert
+		assert.Equal(t, []string{`CREATE TYPE "public"."article_status" AS ENUM ('draft', 'published')`}, data.Types)
+		assert.Equal(t, []string{
+			`"id" bigint GENERATED BY DEFAULT AS IDENTITY NOT NULL,`,
+			`"author_id" int,`,
+			`"status" "public"."article_status" DEFAULT 'draft'::article_status NOT NULL,`,
+			`"price" numeric(10,2) NOT NULL CHECK (price > 0),`,
+			`PRIMARY KEY ("id")`,
+		}, data.Tables[0].Lines)
+	})
+
+	t.Run("MySQL", func(t *testing.T) {
+		// Arrange
+		dialect, _ := getSqlDialect("mysql")
+
+		// Act
+		data := getSqlScriptData(dialect, getSqlTestResult())
+
+		// Assert
+		assert.Nil(t, data.Types)
+		assert.Equal(t, "`status` ENUM('draft', 'published') DEFAULT 'draft' NOT NULL,", data.Tables[0].Lines[2])
+		assert.Equal(t, []string{
+			"`id` int AUTO_INCREMENT NOT NULL,",
+			"`name` varchar(100) NOT NULL COMMENT 'full name',",
+			"CONSTRAINT `author_pkey` PRIMARY KEY (`id`)",
+		}, data.Tables[1].Lines)
+		assert.Equal(t, " COMMENT='The authors'", data.Tables[1].Options)
+		assert.Nil(t, data.Comments)
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		// Arrange
+		dialect, _ := getSqlDialect("sqlite")
+
+		// Act
+		data := getSqlScriptData(dialect, getSqlTestResult())
+
+		// Assert
+		assert.Equal(t, `"article"`, data.Tables[0].Name)
+		assert.Equal(t, []string{
+			`"id" INTEGER PRIMARY KEY AUTOINCREMENT,`,
+			`"author_id" int,`,
+			`"status" TEXT DEFAULT 'draft' NOT NULL CHECK ("status" IN ('draft', 'published')),`,
+			`"price" numeric(10,2) NOT NULL CHECK (price > 0),`,
+			`CONSTRAINT "article_author_fk" FOREIGN KEY ("author_id") REFERENCES "author" ("id")`,
+		}, data.Tables[0].Lines)
+		assert.Equal(t, "The authors", data.Tables[1].Comment)
+		assert.Equal(t, `"name" varchar(100) NOT NULL -- full name`, data.Tables[1].Lines[1])
+		assert.Nil(t, data.ForeignKeys)
+	})
+
+	t.Run("Foreign key to a table that is not part of the model", func(t *testing.T) {
+		// Arrange
+		dialect, _ := getSqlDialect("mysql")
+		result := &database.Result{Tables: []database.TableResult{{
+			Table:   database.TableDetail{Schema: "shop", Name: "order"},
+			Columns: []database.ColumnResult{{Name: "customer_id", DataType: "int"}},
+			Constraints: []database.ConstraintResult{
+				{FkSchema: "shop", FkTable: "order", PkSchema: "shop", PkTable: "customer", ConstraintName: "fk_customer", ColumnName: "customer_id"},
+			},
+		}}}
+
+		// Act
+		data := getSqlScriptData(dialect, result)
+
+		// Assert
+		assert.Nil(t, data.ForeignKeys)
+		assert.Equal(t, []string{"foreign key `fk_customer` of `shop`.`order` is not exported, as the referenced columns are unknown"}, data.Notes)
+	})
+}
+
+func TestGetSqlDialect(t *testing.T) {
+	// Act
+	_, err := getSqlDialect("oracle")
+
+	// Assert
+	assert.EqualError(t, err, `unsupported sql dialect "oracle" (portable, postgres, mysql, sqlserver or sqlite)`)
+}
+
+func TestGetSqlDefault(t *testing.T) {
+	testCases := []struct {
+		dialect        string
+		defaultValue   string
+		expectedResult string
+	}{
+		{"portable", "'draft'::character varying", "'draft'"},
+		{"portable", "((0))", "((0))"},
+		{"mysql", "('draft'::text)", "'draft'"},
+		{"portable", "now()", "now()"},
+		{"postgres", "'draft'::character varying", "'draft'::character varying"},
+		{"sqlserver", "((0))", "((0))"},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Arrange
+			dialect, _ := getSqlDialect(testCase.dialect)
+
+			// Act
+			result := getSqlDefault(dialect, database.ColumnResult{DefaultValue: testCase.defaultValue})
+
+			// Assert
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
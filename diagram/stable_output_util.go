@@ -0,0 +1,31 @@
+package diagram
+
+import (
+	"regexp"
+	"strings"
+)
+
+var blankLinesRegex = regexp.MustCompile(`\n{3,}`)
+
+// normalizeWhitespace removes the whitespace that depends on the template instead of the database (e.g. the blank
+// lines of skipped sections), so the generated files only change if the database changes
+func normalizeWhitespace(output string) string {
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+	for index, line := range lines {
+		lines[index] = strings.TrimRight(line, " \t")
+	}
+
+	output = blankLinesRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.Trim(output, "\n") + "\n"
+}
+
+// isDataOutputFormat is true for the output formats that are written by an encoder, whose whitespace is already
+// stable and may be part of the values
+func isDataOutputFormat(outputFormat string) bool {
+	switch outputFormat {
+	case outputFormatJson, outputFormatYaml, outputFormatCsv, outputFormatTsv:
+		return true
+	default:
+		return false
+	}
+}
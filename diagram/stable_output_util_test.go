@@ -0,0 +1,38 @@
+package diagram
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeWhitespace(t *testing.T) {
+	testCases := []struct {
+		output         string
+		expectedOutput string
+	}{
+		{"erDiagram\n    article {\n        int id PK \n    }\n", "erDiagram\n    article {\n        int id PK\n    }\n"},
+		{"erDiagram\r\n    article\r\n", "erDiagram\n    article\n"},
+		{"\n\nerDiagram\n\n\n\n    article\n\n\n", "erDiagram\n\n    article\n"},
+		{"erDiagram", "erDiagram\n"},
+		{"", "\n"},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			result := normalizeWhitespace(testCase.output)
+
+			// Assert
+			assert.Equal(t, testCase.expectedOutput, result)
+		})
+	}
+}
+
+func TestIsDataOutputFormat(t *testing.T) {
+	assert.True(t, isDataOutputFormat(outputFormatJson))
+	assert.True(t, isDataOutputFormat(outputFormatCsv))
+	assert.False(t, isDataOutputFormat(outputFormatMermaid))
+	assert.False(t, isDataOutputFormat(outputFormatMarkdown))
+}
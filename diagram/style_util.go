@@ -0,0 +1,41 @@
+package diagram
+
+import (
+	"strings"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+// getTableStyleClassData assigns the mermaid classes of the configured table styles to the matching tables
+func getTableStyleClassData(config config.MermerdConfig, tables []database.TableResult) ([]ErdClassData, error) {
+	tableStyles, err := config.TableStyles()
+	if err != nil {
+		return nil, err
+	}
+
+	var classes []ErdClassData
+	for _, tableStyle := range tableStyles {
+		var tableNames []string
+		for _, table := range tables {
+			matches, err := database.MatchAnyTableName(tableStyle.Tables, table.Table)
+			if err != nil {
+				return nil, err
+			}
+
+			if matches {
+				tableNames = append(tableNames, getTableName(config, table.Table))
+			}
+		}
+
+		if len(tableNames) > 0 {
+			classes = append(classes, ErdClassData{
+				Name:       tableStyle.Name,
+				Style:      tableStyle.Style,
+				TableNames: strings.Join(tableNames, ","),
+			})
+		}
+	}
+
+	return classes, nil
+}
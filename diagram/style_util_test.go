@@ -0,0 +1,68 @@
+package diagram
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/mocks"
+)
+
+func TestGetTableStyleClassData(t *testing.T) {
+	tables := []database.TableResult{
+		{Table: database.TableDetail{Schema: "public", Name: "article"}},
+		{Table: database.TableDetail{Schema: "public", Name: "article_audit"}},
+		{Table: database.TableDetail{Schema: "public", Name: "comment_audit"}},
+		{Table: database.TableDetail{Schema: "dwh", Name: "fact_sales"}},
+	}
+
+	t.Run("Assign the classes to the matching tables", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("ShowSchemaPrefix").Return(false)
+		configMock.On("TableStyles").Return([]config.TableStyle{
+			{Name: "audit", Style: "fill:#eee,stroke:#999", Tables: []string{"*_audit"}},
+			{Name: "fact", Style: "fill:#cce5ff", Tables: []string{"dwh.fact_*"}},
+			{Name: "unused", Style: "fill:#fff", Tables: []string{"unknown"}},
+		}, nil)
+
+		// Act
+		classes, err := getTableStyleClassData(&configMock, tables)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, []ErdClassData{
+			{Name: "audit", Style: "fill:#eee,stroke:#999", TableNames: "article_audit,comment_audit"},
+			{Name: "fact", Style: "fill:#cce5ff", TableNames: "fact_sales"},
+		}, classes)
+	})
+
+	t.Run("Invalid pattern", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("TableStyles").Return([]config.TableStyle{{Name: "audit", Style: "fill:#eee", Tables: []string{"/[/"}}}, nil)
+
+		// Act
+		classes, err := getTableStyleClassData(&configMock, tables)
+
+		// Assert
+		assert.NotNil(t, err)
+		assert.Nil(t, classes)
+	})
+
+	t.Run("Invalid table styles", func(t *testing.T) {
+		// Arrange
+		configMock := mocks.MermerdConfig{}
+		configMock.On("TableStyles").Return(nil, errors.New("invalid tableStyles"))
+
+		// Act
+		classes, err := getTableStyleClassData(&configMock, tables)
+
+		// Assert
+		assert.NotNil(t, err)
+		assert.Nil(t, classes)
+	})
+}
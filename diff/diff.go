@@ -0,0 +1,305 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+type Change string
+
+const (
+	Unchanged Change = ""
+	Added     Change = "added"
+	Removed   Change = "removed"
+	Changed   Change = "changed"
+)
+
+type Result struct {
+	Tables []TableDiff
+}
+
+type TableDiff struct {
+	Table       database.TableDetail
+	Change      Change
+	Columns     []ColumnDiff
+	Constraints []ConstraintDiff
+	// Model contains the union of the columns and constraints of both sources, so that it can be used to render
+	// a diagram of the diff
+	Model database.TableResult
+}
+
+type ColumnDiff struct {
+	Name   string
+	Change Change
+	Source database.ColumnResult
+	Target database.ColumnResult
+}
+
+type ConstraintDiff struct {
+	Constraint database.ConstraintResult
+	Change     Change
+}
+
+// Compare reports the tables, columns and constraints that were added, removed or changed from source to target
+func Compare(source *database.Result, target *database.Result) *Result {
+	var result Result
+	for _, sourceTable := range source.Tables {
+		targetTable, ok := findTable(target, sourceTable.Table)
+		if !ok {
+			result.Tables = append(result.Tables, TableDiff{
+				Table:       sourceTable.Table,
+				Change:      Removed,
+				Constraints: getConstraintDiffs(sourceTable, database.TableResult{}),
+				Model:       sourceTable,
+			})
+			continue
+		}
+
+		result.Tables = append(result.Tables, compareTable(sourceTable, targetTable))
+	}
+
+	for _, targetTable := range target.Tables {
+		if _, ok := findTable(source, targetTable.Table); !ok {
+			result.Tables = append(result.Tables, TableDiff{
+				Table:       targetTable.Table,
+				Change:      Added,
+				Constraints: getConstraintDiffs(database.TableResult{}, targetTable),
+				Model:       targetTable,
+			})
+		}
+	}
+
+	return &result
+}
+
+// HasChanges is true if at least one table differs between the two sources
+func (r Result) HasChanges() bool {
+	for _, table := range r.Tables {
+		if table.Change != Unchanged {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Model returns all tables of both sources, so that the diff can be rendered as a diagram
+func (r Result) Model() *database.Result {
+	var model database.Result
+	for _, table := range r.Tables {
+		model.Tables = append(model.Tables, table.Model)
+	}
+
+	return &model
+}
+
+// WriteReport writes a human-readable list of all changes
+func (r Result) WriteReport(w io.Writer) error {
+	if !r.HasChanges() {
+		_, err := fmt.Fprintln(w, "No changes")
+		return err
+	}
+
+	for _, table := range r.Tables {
+		if table.Change == Unchanged {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "%s table %s.%s\n", getChangeSymbol(table.Change), table.Table.Schema, table.Table.Name); err != nil {
+			return err
+		}
+
+		for _, column := range table.Columns {
+			if _, err := fmt.Fprintf(w, "    %s column %s\n", getChangeSymbol(column.Change), describeColumn(column)); err != nil {
+				return err
+			}
+		}
+
+		for _, constraint := range table.Constraints {
+			if _, err := fmt.Fprintf(w, "    %s constraint %s\n", getChangeSymbol(constraint.Change), describeConstraint(constraint.Constraint)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func compareTable(source database.TableResult, target database.TableResult) TableDiff {
+	tableDiff := TableDiff{
+		Table:       target.Table,
+		Constraints: getConstraintDiffs(source, target),
+		Model:       target,
+	}
+
+	for _, sourceColumn := range source.Columns {
+		targetColumn, ok := findColumn(target.Columns, sourceColumn.Name)
+		if !ok {
+			tableDiff.Columns = append(tableDiff.Columns, ColumnDiff{Name: sourceColumn.Name, Change: Removed, Source: sourceColumn})
+			tableDiff.Model.Columns = append(tableDiff.Model.Columns, sourceColumn)
+			continue
+		}
+
+		if !columnsEqual(sourceColumn, targetColumn) {
+			tableDiff.Columns = append(tableDiff.Columns, ColumnDiff{Name: sourceColumn.Name, Change: Changed, Source: sourceColumn, Target: targetColumn})
+		}
+	}
+
+	for _, targetColumn := range target.Columns {
+		if _, ok := findColumn(source.Columns, targetColumn.Name); !ok {
+			tableDiff.Columns = append(tableDiff.Columns, ColumnDiff{Name: targetColumn.Name, Change: Added, Target: targetColumn})
+		}
+	}
+
+	for _, constraint := range tableDiff.Constraints {
+		if constraint.Change == Removed {
+			tableDiff.Model.Constraints = tableDiff.Model.Constraints.AppendIfNotExists(constraint.Constraint)
+		}
+	}
+
+	if len(tableDiff.Columns) > 0 || len(tableDiff.Constraints) > 0 || source.Table != target.Table {
+		tableDiff.Change = Changed
+	}
+
+	return tableDiff
+}
+
+// getConstraintDiffs compares the constraints that reference other tables. Constraints that reference the table
+// itself are reported by the referencing table, so that they are not listed twice.
+func getConstraintDiffs(source database.TableResult, target database.TableResult) []ConstraintDiff {
+	sourceConstraints := getOwnConstraints(source)
+	targetConstraints := getOwnConstraints(target)
+
+	var constraintDiffs []ConstraintDiff
+	for _, constraint := range sourceConstraints {
+		if !containsConstraint(targetConstraints, constraint) {
+			constraintDiffs = append(constraintDiffs, ConstraintDiff{Constraint: constraint, Change: Removed})
+		}
+	}
+
+	for _, constraint := range targetConstraints {
+		if !containsConstraint(sourceConstraints, constraint) {
+			constraintDiffs = append(constraintDiffs, ConstraintDiff{Constraint: constraint, Change: Added})
+		}
+	}
+
+	return constraintDiffs
+}
+
+func getOwnConstraints(table database.TableResult) []database.ConstraintResult {
+	var constraints []database.ConstraintResult
+	for _, constraint := range table.Constraints {
+		if constraint.FkTable == table.Table.Name && constraint.FkSchema == table.Table.Schema {
+			constraints = append(constraints, constraint)
+		}
+	}
+
+	return constraints
+}
+
+func containsConstraint(constraints []database.ConstraintResult, constraint database.ConstraintResult) bool {
+	for _, item := range constraints {
+		if item.Equals(constraint) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func findTable(result *database.Result, table database.TableDetail) (database.TableResult, bool) {
+	for _, item := range result.Tables {
+		if item.Table.Schema == table.Schema && item.Table.Name == table.Name {
+			return item, true
+		}
+	}
+
+	return database.TableResult{}, false
+}
+
+func findColumn(columns []database.ColumnResult, name string) (database.ColumnResult, bool) {
+	for _, column := range columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+
+	return database.ColumnResult{}, false
+}
+
+// columnsEqual ignores the position of the columns, as adding a column would otherwise change all following columns,
+// and the classifications, which are part of the configuration and not of the database
+func columnsEqual(column database.ColumnResult, other database.ColumnResult) bool {
+	column.OrdinalPosition = 0
+	other.OrdinalPosition = 0
+	column.Classifications = nil
+	other.Classifications = nil
+	return reflect.DeepEqual(column, other)
+}
+
+func getChangeSymbol(change Change) string {
+	switch change {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+func describeColumn(column ColumnDiff) string {
+	switch column.Change {
+	case Added:
+		return fmt.Sprintf("%s (%s)", column.Name, column.Target.DataType)
+	case Removed:
+		return fmt.Sprintf("%s (%s)", column.Name, column.Source.DataType)
+	}
+
+	var changes []string
+	if column.Source.DataType != column.Target.DataType {
+		changes = append(changes, fmt.Sprintf("type %s -> %s", column.Source.DataType, column.Target.DataType))
+	}
+	if column.Source.IsPrimary != column.Target.IsPrimary {
+		changes = append(changes, fmt.Sprintf("primary key %t -> %t", column.Source.IsPrimary, column.Target.IsPrimary))
+	}
+	if column.Source.IsForeign != column.Target.IsForeign {
+		changes = append(changes, fmt.Sprintf("foreign key %t -> %t", column.Source.IsForeign, column.Target.IsForeign))
+	}
+	if column.Source.IsUnique != column.Target.IsUnique {
+		changes = append(changes, fmt.Sprintf("unique key %t -> %t", column.Source.IsUnique, column.Target.IsUnique))
+	}
+	if column.Source.IsNullable != column.Target.IsNullable {
+		changes = append(changes, fmt.Sprintf("nullable %t -> %t", column.Source.IsNullable, column.Target.IsNullable))
+	}
+	if column.Source.EnumValues != column.Target.EnumValues {
+		changes = append(changes, fmt.Sprintf("enum values %q -> %q", column.Source.EnumValues, column.Target.EnumValues))
+	}
+	if column.Source.DefaultValue != column.Target.DefaultValue {
+		changes = append(changes, fmt.Sprintf("default value %q -> %q", column.Source.DefaultValue, column.Target.DefaultValue))
+	}
+	if column.Source.CheckConstraints != column.Target.CheckConstraints {
+		changes = append(changes, fmt.Sprintf("check constraints %q -> %q", column.Source.CheckConstraints, column.Target.CheckConstraints))
+	}
+	if column.Source.Comment != column.Target.Comment {
+		changes = append(changes, fmt.Sprintf("comment %q -> %q", column.Source.Comment, column.Target.Comment))
+	}
+	if len(changes) == 0 {
+		changes = append(changes, "attributes")
+	}
+
+	return fmt.Sprintf("%s (%s)", column.Name, strings.Join(changes, ", "))
+}
+
+func describeConstraint(constraint database.ConstraintResult) string {
+	columnNames := constraint.ColumnName
+	if len(constraint.ColumnNames) > 1 {
+		columnNames = "(" + strings.Join(constraint.ColumnNames, ", ") + ")"
+	}
+
+	return fmt.Sprintf("%s (%s.%s -> %s.%s)", constraint.ConstraintName, constraint.FkTable, columnNames, constraint.PkSchema, constraint.PkTable)
+}
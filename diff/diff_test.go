@@ -0,0 +1,150 @@
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+func getTestTable(name string, columns ...database.ColumnResult) database.TableResult {
+	return database.TableResult{Table: database.TableDetail{Schema: "public", Name: name}, Columns: columns}
+}
+
+func TestCompare(t *testing.T) {
+	idColumn := database.ColumnResult{Name: "id", DataType: "int", IsPrimary: true}
+	articleIdColumn := database.ColumnResult{Name: "article_id", DataType: "int", IsForeign: true}
+	constraint := database.ConstraintResult{FkTable: "comment", FkSchema: "public", PkTable: "article", PkSchema: "public", ConstraintName: "fk_article", ColumnName: "article_id"}
+
+	t.Run("Same model has no changes", func(t *testing.T) {
+		// Arrange
+		model := &database.Result{Tables: []database.TableResult{getTestTable("article", idColumn)}}
+
+		// Act
+		result := Compare(model, model)
+
+		// Assert
+		assert.False(t, result.HasChanges())
+		assert.Len(t, result.Tables, 1)
+		assert.Equal(t, Unchanged, result.Tables[0].Change)
+	})
+
+	t.Run("Added and removed tables", func(t *testing.T) {
+		// Arrange
+		source := &database.Result{Tables: []database.TableResult{getTestTable("article", idColumn)}}
+		target := &database.Result{Tables: []database.TableResult{getTestTable("label", idColumn)}}
+
+		// Act
+		result := Compare(source, target)
+
+		// Assert
+		assert.True(t, result.HasChanges())
+		assert.Len(t, result.Tables, 2)
+		assert.Equal(t, "article", result.Tables[0].Table.Name)
+		assert.Equal(t, Removed, result.Tables[0].Change)
+		assert.Equal(t, "label", result.Tables[1].Table.Name)
+		assert.Equal(t, Added, result.Tables[1].Change)
+	})
+
+	t.Run("Changed columns and constraints", func(t *testing.T) {
+		// Arrange
+		sourceComment := getTestTable("comment", idColumn, database.ColumnResult{Name: "text", DataType: "varchar"})
+		targetComment := getTestTable("comment", database.ColumnResult{Name: "id", DataType: "bigint", IsPrimary: true}, articleIdColumn)
+		targetComment.Constraints = database.ConstraintResultList{constraint}
+		source := &database.Result{Tables: []database.TableResult{sourceComment}}
+		target := &database.Result{Tables: []database.TableResult{targetComment}}
+
+		// Act
+		result := Compare(source, target)
+
+		// Assert
+		assert.Len(t, result.Tables, 1)
+		tableDiff := result.Tables[0]
+		assert.Equal(t, Changed, tableDiff.Change)
+		assert.Equal(t, []ColumnDiff{
+			{Name: "id", Change: Changed, Source: idColumn, Target: database.ColumnResult{Name: "id", DataType: "bigint", IsPrimary: true}},
+			{Name: "text", Change: Removed, Source: database.ColumnResult{Name: "text", DataType: "varchar"}},
+			{Name: "article_id", Change: Added, Target: articleIdColumn},
+		}, tableDiff.Columns)
+		assert.Equal(t, []ConstraintDiff{{Constraint: constraint, Change: Added}}, tableDiff.Constraints)
+		assert.Len(t, result.Model().Tables[0].Columns, 3)
+	})
+
+	t.Run("Moved columns are not changed", func(t *testing.T) {
+		// Arrange
+		sourceArticle := getTestTable("article", database.ColumnResult{Name: "id", DataType: "int", OrdinalPosition: 1})
+		targetArticle := getTestTable("article", database.ColumnResult{Name: "id", DataType: "int", OrdinalPosition: 2})
+		source := &database.Result{Tables: []database.TableResult{sourceArticle}}
+		target := &database.Result{Tables: []database.TableResult{targetArticle}}
+
+		// Act
+		result := Compare(source, target)
+
+		// Assert
+		assert.False(t, result.HasChanges())
+	})
+
+	t.Run("Classified columns are not changed", func(t *testing.T) {
+		// Arrange
+		sourceArticle := getTestTable("article", database.ColumnResult{Name: "email", DataType: "varchar"})
+		targetArticle := getTestTable("article", database.ColumnResult{Name: "email", DataType: "varchar", Classifications: []string{"pii"}})
+		source := &database.Result{Tables: []database.TableResult{sourceArticle}}
+		target := &database.Result{Tables: []database.TableResult{targetArticle}}
+
+		// Act
+		result := Compare(source, target)
+
+		// Assert
+		assert.False(t, result.HasChanges())
+	})
+
+	t.Run("Referenced table does not report the constraint", func(t *testing.T) {
+		// Arrange
+		sourceArticle := getTestTable("article", idColumn)
+		targetArticle := getTestTable("article", idColumn)
+		targetArticle.Constraints = database.ConstraintResultList{constraint}
+		source := &database.Result{Tables: []database.TableResult{sourceArticle}}
+		target := &database.Result{Tables: []database.TableResult{targetArticle}}
+
+		// Act
+		result := Compare(source, target)
+
+		// Assert
+		assert.False(t, result.HasChanges())
+	})
+}
+
+func TestResult_WriteReport(t *testing.T) {
+	t.Run("No changes", func(t *testing.T) {
+		// Arrange
+		var report bytes.Buffer
+
+		// Act
+		err := Result{}.WriteReport(&report)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "No changes\n", report.String())
+	})
+
+	t.Run("Changes", func(t *testing.T) {
+		// Arrange
+		source := &database.Result{Tables: []database.TableResult{
+			getTestTable("article", database.ColumnResult{Name: "id", DataType: "int"}, database.ColumnResult{Name: "email", DataType: "varchar"}),
+			getTestTable("label"),
+		}}
+		target := &database.Result{Tables: []database.TableResult{
+			getTestTable("article", database.ColumnResult{Name: "id", DataType: "bigint"}, database.ColumnResult{Name: "email", DataType: "varchar", IsUnique: true}),
+		}}
+		var report bytes.Buffer
+
+		// Act
+		err := Compare(source, target).WriteReport(&report)
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Equal(t, "~ table public.article\n    ~ column id (type int -> bigint)\n    ~ column email (unique key false -> true)\n- table public.label\n", report.String())
+	})
+}
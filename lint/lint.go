@@ -0,0 +1,226 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+type Rule string
+
+const (
+	// PrimaryKey reports the tables without primary key (views are skipped)
+	PrimaryKey Rule = "primary-key"
+	// ForeignKeyIndex reports the foreign keys whose columns are not the leading columns of an index, which makes
+	// joins and deletes of the referenced rows slow
+	ForeignKeyIndex Rule = "foreign-key-index"
+	// Naming reports the table and column names that do not use the naming style (e.g. snake_case) of most names
+	Naming Rule = "naming"
+)
+
+// ErrFindings is returned if the model violates one of the rules, so that e.g. a CI pipeline fails
+var ErrFindings = errors.New("the model violates lint rules")
+
+// AllRules are checked if no rules are configured
+var AllRules = []Rule{PrimaryKey, ForeignKeyIndex, Naming}
+
+type Result struct {
+	Findings []Finding
+}
+
+type Finding struct {
+	Rule  Rule
+	Table database.TableDetail
+	// Column is empty if the finding is about the table
+	Column  string
+	Message string
+}
+
+// GetRules returns the rules of the names, all rules if there are none
+func GetRules(names []string) ([]Rule, error) {
+	var rules []Rule
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+
+		rule, ok := findRule(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown lint rule %s (available rules: %s)", name, strings.Join(getRuleNames(), ", "))
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if len(rules) == 0 {
+		return AllRules, nil
+	}
+
+	return rules, nil
+}
+
+// Check reports the findings of the rules in the model, ordered by table and column
+func Check(model *database.Result, rules []Rule) *Result {
+	var result Result
+	for _, rule := range rules {
+		switch rule {
+		case PrimaryKey:
+			result.Findings = append(result.Findings, checkPrimaryKeys(model)...)
+		case ForeignKeyIndex:
+			result.Findings = append(result.Findings, checkForeignKeyIndexes(model)...)
+		case Naming:
+			result.Findings = append(result.Findings, checkNaming(model)...)
+		}
+	}
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if a.Table.Schema != b.Table.Schema {
+			return a.Table.Schema < b.Table.Schema
+		}
+
+		if a.Table.Name != b.Table.Name {
+			return a.Table.Name < b.Table.Name
+		}
+
+		return a.Column < b.Column
+	})
+
+	return &result
+}
+
+func (r Result) HasFindings() bool {
+	return len(r.Findings) > 0
+}
+
+// WriteReport writes a human-readable list of all findings
+func (r Result) WriteReport(w io.Writer) error {
+	if !r.HasFindings() {
+		_, err := fmt.Fprintln(w, "No findings")
+		return err
+	}
+
+	for _, finding := range r.Findings {
+		name := finding.Table.Schema + "." + finding.Table.Name
+		if finding.Column != "" {
+			name += "." + finding.Column
+		}
+
+		if _, err := fmt.Fprintf(w, "%s [%s] %s\n", name, finding.Rule, finding.Message); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%d findings\n", len(r.Findings))
+	return err
+}
+
+func checkPrimaryKeys(model *database.Result) []Finding {
+	var findings []Finding
+	for _, table := range model.Tables {
+		if table.Table.IsView || hasPrimaryKey(table) {
+			continue
+		}
+
+		findings = append(findings, Finding{Rule: PrimaryKey, Table: table.Table, Message: "table has no primary key"})
+	}
+
+	return findings
+}
+
+func hasPrimaryKey(table database.TableResult) bool {
+	for _, column := range table.Columns {
+		if column.IsPrimary {
+			return true
+		}
+	}
+
+	for _, index := range table.Indexes {
+		if index.IsPrimary {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkForeignKeyIndexes(model *database.Result) []Finding {
+	var findings []Finding
+	for _, table := range model.Tables {
+		if table.Table.IsView {
+			continue
+		}
+
+		for _, constraint := range table.Constraints {
+			if constraint.IsInheritance || constraint.IsInferred || constraint.FkSchema != table.Table.Schema ||
+				constraint.FkTable != table.Table.Name || hasLeadingIndex(table.Indexes, getColumnNames(constraint)) {
+				continue
+			}
+
+			findings = append(findings, Finding{
+				Rule:    ForeignKeyIndex,
+				Table:   table.Table,
+				Column:  strings.Join(getColumnNames(constraint), ", "),
+				Message: fmt.Sprintf("foreign key %s to %s.%s has no index", constraint.ConstraintName, constraint.PkSchema, constraint.PkTable),
+			})
+		}
+	}
+
+	return findings
+}
+
+func getColumnNames(constraint database.ConstraintResult) []string {
+	if len(constraint.ColumnNames) > 0 {
+		return constraint.ColumnNames
+	}
+
+	return []string{constraint.ColumnName}
+}
+
+// hasLeadingIndex is true if the columns (in any order) are the first columns of one of the indexes
+func hasLeadingIndex(indexes []database.IndexResult, columnNames []string) bool {
+	for _, index := range indexes {
+		if len(index.ColumnNames) < len(columnNames) {
+			continue
+		}
+
+		leadingColumns := make(map[string]bool)
+		for _, columnName := range index.ColumnNames[:len(columnNames)] {
+			leadingColumns[columnName] = true
+		}
+
+		covered := true
+		for _, columnName := range columnNames {
+			covered = covered && leadingColumns[columnName]
+		}
+
+		if covered {
+			return true
+		}
+	}
+
+	return false
+}
+
+func findRule(name string) (Rule, bool) {
+	for _, rule := range AllRules {
+		if strings.EqualFold(string(rule), name) {
+			return rule, true
+		}
+	}
+
+	return "", false
+}
+
+func getRuleNames() []string {
+	names := make([]string, len(AllRules))
+	for index, rule := range AllRules {
+		names[index] = string(rule)
+	}
+
+	return names
+}
@@ -0,0 +1,124 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+func getTestTable(name string, columns ...database.ColumnResult) database.TableResult {
+	return database.TableResult{Table: database.TableDetail{Schema: "public", Name: name}, Columns: columns}
+}
+
+func TestGetRules(t *testing.T) {
+	testCases := []struct {
+		names         []string
+		expectedRules []Rule
+		expectedErr   bool
+	}{
+		{nil, AllRules, false},
+		{[]string{""}, AllRules, false},
+		{[]string{"naming", "Primary-Key"}, []Rule{Naming, PrimaryKey}, false},
+		{[]string{"unknown"}, nil, true},
+	}
+
+	for index, testCase := range testCases {
+		t.Run(fmt.Sprintf("run #%d", index), func(t *testing.T) {
+			// Act
+			rules, err := GetRules(testCase.names)
+
+			// Assert
+			assert.Equal(t, testCase.expectedRules, rules)
+			assert.Equal(t, testCase.expectedErr, err != nil)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	idColumn := database.ColumnResult{Name: "id", IsPrimary: true}
+	articleIdColumn := database.ColumnResult{Name: "article_id", IsForeign: true}
+	constraint := database.ConstraintResult{FkTable: "comment", FkSchema: "public", PkTable: "article", PkSchema: "public", ConstraintName: "fk_article", ColumnName: "article_id"}
+
+	t.Run("Table without primary key", func(t *testing.T) {
+		// Arrange
+		view := getTestTable("article_view", database.ColumnResult{Name: "title"})
+		view.Table.IsView = true
+		model := &database.Result{Tables: []database.TableResult{
+			getTestTable("article", idColumn), getTestTable("label", database.ColumnResult{Name: "name"}), view,
+		}}
+
+		// Act
+		result := Check(model, []Rule{PrimaryKey})
+
+		// Assert
+		assert.Len(t, result.Findings, 1)
+		assert.Equal(t, PrimaryKey, result.Findings[0].Rule)
+		assert.Equal(t, "label", result.Findings[0].Table.Name)
+	})
+
+	t.Run("Foreign key without index", func(t *testing.T) {
+		// Arrange
+		comment := getTestTable("comment", idColumn, articleIdColumn)
+		comment.Constraints = database.ConstraintResultList{constraint}
+		indexedComment := comment
+		indexedComment.Indexes = []database.IndexResult{{Name: "ix_article", ColumnNames: []string{"article_id", "id"}}}
+		trailingIndexedComment := comment
+		trailingIndexedComment.Indexes = []database.IndexResult{{Name: "ix_article", ColumnNames: []string{"id", "article_id"}}}
+
+		// Act
+		result := Check(&database.Result{Tables: []database.TableResult{comment}}, []Rule{ForeignKeyIndex})
+		indexedResult := Check(&database.Result{Tables: []database.TableResult{indexedComment}}, []Rule{ForeignKeyIndex})
+		trailingIndexedResult := Check(&database.Result{Tables: []database.TableResult{trailingIndexedComment}}, []Rule{ForeignKeyIndex})
+
+		// Assert
+		assert.Len(t, result.Findings, 1)
+		assert.Equal(t, "article_id", result.Findings[0].Column)
+		assert.False(t, indexedResult.HasFindings())
+		assert.Len(t, trailingIndexedResult.Findings, 1)
+	})
+
+	t.Run("Inconsistent naming", func(t *testing.T) {
+		// Arrange
+		model := &database.Result{Tables: []database.TableResult{
+			getTestTable("article", idColumn, database.ColumnResult{Name: "created_at"}),
+			getTestTable("article_label", database.ColumnResult{Name: "labelName"}),
+			getTestTable("BlogPost", idColumn),
+		}}
+
+		// Act
+		result := Check(model, []Rule{Naming})
+
+		// Assert
+		assert.Len(t, result.Findings, 2)
+		assert.Equal(t, "BlogPost", result.Findings[0].Table.Name)
+		assert.Equal(t, "", result.Findings[0].Column)
+		assert.Equal(t, "article_label", result.Findings[1].Table.Name)
+		assert.Equal(t, "labelName", result.Findings[1].Column)
+	})
+}
+
+func TestWriteReport(t *testing.T) {
+	// Arrange
+	result := Result{Findings: []Finding{
+		{Rule: PrimaryKey, Table: database.TableDetail{Schema: "public", Name: "label"}, Message: "table has no primary key"},
+		{Rule: Naming, Table: database.TableDetail{Schema: "public", Name: "article"}, Column: "labelName", Message: "column name is not snake_case like most column names"},
+	}}
+	var report bytes.Buffer
+	var emptyReport bytes.Buffer
+
+	// Act
+	err := result.WriteReport(&report)
+	emptyErr := Result{}.WriteReport(&emptyReport)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Nil(t, emptyErr)
+	assert.Equal(t, "public.label [primary-key] table has no primary key\n"+
+		"public.article.labelName [naming] column name is not snake_case like most column names\n"+
+		"2 findings\n", report.String())
+	assert.Equal(t, "No findings\n", emptyReport.String())
+}
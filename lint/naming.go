@@ -0,0 +1,90 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/aslakhellesoy/mermerd/database"
+)
+
+type namingStyle string
+
+const (
+	snakeCase      namingStyle = "snake_case"
+	upperSnakeCase namingStyle = "UPPER_SNAKE_CASE"
+	camelCase      namingStyle = "camelCase"
+	pascalCase     namingStyle = "PascalCase"
+)
+
+// namingStyles are ordered by precedence, which decides between styles that are used equally often
+var namingStyles = []namingStyle{snakeCase, camelCase, pascalCase, upperSnakeCase}
+
+var namingStylePatterns = map[namingStyle]*regexp.Regexp{
+	snakeCase:      regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`),
+	upperSnakeCase: regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`),
+	camelCase:      regexp.MustCompile(`^[a-z][a-z0-9]*([A-Z][a-z0-9]*)*$`),
+	pascalCase:     regexp.MustCompile(`^([A-Z][a-z0-9]*)+$`),
+}
+
+// checkNaming compares the table names and the column names with the style that most of them use. A name can match
+// several styles (e.g. id is snake_case and camelCase), so it is only reported if it matches none of the most used.
+func checkNaming(model *database.Result) []Finding {
+	var tableNames []string
+	var columnNames []string
+	for _, table := range model.Tables {
+		tableNames = append(tableNames, table.Table.Name)
+		for _, column := range table.Columns {
+			columnNames = append(columnNames, column.Name)
+		}
+	}
+
+	var findings []Finding
+	if style, ok := getMostUsedNamingStyle(tableNames); ok {
+		for _, table := range model.Tables {
+			if !namingStylePatterns[style].MatchString(table.Table.Name) {
+				findings = append(findings, Finding{
+					Rule:    Naming,
+					Table:   table.Table,
+					Message: fmt.Sprintf("table name is not %s like most table names", style),
+				})
+			}
+		}
+	}
+
+	if style, ok := getMostUsedNamingStyle(columnNames); ok {
+		for _, table := range model.Tables {
+			for _, column := range table.Columns {
+				if !namingStylePatterns[style].MatchString(column.Name) {
+					findings = append(findings, Finding{
+						Rule:    Naming,
+						Table:   table.Table,
+						Column:  column.Name,
+						Message: fmt.Sprintf("column name is not %s like most column names", style),
+					})
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+func getMostUsedNamingStyle(names []string) (namingStyle, bool) {
+	counts := make(map[namingStyle]int)
+	for _, name := range names {
+		for style, pattern := range namingStylePatterns {
+			if pattern.MatchString(name) {
+				counts[style]++
+			}
+		}
+	}
+
+	var mostUsedStyle namingStyle
+	for _, style := range namingStyles {
+		if counts[style] > counts[mostUsedStyle] {
+			mostUsedStyle = style
+		}
+	}
+
+	return mostUsedStyle, counts[mostUsedStyle] > 0
+}
@@ -0,0 +1,125 @@
+package mermerd
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	_ "modernc.org/sqlite"
+
+	"github.com/aslakhellesoy/mermerd/analyzer"
+)
+
+// getSqliteConnectionString creates a database file from the sqlite setup script, so no database server is needed
+func getSqliteConnectionString(t *testing.T) string {
+	dbFile := filepath.Join(t.TempDir(), "mermerd_test.sqlite")
+	setupScript, err := os.ReadFile("../test/sqlite/sqlite-setup.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite", dbFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = db.Exec(string(setupScript)); err != nil {
+		t.Fatal(err)
+	}
+	_ = db.Close()
+
+	return "sqlite://" + dbFile
+}
+
+func TestRun(t *testing.T) {
+	connectionString := getSqliteConnectionString(t)
+
+	t.Run("Returns the model and the diagram", func(t *testing.T) {
+		// Act
+		model, diagram, err := Run(context.Background(), Options{
+			ConnectionString: connectionString,
+			UseAllTables:     true,
+		})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.NotEmpty(t, model.Tables)
+		assert.Contains(t, diagram, "erDiagram")
+	})
+
+	t.Run("Builds the connection string from the connection", func(t *testing.T) {
+		// Act
+		model, _, err := Run(context.Background(), Options{
+			Connection:   Connection{Type: "sqlite", Database: strings.TrimPrefix(connectionString, "sqlite://")},
+			UseAllTables: true,
+		})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.NotEmpty(t, model.Tables)
+	})
+
+	t.Run("Uses the output format", func(t *testing.T) {
+		// Act
+		_, diagram, err := Run(context.Background(), Options{
+			ConnectionString: connectionString,
+			UseAllTables:     true,
+			OutputFormat:     "dot",
+		})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Contains(t, diagram, "digraph")
+	})
+
+	t.Run("Uses the mermaid options and table styles", func(t *testing.T) {
+		// Act
+		_, diagram, err := Run(context.Background(), Options{
+			ConnectionString: connectionString,
+			UseAllTables:     true,
+			MermaidTheme:     "dark",
+			TableStyles:      []TableStyle{{Name: "comments", Style: "fill:#eee", Tables: []string{"*_comment"}}},
+		})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Contains(t, diagram, "theme: dark")
+		assert.Contains(t, diagram, "class article_comment comments")
+	})
+
+	t.Run("Uses the data types", func(t *testing.T) {
+		// Act
+		_, diagram, err := Run(context.Background(), Options{
+			ConnectionString: connectionString,
+			UseAllTables:     true,
+			DataTypes:        map[string]string{"varchar": "string"},
+		})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Contains(t, diagram, "string title")
+	})
+
+	t.Run("Missing tables are not asked for", func(t *testing.T) {
+		// Act
+		_, _, err := Run(context.Background(), Options{ConnectionString: connectionString})
+
+		// Assert
+		assert.ErrorIs(t, err, analyzer.ErrMissingInput)
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		_, _, err := Run(ctx, Options{ConnectionString: connectionString, UseAllTables: true})
+
+		// Assert
+		assert.ErrorIs(t, err, analyzer.ErrConnection)
+	})
+}
@@ -3,8 +3,12 @@
 package mocks
 
 import (
+	context "context"
+
 	database "github.com/aslakhellesoy/mermerd/database"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Analyzer is an autogenerated mock type for the Analyzer type
@@ -38,6 +42,32 @@ func (_m *Analyzer) Analyze() (*database.Result, error) {
 	return r0, r1
 }
 
+// AnalyzeContext provides a mock function with given fields: ctx
+func (_m *Analyzer) AnalyzeContext(ctx context.Context) (*database.Result, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *database.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*database.Result, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *database.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*database.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetColumnsAndConstraints provides a mock function with given fields: db, selectedTables
 func (_m *Analyzer) GetColumnsAndConstraints(db database.Connector, selectedTables []database.TableDetail) ([]database.TableResult, error) {
 	ret := _m.Called(db, selectedTables)
@@ -140,6 +170,32 @@ func (_m *Analyzer) GetTables(db database.Connector, selectedSchemas []string) (
 	return r0, r1
 }
 
+// Watch provides a mock function with given fields: ctx, interval, onChange
+func (_m *Analyzer) Watch(ctx context.Context, interval time.Duration, onChange func(*database.Result) error) (*database.Result, error) {
+	ret := _m.Called(ctx, interval, onChange)
+
+	var r0 *database.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, func(*database.Result) error) (*database.Result, error)); ok {
+		return rf(ctx, interval, onChange)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, func(*database.Result) error) *database.Result); ok {
+		r0 = rf(ctx, interval, onChange)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*database.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration, func(*database.Result) error) error); ok {
+		r1 = rf(ctx, interval, onChange)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewAnalyzer interface {
 	mock.TestingT
 	Cleanup(func())
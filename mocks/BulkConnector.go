@@ -0,0 +1,82 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	database "github.com/aslakhellesoy/mermerd/database"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BulkConnector is an autogenerated mock type for the BulkConnector type
+type BulkConnector struct {
+	mock.Mock
+}
+
+// GetAllColumns provides a mock function with given fields: ctx, schemaNames
+func (_m *BulkConnector) GetAllColumns(ctx context.Context, schemaNames []string) (map[database.TableDetail][]database.ColumnResult, error) {
+	ret := _m.Called(ctx, schemaNames)
+
+	var r0 map[database.TableDetail][]database.ColumnResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[database.TableDetail][]database.ColumnResult, error)); ok {
+		return rf(ctx, schemaNames)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[database.TableDetail][]database.ColumnResult); ok {
+		r0 = rf(ctx, schemaNames)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[database.TableDetail][]database.ColumnResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, schemaNames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllConstraints provides a mock function with given fields: ctx, schemaNames
+func (_m *BulkConnector) GetAllConstraints(ctx context.Context, schemaNames []string) ([]database.ConstraintResult, error) {
+	ret := _m.Called(ctx, schemaNames)
+
+	var r0 []database.ConstraintResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]database.ConstraintResult, error)); ok {
+		return rf(ctx, schemaNames)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []database.ConstraintResult); ok {
+		r0 = rf(ctx, schemaNames)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ConstraintResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, schemaNames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewBulkConnector interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBulkConnector creates a new instance of BulkConnector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBulkConnector(t mockConstructorTestingTNewBulkConnector) *BulkConnector {
+	mock := &BulkConnector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	database "github.com/aslakhellesoy/mermerd/database"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -17,13 +19,13 @@ func (_m *Connector) Close() {
 	_m.Called()
 }
 
-// Connect provides a mock function with given fields:
-func (_m *Connector) Connect() error {
-	ret := _m.Called()
+// Connect provides a mock function with given fields: ctx
+func (_m *Connector) Connect(ctx context.Context) error {
+	ret := _m.Called(ctx)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -31,25 +33,25 @@ func (_m *Connector) Connect() error {
 	return r0
 }
 
-// GetColumns provides a mock function with given fields: tableName
-func (_m *Connector) GetColumns(tableName database.TableDetail) ([]database.ColumnResult, error) {
-	ret := _m.Called(tableName)
+// GetColumns provides a mock function with given fields: ctx, tableName
+func (_m *Connector) GetColumns(ctx context.Context, tableName database.TableDetail) ([]database.ColumnResult, error) {
+	ret := _m.Called(ctx, tableName)
 
 	var r0 []database.ColumnResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(database.TableDetail) ([]database.ColumnResult, error)); ok {
-		return rf(tableName)
+	if rf, ok := ret.Get(0).(func(context.Context, database.TableDetail) ([]database.ColumnResult, error)); ok {
+		return rf(ctx, tableName)
 	}
-	if rf, ok := ret.Get(0).(func(database.TableDetail) []database.ColumnResult); ok {
-		r0 = rf(tableName)
+	if rf, ok := ret.Get(0).(func(context.Context, database.TableDetail) []database.ColumnResult); ok {
+		r0 = rf(ctx, tableName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]database.ColumnResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(database.TableDetail) error); ok {
-		r1 = rf(tableName)
+	if rf, ok := ret.Get(1).(func(context.Context, database.TableDetail) error); ok {
+		r1 = rf(ctx, tableName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,25 +59,25 @@ func (_m *Connector) GetColumns(tableName database.TableDetail) ([]database.Colu
 	return r0, r1
 }
 
-// GetConstraints provides a mock function with given fields: tableName
-func (_m *Connector) GetConstraints(tableName database.TableDetail) ([]database.ConstraintResult, error) {
-	ret := _m.Called(tableName)
+// GetConstraints provides a mock function with given fields: ctx, tableName
+func (_m *Connector) GetConstraints(ctx context.Context, tableName database.TableDetail) ([]database.ConstraintResult, error) {
+	ret := _m.Called(ctx, tableName)
 
 	var r0 []database.ConstraintResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(database.TableDetail) ([]database.ConstraintResult, error)); ok {
-		return rf(tableName)
+	if rf, ok := ret.Get(0).(func(context.Context, database.TableDetail) ([]database.ConstraintResult, error)); ok {
+		return rf(ctx, tableName)
 	}
-	if rf, ok := ret.Get(0).(func(database.TableDetail) []database.ConstraintResult); ok {
-		r0 = rf(tableName)
+	if rf, ok := ret.Get(0).(func(context.Context, database.TableDetail) []database.ConstraintResult); ok {
+		r0 = rf(ctx, tableName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]database.ConstraintResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(database.TableDetail) error); ok {
-		r1 = rf(tableName)
+	if rf, ok := ret.Get(1).(func(context.Context, database.TableDetail) error); ok {
+		r1 = rf(ctx, tableName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -97,25 +99,51 @@ func (_m *Connector) GetDbType() database.DbType {
 	return r0
 }
 
-// GetSchemas provides a mock function with given fields:
-func (_m *Connector) GetSchemas() ([]string, error) {
-	ret := _m.Called()
+// GetIndexes provides a mock function with given fields: ctx, tableName
+func (_m *Connector) GetIndexes(ctx context.Context, tableName database.TableDetail) ([]database.IndexResult, error) {
+	ret := _m.Called(ctx, tableName)
+
+	var r0 []database.IndexResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.TableDetail) ([]database.IndexResult, error)); ok {
+		return rf(ctx, tableName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.TableDetail) []database.IndexResult); ok {
+		r0 = rf(ctx, tableName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.IndexResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.TableDetail) error); ok {
+		r1 = rf(ctx, tableName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSchemas provides a mock function with given fields: ctx
+func (_m *Connector) GetSchemas(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
 
 	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]string, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []string); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -123,25 +151,25 @@ func (_m *Connector) GetSchemas() ([]string, error) {
 	return r0, r1
 }
 
-// GetTables provides a mock function with given fields: schemaNames
-func (_m *Connector) GetTables(schemaNames []string) ([]database.TableDetail, error) {
-	ret := _m.Called(schemaNames)
+// GetTables provides a mock function with given fields: ctx, schemaNames
+func (_m *Connector) GetTables(ctx context.Context, schemaNames []string) ([]database.TableDetail, error) {
+	ret := _m.Called(ctx, schemaNames)
 
 	var r0 []database.TableDetail
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]string) ([]database.TableDetail, error)); ok {
-		return rf(schemaNames)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]database.TableDetail, error)); ok {
+		return rf(ctx, schemaNames)
 	}
-	if rf, ok := ret.Get(0).(func([]string) []database.TableDetail); ok {
-		r0 = rf(schemaNames)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []database.TableDetail); ok {
+		r0 = rf(ctx, schemaNames)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]database.TableDetail)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func([]string) error); ok {
-		r1 = rf(schemaNames)
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, schemaNames)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	io "io"
+
+	database "github.com/aslakhellesoy/mermerd/database"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ConnectorConstructor is an autogenerated mock type for the ConnectorConstructor type
+type ConnectorConstructor struct {
+	mock.Mock
+}
+
+// Execute provides a mock function with given fields: connectionString, tunnel, options
+func (_m *ConnectorConstructor) Execute(connectionString string, tunnel io.Closer, options database.ConnectorOptions) (database.Connector, error) {
+	ret := _m.Called(connectionString, tunnel, options)
+
+	var r0 database.Connector
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, io.Closer, database.ConnectorOptions) (database.Connector, error)); ok {
+		return rf(connectionString, tunnel, options)
+	}
+	if rf, ok := ret.Get(0).(func(string, io.Closer, database.ConnectorOptions) database.Connector); ok {
+		r0 = rf(connectionString, tunnel, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(database.Connector)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, io.Closer, database.ConnectorOptions) error); ok {
+		r1 = rf(connectionString, tunnel, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewConnectorConstructor interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewConnectorConstructor creates a new instance of ConnectorConstructor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewConnectorConstructor(t mockConstructorTestingTNewConnectorConstructor) *ConnectorConstructor {
+	mock := &ConnectorConstructor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,10 @@ package mocks
 import (
 	database "github.com/aslakhellesoy/mermerd/database"
 
+	diff "github.com/aslakhellesoy/mermerd/diff"
+
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -27,6 +31,48 @@ func (_m *Diagram) Create(result *database.Result) error {
 	return r0
 }
 
+// CreateDiff provides a mock function with given fields: result
+func (_m *Diagram) CreateDiff(result *diff.Result) error {
+	ret := _m.Called(result)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*diff.Result) error); ok {
+		r0 = rf(result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Render provides a mock function with given fields: w, result, imageFormat
+func (_m *Diagram) Render(w io.Writer, result *database.Result, imageFormat string) error {
+	ret := _m.Called(w, result, imageFormat)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(io.Writer, *database.Result, string) error); ok {
+		r0 = rf(w, result, imageFormat)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Write provides a mock function with given fields: w, result
+func (_m *Diagram) Write(w io.Writer, result *database.Result) error {
+	ret := _m.Called(w, result)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(io.Writer, *database.Result) error); ok {
+		r0 = rf(w, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewDiagram interface {
 	mock.TestingT
 	Cleanup(func())
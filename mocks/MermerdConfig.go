@@ -2,13 +2,196 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	config "github.com/aslakhellesoy/mermerd/config"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
 
 // MermerdConfig is an autogenerated mock type for the MermerdConfig type
 type MermerdConfig struct {
 	mock.Mock
 }
 
+// AskPassword provides a mock function with given fields:
+func (_m *MermerdConfig) AskPassword() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// AssertReadOnly provides a mock function with given fields:
+func (_m *MermerdConfig) AssertReadOnly() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// AuthMode provides a mock function with given fields:
+func (_m *MermerdConfig) AuthMode() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Cache provides a mock function with given fields:
+func (_m *MermerdConfig) Cache() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// CacheTtl provides a mock function with given fields:
+func (_m *MermerdConfig) CacheTtl() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// Classifications provides a mock function with given fields:
+func (_m *MermerdConfig) Classifications() ([]config.Classification, error) {
+	ret := _m.Called()
+
+	var r0 []config.Classification
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]config.Classification, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []config.Classification); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]config.Classification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CollapseJoinTables provides a mock function with given fields:
+func (_m *MermerdConfig) CollapseJoinTables() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ColumnAliases provides a mock function with given fields:
+func (_m *MermerdConfig) ColumnAliases() ([]config.Alias, error) {
+	ret := _m.Called()
+
+	var r0 []config.Alias
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]config.Alias, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []config.Alias); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]config.Alias)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ColumnOrder provides a mock function with given fields:
+func (_m *MermerdConfig) ColumnOrder() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Connection provides a mock function with given fields:
+func (_m *MermerdConfig) Connection() config.Connection {
+	ret := _m.Called()
+
+	var r0 config.Connection
+	if rf, ok := ret.Get(0).(func() config.Connection); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(config.Connection)
+	}
+
+	return r0
+}
+
+// ConnectionAttempts provides a mock function with given fields:
+func (_m *MermerdConfig) ConnectionAttempts() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // ConnectionString provides a mock function with given fields:
 func (_m *MermerdConfig) ConnectionString() string {
 	ret := _m.Called()
@@ -39,6 +222,52 @@ func (_m *MermerdConfig) ConnectionStringSuggestions() []string {
 	return r0
 }
 
+// ConnectorPlugins provides a mock function with given fields:
+func (_m *MermerdConfig) ConnectorPlugins() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// CredentialCommand provides a mock function with given fields:
+func (_m *MermerdConfig) CredentialCommand() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// DataTypes provides a mock function with given fields:
+func (_m *MermerdConfig) DataTypes() map[string]string {
+	ret := _m.Called()
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
 // Debug provides a mock function with given fields:
 func (_m *MermerdConfig) Debug() bool {
 	ret := _m.Called()
@@ -53,6 +282,20 @@ func (_m *MermerdConfig) Debug() bool {
 	return r0
 }
 
+// Depth provides a mock function with given fields:
+func (_m *MermerdConfig) Depth() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // EncloseWithMermaidBackticks provides a mock function with given fields:
 func (_m *MermerdConfig) EncloseWithMermaidBackticks() bool {
 	ret := _m.Called()
@@ -67,50 +310,56 @@ func (_m *MermerdConfig) EncloseWithMermaidBackticks() bool {
 	return r0
 }
 
-// OmitAttributeKeys provides a mock function with given fields:
-func (_m *MermerdConfig) OmitAttributeKeys() bool {
+// ExcludeColumns provides a mock function with given fields:
+func (_m *MermerdConfig) ExcludeColumns() []string {
 	ret := _m.Called()
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func() bool); ok {
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 
 	return r0
 }
 
-// OmitConstraintLabels provides a mock function with given fields:
-func (_m *MermerdConfig) OmitConstraintLabels() bool {
+// ExcludeTables provides a mock function with given fields:
+func (_m *MermerdConfig) ExcludeTables() []string {
 	ret := _m.Called()
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func() bool); ok {
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 
 	return r0
 }
 
-// OutputFileName provides a mock function with given fields:
-func (_m *MermerdConfig) OutputFileName() string {
+// Focus provides a mock function with given fields:
+func (_m *MermerdConfig) Focus() []string {
 	ret := _m.Called()
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func() string); ok {
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 
 	return r0
 }
 
-// SchemaPrefixSeparator provides a mock function with given fields:
-func (_m *MermerdConfig) SchemaPrefixSeparator() string {
+// GroupBy provides a mock function with given fields:
+func (_m *MermerdConfig) GroupBy() string {
 	ret := _m.Called()
 
 	var r0 string
@@ -123,8 +372,8 @@ func (_m *MermerdConfig) SchemaPrefixSeparator() string {
 	return r0
 }
 
-// Schemas provides a mock function with given fields:
-func (_m *MermerdConfig) Schemas() []string {
+// IncludeColumns provides a mock function with given fields:
+func (_m *MermerdConfig) IncludeColumns() []string {
 	ret := _m.Called()
 
 	var r0 []string
@@ -139,24 +388,22 @@ func (_m *MermerdConfig) Schemas() []string {
 	return r0
 }
 
-// SelectedTables provides a mock function with given fields:
-func (_m *MermerdConfig) SelectedTables() []string {
+// IncludeViews provides a mock function with given fields:
+func (_m *MermerdConfig) IncludeViews() bool {
 	ret := _m.Called()
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func() []string); ok {
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
 		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
 }
 
-// ShowAllConstraints provides a mock function with given fields:
-func (_m *MermerdConfig) ShowAllConstraints() bool {
+// Incremental provides a mock function with given fields:
+func (_m *MermerdConfig) Incremental() bool {
 	ret := _m.Called()
 
 	var r0 bool
@@ -169,8 +416,8 @@ func (_m *MermerdConfig) ShowAllConstraints() bool {
 	return r0
 }
 
-// ShowDescriptions provides a mock function with given fields:
-func (_m *MermerdConfig) ShowDescriptions() []string {
+// InferRelationshipPatterns provides a mock function with given fields:
+func (_m *MermerdConfig) InferRelationshipPatterns() []string {
 	ret := _m.Called()
 
 	var r0 []string
@@ -185,8 +432,8 @@ func (_m *MermerdConfig) ShowDescriptions() []string {
 	return r0
 }
 
-// ShowSchemaPrefix provides a mock function with given fields:
-func (_m *MermerdConfig) ShowSchemaPrefix() bool {
+// InferRelationships provides a mock function with given fields:
+func (_m *MermerdConfig) InferRelationships() bool {
 	ret := _m.Called()
 
 	var r0 bool
@@ -199,8 +446,8 @@ func (_m *MermerdConfig) ShowSchemaPrefix() bool {
 	return r0
 }
 
-// UseAllSchemas provides a mock function with given fields:
-func (_m *MermerdConfig) UseAllSchemas() bool {
+// Inject provides a mock function with given fields:
+func (_m *MermerdConfig) Inject() bool {
 	ret := _m.Called()
 
 	var r0 bool
@@ -213,15 +460,963 @@ func (_m *MermerdConfig) UseAllSchemas() bool {
 	return r0
 }
 
-// UseAllTables provides a mock function with given fields:
-func (_m *MermerdConfig) UseAllTables() bool {
+// LintRules provides a mock function with given fields:
+func (_m *MermerdConfig) LintRules() []string {
 	ret := _m.Called()
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func() bool); ok {
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// LogFormat provides a mock function with given fields:
+func (_m *MermerdConfig) LogFormat() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MaskColumns provides a mock function with given fields:
+func (_m *MermerdConfig) MaskColumns() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// MaxColumnsPerTable provides a mock function with given fields:
+func (_m *MermerdConfig) MaxColumnsPerTable() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// MergeRelationships provides a mock function with given fields:
+func (_m *MermerdConfig) MergeRelationships() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MermaidCommentStyle provides a mock function with given fields:
+func (_m *MermerdConfig) MermaidCommentStyle() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MermaidInitDirective provides a mock function with given fields:
+func (_m *MermerdConfig) MermaidInitDirective() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MermaidLayout provides a mock function with given fields:
+func (_m *MermerdConfig) MermaidLayout() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MermaidMaxTextSize provides a mock function with given fields:
+func (_m *MermerdConfig) MermaidMaxTextSize() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// MermaidTheme provides a mock function with given fields:
+func (_m *MermerdConfig) MermaidTheme() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MultipleAttributeKeys provides a mock function with given fields:
+func (_m *MermerdConfig) MultipleAttributeKeys() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NameCase provides a mock function with given fields:
+func (_m *MermerdConfig) NameCase() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// NonInteractive provides a mock function with given fields:
+func (_m *MermerdConfig) NonInteractive() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// OmitAttributeKeys provides a mock function with given fields:
+func (_m *MermerdConfig) OmitAttributeKeys() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// OmitColumns provides a mock function with given fields:
+func (_m *MermerdConfig) OmitColumns() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// OmitConstraintLabels provides a mock function with given fields:
+func (_m *MermerdConfig) OmitConstraintLabels() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// OmitOrphanTables provides a mock function with given fields:
+func (_m *MermerdConfig) OmitOrphanTables() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// OutputFileName provides a mock function with given fields:
+func (_m *MermerdConfig) OutputFileName() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// OutputFormat provides a mock function with given fields:
+func (_m *MermerdConfig) OutputFormat() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// PartialModel provides a mock function with given fields:
+func (_m *MermerdConfig) PartialModel() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// PasswordFile provides a mock function with given fields:
+func (_m *MermerdConfig) PasswordFile() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Profile provides a mock function with given fields:
+func (_m *MermerdConfig) Profile() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ProfileNames provides a mock function with given fields:
+func (_m *MermerdConfig) ProfileNames() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// QueryTimeout provides a mock function with given fields:
+func (_m *MermerdConfig) QueryTimeout() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// Quiet provides a mock function with given fields:
+func (_m *MermerdConfig) Quiet() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// RelationshipDirection provides a mock function with given fields:
+func (_m *MermerdConfig) RelationshipDirection() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// RelationshipLabel provides a mock function with given fields:
+func (_m *MermerdConfig) RelationshipLabel() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// RelationshipOrder provides a mock function with given fields:
+func (_m *MermerdConfig) RelationshipOrder() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Render provides a mock function with given fields:
+func (_m *MermerdConfig) Render() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Run provides a mock function with given fields:
+func (_m *MermerdConfig) Run() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// RunNames provides a mock function with given fields:
+func (_m *MermerdConfig) RunNames() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// SampleRows provides a mock function with given fields:
+func (_m *MermerdConfig) SampleRows() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// SchemaPrefixSeparator provides a mock function with given fields:
+func (_m *MermerdConfig) SchemaPrefixSeparator() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Schemas provides a mock function with given fields:
+func (_m *MermerdConfig) Schemas() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// SelectedTables provides a mock function with given fields:
+func (_m *MermerdConfig) SelectedTables() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// ShowAllConstraints provides a mock function with given fields:
+func (_m *MermerdConfig) ShowAllConstraints() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ShowDataTypePrecision provides a mock function with given fields:
+func (_m *MermerdConfig) ShowDataTypePrecision() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ShowDescriptions provides a mock function with given fields:
+func (_m *MermerdConfig) ShowDescriptions() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// ShowEnumsAsEntities provides a mock function with given fields:
+func (_m *MermerdConfig) ShowEnumsAsEntities() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ShowGhostTables provides a mock function with given fields:
+func (_m *MermerdConfig) ShowGhostTables() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ShowIdentity provides a mock function with given fields:
+func (_m *MermerdConfig) ShowIdentity() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ShowIndexes provides a mock function with given fields:
+func (_m *MermerdConfig) ShowIndexes() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ShowNullable provides a mock function with given fields:
+func (_m *MermerdConfig) ShowNullable() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ShowPartitions provides a mock function with given fields:
+func (_m *MermerdConfig) ShowPartitions() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ShowSchemaPrefix provides a mock function with given fields:
+func (_m *MermerdConfig) ShowSchemaPrefix() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SkipFailingTables provides a mock function with given fields:
+func (_m *MermerdConfig) SkipFailingTables() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SqlDialect provides a mock function with given fields:
+func (_m *MermerdConfig) SqlDialect() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Ssh provides a mock function with given fields:
+func (_m *MermerdConfig) Ssh() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// SshIdentityFile provides a mock function with given fields:
+func (_m *MermerdConfig) SshIdentityFile() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// StableOutput provides a mock function with given fields:
+func (_m *MermerdConfig) StableOutput() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Summary provides a mock function with given fields:
+func (_m *MermerdConfig) Summary() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SummaryFile provides a mock function with given fields:
+func (_m *MermerdConfig) SummaryFile() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TableAliases provides a mock function with given fields:
+func (_m *MermerdConfig) TableAliases() ([]config.Alias, error) {
+	ret := _m.Called()
+
+	var r0 []config.Alias
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]config.Alias, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []config.Alias); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]config.Alias)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TableGroups provides a mock function with given fields:
+func (_m *MermerdConfig) TableGroups() ([]config.TableGroup, error) {
+	ret := _m.Called()
+
+	var r0 []config.TableGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]config.TableGroup, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []config.TableGroup); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]config.TableGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TableStyles provides a mock function with given fields:
+func (_m *MermerdConfig) TableStyles() ([]config.TableStyle, error) {
+	ret := _m.Called()
+
+	var r0 []config.TableStyle
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]config.TableStyle, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []config.TableStyle); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]config.TableStyle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TlsCaCert provides a mock function with given fields:
+func (_m *MermerdConfig) TlsCaCert() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TlsClientCert provides a mock function with given fields:
+func (_m *MermerdConfig) TlsClientCert() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TlsClientKey provides a mock function with given fields:
+func (_m *MermerdConfig) TlsClientKey() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TlsSkipVerify provides a mock function with given fields:
+func (_m *MermerdConfig) TlsSkipVerify() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// TraceSql provides a mock function with given fields:
+func (_m *MermerdConfig) TraceSql() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// UseAllSchemas provides a mock function with given fields:
+func (_m *MermerdConfig) UseAllSchemas() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// UseAllTables provides a mock function with given fields:
+func (_m *MermerdConfig) UseAllTables() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Verbose provides a mock function with given fields:
+func (_m *MermerdConfig) Verbose() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Watch provides a mock function with given fields:
+func (_m *MermerdConfig) Watch() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// WatchInterval provides a mock function with given fields:
+func (_m *MermerdConfig) WatchInterval() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
 	}
 
 	return r0
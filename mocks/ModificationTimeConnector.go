@@ -0,0 +1,58 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	database "github.com/aslakhellesoy/mermerd/database"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ModificationTimeConnector is an autogenerated mock type for the ModificationTimeConnector type
+type ModificationTimeConnector struct {
+	mock.Mock
+}
+
+// GetModificationTimes provides a mock function with given fields: ctx, schemaNames
+func (_m *ModificationTimeConnector) GetModificationTimes(ctx context.Context, schemaNames []string) (map[database.TableDetail]time.Time, error) {
+	ret := _m.Called(ctx, schemaNames)
+
+	var r0 map[database.TableDetail]time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[database.TableDetail]time.Time, error)); ok {
+		return rf(ctx, schemaNames)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[database.TableDetail]time.Time); ok {
+		r0 = rf(ctx, schemaNames)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[database.TableDetail]time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, schemaNames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewModificationTimeConnector interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewModificationTimeConnector creates a new instance of ModificationTimeConnector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewModificationTimeConnector(t mockConstructorTestingTNewModificationTimeConnector) *ModificationTimeConnector {
+	mock := &ModificationTimeConnector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,55 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PermissionConnector is an autogenerated mock type for the PermissionConnector type
+type PermissionConnector struct {
+	mock.Mock
+}
+
+// GetMissingPermissions provides a mock function with given fields: ctx, schemaNames
+func (_m *PermissionConnector) GetMissingPermissions(ctx context.Context, schemaNames []string) ([]string, error) {
+	ret := _m.Called(ctx, schemaNames)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(ctx, schemaNames)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, schemaNames)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, schemaNames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPermissionConnector interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPermissionConnector creates a new instance of PermissionConnector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPermissionConnector(t mockConstructorTestingTNewPermissionConnector) *PermissionConnector {
+	mock := &PermissionConnector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
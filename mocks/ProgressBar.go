@@ -0,0 +1,40 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// ProgressBar is an autogenerated mock type for the ProgressBar type
+type ProgressBar struct {
+	mock.Mock
+}
+
+// Increment provides a mock function with given fields:
+func (_m *ProgressBar) Increment() {
+	_m.Called()
+}
+
+// Start provides a mock function with given fields: text, unit, total
+func (_m *ProgressBar) Start(text string, unit string, total int) {
+	_m.Called(text, unit, total)
+}
+
+// Stop provides a mock function with given fields:
+func (_m *ProgressBar) Stop() {
+	_m.Called()
+}
+
+type mockConstructorTestingTNewProgressBar interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewProgressBar creates a new instance of ProgressBar. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewProgressBar(t mockConstructorTestingTNewProgressBar) *ProgressBar {
+	mock := &ProgressBar{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -2,13 +2,54 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	config "github.com/aslakhellesoy/mermerd/config"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Questioner is an autogenerated mock type for the Questioner type
 type Questioner struct {
 	mock.Mock
 }
 
+// Answers provides a mock function with given fields:
+func (_m *Questioner) Answers() config.RunConfig {
+	ret := _m.Called()
+
+	var r0 config.RunConfig
+	if rf, ok := ret.Get(0).(func() config.RunConfig); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(config.RunConfig)
+	}
+
+	return r0
+}
+
+// AskConfirmQuestion provides a mock function with given fields: message, defaultValue
+func (_m *Questioner) AskConfirmQuestion(message string, defaultValue bool) (bool, error) {
+	ret := _m.Called(message, defaultValue)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, bool) (bool, error)); ok {
+		return rf(message, defaultValue)
+	}
+	if rf, ok := ret.Get(0).(func(string, bool) bool); ok {
+		r0 = rf(message, defaultValue)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(message, defaultValue)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AskConnectionQuestion provides a mock function with given fields: suggestions
 func (_m *Questioner) AskConnectionQuestion(suggestions []string) (string, error) {
 	ret := _m.Called(suggestions)
@@ -33,6 +74,78 @@ func (_m *Questioner) AskConnectionQuestion(suggestions []string) (string, error
 	return r0, r1
 }
 
+// AskInputQuestion provides a mock function with given fields: message, defaultValue
+func (_m *Questioner) AskInputQuestion(message string, defaultValue string) (string, error) {
+	ret := _m.Called(message, defaultValue)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(message, defaultValue)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(message, defaultValue)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(message, defaultValue)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AskPasswordQuestion provides a mock function with given fields:
+func (_m *Questioner) AskPasswordQuestion() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AskProfileQuestion provides a mock function with given fields: profiles
+func (_m *Questioner) AskProfileQuestion(profiles []string) (string, error) {
+	ret := _m.Called(profiles)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) (string, error)); ok {
+		return rf(profiles)
+	}
+	if rf, ok := ret.Get(0).(func([]string) string); ok {
+		r0 = rf(profiles)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(profiles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AskSchemaQuestion provides a mock function with given fields: schemas
 func (_m *Questioner) AskSchemaQuestion(schemas []string) ([]string, error) {
 	ret := _m.Called(schemas)
@@ -59,6 +172,30 @@ func (_m *Questioner) AskSchemaQuestion(schemas []string) ([]string, error) {
 	return r0, r1
 }
 
+// AskSelectQuestion provides a mock function with given fields: message, options, defaultOption
+func (_m *Questioner) AskSelectQuestion(message string, options []string, defaultOption string) (string, error) {
+	ret := _m.Called(message, options, defaultOption)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string, string) (string, error)); ok {
+		return rf(message, options, defaultOption)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string, string) string); ok {
+		r0 = rf(message, options, defaultOption)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []string, string) error); ok {
+		r1 = rf(message, options, defaultOption)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AskTableQuestion provides a mock function with given fields: tables
 func (_m *Questioner) AskTableQuestion(tables []string) ([]string, error) {
 	ret := _m.Called(tables)
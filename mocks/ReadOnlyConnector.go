@@ -0,0 +1,55 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReadOnlyConnector is an autogenerated mock type for the ReadOnlyConnector type
+type ReadOnlyConnector struct {
+	mock.Mock
+}
+
+// GetWritePrivileges provides a mock function with given fields: ctx
+func (_m *ReadOnlyConnector) GetWritePrivileges(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewReadOnlyConnector interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewReadOnlyConnector creates a new instance of ReadOnlyConnector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewReadOnlyConnector(t mockConstructorTestingTNewReadOnlyConnector) *ReadOnlyConnector {
+	mock := &ReadOnlyConnector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,56 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	database "github.com/aslakhellesoy/mermerd/database"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SampleConnector is an autogenerated mock type for the SampleConnector type
+type SampleConnector struct {
+	mock.Mock
+}
+
+// GetSampleRows provides a mock function with given fields: ctx, tableName, columnNames, limit
+func (_m *SampleConnector) GetSampleRows(ctx context.Context, tableName database.TableDetail, columnNames []string, limit int) ([][]string, error) {
+	ret := _m.Called(ctx, tableName, columnNames, limit)
+
+	var r0 [][]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.TableDetail, []string, int) ([][]string, error)); ok {
+		return rf(ctx, tableName, columnNames, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.TableDetail, []string, int) [][]string); ok {
+		r0 = rf(ctx, tableName, columnNames, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.TableDetail, []string, int) error); ok {
+		r1 = rf(ctx, tableName, columnNames, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewSampleConnector interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSampleConnector creates a new instance of SampleConnector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSampleConnector(t mockConstructorTestingTNewSampleConnector) *SampleConnector {
+	mock := &SampleConnector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,39 @@
+// Code generated by mockery v2.21.4. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// nameCase is an autogenerated mock type for the nameCase type
+type nameCase struct {
+	mock.Mock
+}
+
+// Execute provides a mock function with given fields: words
+func (_m *nameCase) Execute(words []string) string {
+	ret := _m.Called(words)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func([]string) string); ok {
+		r0 = rf(words)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTnewNameCase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// newNameCase creates a new instance of nameCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func newNameCase(t mockConstructorTestingTnewNameCase) *nameCase {
+	mock := &nameCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
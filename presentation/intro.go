@@ -8,6 +8,10 @@ import (
 )
 
 func ShowIntro() {
+	if quiet {
+		return
+	}
+
 	color.Green(fmt.Sprintf(`
 .  ..___.__ .  ..___.__ .__ 
 |\/|[__ [__)|\/|[__ [__)|  \
@@ -1,8 +1,6 @@
 package presentation
 
 import (
-	"time"
-
 	"github.com/briandowns/spinner"
 )
 
@@ -16,12 +14,15 @@ type loadingSpinner struct {
 }
 
 func NewLoadingSpinner() LoadingSpinner {
-	s := spinner.New(spinner.CharSets[36], 100*time.Millisecond)
-	_ = s.Color("green")
+	s := newSpinner()
 	return &loadingSpinner{spinner: s}
 }
 
 func (s *loadingSpinner) Start(text string) {
+	if quiet {
+		return
+	}
+
 	s.spinner.Suffix = text
 	s.spinner.Start()
 }
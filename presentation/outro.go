@@ -2,11 +2,25 @@ package presentation
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 )
 
 func ShowSuccess(fileName string) {
+	if quiet {
+		// the diagram itself is the output
+		if !diagramOnStdout {
+			fmt.Println(fileName)
+		}
+
+		return
+	}
+
+	if diagramOnStdout {
+		fileName = "stdout"
+	}
+
 	color.Green(fmt.Sprintf(`
 
 ✓ Diagram was created successfully (%s)
@@ -21,3 +35,37 @@ X Something went wrong!
 
 `)
 }
+
+// ShowPartialModel shows the file of the tables that were analyzed before the analysis was cancelled
+func ShowPartialModel(fileName string, tableCount int) {
+	color.Yellow(fmt.Sprintf(`
+
+! The analysis was cancelled, the model of the %d analyzed tables was written to %s
+`, tableCount, fileName))
+}
+
+// maxShownSkippedTables is the number of skipped tables that are listed, the others are only counted
+const maxShownSkippedTables = 5
+
+// ShowSkippedTables shows the tables that are missing in the diagram, as they could not be read
+func ShowSkippedTables(tables []string) {
+	if quiet {
+		return
+	}
+
+	shownTables := tables
+	if len(tables) > maxShownSkippedTables {
+		shownTables = append(tables[:maxShownSkippedTables:maxShownSkippedTables], fmt.Sprintf("%d more", len(tables)-maxShownSkippedTables))
+	}
+
+	color.Yellow(fmt.Sprintf("\n! %d tables could not be read and are missing: %s\n", len(tables), strings.Join(shownTables, ", ")))
+}
+
+// ShowUncheckedPermissions shows that the permission check could not be done, the analysis continues without it
+func ShowUncheckedPermissions(err error) {
+	if quiet {
+		return
+	}
+
+	color.Yellow(fmt.Sprintf("\n! The permissions of the user could not be checked, the analysis continues without the check: %v\n", err))
+}
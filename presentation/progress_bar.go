@@ -0,0 +1,68 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/briandowns/spinner"
+)
+
+const progressBarWidth = 20
+
+// ProgressBar shows the progress of a step with a known number of items (e.g. "table 37/214"). If the number of
+// items is unknown (total of 0), it falls back to the loading spinner.
+type ProgressBar interface {
+	Start(text string, unit string, total int)
+	Increment()
+	Stop()
+}
+
+type progressBar struct {
+	spinner *spinner.Spinner
+	text    string
+	unit    string
+	current int
+	total   int
+}
+
+func NewProgressBar() ProgressBar {
+	s := newSpinner()
+	return &progressBar{spinner: s}
+}
+
+func (p *progressBar) Start(text string, unit string, total int) {
+	p.text = text
+	p.unit = unit
+	p.current = 0
+	p.total = total
+	p.spinner.Suffix = p.getSuffix()
+	if !quiet {
+		p.spinner.Start()
+	}
+}
+
+func (p *progressBar) Increment() {
+	// the suffix is read by the spinner while it is running
+	p.spinner.Lock()
+	defer p.spinner.Unlock()
+	p.current++
+	p.spinner.Suffix = p.getSuffix()
+}
+
+func (p *progressBar) Stop() {
+	p.spinner.Stop()
+}
+
+func (p *progressBar) getSuffix() string {
+	if p.total <= 0 {
+		return p.text
+	}
+
+	filled := p.current * progressBarWidth / p.total
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+	return fmt.Sprintf("%s [%s] %s %d/%d", p.text, bar, p.unit, p.current, p.total)
+}
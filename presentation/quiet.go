@@ -0,0 +1,8 @@
+package presentation
+
+// quiet suppresses the intro, the loading spinner and the progress bar, only the output file name is shown
+var quiet bool
+
+func SetQuiet(value bool) {
+	quiet = value
+}
@@ -0,0 +1,39 @@
+package presentation
+
+import (
+	"os"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/fatih/color"
+	"github.com/mattn/go-colorable"
+)
+
+// diagramOnStdout routes the intro, the spinners, the questions and the messages to stderr, as stdout only contains
+// the diagram
+var diagramOnStdout bool
+
+func SetDiagramOnStdout(value bool) {
+	diagramOnStdout = value
+	if value {
+		color.Output = colorable.NewColorableStderr()
+	} else {
+		color.Output = colorable.NewColorableStdout()
+	}
+}
+
+func IsDiagramOnStdout() bool {
+	return diagramOnStdout
+}
+
+// newSpinner creates the spinner of the loading spinner and the progress bar
+func newSpinner() *spinner.Spinner {
+	var options []spinner.Option
+	if diagramOnStdout {
+		options = append(options, spinner.WithWriterFile(os.Stderr))
+	}
+
+	s := spinner.New(spinner.CharSets[36], 100*time.Millisecond, options...)
+	_ = s.Color("green")
+	return s
+}
@@ -0,0 +1,132 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// Summary contains the durations of the phases and the size of the result of a run (--summary)
+type Summary struct {
+	DurationMs  int64   `json:"durationMs"`
+	Phases      []Phase `json:"phases"`
+	Tables      int     `json:"tables"`
+	Columns     int     `json:"columns"`
+	Constraints int     `json:"constraints"`
+	// OutputBytes is the size of the output file, which is not known if the diagram is written to stdout
+	OutputBytes int64 `json:"outputBytes,omitempty"`
+}
+
+type Phase struct {
+	Name       string `json:"name"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// SummaryRecorder records the summary of a run, every run has its own recorder so that concurrent runs (e.g. of
+// generate-all) do not mix their summaries
+type SummaryRecorder struct {
+	mutex   sync.Mutex
+	summary Summary
+	start   time.Time
+}
+
+func NewSummaryRecorder() *SummaryRecorder {
+	return &SummaryRecorder{start: time.Now()}
+}
+
+// Reset starts the summary of the next diagram (e.g. in watch mode)
+func (r *SummaryRecorder) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.summary = Summary{}
+	r.start = time.Now()
+}
+
+// RecordPhase adds the duration since start to the phase of the summary, the durations of a phase that occurs
+// multiple times are added up
+func (r *SummaryRecorder) RecordPhase(name string, start time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	durationMs := time.Since(start).Milliseconds()
+	for i := range r.summary.Phases {
+		if r.summary.Phases[i].Name == name {
+			r.summary.Phases[i].DurationMs += durationMs
+			return
+		}
+	}
+
+	r.summary.Phases = append(r.summary.Phases, Phase{Name: name, DurationMs: durationMs})
+}
+
+// RecordResult sets the counts and the output size of the summary
+func (r *SummaryRecorder) RecordResult(tables int, columns int, constraints int, outputBytes int64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.summary.Tables = tables
+	r.summary.Columns = columns
+	r.summary.Constraints = constraints
+	r.summary.OutputBytes = outputBytes
+}
+
+// GetSummary returns the summary with the duration since the recorder was created or reset
+func (r *SummaryRecorder) GetSummary() Summary {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	result := r.summary
+	result.Phases = append([]Phase(nil), r.summary.Phases...)
+	result.DurationMs = time.Since(r.start).Milliseconds()
+	return result
+}
+
+type summaryRecorderKey struct{}
+
+// ContextWithSummaryRecorder returns a context whose phases are recorded by the recorder (see RecordPhase)
+func ContextWithSummaryRecorder(ctx context.Context, recorder *SummaryRecorder) context.Context {
+	return context.WithValue(ctx, summaryRecorderKey{}, recorder)
+}
+
+// RecordPhase records the phase with the recorder of the context, the phase is not recorded without a recorder
+// (e.g. if mermerd is used as library)
+func RecordPhase(ctx context.Context, name string, start time.Time) {
+	if recorder, ok := ctx.Value(summaryRecorderKey{}).(*SummaryRecorder); ok {
+		recorder.RecordPhase(name, start)
+	}
+}
+
+func ShowSummary(summary Summary) {
+	if quiet {
+		return
+	}
+
+	output := fmt.Sprintln("Summary")
+	for _, phase := range summary.Phases {
+		output += fmt.Sprintf("  %-26s %8d ms\n", phase.Name, phase.DurationMs)
+	}
+
+	output += fmt.Sprintf("  %-26s %8d ms\n", "total", summary.DurationMs)
+	output += fmt.Sprintf("  %d tables, %d columns, %d constraints", summary.Tables, summary.Columns, summary.Constraints)
+	if summary.OutputBytes > 0 {
+		output += fmt.Sprintf(", %s output", formatBytes(summary.OutputBytes))
+	}
+
+	_, _ = fmt.Fprintln(color.Output, output)
+}
+
+// formatBytes formats the size with binary units (e.g. 4.2 KiB)
+func formatBytes(bytes int64) string {
+	switch {
+	case bytes < 1024:
+		return fmt.Sprintf("%d B", bytes)
+	case bytes < 1024*1024:
+		return fmt.Sprintf("%.1f KiB", float64(bytes)/1024)
+	default:
+		return fmt.Sprintf("%.1f MiB", float64(bytes)/(1024*1024))
+	}
+}
@@ -0,0 +1,247 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/aslakhellesoy/mermerd/analyzer"
+	"github.com/aslakhellesoy/mermerd/config"
+	"github.com/aslakhellesoy/mermerd/database"
+	"github.com/aslakhellesoy/mermerd/diagram"
+	"github.com/aslakhellesoy/mermerd/util"
+)
+
+// contentTypes contains the supported output formats of the diagram
+var contentTypes = map[string]string{
+	"mermaid":  "text/plain; charset=utf-8",
+	"dot":      "text/vnd.graphviz; charset=utf-8",
+	"markdown": "text/markdown; charset=utf-8",
+	"html":     "text/html; charset=utf-8",
+	"prisma":   "text/plain; charset=utf-8",
+	"gorm":     "text/x-go; charset=utf-8",
+	"sql":      "application/sql",
+	"csv":      "text/csv; charset=utf-8",
+	"tsv":      "text/tab-separated-values; charset=utf-8",
+	"openapi":  "application/yaml",
+	"json":     "application/json",
+	"yaml":     "application/yaml",
+	"svg":      "image/svg+xml",
+	"png":      "image/png",
+}
+
+type server struct {
+	config           config.MermerdConfig
+	connectorFactory database.ConnectorFactory
+}
+
+// NewHandler returns the handler of the http server, which analyzes the configured database on every request of
+// GET /erd. The query parameters schema, tables, focus, depth and format replace the configured values, but cannot
+// select schemas or tables outside the configured ones. The svg and png formats require the mermaid cli (see
+// diagram.Render).
+func NewHandler(config config.MermerdConfig, connectorFactory database.ConnectorFactory) http.Handler {
+	s := server{config, connectorFactory}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/erd", s.handleErd)
+	return mux
+}
+
+func (s server) handleErd(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	requestConfig, err := newRequestConfig(s.config, r)
+	if errors.Is(err, errNotAllowed) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	contentType, ok := contentTypes[requestConfig.OutputFormat()]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unsupported format %q", requestConfig.OutputFormat()), http.StatusBadRequest)
+		return
+	}
+
+	// the questioner is never used, as missing values are errors in non-interactive mode
+	result, err := analyzer.NewAnalyzer(requestConfig, s.connectorFactory, analyzer.NewQuestioner()).AnalyzeContext(r.Context())
+	if err != nil {
+		// the errors of the driver can contain details of the database, so they are only logged
+		logrus.WithField("url", r.URL.String()).Error("Analyzing the database failed", " | ", err)
+		statusCode := getStatusCode(err)
+		http.Error(w, http.StatusText(statusCode), statusCode)
+		return
+	}
+
+	// the diagram is buffered, so an error can still be returned with the status code
+	var output bytes.Buffer
+	if format := requestConfig.OutputFormat(); format == "svg" || format == "png" {
+		err = diagram.NewDiagram(requestConfig).Render(&output, result, format)
+	} else {
+		err = diagram.NewDiagram(requestConfig).Write(&output, result)
+	}
+	if err != nil {
+		logrus.WithField("url", r.URL.String()).Error("Creating the diagram failed", " | ", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(output.Bytes())
+}
+
+func getStatusCode(err error) int {
+	switch {
+	case errors.Is(err, analyzer.ErrMissingInput):
+		return http.StatusBadRequest
+	case errors.Is(err, analyzer.ErrNoSchemas), errors.Is(err, analyzer.ErrNoTables):
+		return http.StatusNotFound
+	case errors.Is(err, analyzer.ErrConnection):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// errNotAllowed is returned if the query parameters select schemas or tables outside the configured ones
+var errNotAllowed = errors.New("not allowed")
+
+// requestConfig replaces the configured values with the query parameters of the request
+type requestConfig struct {
+	config.MermerdConfig
+	schemas        []string
+	selectedTables []string
+	focus          []string
+	depth          *int
+	outputFormat   string
+}
+
+func newRequestConfig(mermerdConfig config.MermerdConfig, r *http.Request) (requestConfig, error) {
+	query := r.URL.Query()
+	c := requestConfig{
+		MermerdConfig:  mermerdConfig,
+		schemas:        getListParameter(query.Get("schema")),
+		selectedTables: getListParameter(query.Get("tables")),
+		focus:          getListParameter(query.Get("focus")),
+		outputFormat:   query.Get("format"),
+	}
+
+	if depth := query.Get("depth"); depth != "" {
+		value, err := strconv.Atoi(depth)
+		if err != nil || value < 0 {
+			return requestConfig{}, fmt.Errorf("invalid depth %q", depth)
+		}
+
+		c.depth = &value
+	}
+
+	if err := checkAllowedParameters(mermerdConfig, c); err != nil {
+		return requestConfig{}, err
+	}
+
+	return c, nil
+}
+
+// checkAllowedParameters ensures that the query parameters only narrow the configured schemas and tables, as the
+// server has no authentication and every client could read any schema otherwise
+func checkAllowedParameters(mermerdConfig config.MermerdConfig, c requestConfig) error {
+	if len(c.schemas) > 0 && !mermerdConfig.UseAllSchemas() {
+		for _, schema := range c.schemas {
+			if !util.Contains(mermerdConfig.Schemas(), schema) {
+				return fmt.Errorf("%w: schema %q is not configured", errNotAllowed, schema)
+			}
+		}
+	}
+
+	// without configured tables (or with useAllTables) all tables of the schemas are served
+	configuredTables := util.Filter(mermerdConfig.SelectedTables(), isNotEmpty)
+	configuredFocus := util.Filter(mermerdConfig.Focus(), isNotEmpty)
+	if mermerdConfig.UseAllTables() || (len(configuredTables) == 0 && len(configuredFocus) == 0) {
+		return nil
+	}
+
+	// the related tables of a focus could be outside the configured tables
+	if len(c.focus) > 0 {
+		return fmt.Errorf("%w: focus, as the tables are configured", errNotAllowed)
+	}
+
+	for _, table := range c.selectedTables {
+		if !util.Contains(configuredTables, table) {
+			return fmt.Errorf("%w: table %q is not configured", errNotAllowed, table)
+		}
+	}
+
+	return nil
+}
+
+func isNotEmpty(value string) bool {
+	return strings.TrimSpace(value) != ""
+}
+
+func getListParameter(value string) []string {
+	return util.Filter(strings.Split(value, ","), isNotEmpty)
+}
+
+func (c requestConfig) Schemas() []string {
+	if len(c.schemas) > 0 {
+		return c.schemas
+	}
+
+	return c.MermerdConfig.Schemas()
+}
+
+func (c requestConfig) SelectedTables() []string {
+	if len(c.selectedTables) > 0 {
+		return c.selectedTables
+	}
+
+	return c.MermerdConfig.SelectedTables()
+}
+
+func (c requestConfig) Focus() []string {
+	if len(c.focus) > 0 {
+		return c.focus
+	}
+
+	return c.MermerdConfig.Focus()
+}
+
+func (c requestConfig) Depth() int {
+	if c.depth != nil {
+		return *c.depth
+	}
+
+	return c.MermerdConfig.Depth()
+}
+
+// UseAllTables is the default of the server, as there is no questioner to select the tables
+func (c requestConfig) UseAllTables() bool {
+	return c.MermerdConfig.UseAllTables() || (len(c.SelectedTables()) == 0 && len(c.Focus()) == 0)
+}
+
+func (c requestConfig) OutputFormat() string {
+	if c.outputFormat != "" {
+		return c.outputFormat
+	}
+
+	if outputFormat := c.MermerdConfig.OutputFormat(); outputFormat != "" {
+		return outputFormat
+	}
+
+	return "mermaid"
+}
+
+func (c requestConfig) NonInteractive() bool {
+	return true
+}
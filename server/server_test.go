@@ -0,0 +1,132 @@
+package server
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	_ "modernc.org/sqlite"
+
+	"github.com/aslakhellesoy/mermerd/analyzer"
+	"github.com/aslakhellesoy/mermerd/config"
+)
+
+// getSqliteHandler creates a database file from the sqlite setup script, so no database server is needed
+func getSqliteHandler(t *testing.T, values map[string]any) http.Handler {
+	dbFile := filepath.Join(t.TempDir(), "mermerd_test.sqlite")
+	setupScript, err := os.ReadFile("../test/sqlite/sqlite-setup.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite", dbFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = db.Exec(string(setupScript)); err != nil {
+		t.Fatal(err)
+	}
+	_ = db.Close()
+
+	values[config.ConnectionStringKey] = "sqlite://" + dbFile
+	values[config.ConnectionAttemptsKey] = 1
+	mermerdConfig := config.NewConfigWithValues(values)
+	return NewHandler(mermerdConfig, analyzer.NewConnectorFactory(mermerdConfig))
+}
+
+func TestHandler(t *testing.T) {
+	handler := getSqliteHandler(t, map[string]any{config.SchemaKey: []string{"main"}})
+
+	testCases := []struct {
+		url                 string
+		method              string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{url: "/erd", expectedStatus: http.StatusOK, expectedContentType: "text/plain; charset=utf-8", expectedBody: "article_label {"},
+		{url: "/erd?schema=main&tables=article", expectedStatus: http.StatusOK, expectedBody: "article {"},
+		{url: "/erd?focus=label&depth=0", expectedStatus: http.StatusOK, expectedBody: "label {"},
+		{url: "/erd?format=json", expectedStatus: http.StatusOK, expectedContentType: "application/json", expectedBody: `"tables"`},
+		{url: "/erd?format=dot", expectedStatus: http.StatusOK, expectedBody: "digraph"},
+		{url: "/erd?format=gif", expectedStatus: http.StatusBadRequest},
+		{url: "/erd?depth=-1", expectedStatus: http.StatusBadRequest},
+		{url: "/erd?focus=missing", expectedStatus: http.StatusNotFound, expectedBody: "Not Found"},
+		{url: "/erd?schema=other", expectedStatus: http.StatusForbidden},
+		{url: "/erd", method: http.MethodPost, expectedStatus: http.StatusMethodNotAllowed},
+		{url: "/other", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.method+" "+testCase.url, func(t *testing.T) {
+			// Arrange
+			method := testCase.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			recorder := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(recorder, httptest.NewRequest(method, testCase.url, nil))
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatus, recorder.Code, recorder.Body.String())
+			if testCase.expectedContentType != "" {
+				assert.Equal(t, testCase.expectedContentType, recorder.Header().Get("Content-Type"))
+			}
+			assert.Contains(t, recorder.Body.String(), testCase.expectedBody)
+		})
+	}
+}
+
+func TestHandler_ConfiguredTables(t *testing.T) {
+	handler := getSqliteHandler(t, map[string]any{
+		config.SchemaKey: []string{"main"}, config.SelectedTablesKey: []string{"article", "label"},
+	})
+
+	testCases := []struct {
+		url            string
+		expectedStatus int
+	}{
+		{url: "/erd", expectedStatus: http.StatusOK},
+		{url: "/erd?tables=label", expectedStatus: http.StatusOK},
+		{url: "/erd?tables=article,comment", expectedStatus: http.StatusForbidden},
+		{url: "/erd?focus=article", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.url, func(t *testing.T) {
+			// Arrange
+			recorder := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, testCase.url, nil))
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatus, recorder.Code, recorder.Body.String())
+		})
+	}
+}
+
+func TestHandler_Render(t *testing.T) {
+	// Arrange
+	handler := getSqliteHandler(t, map[string]any{})
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "mmdc"), []byte("#!/bin/sh\ncp \"$2\" \"$4\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	recorder := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/erd?format=svg", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	assert.Equal(t, "image/svg+xml", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), "erDiagram")
+}
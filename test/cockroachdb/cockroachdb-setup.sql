@@ -0,0 +1,5 @@
+-- Tables without a primary key get a hidden rowid column
+create table test_crdb_no_pk
+(
+    title varchar(255) not null
+);
@@ -0,0 +1,11 @@
+-- Virtual columns and sequences are reported differently than in mysql
+create sequence test_mariadb_sequence;
+
+create table test_mariadb_columns
+(
+    id          int          not null auto_increment primary key,
+    sequence_id int          not null default nextval(test_mariadb_sequence),
+    first_name  varchar(255) not null,
+    last_name   varchar(255) not null,
+    full_name   varchar(511) as (concat(first_name, ' ', last_name)) virtual
+);
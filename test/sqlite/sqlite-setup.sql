@@ -0,0 +1,60 @@
+create table article
+(
+    id    int          not null primary key,
+    title varchar(255) not null
+);
+
+create table article_detail
+(
+    id         int       not null primary key,
+    created_at timestamp not null,
+    -- one-to-one relation
+    constraint fk_article_detail_id foreign key (id) references article (id)
+);
+
+create table article_comment
+(
+    id         int          not null primary key,
+    article_id int          not null,
+    comment    varchar(255) not null check (length(comment) > 0),
+    -- one-to-many relation
+    constraint fk_article_comment_id foreign key (article_id) references article (id)
+);
+
+create table label
+(
+    id    int          not null primary key,
+    label varchar(255) not null
+);
+
+-- many-to-many relation
+create table article_label
+(
+    article_id int not null,
+    label_id   int not null,
+    primary key (article_id, label_id),
+    constraint fk_article_label_article_id foreign key (article_id) references article (id),
+    constraint fk_article_label_label_id foreign key (label_id) references label (id)
+);
+
+-- Test case for https://github.com/KarnerTh/mermerd/issues/8
+create table test_1_a
+(
+    id  int,
+    xid int,
+    primary key (id, xid)
+);
+
+create table test_1_b
+(
+    aid int,
+    bid int,
+    primary key (aid, bid),
+    foreign key (aid, bid) references test_1_a (id, xid)
+);
+
+create view article_overview as
+select a.id, a.title, count(c.id) as comment_count
+from article a
+         left join article_comment c on c.article_id = a.id
+group by a.id, a.title;
@@ -0,0 +1,22 @@
+package util
+
+func Filter[T any](data []T, f func(T) bool) []T {
+	res := make([]T, 0, len(data))
+	for _, e := range data {
+		if f(e) {
+			res = append(res, e)
+		}
+	}
+
+	return res
+}
+
+func Contains[T comparable](data []T, value T) bool {
+	for _, e := range data {
+		if e == value {
+			return true
+		}
+	}
+
+	return false
+}
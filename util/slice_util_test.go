@@ -0,0 +1,34 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	// Arrange
+	input := []int{1, 2, 3, 4}
+
+	// Act
+	result := Filter(input, func(value int) bool {
+		return value%2 == 0
+	})
+
+	// Assert
+	expectedResult := []int{2, 4}
+	assert.ElementsMatch(t, expectedResult, result)
+}
+
+func TestContains(t *testing.T) {
+	// Arrange
+	input := []string{"a", "b"}
+
+	// Act
+	containsB := Contains(input, "b")
+	containsC := Contains(input, "c")
+
+	// Assert
+	assert.True(t, containsB)
+	assert.False(t, containsC)
+}